@@ -111,6 +111,10 @@ func (vde ValueDecoderError) Error() string {
 type EncodeContext struct {
 	*Registry
 	MinSize bool
+
+	// NilSliceAsEmpty causes nil slices to be encoded like empty slices: as an empty array, an empty binary for byte
+	// slices, or an empty document for primitive.D. By default, nil slices are encoded as null.
+	NilSliceAsEmpty bool
 }
 
 // DecodeContext is the contextual information required for a Codec to decode a
@@ -79,6 +79,10 @@ func (dvd DefaultValueDecoders) RegisterDefaultDecoders(rb *RegistryBuilder) {
 		RegisterTypeDecoder(tSymbol, decodeAdapter{dvd.SymbolDecodeValue, dvd.symbolDecodeType}).
 		RegisterTypeDecoder(tByteSlice, defaultByteSliceCodec).
 		RegisterTypeDecoder(tTime, defaultTimeCodec).
+		RegisterTypeDecoder(tSQLNullString, defaultSQLNullCodec).
+		RegisterTypeDecoder(tSQLNullInt64, defaultSQLNullCodec).
+		RegisterTypeDecoder(tSQLNullFloat64, defaultSQLNullCodec).
+		RegisterTypeDecoder(tSQLNullBool, defaultSQLNullCodec).
 		RegisterTypeDecoder(tEmpty, defaultEmptyInterfaceCodec).
 		RegisterTypeDecoder(tCoreArray, defaultArrayCodec).
 		RegisterTypeDecoder(tOID, decodeAdapter{dvd.ObjectIDDecodeValue, dvd.objectIDDecodeType}).
@@ -69,6 +69,10 @@ func (dve DefaultValueEncoders) RegisterDefaultEncoders(rb *RegistryBuilder) {
 	rb.
 		RegisterTypeEncoder(tByteSlice, defaultByteSliceCodec).
 		RegisterTypeEncoder(tTime, defaultTimeCodec).
+		RegisterTypeEncoder(tSQLNullString, defaultSQLNullCodec).
+		RegisterTypeEncoder(tSQLNullInt64, defaultSQLNullCodec).
+		RegisterTypeEncoder(tSQLNullFloat64, defaultSQLNullCodec).
+		RegisterTypeEncoder(tSQLNullBool, defaultSQLNullCodec).
 		RegisterTypeEncoder(tEmpty, defaultEmptyInterfaceCodec).
 		RegisterTypeEncoder(tCoreArray, defaultArrayCodec).
 		RegisterTypeEncoder(tOID, ValueEncoderFunc(dve.ObjectIDEncodeValue)).
@@ -0,0 +1,104 @@
+// Copyright (C) MongoDB, Inc. 2017-present.
+//
+// Licensed under the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License. You may obtain
+// a copy of the License at http://www.apache.org/licenses/LICENSE-2.0
+
+package bsoncodec
+
+import (
+	"reflect"
+
+	"go.mongodb.org/mongo-driver/bson/bsonrw"
+	"go.mongodb.org/mongo-driver/bson/bsontype"
+)
+
+// sqlNullValueFields maps each supported database/sql Null* type to the name of the field that holds its value.
+var sqlNullValueFields = map[reflect.Type]string{
+	tSQLNullString:  "String",
+	tSQLNullInt64:   "Int64",
+	tSQLNullFloat64: "Float64",
+	tSQLNullBool:    "Bool",
+}
+
+var sqlNullTypes = []reflect.Type{tSQLNullString, tSQLNullInt64, tSQLNullFloat64, tSQLNullBool}
+
+// SQLNullCodec is the Codec used for the sql.NullString, sql.NullInt64, sql.NullFloat64, and sql.NullBool types
+// from the database/sql package. A valid value is encoded as its underlying value and an invalid value is encoded as
+// BSON null. When decoding, BSON null and undefined produce an invalid value and any other BSON type is decoded into
+// the underlying value, which is then marked as valid.
+//
+// Invalid values are considered empty, so fields with the "omitempty" struct tag are omitted when they are invalid.
+type SQLNullCodec struct{}
+
+var (
+	defaultSQLNullCodec = NewSQLNullCodec()
+
+	_ ValueCodec = defaultSQLNullCodec
+)
+
+// NewSQLNullCodec returns a SQLNullCodec.
+func NewSQLNullCodec() *SQLNullCodec {
+	return &SQLNullCodec{}
+}
+
+// EncodeValue is the ValueEncoderFunc for the database/sql Null* types.
+func (snc *SQLNullCodec) EncodeValue(ec EncodeContext, vw bsonrw.ValueWriter, val reflect.Value) error {
+	if !val.IsValid() {
+		return ValueEncoderError{Name: "SQLNullEncodeValue", Types: sqlNullTypes, Received: val}
+	}
+	field, ok := sqlNullValueFields[val.Type()]
+	if !ok {
+		return ValueEncoderError{Name: "SQLNullEncodeValue", Types: sqlNullTypes, Received: val}
+	}
+
+	if !val.FieldByName("Valid").Bool() {
+		return vw.WriteNull()
+	}
+
+	inner := val.FieldByName(field)
+	encoder, err := ec.LookupEncoder(inner.Type())
+	if err != nil {
+		return err
+	}
+	return encoder.EncodeValue(ec, vw, inner)
+}
+
+// DecodeValue is the ValueDecoderFunc for the database/sql Null* types.
+func (snc *SQLNullCodec) DecodeValue(dc DecodeContext, vr bsonrw.ValueReader, val reflect.Value) error {
+	if !val.CanSet() {
+		return ValueDecoderError{Name: "SQLNullDecodeValue", Types: sqlNullTypes, Received: val}
+	}
+	field, ok := sqlNullValueFields[val.Type()]
+	if !ok {
+		return ValueDecoderError{Name: "SQLNullDecodeValue", Types: sqlNullTypes, Received: val}
+	}
+
+	switch vr.Type() {
+	case bsontype.Null:
+		val.Set(reflect.Zero(val.Type()))
+		return vr.ReadNull()
+	case bsontype.Undefined:
+		val.Set(reflect.Zero(val.Type()))
+		return vr.ReadUndefined()
+	}
+
+	inner := val.FieldByName(field)
+	decoder, err := dc.LookupDecoder(inner.Type())
+	if err != nil {
+		return err
+	}
+	if err = decoder.DecodeValue(dc, vr, inner); err != nil {
+		return err
+	}
+	val.FieldByName("Valid").SetBool(true)
+	return nil
+}
+
+// isSQLNullInvalid returns true if val is one of the database/sql Null* types and is not valid.
+func isSQLNullInvalid(val reflect.Value) bool {
+	if _, ok := sqlNullValueFields[val.Type()]; !ok {
+		return false
+	}
+	return !val.FieldByName("Valid").Bool()
+}
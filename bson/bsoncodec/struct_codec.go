@@ -177,7 +177,7 @@ func (sc *StructCodec) EncodeValue(r EncodeContext, vw bsonrw.ValueWriter, val r
 			return err
 		}
 
-		ectx := EncodeContext{Registry: r.Registry, MinSize: desc.minSize}
+		ectx := EncodeContext{Registry: r.Registry, MinSize: desc.minSize, NilSliceAsEmpty: r.NilSliceAsEmpty}
 		err = encoder.EncodeValue(ectx, vw2, rv)
 		if err != nil {
 			return err
@@ -366,6 +366,9 @@ func (sc *StructCodec) isZero(i interface{}) bool {
 	case reflect.Interface, reflect.Ptr:
 		return v.IsNil()
 	case reflect.Struct:
+		if isSQLNullInvalid(v) {
+			return true
+		}
 		if sc.EncodeOmitDefaultStruct {
 			vt := v.Type()
 			if vt == tTime {
@@ -7,6 +7,7 @@
 package bsoncodec
 
 import (
+	"database/sql"
 	"encoding/json"
 	"net/url"
 	"reflect"
@@ -53,5 +54,10 @@ var tD = reflect.TypeOf(primitive.D{})
 var tA = reflect.TypeOf(primitive.A{})
 var tE = reflect.TypeOf(primitive.E{})
 
+var tSQLNullString = reflect.TypeOf(sql.NullString{})
+var tSQLNullInt64 = reflect.TypeOf(sql.NullInt64{})
+var tSQLNullFloat64 = reflect.TypeOf(sql.NullFloat64{})
+var tSQLNullBool = reflect.TypeOf(sql.NullBool{})
+
 var tCoreDocument = reflect.TypeOf(bsoncore.Document{})
 var tCoreArray = reflect.TypeOf(bsoncore.Array{})
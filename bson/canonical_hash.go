@@ -0,0 +1,83 @@
+// Copyright (C) MongoDB, Inc. 2017-present.
+//
+// Licensed under the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License. You may obtain
+// a copy of the License at http://www.apache.org/licenses/LICENSE-2.0
+
+package bson
+
+import (
+	"crypto/sha256"
+	"math"
+	"sort"
+	"strconv"
+
+	"go.mongodb.org/mongo-driver/bson/bsontype"
+	"go.mongodb.org/mongo-driver/x/bsonx/bsoncore"
+)
+
+// CanonicalHash returns a SHA-256 hash of the semantic content of a document. Before hashing, the elements of the
+// document and of all embedded documents are sorted by key, and int32, int64, and double values are normalized so that
+// values representing the same number hash equal, e.g. int32(1), int64(1), and 1.0. The order of array elements is
+// preserved. Decimal128 values are not normalized. An error is returned if the document is invalid.
+func CanonicalHash(raw Raw) ([]byte, error) {
+	if err := raw.Validate(); err != nil {
+		return nil, err
+	}
+
+	canonical, err := appendCanonicalDocument(nil, bsoncore.Document(raw))
+	if err != nil {
+		return nil, err
+	}
+	sum := sha256.Sum256(canonical)
+	return sum[:], nil
+}
+
+// appendCanonicalDocument appends doc to dst with its elements sorted by key and its values canonicalized.
+func appendCanonicalDocument(dst []byte, doc bsoncore.Document) ([]byte, error) {
+	elems, err := doc.Elements()
+	if err != nil {
+		return dst, err
+	}
+	sort.SliceStable(elems, func(i, j int) bool {
+		return elems[i].Key() < elems[j].Key()
+	})
+
+	idx, dst := bsoncore.AppendDocumentStart(dst)
+	for _, elem := range elems {
+		if dst, err = appendCanonicalValue(dst, elem.Key(), elem.Value()); err != nil {
+			return dst, err
+		}
+	}
+	return bsoncore.AppendDocumentEnd(dst, idx)
+}
+
+// appendCanonicalValue appends an element with key and the canonical form of val to dst.
+func appendCanonicalValue(dst []byte, key string, val bsoncore.Value) ([]byte, error) {
+	switch val.Type {
+	case bsontype.EmbeddedDocument:
+		dst = bsoncore.AppendHeader(dst, bsontype.EmbeddedDocument, key)
+		return appendCanonicalDocument(dst, val.Document())
+	case bsontype.Array:
+		vals, err := val.Array().Values()
+		if err != nil {
+			return dst, err
+		}
+		idx, dst := bsoncore.AppendArrayElementStart(dst, key)
+		for i, v := range vals {
+			if dst, err = appendCanonicalValue(dst, strconv.Itoa(i), v); err != nil {
+				return dst, err
+			}
+		}
+		return bsoncore.AppendArrayEnd(dst, idx)
+	case bsontype.Int32:
+		return bsoncore.AppendInt64Element(dst, key, int64(val.Int32())), nil
+	case bsontype.Double:
+		// Doubles with an integral value that fits in an int64 are hashed like the equivalent int64.
+		f := val.Double()
+		if f == math.Trunc(f) && f >= math.MinInt64 && f < math.MaxInt64 {
+			return bsoncore.AppendInt64Element(dst, key, int64(f)), nil
+		}
+	}
+	return bsoncore.AppendValueElement(dst, key, val), nil
+}
@@ -0,0 +1,80 @@
+// Copyright (C) MongoDB, Inc. 2017-present.
+//
+// Licensed under the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License. You may obtain
+// a copy of the License at http://www.apache.org/licenses/LICENSE-2.0
+
+package bson
+
+import (
+	"bytes"
+	"testing"
+
+	"go.mongodb.org/mongo-driver/internal/testutil/assert"
+)
+
+func TestCanonicalHash(t *testing.T) {
+	hash := func(t *testing.T, val interface{}) []byte {
+		t.Helper()
+
+		b, err := Marshal(val)
+		assert.Nil(t, err, "Marshal error: %v", err)
+		h, err := CanonicalHash(b)
+		assert.Nil(t, err, "CanonicalHash error: %v", err)
+		return h
+	}
+
+	t.Run("equal", func(t *testing.T) {
+		testCases := []struct {
+			name   string
+			first  interface{}
+			second interface{}
+		}{
+			{
+				"reordered keys",
+				D{{"a", "x"}, {"b", true}, {"c", nil}},
+				D{{"c", nil}, {"a", "x"}, {"b", true}},
+			},
+			{
+				"reordered nested keys",
+				D{{"server", D{{"host", "localhost"}, {"port", int32(27017)}}}, {"tags", A{D{{"x", 1.5}, {"y", "z"}}}}},
+				D{{"tags", A{D{{"y", "z"}, {"x", 1.5}}}}, {"server", D{{"port", int32(27017)}, {"host", "localhost"}}}},
+			},
+			{
+				"numeric types",
+				D{{"a", int32(1)}, {"b", int64(-2)}, {"c", 3.0}},
+				D{{"a", 1.0}, {"b", int32(-2)}, {"c", int64(3)}},
+			},
+		}
+		for _, tc := range testCases {
+			t.Run(tc.name, func(t *testing.T) {
+				first, second := hash(t, tc.first), hash(t, tc.second)
+				assert.True(t, bytes.Equal(first, second), "expected equal hashes, got %x and %x", first, second)
+			})
+		}
+	})
+	t.Run("distinct", func(t *testing.T) {
+		testCases := []struct {
+			name   string
+			first  interface{}
+			second interface{}
+		}{
+			{"different values", D{{"a", "x"}}, D{{"a", "y"}}},
+			{"different keys", D{{"a", "x"}}, D{{"b", "x"}}},
+			{"different types", D{{"a", int32(1)}}, D{{"a", "1"}}},
+			{"non-integral double", D{{"a", int32(1)}}, D{{"a", 1.5}}},
+			{"reordered array", D{{"a", A{int32(1), int32(2)}}}, D{{"a", A{int32(2), int32(1)}}}},
+			{"extra element", D{{"a", "x"}}, D{{"a", "x"}, {"b", "y"}}},
+		}
+		for _, tc := range testCases {
+			t.Run(tc.name, func(t *testing.T) {
+				first, second := hash(t, tc.first), hash(t, tc.second)
+				assert.False(t, bytes.Equal(first, second), "expected distinct hashes, got %x for both", first)
+			})
+		}
+	})
+	t.Run("invalid document", func(t *testing.T) {
+		_, err := CanonicalHash(Raw{0x05, 0x00})
+		assert.NotNil(t, err, "expected error for invalid document, got nil")
+	})
+}
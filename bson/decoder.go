@@ -19,6 +19,10 @@ import (
 // ErrDecodeToNil is the error returned when trying to decode to a nil value
 var ErrDecodeToNil = errors.New("cannot Decode to nil value")
 
+// ErrMaxDepthExceeded is the error returned by a Decoder when a document is nested more deeply than the limit set with
+// SetMaxDepth.
+var ErrMaxDepthExceeded = errors.New("BSON nesting depth exceeds the maximum depth")
+
 // This pool is used to keep the allocations of Decoders down. This is only used for the Marshal*
 // methods and is not consumable from outside of this package. The Decoders retrieved from this pool
 // must have both Reset and SetRegistry called on them.
@@ -31,8 +35,9 @@ var decPool = sync.Pool{
 // A Decoder reads and decodes BSON documents from a stream. It reads from a bsonrw.ValueReader as
 // the source of BSON data.
 type Decoder struct {
-	dc bsoncodec.DecodeContext
-	vr bsonrw.ValueReader
+	dc       bsoncodec.DecodeContext
+	vr       bsonrw.ValueReader
+	maxDepth int
 }
 
 // NewDecoder returns a new decoder that uses the DefaultRegistry to read from vr.
@@ -68,9 +73,14 @@ func NewDecoderWithContext(dc bsoncodec.DecodeContext, vr bsonrw.ValueReader) (*
 // The documentation for Unmarshal contains details about of BSON into a Go
 // value.
 func (d *Decoder) Decode(val interface{}) error {
+	vr := d.vr
+	if d.maxDepth > 0 {
+		vr = &depthLimitedValueReader{ValueReader: vr, maxDepth: d.maxDepth}
+	}
+
 	if unmarshaler, ok := val.(Unmarshaler); ok {
 		// TODO(skriptble): Reuse a []byte here and use the AppendDocumentBytes method.
-		buf, err := bsonrw.Copier{}.CopyDocumentToBytes(d.vr)
+		buf, err := bsonrw.Copier{}.CopyDocumentToBytes(vr)
 		if err != nil {
 			return err
 		}
@@ -95,7 +105,7 @@ func (d *Decoder) Decode(val interface{}) error {
 	if err != nil {
 		return err
 	}
-	return decoder.DecodeValue(d.dc, d.vr, rval)
+	return decoder.DecodeValue(d.dc, vr, rval)
 }
 
 // Reset will reset the state of the decoder, using the same *DecodeContext used in
@@ -116,3 +126,93 @@ func (d *Decoder) SetContext(dc bsoncodec.DecodeContext) error {
 	d.dc = dc
 	return nil
 }
+
+// SetMaxDepth limits the nesting depth of the documents the decoder reads to depth. The top-level document has a
+// depth of 1 and each embedded document, array, or code with scope value adds 1 to the depth of its parent. If a
+// document is nested more deeply than depth, Decode returns ErrMaxDepthExceeded, which may be wrapped in a
+// *bsoncodec.DecodeError that reports the keys leading to the value. This protects against deeply nested documents
+// from untrusted sources using excessive stack space. A depth of 0 removes the limit, which is the default. An error
+// is returned if depth is negative.
+func (d *Decoder) SetMaxDepth(depth int) error {
+	if depth < 0 {
+		return fmt.Errorf("max depth must be non-negative, got %d", depth)
+	}
+	d.maxDepth = depth
+	return nil
+}
+
+// depthLimitedValueReader is a ValueReader that returns ErrMaxDepthExceeded when reading a document, array, or code
+// with scope value would exceed maxDepth levels of nesting. depth is the nesting depth of the value being read.
+type depthLimitedValueReader struct {
+	bsonrw.ValueReader
+	depth    int
+	maxDepth int
+}
+
+func (vr *depthLimitedValueReader) enter() error {
+	if vr.depth+1 > vr.maxDepth {
+		return ErrMaxDepthExceeded
+	}
+	return nil
+}
+
+func (vr *depthLimitedValueReader) ReadDocument() (bsonrw.DocumentReader, error) {
+	if err := vr.enter(); err != nil {
+		return nil, err
+	}
+	dr, err := vr.ValueReader.ReadDocument()
+	if err != nil {
+		return nil, err
+	}
+	return &depthLimitedDocumentReader{DocumentReader: dr, depth: vr.depth + 1, maxDepth: vr.maxDepth}, nil
+}
+
+func (vr *depthLimitedValueReader) ReadArray() (bsonrw.ArrayReader, error) {
+	if err := vr.enter(); err != nil {
+		return nil, err
+	}
+	ar, err := vr.ValueReader.ReadArray()
+	if err != nil {
+		return nil, err
+	}
+	return &depthLimitedArrayReader{ArrayReader: ar, depth: vr.depth + 1, maxDepth: vr.maxDepth}, nil
+}
+
+func (vr *depthLimitedValueReader) ReadCodeWithScope() (string, bsonrw.DocumentReader, error) {
+	if err := vr.enter(); err != nil {
+		return "", nil, err
+	}
+	code, dr, err := vr.ValueReader.ReadCodeWithScope()
+	if err != nil {
+		return "", nil, err
+	}
+	return code, &depthLimitedDocumentReader{DocumentReader: dr, depth: vr.depth + 1, maxDepth: vr.maxDepth}, nil
+}
+
+type depthLimitedDocumentReader struct {
+	bsonrw.DocumentReader
+	depth    int
+	maxDepth int
+}
+
+func (dr *depthLimitedDocumentReader) ReadElement() (string, bsonrw.ValueReader, error) {
+	key, vr, err := dr.DocumentReader.ReadElement()
+	if err != nil {
+		return "", nil, err
+	}
+	return key, &depthLimitedValueReader{ValueReader: vr, depth: dr.depth, maxDepth: dr.maxDepth}, nil
+}
+
+type depthLimitedArrayReader struct {
+	bsonrw.ArrayReader
+	depth    int
+	maxDepth int
+}
+
+func (ar *depthLimitedArrayReader) ReadValue() (bsonrw.ValueReader, error) {
+	vr, err := ar.ArrayReader.ReadValue()
+	if err != nil {
+		return nil, err
+	}
+	return &depthLimitedValueReader{ValueReader: vr, depth: ar.depth, maxDepth: ar.maxDepth}, nil
+}
@@ -235,6 +235,76 @@ func TestDecoderv2(t *testing.T) {
 			t.Fatalf("Decode error mismatch; expected %v, got %v", ErrDecodeToNil, err)
 		}
 	})
+	t.Run("SetMaxDepth", func(t *testing.T) {
+		// nested returns a document with the given nesting depth. Odd levels are documents and even levels are
+		// arrays.
+		nested := func(depth int) []byte {
+			var val interface{} = "leaf"
+			for i := depth; i > 1; i-- {
+				if i%2 == 0 {
+					val = A{val}
+				} else {
+					val = D{{"a", val}}
+				}
+			}
+			return docToBytes(D{{"a", val}})
+		}
+		// decode decodes data into val and unwraps the returned error if it is a *bsoncodec.DecodeError.
+		decode := func(t *testing.T, maxDepth int, data []byte, val interface{}) error {
+			t.Helper()
+			dec, err := NewDecoder(bsonrw.NewBSONDocumentReader(data))
+			noerr(t, err)
+			noerr(t, dec.SetMaxDepth(maxDepth))
+			err = dec.Decode(val)
+			if de, ok := err.(*bsoncodec.DecodeError); ok {
+				return de.Unwrap()
+			}
+			return err
+		}
+
+		testCases := []struct {
+			name string
+			val  func() interface{}
+		}{
+			{"D", func() interface{} { return &D{} }},
+			{"M", func() interface{} { return &M{} }},
+			{"Raw", func() interface{} { return &Raw{} }},
+			{"Unmarshaler", func() interface{} { return &testUnmarshaler{} }},
+		}
+		for _, tc := range testCases {
+			t.Run(tc.name, func(t *testing.T) {
+				err := decode(t, 5, nested(5), tc.val())
+				assert.Nil(t, err, "expected no error at the maximum depth, got %v", err)
+
+				err = decode(t, 5, nested(6), tc.val())
+				assert.Equal(t, ErrMaxDepthExceeded, err, "expected error %v, got %v", ErrMaxDepthExceeded, err)
+
+				err = decode(t, 0, nested(100), tc.val())
+				assert.Nil(t, err, "expected no error without a maximum depth, got %v", err)
+			})
+		}
+		t.Run("struct", func(t *testing.T) {
+			type inner struct {
+				B []int32
+			}
+			type outer struct {
+				A inner
+			}
+			data := docToBytes(D{{"a", D{{"b", A{int32(1)}}}}})
+
+			var got outer
+			err := decode(t, 3, data, &got)
+			assert.Nil(t, err, "expected no error at the maximum depth, got %v", err)
+			err = decode(t, 2, data, &got)
+			assert.Equal(t, ErrMaxDepthExceeded, err, "expected error %v, got %v", ErrMaxDepthExceeded, err)
+		})
+		t.Run("negative depth", func(t *testing.T) {
+			dec, err := NewDecoder(bsonrw.NewBSONDocumentReader(nested(1)))
+			noerr(t, err)
+			err = dec.SetMaxDepth(-1)
+			assert.NotNil(t, err, "expected SetMaxDepth error, got nil")
+		})
+	})
 }
 
 type testUnmarshaler struct {
@@ -92,6 +92,13 @@ func (e *Encoder) SetRegistry(r *bsoncodec.Registry) error {
 	return nil
 }
 
+// SetNilSliceAsEmpty specifies whether nil slices are encoded like empty slices instead of as null. If b is true, nil
+// slices are encoded as empty arrays, nil byte slices as empty binaries, and nil primitive.D values as empty documents.
+func (e *Encoder) SetNilSliceAsEmpty(b bool) error {
+	e.ec.NilSliceAsEmpty = b
+	return nil
+}
+
 // SetContext replaces the current EncodeContext of the encoder with er.
 func (e *Encoder) SetContext(ec bsoncodec.EncodeContext) error {
 	e.ec = ec
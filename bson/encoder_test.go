@@ -37,6 +37,70 @@ func TestBasicEncode(t *testing.T) {
 	}
 }
 
+func TestEncoderNilSliceAsEmpty(t *testing.T) {
+	type nested struct {
+		Ints []int
+	}
+	newDoc := func(strings []string, bytes []byte, doc D, array A, ints []int) D {
+		return D{
+			{"strings", strings},
+			{"bytes", bytes},
+			{"doc", doc},
+			{"array", array},
+			{"nested", nested{Ints: ints}},
+			{"map", M{"ints": ints}},
+		}
+	}
+	nilDoc := newDoc(nil, nil, nil, nil, nil)
+	emptyDoc := newDoc([]string{}, []byte{}, D{}, A{}, []int{})
+
+	nullResult := D{
+		{"strings", nil},
+		{"bytes", nil},
+		{"doc", nil},
+		{"array", nil},
+		{"nested", D{{"ints", nil}}},
+		{"map", D{{"ints", nil}}},
+	}
+	emptyResult := D{
+		{"strings", A{}},
+		{"bytes", []byte{}},
+		{"doc", D{}},
+		{"array", A{}},
+		{"nested", D{{"ints", A{}}}},
+		{"map", D{{"ints", A{}}}},
+	}
+
+	testCases := []struct {
+		name            string
+		nilSliceAsEmpty bool
+		val             D
+		want            D
+	}{
+		{"nil slices encode as null by default", false, nilDoc, nullResult},
+		{"empty slices encode as empty by default", false, emptyDoc, emptyResult},
+		{"nil slices encode as empty with NilSliceAsEmpty", true, nilDoc, emptyResult},
+		{"empty slices encode as empty with NilSliceAsEmpty", true, emptyDoc, emptyResult},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			got := make(bsonrw.SliceWriter, 0, 1024)
+			vw, err := bsonrw.NewBSONValueWriter(&got)
+			noerr(t, err)
+			enc, err := NewEncoder(vw)
+			noerr(t, err)
+			noerr(t, enc.SetNilSliceAsEmpty(tc.nilSliceAsEmpty))
+			noerr(t, enc.Encode(tc.val))
+
+			want, err := Marshal(tc.want)
+			noerr(t, err)
+			if !bytes.Equal(got, want) {
+				t.Errorf("documents are not equal. got %v; want %v", Raw(got), Raw(want))
+			}
+		})
+	}
+}
+
 func TestEncoderEncode(t *testing.T) {
 	for _, tc := range marshalingTestCases {
 		t.Run(tc.name, func(t *testing.T) {
@@ -0,0 +1,106 @@
+// Copyright (C) MongoDB, Inc. 2017-present.
+//
+// Licensed under the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License. You may obtain
+// a copy of the License at http://www.apache.org/licenses/LICENSE-2.0
+
+package bson
+
+import (
+	"errors"
+	"fmt"
+	"reflect"
+
+	"go.mongodb.org/mongo-driver/bson/bsoncodec"
+	"go.mongodb.org/mongo-driver/bson/bsonrw"
+	"go.mongodb.org/mongo-driver/bson/bsontype"
+)
+
+var tString = reflect.TypeOf("")
+var tError = reflect.TypeOf((*error)(nil)).Elem()
+
+// enumCodec is the ValueCodec returned by EnumCodec.
+type enumCodec struct {
+	t        reflect.Type
+	stringer reflect.Value
+	parser   reflect.Value
+}
+
+var _ bsoncodec.ValueCodec = (*enumCodec)(nil)
+
+// EnumCodec returns a bsoncodec.ValueCodec that stores values of an enum type T as BSON strings and parses them back
+// when decoding. The stringer parameter must be a function of the form func(T) string and the parser parameter must
+// be a function of the form func(string) (T, error). An error is returned if either function does not match these
+// forms.
+//
+// The returned codec should be registered as both the encoder and decoder for T:
+//
+//	codec, err := bson.EnumCodec(Color.String, ParseColor)
+//	if err != nil { ... }
+//	tColor := reflect.TypeOf(Color(0))
+//	reg := bson.NewRegistryBuilder().RegisterTypeEncoder(tColor, codec).RegisterTypeDecoder(tColor, codec).Build()
+//
+// Errors returned by the parser are returned from decoding.
+func EnumCodec(stringer, parser interface{}) (bsoncodec.ValueCodec, error) {
+	sv := reflect.ValueOf(stringer)
+	pv := reflect.ValueOf(parser)
+	if sv.Kind() != reflect.Func || sv.IsNil() {
+		return nil, errors.New("stringer must be a non-nil function")
+	}
+	if pv.Kind() != reflect.Func || pv.IsNil() {
+		return nil, errors.New("parser must be a non-nil function")
+	}
+
+	st := sv.Type()
+	if st.NumIn() != 1 || st.NumOut() != 1 || st.Out(0) != tString {
+		return nil, fmt.Errorf("stringer must be of the form func(T) string, got %v", st)
+	}
+	t := st.In(0)
+
+	pt := pv.Type()
+	if pt.NumIn() != 1 || pt.In(0) != tString || pt.NumOut() != 2 || pt.Out(0) != t || pt.Out(1) != tError {
+		return nil, fmt.Errorf("parser must be of the form func(string) (%v, error), got %v", t, pt)
+	}
+
+	return &enumCodec{t: t, stringer: sv, parser: pv}, nil
+}
+
+// EncodeValue is the ValueEncoderFunc for the enum type.
+func (ec *enumCodec) EncodeValue(_ bsoncodec.EncodeContext, vw bsonrw.ValueWriter, val reflect.Value) error {
+	if !val.IsValid() || val.Type() != ec.t {
+		return bsoncodec.ValueEncoderError{Name: "EnumEncodeValue", Types: []reflect.Type{ec.t}, Received: val}
+	}
+
+	out := ec.stringer.Call([]reflect.Value{val})
+	return vw.WriteString(out[0].String())
+}
+
+// DecodeValue is the ValueDecoderFunc for the enum type.
+func (ec *enumCodec) DecodeValue(_ bsoncodec.DecodeContext, vr bsonrw.ValueReader, val reflect.Value) error {
+	if !val.CanSet() || val.Type() != ec.t {
+		return bsoncodec.ValueDecoderError{Name: "EnumDecodeValue", Types: []reflect.Type{ec.t}, Received: val}
+	}
+
+	switch vr.Type() {
+	case bsontype.String:
+		str, err := vr.ReadString()
+		if err != nil {
+			return err
+		}
+
+		out := ec.parser.Call([]reflect.Value{reflect.ValueOf(str)})
+		if errVal := out[1]; !errVal.IsNil() {
+			return fmt.Errorf("cannot decode %q into a %v: %v", str, ec.t, errVal.Interface())
+		}
+		val.Set(out[0])
+		return nil
+	case bsontype.Null:
+		val.Set(reflect.Zero(ec.t))
+		return vr.ReadNull()
+	case bsontype.Undefined:
+		val.Set(reflect.Zero(ec.t))
+		return vr.ReadUndefined()
+	default:
+		return fmt.Errorf("cannot decode %v into a %v", vr.Type(), ec.t)
+	}
+}
@@ -0,0 +1,110 @@
+// Copyright (C) MongoDB, Inc. 2017-present.
+//
+// Licensed under the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License. You may obtain
+// a copy of the License at http://www.apache.org/licenses/LICENSE-2.0
+
+package bson
+
+import (
+	"fmt"
+	"reflect"
+	"strings"
+	"testing"
+
+	"go.mongodb.org/mongo-driver/bson/bsontype"
+	"go.mongodb.org/mongo-driver/internal/testutil/assert"
+)
+
+type testColor int
+
+const (
+	testColorRed testColor = iota
+	testColorGreen
+)
+
+func (c testColor) String() string {
+	switch c {
+	case testColorRed:
+		return "red"
+	case testColorGreen:
+		return "green"
+	}
+	return "unknown"
+}
+
+func parseTestColor(s string) (testColor, error) {
+	switch s {
+	case "red":
+		return testColorRed, nil
+	case "green":
+		return testColorGreen, nil
+	}
+	return 0, fmt.Errorf("unknown color %q", s)
+}
+
+func TestEnumCodec(t *testing.T) {
+	tColor := reflect.TypeOf(testColor(0))
+	codec, err := EnumCodec(testColor.String, parseTestColor)
+	assert.Nil(t, err, "EnumCodec error: %v", err)
+	reg := NewRegistryBuilder().RegisterTypeEncoder(tColor, codec).RegisterTypeDecoder(tColor, codec).Build()
+
+	type palette struct {
+		Primary testColor
+	}
+
+	t.Run("stored as string", func(t *testing.T) {
+		doc, err := MarshalWithRegistry(reg, palette{Primary: testColorGreen})
+		assert.Nil(t, err, "Marshal error: %v", err)
+
+		val := Raw(doc).Lookup("primary")
+		assert.Equal(t, bsontype.String, val.Type, "expected type %v, got %v", bsontype.String, val.Type)
+		assert.Equal(t, "green", val.StringValue(), "expected value %q, got %q", "green", val.StringValue())
+	})
+	t.Run("round trip", func(t *testing.T) {
+		doc, err := MarshalWithRegistry(reg, palette{Primary: testColorGreen})
+		assert.Nil(t, err, "Marshal error: %v", err)
+
+		var got palette
+		err = UnmarshalWithRegistry(reg, doc, &got)
+		assert.Nil(t, err, "Unmarshal error: %v", err)
+		assert.Equal(t, testColorGreen, got.Primary, "expected %v, got %v", testColorGreen, got.Primary)
+	})
+	t.Run("unknown value", func(t *testing.T) {
+		doc, err := Marshal(D{{"primary", "purple"}})
+		assert.Nil(t, err, "Marshal error: %v", err)
+
+		var got palette
+		err = UnmarshalWithRegistry(reg, doc, &got)
+		assert.NotNil(t, err, "expected Unmarshal error, got nil")
+		assert.True(t, strings.Contains(err.Error(), `unknown color "purple"`),
+			"expected parser error in %q", err.Error())
+	})
+	t.Run("wrong BSON type", func(t *testing.T) {
+		doc, err := Marshal(D{{"primary", int32(1)}})
+		assert.Nil(t, err, "Marshal error: %v", err)
+
+		var got palette
+		err = UnmarshalWithRegistry(reg, doc, &got)
+		assert.NotNil(t, err, "expected Unmarshal error, got nil")
+	})
+	t.Run("invalid functions", func(t *testing.T) {
+		testCases := []struct {
+			name     string
+			stringer interface{}
+			parser   interface{}
+		}{
+			{"nil stringer", nil, parseTestColor},
+			{"nil parser", testColor.String, nil},
+			{"stringer returns int", func(testColor) int { return 0 }, parseTestColor},
+			{"parser type mismatch", testColor.String, func(string) (int, error) { return 0, nil }},
+			{"parser without error", testColor.String, func(string) testColor { return 0 }},
+		}
+		for _, tc := range testCases {
+			t.Run(tc.name, func(t *testing.T) {
+				_, err := EnumCodec(tc.stringer, tc.parser)
+				assert.NotNil(t, err, "expected EnumCodec error, got nil")
+			})
+		}
+	})
+}
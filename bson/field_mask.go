@@ -0,0 +1,48 @@
+// Copyright (C) MongoDB, Inc. 2017-present.
+//
+// Licensed under the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License. You may obtain
+// a copy of the License at http://www.apache.org/licenses/LICENSE-2.0
+
+package bson
+
+import (
+	"fmt"
+	"strings"
+)
+
+// MarshalFieldMask marshals v and returns only the fields at the given paths. Paths use the marshaled field names,
+// e.g. the names given by bson struct tags, and nested fields are separated by dots, e.g. "address.city". The returned
+// document has one element per path, in the order of paths, keyed by the full path so it can be used directly as the
+// value of an update's $set operator.
+//
+// An error is returned if a path is empty, if a path is given more than once or is nested inside another path, or if
+// a path does not exist in the marshaled document. Fields omitted by the omitempty struct tag option do not exist in
+// the marshaled document.
+func MarshalFieldMask(v interface{}, paths []string) (D, error) {
+	for i, path := range paths {
+		if path == "" {
+			return nil, fmt.Errorf("field mask path at index %d must not be empty", i)
+		}
+		for _, other := range paths[:i] {
+			if path == other || strings.HasPrefix(path, other+".") || strings.HasPrefix(other, path+".") {
+				return nil, fmt.Errorf("field mask paths %q and %q overlap", other, path)
+			}
+		}
+	}
+
+	raw, err := Marshal(v)
+	if err != nil {
+		return nil, err
+	}
+
+	masked := make(D, 0, len(paths))
+	for _, path := range paths {
+		val, err := Raw(raw).LookupErr(strings.Split(path, ".")...)
+		if err != nil {
+			return nil, fmt.Errorf("field mask path %q not found: %v", path, err)
+		}
+		masked = append(masked, E{Key: path, Value: val})
+	}
+	return masked, nil
+}
@@ -0,0 +1,87 @@
+// Copyright (C) MongoDB, Inc. 2017-present.
+//
+// Licensed under the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License. You may obtain
+// a copy of the License at http://www.apache.org/licenses/LICENSE-2.0
+
+package bson
+
+import (
+	"testing"
+
+	"go.mongodb.org/mongo-driver/internal/testutil/assert"
+)
+
+func TestMarshalFieldMask(t *testing.T) {
+	type address struct {
+		Street string `bson:"street"`
+		City   string `bson:"city"`
+	}
+	type user struct {
+		Name    string   `bson:"name"`
+		Email   string   `bson:"email_address"`
+		Age     int32    `bson:"age"`
+		Tags    []string `bson:"tags,omitempty"`
+		Address address  `bson:"address"`
+	}
+	u := user{
+		Name:    "Ada",
+		Email:   "ada@example.com",
+		Age:     36,
+		Address: address{Street: "1 Main St", City: "London"},
+	}
+
+	t.Run("success", func(t *testing.T) {
+		testCases := []struct {
+			name     string
+			paths    []string
+			expected D
+		}{
+			{"no paths", nil, D{}},
+			{"top-level fields", []string{"name", "age"}, D{{"name", "Ada"}, {"age", int32(36)}}},
+			{"tag names", []string{"email_address"}, D{{"email_address", "ada@example.com"}}},
+			{"path order", []string{"age", "name"}, D{{"age", int32(36)}, {"name", "Ada"}}},
+			{"nested field", []string{"address.city"}, D{{"address.city", "London"}}},
+			{"embedded document", []string{"address"}, D{{"address", D{{"street", "1 Main St"}, {"city", "London"}}}}},
+			{
+				"top-level and nested fields",
+				[]string{"name", "address.street"},
+				D{{"name", "Ada"}, {"address.street", "1 Main St"}},
+			},
+		}
+		for _, tc := range testCases {
+			t.Run(tc.name, func(t *testing.T) {
+				masked, err := MarshalFieldMask(u, tc.paths)
+				assert.Nil(t, err, "MarshalFieldMask error: %v", err)
+				assert.Equal(t, len(tc.paths), len(masked), "expected %d fields, got %d", len(tc.paths), len(masked))
+
+				got, err := Marshal(masked)
+				assert.Nil(t, err, "Marshal error: %v", err)
+				expected, err := Marshal(tc.expected)
+				assert.Nil(t, err, "Marshal error: %v", err)
+				assert.Equal(t, Raw(expected), Raw(got), "expected document %v, got %v", Raw(expected), Raw(got))
+			})
+		}
+	})
+	t.Run("errors", func(t *testing.T) {
+		testCases := []struct {
+			name  string
+			paths []string
+		}{
+			{"empty path", []string{""}},
+			{"unknown field", []string{"phone"}},
+			{"go field name", []string{"Email"}},
+			{"omitted field", []string{"tags"}},
+			{"unknown nested field", []string{"address.zip"}},
+			{"nested path in non-document", []string{"name.first"}},
+			{"duplicate path", []string{"name", "name"}},
+			{"overlapping paths", []string{"address", "address.city"}},
+		}
+		for _, tc := range testCases {
+			t.Run(tc.name, func(t *testing.T) {
+				_, err := MarshalFieldMask(u, tc.paths)
+				assert.NotNil(t, err, "expected MarshalFieldMask error for paths %v, got nil", tc.paths)
+			})
+		}
+	})
+}
@@ -0,0 +1,106 @@
+// Copyright (C) MongoDB, Inc. 2017-present.
+//
+// Licensed under the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License. You may obtain
+// a copy of the License at http://www.apache.org/licenses/LICENSE-2.0
+
+package bson
+
+import (
+	"fmt"
+	"reflect"
+
+	"go.mongodb.org/mongo-driver/bson/bsoncodec"
+	"go.mongodb.org/mongo-driver/bson/bsonrw"
+	"go.mongodb.org/mongo-driver/bson/bsontype"
+)
+
+var tLazy = reflect.TypeOf(Lazy{})
+
+// Lazy is a struct field type that defers decoding of a BSON value until it is needed. When a document is
+// unmarshalled, a Lazy field only keeps a copy of the value's bytes. The value is decoded the first time Decode is
+// called, which avoids the cost of decoding large arrays or subdocuments that are not always used.
+//
+//	type Order struct {
+//		ID    primitive.ObjectID `bson:"_id"`
+//		Items bson.Lazy          `bson:"items"`
+//	}
+//
+//	var items []Item
+//	err := order.Items.Decode(&items)
+//
+// The decoded value is cached, so later calls to Decode with a pointer to the same type copy the cached value instead
+// of decoding again. The copy is shallow: slices and maps share their contents with the cached value. Decoding uses
+// the registry the enclosing document was unmarshalled with. A Lazy is marshalled as the bytes it holds, so
+// documents can be round-tripped without decoding it. A Lazy is not safe for concurrent use.
+type Lazy struct {
+	raw     RawValue
+	decoded reflect.Value
+}
+
+// Raw returns the undecoded BSON value. The Type of the returned RawValue is 0 if the Lazy is empty.
+func (l Lazy) Raw() RawValue {
+	return l.raw
+}
+
+// IsZero returns true if the Lazy does not hold a value, which is the case if the field was missing or BSON null.
+// It allows a Lazy field tagged with omitempty to be omitted when marshalling.
+func (l Lazy) IsZero() bool {
+	return l.raw.Type == 0
+}
+
+// Decode decodes the held value into val, which must be a non-nil pointer. The value is only decoded on the first call
+// for a given type. If the Lazy is empty, val is left unchanged.
+func (l *Lazy) Decode(val interface{}) error {
+	rval := reflect.ValueOf(val)
+	if rval.Kind() != reflect.Ptr || rval.IsNil() {
+		return fmt.Errorf("argument to Decode must be a non-nil pointer to a type, but got %v", rval)
+	}
+	if l.IsZero() {
+		return nil
+	}
+	rval = rval.Elem()
+
+	if !l.decoded.IsValid() || l.decoded.Type() != rval.Type() {
+		decoded := reflect.New(rval.Type())
+		if err := l.raw.Unmarshal(decoded.Interface()); err != nil {
+			return err
+		}
+		l.decoded = decoded.Elem()
+	}
+
+	rval.Set(l.decoded)
+	return nil
+}
+
+// LazyEncodeValue is the ValueEncoderFunc for Lazy.
+func (PrimitiveCodecs) LazyEncodeValue(ec bsoncodec.EncodeContext, vw bsonrw.ValueWriter, val reflect.Value) error {
+	if !val.IsValid() || val.Type() != tLazy {
+		return bsoncodec.ValueEncoderError{Name: "LazyEncodeValue", Types: []reflect.Type{tLazy}, Received: val}
+	}
+
+	lazy := val.Interface().(Lazy)
+	if lazy.IsZero() {
+		return vw.WriteNull()
+	}
+	return bsonrw.Copier{}.CopyValueFromBytes(vw, lazy.raw.Type, lazy.raw.Value)
+}
+
+// LazyDecodeValue is the ValueDecoderFunc for Lazy.
+func (PrimitiveCodecs) LazyDecodeValue(dc bsoncodec.DecodeContext, vr bsonrw.ValueReader, val reflect.Value) error {
+	if !val.CanSet() || val.Type() != tLazy {
+		return bsoncodec.ValueDecoderError{Name: "LazyDecodeValue", Types: []reflect.Type{tLazy}, Received: val}
+	}
+
+	t, value, err := bsonrw.Copier{}.CopyValueToBytes(vr)
+	if err != nil {
+		return err
+	}
+
+	var lazy Lazy
+	if t != bsontype.Null && t != bsontype.Undefined {
+		lazy.raw = RawValue{Type: t, Value: value, r: dc.Registry}
+	}
+	val.Set(reflect.ValueOf(lazy))
+	return nil
+}
@@ -0,0 +1,107 @@
+// Copyright (C) MongoDB, Inc. 2017-present.
+//
+// Licensed under the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License. You may obtain
+// a copy of the License at http://www.apache.org/licenses/LICENSE-2.0
+
+package bson
+
+import (
+	"testing"
+
+	"go.mongodb.org/mongo-driver/bson/bsontype"
+	"go.mongodb.org/mongo-driver/internal/testutil/assert"
+)
+
+// countingInt counts how many times values of its type are decoded.
+type countingInt int32
+
+var countingIntDecodes int
+
+func (ci *countingInt) UnmarshalBSONValue(t bsontype.Type, data []byte) error {
+	countingIntDecodes++
+	var i int32
+	if err := (RawValue{Type: t, Value: data}).Unmarshal(&i); err != nil {
+		return err
+	}
+	*ci = countingInt(i)
+	return nil
+}
+
+func TestLazy(t *testing.T) {
+	type order struct {
+		Name  string
+		Items Lazy `bson:"items,omitempty"`
+	}
+
+	doc, err := Marshal(D{{"name", "big"}, {"items", A{int32(1), int32(2), int32(3)}}})
+	assert.Nil(t, err, "Marshal error: %v", err)
+
+	t.Run("decoded on first access", func(t *testing.T) {
+		countingIntDecodes = 0
+
+		var got order
+		err := Unmarshal(doc, &got)
+		assert.Nil(t, err, "Unmarshal error: %v", err)
+		assert.Equal(t, "big", got.Name, "expected name 'big', got %q", got.Name)
+		assert.Equal(t, 0, countingIntDecodes, "expected items not to be decoded, got %v decodes", countingIntDecodes)
+		assert.Equal(t, bsontype.Array, got.Items.Raw().Type, "expected raw type %v, got %v", bsontype.Array,
+			got.Items.Raw().Type)
+
+		var items []countingInt
+		err = got.Items.Decode(&items)
+		assert.Nil(t, err, "Decode error: %v", err)
+		assert.Equal(t, []countingInt{1, 2, 3}, items, "expected items [1 2 3], got %v", items)
+		assert.Equal(t, 3, countingIntDecodes, "expected 3 decodes, got %v", countingIntDecodes)
+
+		var again []countingInt
+		err = got.Items.Decode(&again)
+		assert.Nil(t, err, "Decode error: %v", err)
+		assert.Equal(t, items, again, "expected items %v, got %v", items, again)
+		assert.Equal(t, 3, countingIntDecodes, "expected cached value to be used, got %v decodes", countingIntDecodes)
+
+		var asInt64s []int64
+		err = got.Items.Decode(&asInt64s)
+		assert.Nil(t, err, "Decode error: %v", err)
+		assert.Equal(t, []int64{1, 2, 3}, asInt64s, "expected items [1 2 3], got %v", asInt64s)
+	})
+	t.Run("round trip", func(t *testing.T) {
+		var got order
+		err := Unmarshal(doc, &got)
+		assert.Nil(t, err, "Unmarshal error: %v", err)
+
+		out, err := Marshal(got)
+		assert.Nil(t, err, "Marshal error: %v", err)
+		assert.Equal(t, Raw(doc), Raw(out), "expected %v, got %v", Raw(doc), Raw(out))
+	})
+	t.Run("missing and null", func(t *testing.T) {
+		for _, input := range []D{{{"name", "empty"}}, {{"name", "empty"}, {"items", nil}}} {
+			b, err := Marshal(input)
+			assert.Nil(t, err, "Marshal error: %v", err)
+
+			var got order
+			err = Unmarshal(b, &got)
+			assert.Nil(t, err, "Unmarshal error: %v", err)
+			assert.True(t, got.Items.IsZero(), "expected Lazy to be empty")
+
+			items := []int32{7}
+			err = got.Items.Decode(&items)
+			assert.Nil(t, err, "Decode error: %v", err)
+			assert.Equal(t, []int32{7}, items, "expected items to be unchanged, got %v", items)
+
+			out, err := Marshal(got)
+			assert.Nil(t, err, "Marshal error: %v", err)
+			_, err = Raw(out).LookupErr("items")
+			assert.NotNil(t, err, "expected empty Lazy to be omitted, got %v", Raw(out))
+		}
+	})
+	t.Run("non-pointer argument", func(t *testing.T) {
+		var got order
+		err := Unmarshal(doc, &got)
+		assert.Nil(t, err, "Unmarshal error: %v", err)
+
+		var items []int32
+		err = got.Items.Decode(items)
+		assert.NotNil(t, err, "expected Decode error, got nil")
+	})
+}
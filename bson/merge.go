@@ -0,0 +1,134 @@
+// Copyright (C) MongoDB, Inc. 2017-present.
+//
+// Licensed under the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License. You may obtain
+// a copy of the License at http://www.apache.org/licenses/LICENSE-2.0
+
+package bson
+
+import (
+	"strconv"
+
+	"go.mongodb.org/mongo-driver/bson/bsontype"
+	"go.mongodb.org/mongo-driver/x/bsonx/bsoncore"
+)
+
+// MergeOptions represents options that can be used to configure Merge.
+type MergeOptions struct {
+	// If true, arrays that exist in both documents are merged by appending the elements of the override array to the
+	// elements of the base array. If false, the override array replaces the base array. Defaults to false.
+	AppendArrays *bool
+}
+
+// NewMergeOptions creates a new *MergeOptions.
+func NewMergeOptions() *MergeOptions {
+	return &MergeOptions{}
+}
+
+// SetAppendArrays specifies if arrays that exist in both documents are merged by appending the elements of the
+// override array to the elements of the base array instead of replacing the base array. Defaults to false.
+func (mo *MergeOptions) SetAppendArrays(b bool) *MergeOptions {
+	mo.AppendArrays = &b
+	return mo
+}
+
+// Merge deep-merges two documents and returns the result. Elements of override take precedence over elements of base
+// with the same key. If both values are embedded documents, they are merged recursively. If both values are arrays,
+// the override array replaces the base array unless the AppendArrays option is set. All other values from override
+// replace the values from base.
+//
+// The elements of base keep their order and the elements that exist only in override are appended in the order they
+// appear in override. An error is returned if either document is invalid.
+func Merge(base, override Raw, opts ...*MergeOptions) (Raw, error) {
+	if err := base.Validate(); err != nil {
+		return nil, err
+	}
+	if err := override.Validate(); err != nil {
+		return nil, err
+	}
+
+	var appendArrays bool
+	for _, opt := range opts {
+		if opt != nil && opt.AppendArrays != nil {
+			appendArrays = *opt.AppendArrays
+		}
+	}
+
+	merged, err := mergeDocuments(nil, bsoncore.Document(base), bsoncore.Document(override), appendArrays)
+	if err != nil {
+		return nil, err
+	}
+	return Raw(merged), nil
+}
+
+func mergeDocuments(dst []byte, base, override bsoncore.Document, appendArrays bool) ([]byte, error) {
+	baseElems, err := base.Elements()
+	if err != nil {
+		return dst, err
+	}
+	overrideElems, err := override.Elements()
+	if err != nil {
+		return dst, err
+	}
+
+	idx, dst := bsoncore.AppendDocumentStart(dst)
+	seen := make(map[string]struct{}, len(baseElems))
+	for _, elem := range baseElems {
+		key := elem.Key()
+		seen[key] = struct{}{}
+
+		baseVal := elem.Value()
+		overrideVal, err := override.LookupErr(key)
+		if err == bsoncore.ErrElementNotFound {
+			dst = bsoncore.AppendValueElement(dst, key, baseVal)
+			continue
+		}
+		if err != nil {
+			return dst, err
+		}
+
+		switch {
+		case baseVal.Type == bsontype.EmbeddedDocument && overrideVal.Type == bsontype.EmbeddedDocument:
+			dst = bsoncore.AppendHeader(dst, bsontype.EmbeddedDocument, key)
+			dst, err = mergeDocuments(dst, baseVal.Document(), overrideVal.Document(), appendArrays)
+			if err != nil {
+				return dst, err
+			}
+		case appendArrays && baseVal.Type == bsontype.Array && overrideVal.Type == bsontype.Array:
+			dst, err = appendArrayElements(dst, key, baseVal.Array(), overrideVal.Array())
+			if err != nil {
+				return dst, err
+			}
+		default:
+			dst = bsoncore.AppendValueElement(dst, key, overrideVal)
+		}
+	}
+
+	for _, elem := range overrideElems {
+		if _, ok := seen[elem.Key()]; ok {
+			continue
+		}
+		dst = bsoncore.AppendValueElement(dst, elem.Key(), elem.Value())
+	}
+
+	return bsoncore.AppendDocumentEnd(dst, idx)
+}
+
+// appendArrayElements appends an array element with key to dst containing the values of first followed by the values
+// of second.
+func appendArrayElements(dst []byte, key string, first, second bsoncore.Array) ([]byte, error) {
+	firstVals, err := first.Values()
+	if err != nil {
+		return dst, err
+	}
+	secondVals, err := second.Values()
+	if err != nil {
+		return dst, err
+	}
+
+	idx, dst := bsoncore.AppendArrayElementStart(dst, key)
+	for i, val := range append(firstVals, secondVals...) {
+		dst = bsoncore.AppendValueElement(dst, strconv.Itoa(i), val)
+	}
+	return bsoncore.AppendArrayEnd(dst, idx)
+}
@@ -0,0 +1,157 @@
+// Copyright (C) MongoDB, Inc. 2017-present.
+//
+// Licensed under the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License. You may obtain
+// a copy of the License at http://www.apache.org/licenses/LICENSE-2.0
+
+package bson
+
+import (
+	"testing"
+
+	"go.mongodb.org/mongo-driver/internal/testutil/assert"
+)
+
+func TestMerge(t *testing.T) {
+	marshal := func(t *testing.T, val interface{}) Raw {
+		t.Helper()
+
+		b, err := Marshal(val)
+		assert.Nil(t, err, "Marshal error: %v", err)
+		return b
+	}
+
+	base := D{
+		{"name", "app"},
+		{"server", D{
+			{"host", "localhost"},
+			{"port", int32(8080)},
+			{"tls", D{{"enabled", false}, {"ca", "ca.pem"}}},
+		}},
+		{"tags", A{"a", "b"}},
+		{"replicas", int32(1)},
+	}
+
+	testCases := []struct {
+		name     string
+		base     interface{}
+		override interface{}
+		opts     *MergeOptions
+		expected interface{}
+	}{
+		{
+			"scalar override",
+			D{{"a", int32(1)}, {"b", "x"}},
+			D{{"b", "y"}},
+			nil,
+			D{{"a", int32(1)}, {"b", "y"}},
+		},
+		{
+			"new keys are appended",
+			D{{"a", int32(1)}},
+			D{{"c", int32(3)}, {"b", int32(2)}},
+			nil,
+			D{{"a", int32(1)}, {"c", int32(3)}, {"b", int32(2)}},
+		},
+		{
+			"nested merge",
+			base,
+			D{
+				{"server", D{
+					{"port", int32(9090)},
+					{"tls", D{{"enabled", true}}},
+				}},
+				{"replicas", int32(3)},
+			},
+			nil,
+			D{
+				{"name", "app"},
+				{"server", D{
+					{"host", "localhost"},
+					{"port", int32(9090)},
+					{"tls", D{{"enabled", true}, {"ca", "ca.pem"}}},
+				}},
+				{"tags", A{"a", "b"}},
+				{"replicas", int32(3)},
+			},
+		},
+		{
+			"array replace by default",
+			base,
+			D{{"tags", A{"c"}}},
+			nil,
+			D{
+				{"name", "app"},
+				{"server", D{
+					{"host", "localhost"},
+					{"port", int32(8080)},
+					{"tls", D{{"enabled", false}, {"ca", "ca.pem"}}},
+				}},
+				{"tags", A{"c"}},
+				{"replicas", int32(1)},
+			},
+		},
+		{
+			"array append",
+			base,
+			D{{"tags", A{"c", int32(4)}}},
+			NewMergeOptions().SetAppendArrays(true),
+			D{
+				{"name", "app"},
+				{"server", D{
+					{"host", "localhost"},
+					{"port", int32(8080)},
+					{"tls", D{{"enabled", false}, {"ca", "ca.pem"}}},
+				}},
+				{"tags", A{"a", "b", "c", int32(4)}},
+				{"replicas", int32(1)},
+			},
+		},
+		{
+			"array append in nested document",
+			D{{"outer", D{{"list", A{int32(1)}}}}},
+			D{{"outer", D{{"list", A{int32(2)}}}}},
+			NewMergeOptions().SetAppendArrays(true),
+			D{{"outer", D{{"list", A{int32(1), int32(2)}}}}},
+		},
+		{
+			"document replaces scalar",
+			D{{"a", int32(1)}},
+			D{{"a", D{{"b", int32(2)}}}},
+			nil,
+			D{{"a", D{{"b", int32(2)}}}},
+		},
+		{
+			"scalar replaces document",
+			D{{"a", D{{"b", int32(2)}}}},
+			D{{"a", "flat"}},
+			nil,
+			D{{"a", "flat"}},
+		},
+		{
+			"array append ignored for mismatched types",
+			D{{"a", A{int32(1)}}},
+			D{{"a", int32(2)}},
+			NewMergeOptions().SetAppendArrays(true),
+			D{{"a", int32(2)}},
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			got, err := Merge(marshal(t, tc.base), marshal(t, tc.override), tc.opts)
+			assert.Nil(t, err, "Merge error: %v", err)
+
+			expected := marshal(t, tc.expected)
+			assert.Equal(t, expected, got, "expected document %v, got %v", expected, got)
+		})
+	}
+	t.Run("invalid document", func(t *testing.T) {
+		valid := marshal(t, D{{"a", int32(1)}})
+		invalid := Raw{0x05, 0x00}
+
+		_, err := Merge(invalid, valid)
+		assert.NotNil(t, err, "expected Merge error for invalid base, got nil")
+		_, err = Merge(valid, invalid)
+		assert.NotNil(t, err, "expected Merge error for invalid override, got nil")
+	})
+}
@@ -0,0 +1,223 @@
+// Copyright (C) MongoDB, Inc. 2017-present.
+//
+// Licensed under the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License. You may obtain
+// a copy of the License at http://www.apache.org/licenses/LICENSE-2.0
+
+package bson
+
+import (
+	"errors"
+	"fmt"
+	"math/big"
+	"reflect"
+	"strconv"
+	"strings"
+
+	"go.mongodb.org/mongo-driver/bson/bsoncodec"
+	"go.mongodb.org/mongo-driver/bson/bsonrw"
+	"go.mongodb.org/mongo-driver/bson/bsontype"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+// maxMoneyScale is the largest number of decimal places supported by Money. 10^18 is the largest power of ten that
+// fits in an int64.
+const maxMoneyScale = 18
+
+var tMoney = reflect.TypeOf(Money{})
+
+var errMoneyOverflow = errors.New("amount does not fit in a Money value")
+
+// Money is a fixed-point decimal amount equal to Units * 10^-Scale. For example, Money{Units: 1999, Scale: 2} is 19.99.
+// Scale must be between 0 and 18.
+type Money struct {
+	Units int64
+	Scale int
+}
+
+// ParseMoney parses a decimal string such as "19.99" or "-0.5" into a Money with the given scale. If the string has more
+// decimal places than scale, the amount is rounded half away from zero.
+func ParseMoney(s string, scale int) (Money, error) {
+	d, err := primitive.ParseDecimal128(s)
+	if err != nil {
+		return Money{}, err
+	}
+	return moneyFromDecimal128(d, scale)
+}
+
+// Round returns the amount rounded to the given number of decimal places. Rounding is half away from zero, so 0.125
+// rounded to 2 decimal places is 0.13 and -0.125 is -0.13.
+func (m Money) Round(scale int) (Money, error) {
+	if err := validateMoneyScale(m.Scale); err != nil {
+		return Money{}, err
+	}
+	return rescaleMoney(big.NewInt(m.Units), -m.Scale, scale)
+}
+
+// String returns the amount formatted as a decimal string with exactly Scale decimal places.
+func (m Money) String() string {
+	s := strconv.FormatInt(m.Units, 10)
+	sign := ""
+	if strings.HasPrefix(s, "-") {
+		sign, s = "-", s[1:]
+	}
+	if m.Scale <= 0 {
+		return sign + s + strings.Repeat("0", -m.Scale)
+	}
+
+	if len(s) <= m.Scale {
+		s = strings.Repeat("0", m.Scale-len(s)+1) + s
+	}
+	return sign + s[:len(s)-m.Scale] + "." + s[len(s)-m.Scale:]
+}
+
+// Decimal128 returns the amount as a primitive.Decimal128 with Scale decimal places.
+func (m Money) Decimal128() (primitive.Decimal128, error) {
+	if err := validateMoneyScale(m.Scale); err != nil {
+		return primitive.Decimal128{}, err
+	}
+	d, ok := primitive.ParseDecimal128FromBigInt(big.NewInt(m.Units), -m.Scale)
+	if !ok {
+		return primitive.Decimal128{}, fmt.Errorf("cannot convert %v to a Decimal128", m)
+	}
+	return d, nil
+}
+
+// moneyCodec is the ValueCodec returned by MoneyCodec.
+type moneyCodec struct {
+	scale int
+}
+
+var _ bsoncodec.ValueCodec = (*moneyCodec)(nil)
+
+// MoneyCodec returns a bsoncodec.ValueCodec that stores Money values as BSON Decimal128 values with the given number of
+// decimal places. Amounts are rounded half away from zero to scale decimal places when they are encoded and when they
+// are decoded, so decoded values always have Scale set to scale. Decoding also accepts BSON int32, int64, and double
+// values. An error is returned if scale is not between 0 and 18.
+//
+// The returned codec should be registered as both the encoder and decoder for Money:
+//
+//	codec, err := bson.MoneyCodec(2)
+//	if err != nil { ... }
+//	tMoney := reflect.TypeOf(bson.Money{})
+//	reg := bson.NewRegistryBuilder().RegisterTypeEncoder(tMoney, codec).RegisterTypeDecoder(tMoney, codec).Build()
+func MoneyCodec(scale int) (bsoncodec.ValueCodec, error) {
+	if err := validateMoneyScale(scale); err != nil {
+		return nil, err
+	}
+	return &moneyCodec{scale: scale}, nil
+}
+
+// EncodeValue is the ValueEncoderFunc for Money.
+func (mc *moneyCodec) EncodeValue(_ bsoncodec.EncodeContext, vw bsonrw.ValueWriter, val reflect.Value) error {
+	if !val.IsValid() || val.Type() != tMoney {
+		return bsoncodec.ValueEncoderError{Name: "MoneyEncodeValue", Types: []reflect.Type{tMoney}, Received: val}
+	}
+
+	rounded, err := val.Interface().(Money).Round(mc.scale)
+	if err != nil {
+		return err
+	}
+	d, err := rounded.Decimal128()
+	if err != nil {
+		return err
+	}
+	return vw.WriteDecimal128(d)
+}
+
+// DecodeValue is the ValueDecoderFunc for Money.
+func (mc *moneyCodec) DecodeValue(_ bsoncodec.DecodeContext, vr bsonrw.ValueReader, val reflect.Value) error {
+	if !val.CanSet() || val.Type() != tMoney {
+		return bsoncodec.ValueDecoderError{Name: "MoneyDecodeValue", Types: []reflect.Type{tMoney}, Received: val}
+	}
+
+	var m Money
+	var err error
+	switch vr.Type() {
+	case bsontype.Decimal128:
+		var d primitive.Decimal128
+		if d, err = vr.ReadDecimal128(); err != nil {
+			return err
+		}
+		m, err = moneyFromDecimal128(d, mc.scale)
+	case bsontype.Int32:
+		var i32 int32
+		if i32, err = vr.ReadInt32(); err != nil {
+			return err
+		}
+		m, err = rescaleMoney(big.NewInt(int64(i32)), 0, mc.scale)
+	case bsontype.Int64:
+		var i64 int64
+		if i64, err = vr.ReadInt64(); err != nil {
+			return err
+		}
+		m, err = rescaleMoney(big.NewInt(i64), 0, mc.scale)
+	case bsontype.Double:
+		var f64 float64
+		if f64, err = vr.ReadDouble(); err != nil {
+			return err
+		}
+		m, err = ParseMoney(strconv.FormatFloat(f64, 'f', -1, 64), mc.scale)
+	case bsontype.Null:
+		m = Money{Scale: mc.scale}
+		err = vr.ReadNull()
+	case bsontype.Undefined:
+		m = Money{Scale: mc.scale}
+		err = vr.ReadUndefined()
+	default:
+		return fmt.Errorf("cannot decode %v into a Money", vr.Type())
+	}
+	if err != nil {
+		return err
+	}
+
+	val.Set(reflect.ValueOf(m))
+	return nil
+}
+
+func validateMoneyScale(scale int) error {
+	if scale < 0 || scale > maxMoneyScale {
+		return fmt.Errorf("money scale must be between 0 and %d, got %d", maxMoneyScale, scale)
+	}
+	return nil
+}
+
+func moneyFromDecimal128(d primitive.Decimal128, scale int) (Money, error) {
+	if d.IsNaN() || d.IsInf() != 0 {
+		return Money{}, fmt.Errorf("cannot convert %v to a Money", d)
+	}
+	coefficient, exp, err := d.BigInt()
+	if err != nil {
+		return Money{}, err
+	}
+	return rescaleMoney(coefficient, exp, scale)
+}
+
+// rescaleMoney returns the amount coefficient * 10^exp as a Money with the given scale, rounding half away from zero.
+func rescaleMoney(coefficient *big.Int, exp int, scale int) (Money, error) {
+	if err := validateMoneyScale(scale); err != nil {
+		return Money{}, err
+	}
+
+	units := new(big.Int).Set(coefficient)
+	shift := exp + scale
+	switch {
+	case shift > 0:
+		units.Mul(units, new(big.Int).Exp(big.NewInt(10), big.NewInt(int64(shift)), nil))
+	case shift < 0:
+		divisor := new(big.Int).Exp(big.NewInt(10), big.NewInt(int64(-shift)), nil)
+		remainder := new(big.Int)
+		units.QuoRem(units, divisor, remainder)
+
+		// Round half away from zero by comparing twice the remainder against the divisor.
+		remainder.Abs(remainder).Lsh(remainder, 1)
+		if remainder.Cmp(divisor) >= 0 {
+			units.Add(units, big.NewInt(int64(coefficient.Sign())))
+		}
+	}
+
+	if !units.IsInt64() {
+		return Money{}, errMoneyOverflow
+	}
+	return Money{Units: units.Int64(), Scale: scale}, nil
+}
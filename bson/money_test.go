@@ -0,0 +1,164 @@
+// Copyright (C) MongoDB, Inc. 2017-present.
+//
+// Licensed under the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License. You may obtain
+// a copy of the License at http://www.apache.org/licenses/LICENSE-2.0
+
+package bson
+
+import (
+	"math"
+	"testing"
+
+	"go.mongodb.org/mongo-driver/bson/bsontype"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/internal/testutil/assert"
+)
+
+func TestMoney(t *testing.T) {
+	t.Run("ParseMoney rounding", func(t *testing.T) {
+		testCases := []struct {
+			input    string
+			expected Money
+		}{
+			{"19.99", Money{1999, 2}},
+			{"19.9", Money{1990, 2}},
+			{"20", Money{2000, 2}},
+			{"0.125", Money{13, 2}},
+			{"0.124", Money{12, 2}},
+			{"-0.125", Money{-13, 2}},
+			{"-0.124", Money{-12, 2}},
+			{"1.005", Money{101, 2}},
+			{"0.0049", Money{0, 2}},
+			{"1E+2", Money{10000, 2}},
+		}
+		for _, tc := range testCases {
+			t.Run(tc.input, func(t *testing.T) {
+				got, err := ParseMoney(tc.input, 2)
+				assert.Nil(t, err, "ParseMoney error: %v", err)
+				assert.Equal(t, tc.expected, got, "expected %v, got %v", tc.expected, got)
+			})
+		}
+	})
+	t.Run("String", func(t *testing.T) {
+		testCases := []struct {
+			m        Money
+			expected string
+		}{
+			{Money{1999, 2}, "19.99"},
+			{Money{5, 2}, "0.05"},
+			{Money{-5, 2}, "-0.05"},
+			{Money{-1999, 2}, "-19.99"},
+			{Money{42, 0}, "42"},
+			{Money{1, 3}, "0.001"},
+		}
+		for _, tc := range testCases {
+			got := tc.m.String()
+			assert.Equal(t, tc.expected, got, "expected %q, got %q", tc.expected, got)
+		}
+	})
+	t.Run("Round", func(t *testing.T) {
+		got, err := Money{12345, 3}.Round(2)
+		assert.Nil(t, err, "Round error: %v", err)
+		assert.Equal(t, Money{1235, 2}, got, "expected 12.35, got %v", got)
+
+		got, err = Money{1234, 2}.Round(4)
+		assert.Nil(t, err, "Round error: %v", err)
+		assert.Equal(t, Money{123400, 4}, got, "expected 12.3400, got %v", got)
+
+		_, err = Money{math.MaxInt64, 0}.Round(2)
+		assert.NotNil(t, err, "expected overflow error, got nil")
+	})
+	t.Run("invalid scale", func(t *testing.T) {
+		_, err := MoneyCodec(-1)
+		assert.NotNil(t, err, "expected MoneyCodec error, got nil")
+		_, err = MoneyCodec(19)
+		assert.NotNil(t, err, "expected MoneyCodec error, got nil")
+	})
+}
+
+func TestMoneyCodec(t *testing.T) {
+	codec, err := MoneyCodec(2)
+	assert.Nil(t, err, "MoneyCodec error: %v", err)
+	reg := NewRegistryBuilder().RegisterTypeEncoder(tMoney, codec).RegisterTypeDecoder(tMoney, codec).Build()
+
+	type invoice struct {
+		Total Money
+	}
+
+	t.Run("stored as decimal128", func(t *testing.T) {
+		doc, err := MarshalWithRegistry(reg, invoice{Total: Money{1999, 2}})
+		assert.Nil(t, err, "Marshal error: %v", err)
+
+		val := Raw(doc).Lookup("total")
+		assert.Equal(t, bsontype.Decimal128, val.Type, "expected type %v, got %v", bsontype.Decimal128, val.Type)
+		assert.Equal(t, "19.99", val.Decimal128().String(), "expected value 19.99, got %v", val.Decimal128())
+	})
+	t.Run("round trip", func(t *testing.T) {
+		for _, amount := range []string{"0.00", "0.01", "9.99", "19.99", "-42.50", "1234567.89"} {
+			m, err := ParseMoney(amount, 2)
+			assert.Nil(t, err, "ParseMoney error: %v", err)
+
+			doc, err := MarshalWithRegistry(reg, invoice{Total: m})
+			assert.Nil(t, err, "Marshal error: %v", err)
+
+			var got invoice
+			err = UnmarshalWithRegistry(reg, doc, &got)
+			assert.Nil(t, err, "Unmarshal error: %v", err)
+			assert.Equal(t, m, got.Total, "expected %v, got %v", m, got.Total)
+			assert.Equal(t, amount, got.Total.String(), "expected %q, got %q", amount, got.Total.String())
+		}
+	})
+	t.Run("encode rounds to scale", func(t *testing.T) {
+		doc, err := MarshalWithRegistry(reg, invoice{Total: Money{10005, 3}})
+		assert.Nil(t, err, "Marshal error: %v", err)
+
+		got := Raw(doc).Lookup("total").Decimal128().String()
+		assert.Equal(t, "10.01", got, "expected value 10.01, got %v", got)
+	})
+	t.Run("decode rounds to scale", func(t *testing.T) {
+		d, err := primitive.ParseDecimal128("2.675")
+		assert.Nil(t, err, "ParseDecimal128 error: %v", err)
+		doc, err := Marshal(D{{"total", d}})
+		assert.Nil(t, err, "Marshal error: %v", err)
+
+		var got invoice
+		err = UnmarshalWithRegistry(reg, doc, &got)
+		assert.Nil(t, err, "Unmarshal error: %v", err)
+		assert.Equal(t, Money{268, 2}, got.Total, "expected 2.68, got %v", got.Total)
+	})
+	t.Run("decode other numeric types", func(t *testing.T) {
+		testCases := []struct {
+			name     string
+			value    interface{}
+			expected Money
+		}{
+			{"int32", int32(5), Money{500, 2}},
+			{"int64", int64(-7), Money{-700, 2}},
+			{"double", 0.1, Money{10, 2}},
+			{"double rounding", 2.675, Money{268, 2}},
+			{"null", nil, Money{0, 2}},
+		}
+		for _, tc := range testCases {
+			t.Run(tc.name, func(t *testing.T) {
+				doc, err := Marshal(D{{"total", tc.value}})
+				assert.Nil(t, err, "Marshal error: %v", err)
+
+				var got invoice
+				err = UnmarshalWithRegistry(reg, doc, &got)
+				assert.Nil(t, err, "Unmarshal error: %v", err)
+				assert.Equal(t, tc.expected, got.Total, "expected %v, got %v", tc.expected, got.Total)
+			})
+		}
+	})
+	t.Run("decode errors", func(t *testing.T) {
+		for _, value := range []interface{}{"19.99", primitive.NewDecimal128(0x7C00000000000000, 0)} {
+			doc, err := Marshal(D{{"total", value}})
+			assert.Nil(t, err, "Marshal error: %v", err)
+
+			var got invoice
+			err = UnmarshalWithRegistry(reg, doc, &got)
+			assert.NotNil(t, err, "expected Unmarshal error for %v, got nil", value)
+		}
+	})
+}
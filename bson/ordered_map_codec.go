@@ -0,0 +1,152 @@
+// Copyright (C) MongoDB, Inc. 2017-present.
+//
+// Licensed under the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License. You may obtain
+// a copy of the License at http://www.apache.org/licenses/LICENSE-2.0
+
+package bson
+
+import (
+	"fmt"
+	"reflect"
+
+	"go.mongodb.org/mongo-driver/bson/bsoncodec"
+	"go.mongodb.org/mongo-driver/bson/bsonrw"
+	"go.mongodb.org/mongo-driver/bson/bsontype"
+)
+
+// OrderedMap is implemented by types that store key-value pairs and remember the order in which keys were first set.
+// Keys must return the keys in that order.
+type OrderedMap interface {
+	Set(key string, value interface{})
+	Get(key string) (interface{}, bool)
+	Keys() []string
+}
+
+var tOrderedMap = reflect.TypeOf((*OrderedMap)(nil)).Elem()
+var tEmptyInterface = reflect.TypeOf((*interface{})(nil)).Elem()
+
+// OrderedMapCodec is the ValueCodec for types that implement OrderedMap. Documents are decoded by calling Set for each
+// element in the order the elements appear in the document, and encoded by writing the elements in the order returned
+// by Keys. Element values are decoded the same way they would be decoded into an interface{}, except that embedded
+// documents are decoded into the same OrderedMap implementation so order is preserved at every level. If only the
+// pointer to a type implements OrderedMap, embedded documents are decoded into that pointer type.
+//
+// The codec supports pointer types implementing OrderedMap and types whose pointer implements OrderedMap. The zero
+// value of the type must be ready to use. OrderedMapCodec can be registered for a specific type or for every type
+// implementing OrderedMap:
+//
+//	reg := bson.NewRegistryBuilder().
+//		RegisterHookEncoder(reflect.TypeOf((*bson.OrderedMap)(nil)).Elem(), bson.OrderedMapCodec{}).
+//		RegisterHookDecoder(reflect.TypeOf((*bson.OrderedMap)(nil)).Elem(), bson.OrderedMapCodec{}).
+//		Build()
+type OrderedMapCodec struct{}
+
+var _ bsoncodec.ValueCodec = OrderedMapCodec{}
+
+// EncodeValue is the ValueEncoderFunc for OrderedMap implementations.
+func (OrderedMapCodec) EncodeValue(ec bsoncodec.EncodeContext, vw bsonrw.ValueWriter, val reflect.Value) error {
+	if !val.IsValid() || !val.Type().Implements(tOrderedMap) {
+		return bsoncodec.ValueEncoderError{Name: "OrderedMapEncodeValue", Types: []reflect.Type{tOrderedMap}, Received: val}
+	}
+	if val.Kind() == reflect.Ptr && val.IsNil() {
+		return vw.WriteNull()
+	}
+
+	om := val.Interface().(OrderedMap)
+	dw, err := vw.WriteDocument()
+	if err != nil {
+		return err
+	}
+
+	for _, key := range om.Keys() {
+		value, _ := om.Get(key)
+		evw, err := dw.WriteDocumentElement(key)
+		if err != nil {
+			return err
+		}
+
+		if value == nil {
+			if err = evw.WriteNull(); err != nil {
+				return err
+			}
+			continue
+		}
+
+		encoder, err := ec.LookupEncoder(reflect.TypeOf(value))
+		if err != nil {
+			return err
+		}
+		if err = encoder.EncodeValue(ec, evw, reflect.ValueOf(value)); err != nil {
+			return err
+		}
+	}
+
+	return dw.WriteDocumentEnd()
+}
+
+// DecodeValue is the ValueDecoderFunc for OrderedMap implementations.
+func (OrderedMapCodec) DecodeValue(dc bsoncodec.DecodeContext, vr bsonrw.ValueReader, val reflect.Value) error {
+	if !val.CanSet() {
+		return bsoncodec.ValueDecoderError{Name: "OrderedMapDecodeValue", Types: []reflect.Type{tOrderedMap}, Received: val}
+	}
+
+	var newMap func() OrderedMap
+	switch {
+	case val.Kind() == reflect.Ptr && val.Type().Implements(tOrderedMap):
+		dc.Ancestor = val.Type()
+		newMap = func() OrderedMap {
+			val.Set(reflect.New(val.Type().Elem()))
+			return val.Interface().(OrderedMap)
+		}
+	case val.CanAddr() && reflect.PtrTo(val.Type()).Implements(tOrderedMap):
+		dc.Ancestor = reflect.PtrTo(val.Type())
+		newMap = func() OrderedMap {
+			val.Set(reflect.Zero(val.Type()))
+			return val.Addr().Interface().(OrderedMap)
+		}
+	default:
+		return bsoncodec.ValueDecoderError{Name: "OrderedMapDecodeValue", Types: []reflect.Type{tOrderedMap}, Received: val}
+	}
+
+	switch vr.Type() {
+	case bsontype.Type(0), bsontype.EmbeddedDocument:
+	case bsontype.Null:
+		val.Set(reflect.Zero(val.Type()))
+		return vr.ReadNull()
+	case bsontype.Undefined:
+		val.Set(reflect.Zero(val.Type()))
+		return vr.ReadUndefined()
+	default:
+		return fmt.Errorf("cannot decode %v into a %v", vr.Type(), val.Type())
+	}
+
+	decoder, err := dc.LookupDecoder(tEmptyInterface)
+	if err != nil {
+		return err
+	}
+
+	dr, err := vr.ReadDocument()
+	if err != nil {
+		return err
+	}
+
+	om := newMap()
+	for {
+		key, evr, err := dr.ReadElement()
+		if err == bsonrw.ErrEOD {
+			break
+		}
+		if err != nil {
+			return err
+		}
+
+		elem := reflect.New(tEmptyInterface).Elem()
+		if err = decoder.DecodeValue(dc, evr, elem); err != nil {
+			return err
+		}
+		om.Set(key, elem.Interface())
+	}
+
+	return nil
+}
@@ -0,0 +1,128 @@
+// Copyright (C) MongoDB, Inc. 2017-present.
+//
+// Licensed under the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License. You may obtain
+// a copy of the License at http://www.apache.org/licenses/LICENSE-2.0
+
+package bson
+
+import (
+	"reflect"
+	"testing"
+
+	"go.mongodb.org/mongo-driver/bson/bsoncodec"
+	"go.mongodb.org/mongo-driver/internal/testutil/assert"
+)
+
+type testOrderedMap struct {
+	keys   []string
+	values map[string]interface{}
+}
+
+func (m *testOrderedMap) Set(key string, value interface{}) {
+	if m.values == nil {
+		m.values = make(map[string]interface{})
+	}
+	if _, ok := m.values[key]; !ok {
+		m.keys = append(m.keys, key)
+	}
+	m.values[key] = value
+}
+
+func (m *testOrderedMap) Get(key string) (interface{}, bool) {
+	value, ok := m.values[key]
+	return value, ok
+}
+
+func (m *testOrderedMap) Keys() []string {
+	return m.keys
+}
+
+func TestOrderedMapCodec(t *testing.T) {
+	reg := NewRegistryBuilder().
+		RegisterHookEncoder(tOrderedMap, OrderedMapCodec{}).
+		RegisterHookDecoder(tOrderedMap, OrderedMapCodec{}).
+		Build()
+
+	doc, err := Marshal(D{
+		{"zebra", int32(1)},
+		{"apple", "two"},
+		{"mango", D{{"y", true}, {"x", 3.5}}},
+		{"nothing", nil},
+	})
+	assert.Nil(t, err, "Marshal error: %v", err)
+
+	t.Run("preserves order", func(t *testing.T) {
+		var got testOrderedMap
+		err := UnmarshalWithRegistry(reg, doc, &got)
+		assert.Nil(t, err, "Unmarshal error: %v", err)
+
+		expected := []string{"zebra", "apple", "mango", "nothing"}
+		assert.Equal(t, expected, got.Keys(), "expected keys %v, got %v", expected, got.Keys())
+	})
+	t.Run("lookup by key", func(t *testing.T) {
+		var got testOrderedMap
+		err := UnmarshalWithRegistry(reg, doc, &got)
+		assert.Nil(t, err, "Unmarshal error: %v", err)
+
+		apple, ok := got.Get("apple")
+		assert.True(t, ok, "expected key 'apple' to be found")
+		assert.Equal(t, "two", apple, "expected value %q for key 'apple', got %v", "two", apple)
+		zebra, ok := got.Get("zebra")
+		assert.True(t, ok, "expected key 'zebra' to be found")
+		assert.Equal(t, int32(1), zebra, "expected value 1 for key 'zebra', got %v", zebra)
+		nothing, ok := got.Get("nothing")
+		assert.True(t, ok, "expected key 'nothing' to be found")
+		assert.Nil(t, nothing, "expected nil value for key 'nothing', got %v", nothing)
+		_, ok = got.Get("missing")
+		assert.False(t, ok, "expected key 'missing' not to be found")
+	})
+	t.Run("embedded documents", func(t *testing.T) {
+		var got testOrderedMap
+		err := UnmarshalWithRegistry(reg, doc, &got)
+		assert.Nil(t, err, "Unmarshal error: %v", err)
+
+		mango, _ := got.Get("mango")
+		embedded, ok := mango.(*testOrderedMap)
+		assert.True(t, ok, "expected embedded document of type *testOrderedMap, got %T", mango)
+		expected := []string{"y", "x"}
+		assert.Equal(t, expected, embedded.Keys(), "expected keys %v, got %v", expected, embedded.Keys())
+	})
+	t.Run("struct field", func(t *testing.T) {
+		type wrapper struct {
+			Fields *testOrderedMap
+		}
+		wrapped, err := Marshal(D{{"fields", Raw(doc)}})
+		assert.Nil(t, err, "Marshal error: %v", err)
+
+		var got wrapper
+		err = UnmarshalWithRegistry(reg, wrapped, &got)
+		assert.Nil(t, err, "Unmarshal error: %v", err)
+		assert.NotNil(t, got.Fields, "expected Fields to be set")
+		expected := []string{"zebra", "apple", "mango", "nothing"}
+		assert.Equal(t, expected, got.Fields.Keys(), "expected keys %v, got %v", expected, got.Fields.Keys())
+	})
+	t.Run("round trip", func(t *testing.T) {
+		var got testOrderedMap
+		err := UnmarshalWithRegistry(reg, doc, &got)
+		assert.Nil(t, err, "Unmarshal error: %v", err)
+
+		encoded, err := MarshalWithRegistry(reg, &got)
+		assert.Nil(t, err, "Marshal error: %v", err)
+		assert.Equal(t, Raw(doc), Raw(encoded), "expected document %v, got %v", Raw(doc), Raw(encoded))
+	})
+	t.Run("wrong BSON type", func(t *testing.T) {
+		wrapped, err := Marshal(D{{"fields", int32(1)}})
+		assert.Nil(t, err, "Marshal error: %v", err)
+
+		var got struct {
+			Fields *testOrderedMap
+		}
+		err = UnmarshalWithRegistry(reg, wrapped, &got)
+		assert.NotNil(t, err, "expected Unmarshal error, got nil")
+	})
+	t.Run("unsupported type", func(t *testing.T) {
+		err := OrderedMapCodec{}.DecodeValue(bsoncodec.DecodeContext{Registry: reg}, nil, reflect.ValueOf(new(int)).Elem())
+		assert.NotNil(t, err, "expected DecodeValue error, got nil")
+	})
+}
@@ -151,6 +151,17 @@ func (tp Timestamp) IsZero() bool {
 	return tp.T == 0 && tp.I == 0
 }
 
+// Time returns the time represented by the seconds component of tp. The increment is ignored.
+func (tp Timestamp) Time() time.Time {
+	return time.Unix(int64(tp.T), 0)
+}
+
+// NewTimestampFromTime creates a new Timestamp with the seconds component set from t and the given increment. The
+// sub-second part of t is truncated.
+func NewTimestampFromTime(t time.Time, i uint32) Timestamp {
+	return Timestamp{T: uint32(t.Unix()), I: i}
+}
+
 // CompareTimestamp returns an integer comparing two Timestamps, where T is compared first, followed by I.
 // Returns 0 if tp = tp2, 1 if tp > tp2, -1 if tp < tp2.
 func CompareTimestamp(tp, tp2 Timestamp) int {
@@ -42,6 +42,26 @@ func TestTimestampCompare(t *testing.T) {
 	}
 }
 
+func TestTimestampTime(t *testing.T) {
+	t.Run("Time", func(t *testing.T) {
+		tp := Timestamp{T: 1617235200, I: 7}
+		expected := time.Date(2021, time.April, 1, 0, 0, 0, 0, time.UTC)
+		got := tp.Time()
+		assert.True(t, expected.Equal(got), "expected time %v, got %v", expected, got)
+	})
+	t.Run("NewTimestampFromTime", func(t *testing.T) {
+		tm := time.Date(2021, time.April, 1, 0, 0, 0, 999999999, time.UTC)
+		got := NewTimestampFromTime(tm, 3)
+		expected := Timestamp{T: 1617235200, I: 3}
+		assert.Equal(t, expected, got, "expected timestamp %v, got %v", expected, got)
+	})
+	t.Run("round trip", func(t *testing.T) {
+		tp := Timestamp{T: 1234567890, I: 42}
+		got := NewTimestampFromTime(tp.Time(), tp.I)
+		assert.Equal(t, tp, got, "expected timestamp %v, got %v", tp, got)
+	})
+}
+
 func TestPrimitiveIsZero(t *testing.T) {
 	testcases := []struct {
 		name    string
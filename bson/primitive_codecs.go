@@ -34,7 +34,9 @@ func (pc PrimitiveCodecs) RegisterPrimitiveCodecs(rb *bsoncodec.RegistryBuilder)
 		RegisterTypeEncoder(tRawValue, bsoncodec.ValueEncoderFunc(pc.RawValueEncodeValue)).
 		RegisterTypeEncoder(tRaw, bsoncodec.ValueEncoderFunc(pc.RawEncodeValue)).
 		RegisterTypeDecoder(tRawValue, bsoncodec.ValueDecoderFunc(pc.RawValueDecodeValue)).
-		RegisterTypeDecoder(tRaw, bsoncodec.ValueDecoderFunc(pc.RawDecodeValue))
+		RegisterTypeDecoder(tRaw, bsoncodec.ValueDecoderFunc(pc.RawDecodeValue)).
+		RegisterTypeEncoder(tLazy, bsoncodec.ValueEncoderFunc(pc.LazyEncodeValue)).
+		RegisterTypeDecoder(tLazy, bsoncodec.ValueDecoderFunc(pc.LazyDecodeValue))
 }
 
 // RawValueEncodeValue is the ValueEncoderFunc for RawValue.
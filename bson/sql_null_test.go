@@ -0,0 +1,146 @@
+// Copyright (C) MongoDB, Inc. 2017-present.
+//
+// Licensed under the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License. You may obtain
+// a copy of the License at http://www.apache.org/licenses/LICENSE-2.0
+
+package bson
+
+import (
+	"database/sql"
+	"reflect"
+	"testing"
+
+	"go.mongodb.org/mongo-driver/bson/bsontype"
+	"go.mongodb.org/mongo-driver/internal/testutil/assert"
+)
+
+func TestSQLNullTypes(t *testing.T) {
+	type sqlNulls struct {
+		String  sql.NullString
+		Int64   sql.NullInt64
+		Float64 sql.NullFloat64
+		Bool    sql.NullBool
+	}
+	type sqlNullsOmitEmpty struct {
+		String  sql.NullString  `bson:",omitempty"`
+		Int64   sql.NullInt64   `bson:",omitempty"`
+		Float64 sql.NullFloat64 `bson:",omitempty"`
+		Bool    sql.NullBool    `bson:",omitempty"`
+	}
+
+	t.Run("valid", func(t *testing.T) {
+		in := sqlNulls{
+			String:  sql.NullString{String: "foo", Valid: true},
+			Int64:   sql.NullInt64{Int64: 42, Valid: true},
+			Float64: sql.NullFloat64{Float64: 3.14, Valid: true},
+			Bool:    sql.NullBool{Bool: true, Valid: true},
+		}
+		doc, err := Marshal(in)
+		assert.Nil(t, err, "Marshal error: %v", err)
+
+		expected := D{{"string", "foo"}, {"int64", int64(42)}, {"float64", 3.14}, {"bool", true}}
+		expectedDoc, err := Marshal(expected)
+		assert.Nil(t, err, "Marshal error: %v", err)
+		assert.Equal(t, Raw(expectedDoc), Raw(doc), "expected document %v, got %v", Raw(expectedDoc), Raw(doc))
+
+		var out sqlNulls
+		err = Unmarshal(doc, &out)
+		assert.Nil(t, err, "Unmarshal error: %v", err)
+		assert.Equal(t, in, out, "expected %v, got %v", in, out)
+	})
+	t.Run("valid zero values", func(t *testing.T) {
+		in := sqlNullsOmitEmpty{
+			String:  sql.NullString{Valid: true},
+			Int64:   sql.NullInt64{Valid: true},
+			Float64: sql.NullFloat64{Valid: true},
+			Bool:    sql.NullBool{Valid: true},
+		}
+		doc, err := Marshal(in)
+		assert.Nil(t, err, "Marshal error: %v", err)
+
+		elems, err := Raw(doc).Elements()
+		assert.Nil(t, err, "Elements error: %v", err)
+		assert.Equal(t, 4, len(elems), "expected 4 elements, got %v", len(elems))
+
+		var out sqlNullsOmitEmpty
+		err = Unmarshal(doc, &out)
+		assert.Nil(t, err, "Unmarshal error: %v", err)
+		assert.Equal(t, in, out, "expected %v, got %v", in, out)
+	})
+	t.Run("invalid", func(t *testing.T) {
+		// The underlying values should be ignored for invalid values.
+		in := sqlNulls{
+			String:  sql.NullString{String: "foo"},
+			Int64:   sql.NullInt64{Int64: 42},
+			Float64: sql.NullFloat64{Float64: 3.14},
+			Bool:    sql.NullBool{Bool: true},
+		}
+		doc, err := Marshal(in)
+		assert.Nil(t, err, "Marshal error: %v", err)
+
+		for _, key := range []string{"string", "int64", "float64", "bool"} {
+			val := Raw(doc).Lookup(key)
+			assert.Equal(t, bsontype.Null, val.Type, "expected %q to be %v, got %v", key, bsontype.Null, val.Type)
+		}
+
+		out := sqlNulls{
+			String:  sql.NullString{String: "bar", Valid: true},
+			Int64:   sql.NullInt64{Int64: 1, Valid: true},
+			Float64: sql.NullFloat64{Float64: 1, Valid: true},
+			Bool:    sql.NullBool{Bool: true, Valid: true},
+		}
+		err = Unmarshal(doc, &out)
+		assert.Nil(t, err, "Unmarshal error: %v", err)
+		assert.Equal(t, sqlNulls{}, out, "expected %v, got %v", sqlNulls{}, out)
+	})
+	t.Run("invalid omitempty", func(t *testing.T) {
+		in := sqlNullsOmitEmpty{
+			String: sql.NullString{String: "foo"},
+			Int64:  sql.NullInt64{Int64: 42},
+		}
+		doc, err := Marshal(in)
+		assert.Nil(t, err, "Marshal error: %v", err)
+		elems, err := Raw(doc).Elements()
+		assert.Nil(t, err, "Elements error: %v", err)
+		assert.Equal(t, 0, len(elems), "expected empty document, got %v", Raw(doc))
+
+		var out sqlNullsOmitEmpty
+		err = Unmarshal(doc, &out)
+		assert.Nil(t, err, "Unmarshal error: %v", err)
+		assert.Equal(t, sqlNullsOmitEmpty{}, out, "expected %v, got %v", sqlNullsOmitEmpty{}, out)
+	})
+	t.Run("decode converts numeric types", func(t *testing.T) {
+		doc, err := Marshal(D{{"int64", int32(7)}, {"float64", int32(2)}})
+		assert.Nil(t, err, "Marshal error: %v", err)
+
+		var out sqlNulls
+		err = Unmarshal(doc, &out)
+		assert.Nil(t, err, "Unmarshal error: %v", err)
+		assert.Equal(t, sql.NullInt64{Int64: 7, Valid: true}, out.Int64, "expected %v, got %v",
+			sql.NullInt64{Int64: 7, Valid: true}, out.Int64)
+		assert.Equal(t, sql.NullFloat64{Float64: 2, Valid: true}, out.Float64, "expected %v, got %v",
+			sql.NullFloat64{Float64: 2, Valid: true}, out.Float64)
+	})
+	t.Run("decode wrong type", func(t *testing.T) {
+		doc, err := Marshal(D{{"string", int32(1)}})
+		assert.Nil(t, err, "Marshal error: %v", err)
+
+		var out sqlNulls
+		err = Unmarshal(doc, &out)
+		assert.NotNil(t, err, "expected Unmarshal error, got nil")
+	})
+	t.Run("pointer fields", func(t *testing.T) {
+		type withPointer struct {
+			String *sql.NullString
+		}
+		in := withPointer{String: &sql.NullString{String: "foo", Valid: true}}
+		doc, err := Marshal(in)
+		assert.Nil(t, err, "Marshal error: %v", err)
+
+		var out withPointer
+		err = Unmarshal(doc, &out)
+		assert.Nil(t, err, "Unmarshal error: %v", err)
+		assert.True(t, reflect.DeepEqual(in, out), "expected %v, got %v", in, out)
+	})
+}
@@ -0,0 +1,108 @@
+// Copyright (C) MongoDB, Inc. 2017-present.
+//
+// Licensed under the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License. You may obtain
+// a copy of the License at http://www.apache.org/licenses/LICENSE-2.0
+
+package bson
+
+import (
+	"fmt"
+	"reflect"
+
+	"go.mongodb.org/mongo-driver/bson/bsoncodec"
+	"go.mongodb.org/mongo-driver/bson/bsonrw"
+	"go.mongodb.org/mongo-driver/bson/bsontype"
+	"go.mongodb.org/mongo-driver/x/bsonx/bsoncore"
+)
+
+// typeSwitch is the ValueDecoder returned by NewTypeSwitch.
+type typeSwitch struct {
+	field   string
+	mapping map[string]reflect.Type
+}
+
+var _ bsoncodec.ValueDecoder = (*typeSwitch)(nil)
+
+// NewTypeSwitch returns a bsoncodec.ValueDecoder that decodes documents into an interface type by choosing the
+// concrete type from a discriminator field. The value of the field named by the field parameter must be a string, and
+// it is looked up in mapping to find the type to decode the document into. Each type in mapping must be assignable to
+// the interface type the decoder is registered for.
+//
+// The returned decoder should be registered as the type decoder for the interface type:
+//
+//	tShape := reflect.TypeOf((*Shape)(nil)).Elem()
+//	ts := bson.NewTypeSwitch("_type", map[string]reflect.Type{
+//		"circle": reflect.TypeOf(Circle{}),
+//		"square": reflect.TypeOf(Square{}),
+//	})
+//	reg := bson.NewRegistryBuilder().RegisterTypeDecoder(tShape, ts).Build()
+//
+// Decoding returns an error if the discriminator field is missing, is not a string, or has a value that is not in
+// mapping. A BSON null or undefined value decodes to a nil interface. The discriminator field is decoded into the
+// concrete type like any other field, so it is only kept if the concrete type has a field for it.
+func NewTypeSwitch(field string, mapping map[string]reflect.Type) bsoncodec.ValueDecoder {
+	ts := &typeSwitch{
+		field:   field,
+		mapping: make(map[string]reflect.Type, len(mapping)),
+	}
+	for name, t := range mapping {
+		ts.mapping[name] = t
+	}
+	return ts
+}
+
+// DecodeValue is the ValueDecoderFunc for the interface type.
+func (ts *typeSwitch) DecodeValue(dc bsoncodec.DecodeContext, vr bsonrw.ValueReader, val reflect.Value) error {
+	if !val.CanSet() || val.Kind() != reflect.Interface {
+		return bsoncodec.ValueDecoderError{Name: "TypeSwitchDecodeValue", Kinds: []reflect.Kind{reflect.Interface}, Received: val}
+	}
+
+	switch vr.Type() {
+	case bsontype.Type(0), bsontype.EmbeddedDocument:
+	case bsontype.Null:
+		val.Set(reflect.Zero(val.Type()))
+		return vr.ReadNull()
+	case bsontype.Undefined:
+		val.Set(reflect.Zero(val.Type()))
+		return vr.ReadUndefined()
+	default:
+		return fmt.Errorf("cannot decode %v into a %v", vr.Type(), val.Type())
+	}
+
+	doc, err := bsonrw.Copier{}.CopyDocumentToBytes(vr)
+	if err != nil {
+		return err
+	}
+
+	discriminator, err := bsoncore.Document(doc).LookupErr(ts.field)
+	if err != nil {
+		return fmt.Errorf("cannot decode document into a %v: missing discriminator field %q", val.Type(), ts.field)
+	}
+	name, ok := discriminator.StringValueOK()
+	if !ok {
+		return fmt.Errorf("cannot decode document into a %v: discriminator field %q must be a string, got %v",
+			val.Type(), ts.field, discriminator.Type)
+	}
+	t, ok := ts.mapping[name]
+	if !ok || t == nil {
+		return fmt.Errorf("cannot decode document into a %v: no type registered for %q value %q", val.Type(),
+			ts.field, name)
+	}
+	if !t.AssignableTo(val.Type()) {
+		return fmt.Errorf("cannot decode document into a %v: type %v registered for %q value %q does not implement it",
+			val.Type(), t, ts.field, name)
+	}
+
+	decoder, err := dc.LookupDecoder(t)
+	if err != nil {
+		return err
+	}
+	target := reflect.New(t).Elem()
+	if err = decoder.DecodeValue(dc, bsonrw.NewBSONDocumentReader(doc), target); err != nil {
+		return err
+	}
+
+	val.Set(target)
+	return nil
+}
@@ -0,0 +1,109 @@
+// Copyright (C) MongoDB, Inc. 2017-present.
+//
+// Licensed under the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License. You may obtain
+// a copy of the License at http://www.apache.org/licenses/LICENSE-2.0
+
+package bson
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+
+	"go.mongodb.org/mongo-driver/internal/testutil/assert"
+)
+
+type testShape interface {
+	area() float64
+}
+
+type testCircle struct {
+	Type   string `bson:"_type"`
+	Radius float64
+}
+
+func (c testCircle) area() float64 { return 3 * c.Radius * c.Radius }
+
+type testRectangle struct {
+	Width  float64
+	Height float64
+}
+
+func (r *testRectangle) area() float64 { return r.Width * r.Height }
+
+func TestTypeSwitch(t *testing.T) {
+	tShape := reflect.TypeOf((*testShape)(nil)).Elem()
+	ts := NewTypeSwitch("_type", map[string]reflect.Type{
+		"circle":    reflect.TypeOf(testCircle{}),
+		"rectangle": reflect.TypeOf(&testRectangle{}),
+		"string":    reflect.TypeOf(""),
+	})
+	reg := NewRegistryBuilder().RegisterTypeDecoder(tShape, ts).Build()
+
+	type drawing struct {
+		Main   testShape
+		Others []testShape
+	}
+
+	t.Run("dispatches on discriminator", func(t *testing.T) {
+		doc, err := Marshal(D{
+			{"main", D{{"_type", "circle"}, {"radius", 2.0}}},
+			{"others", A{
+				D{{"_type", "rectangle"}, {"width", 2.0}, {"height", 3.0}},
+				nil,
+			}},
+		})
+		assert.Nil(t, err, "Marshal error: %v", err)
+
+		var got drawing
+		err = UnmarshalWithRegistry(reg, doc, &got)
+		assert.Nil(t, err, "Unmarshal error: %v", err)
+
+		circle, ok := got.Main.(testCircle)
+		assert.True(t, ok, "expected main to be a testCircle, got %T", got.Main)
+		assert.Equal(t, testCircle{Type: "circle", Radius: 2}, circle, "expected circle %v, got %v",
+			testCircle{Type: "circle", Radius: 2}, circle)
+
+		assert.Equal(t, 2, len(got.Others), "expected 2 other shapes, got %v", len(got.Others))
+		rect, ok := got.Others[0].(*testRectangle)
+		assert.True(t, ok, "expected first other shape to be a *testRectangle, got %T", got.Others[0])
+		assert.Equal(t, 6.0, rect.area(), "expected area 6, got %v", rect.area())
+		assert.Nil(t, got.Others[1], "expected second other shape to be nil, got %v", got.Others[1])
+	})
+	t.Run("top-level document", func(t *testing.T) {
+		doc, err := Marshal(D{{"_type", "rectangle"}, {"width", 4.0}, {"height", 5.0}})
+		assert.Nil(t, err, "Marshal error: %v", err)
+
+		var got testShape
+		err = UnmarshalWithRegistry(reg, doc, &got)
+		assert.Nil(t, err, "Unmarshal error: %v", err)
+		assert.Equal(t, &testRectangle{Width: 4, Height: 5}, got, "expected %v, got %v",
+			&testRectangle{Width: 4, Height: 5}, got)
+	})
+	t.Run("errors", func(t *testing.T) {
+		testCases := []struct {
+			name   string
+			main   interface{}
+			errMsg string
+		}{
+			{"missing discriminator", D{{"radius", 1.0}}, "missing discriminator field"},
+			{"non-string discriminator", D{{"_type", 1}}, "must be a string"},
+			{"unknown discriminator", D{{"_type", "triangle"}}, "no type registered"},
+			{"type does not implement interface", D{{"_type", "string"}}, "does not implement"},
+			{"not a document", "circle", "cannot decode string"},
+		}
+		for _, tc := range testCases {
+			t.Run(tc.name, func(t *testing.T) {
+				doc, err := Marshal(D{{"main", tc.main}})
+				assert.Nil(t, err, "Marshal error: %v", err)
+
+				var got drawing
+				err = UnmarshalWithRegistry(reg, doc, &got)
+				assert.NotNil(t, err, "expected Unmarshal error, got nil")
+				assert.True(t, strings.Contains(err.Error(), tc.errMsg), "expected error to contain %q, got %v",
+					tc.errMsg, err)
+			})
+		}
+	})
+}
@@ -8,6 +8,7 @@ package event // import "go.mongodb.org/mongo-driver/event"
 
 import (
 	"context"
+	"time"
 
 	"go.mongodb.org/mongo-driver/bson"
 	"go.mongodb.org/mongo-driver/bson/primitive"
@@ -28,6 +29,13 @@ type CommandStartedEvent struct {
 	// ServiceID contains the ID of the server to which the command was sent if it is running behind a load balancer.
 	// Otherwise, it is unset.
 	ServiceID *primitive.ObjectID
+	// ConnectionTag contains the workload tag of the connection used to send the command. If the client was not
+	// configured with a connection tag, it is empty.
+	ConnectionTag string
+	// RetryCount is the number of times the operation was retried before this command was sent, including a retry
+	// against the primary made because of primary fallback. It is 0 for the first attempt, so a CommandSucceededEvent
+	// with RetryCount 1 means the operation succeeded after retrying once.
+	RetryCount int
 }
 
 // CommandFinishedEvent represents a generic command finishing.
@@ -42,6 +50,32 @@ type CommandFinishedEvent struct {
 	// ServiceID contains the ID of the server to which the command was sent if it is running behind a load balancer.
 	// Otherwise, it is unset.
 	ServiceID *primitive.ObjectID
+	// ConnectionTag contains the workload tag of the connection used to send the command. If the client was not
+	// configured with a connection tag, it is empty.
+	ConnectionTag string
+	// RetryCount is the number of times the operation was retried before this command was sent, including a retry
+	// against the primary made because of primary fallback. It is 0 for the first attempt, so a CommandSucceededEvent
+	// with RetryCount 1 means the operation succeeded after retrying once.
+	RetryCount int
+	// Timings contains the time spent in each phase of running the command.
+	Timings CommandTimings
+}
+
+// CommandTimings breaks down the time spent running a command into phases. A phase that was not run for a command is
+// zero, e.g. ServerSelection and ConnectionCheckout are zero for the second and later commands of a batched write,
+// which reuse the connection of the first command.
+type CommandTimings struct {
+	// ServerSelection is the time spent selecting a server.
+	ServerSelection time.Duration
+	// ConnectionCheckout is the time spent checking out a connection from the selected server's pool.
+	ConnectionCheckout time.Duration
+	// RoundTrip is the time spent sending the command and reading the reply from the network.
+	RoundTrip time.Duration
+	// Decode is the time spent decompressing, decoding, and decrypting the reply.
+	Decode time.Duration
+	// Total is the time from the start of server selection for the command until the command finished. It includes
+	// the other phases as well as the time spent building the command.
+	Total time.Duration
 }
 
 // CommandSucceededEvent represents an event generated when a command's execution succeeds.
@@ -67,6 +101,7 @@ type CommandMonitor struct {
 const (
 	ReasonIdle              = "idle"
 	ReasonPoolClosed        = "poolClosed"
+	ReasonPoolDraining      = "poolDraining"
 	ReasonStale             = "stale"
 	ReasonConnectionErrored = "connectionError"
 	ReasonTimedOut          = "timeout"
@@ -78,6 +113,7 @@ const (
 	PoolCreated        = "ConnectionPoolCreated"
 	PoolReady          = "ConnectionPoolReady"
 	PoolCleared        = "ConnectionPoolCleared"
+	PoolWarmedUp       = "ConnectionPoolWarmedUp"
 	PoolClosedEvent    = "ConnectionPoolClosed"
 	ConnectionCreated  = "ConnectionCreated"
 	ConnectionReady    = "ConnectionReady"
@@ -105,6 +141,12 @@ type PoolEvent struct {
 	// ServiceID is only set if the Type is PoolCleared and the server is deployed behind a load balancer. This field
 	// can be used to distinguish between individual servers in a load balanced deployment.
 	ServiceID *primitive.ObjectID `json:"serviceId"`
+	// ConnectionTag is the workload tag of the pool's connections. If the client was not configured with a connection
+	// tag, it is empty.
+	ConnectionTag string `json:"connectionTag,omitempty"`
+	// DurationNanos is only set if the Type is PoolWarmedUp. It is the time between the pool being marked ready and
+	// the pool having established its minimum number of connections.
+	DurationNanos int64 `json:"durationNanos,omitempty"`
 }
 
 // PoolMonitor is a function that allows the user to gain access to events occurring in the pool
@@ -149,6 +191,56 @@ type TopologyClosedEvent struct {
 	TopologyID primitive.ObjectID // A unique identifier for the topology this server is a part of
 }
 
+// SplitBrainDetectedEvent is an event generated when two servers in the same replica set both claim to be primary
+// with the same election ID. This indicates a misconfigured deployment: a single election can only produce one
+// primary, so the driver has no way to tell which of the two is authoritative. The driver keeps the most recently
+// reported primary and marks the other as unknown, as it would for any primary change.
+type SplitBrainDetectedEvent struct {
+	TopologyID primitive.ObjectID // A unique identifier for the topology this server is a part of
+	SetName    string
+	ElectionID primitive.ObjectID
+	Primaries  []description.Server // The previously known primary followed by the newly reported primary
+}
+
+// TopologyCompatibilityChangedEvent is an event generated when the topology becomes incompatible with the driver
+// because a server reports a wire version range that does not overlap with the range supported by the driver, or
+// when it becomes compatible again. While the topology is incompatible, server selection fails with Error. The event
+// is only generated when compatibility changes, not for every heartbeat while the condition persists.
+type TopologyCompatibilityChangedEvent struct {
+	TopologyID primitive.ObjectID // A unique identifier for the topology this server is a part of
+	Compatible bool
+	Error      error // The compatibility error, or nil if Compatible is true
+
+	// The server that reported the unsupported wire version. If Compatible is true, this is the server that made the
+	// topology incompatible.
+	Address               address.Address
+	WireVersion           *description.VersionRange // The wire version range reported by Address
+	SupportedWireVersions description.VersionRange  // The wire version range supported by the driver
+}
+
+// TopologyCompatibilityWarningEvent is an event generated when the topology becomes incompatible with the driver but
+// server selection for read operations will still proceed because the topology was created with the
+// CompatibilityWarnReadOnly mode. It is generated once each time the compatibility error changes, not for every
+// server selection attempt that ignores the error.
+type TopologyCompatibilityWarningEvent struct {
+	TopologyID primitive.ObjectID // A unique identifier for the topology this server is a part of
+	Error      error              // The compatibility error that was ignored
+}
+
+// HandshakeInfo describes the outcome of a successful connection handshake.
+type HandshakeInfo struct {
+	// The command used for the handshake: "hello", or the legacy "isMaster" if the server was not yet known to
+	// support hello.
+	HelloCommand string
+
+	// The wire version range reported by the server. Both fields are 0 if the server did not report a wire version.
+	MinWireVersion int32
+	MaxWireVersion int32
+}
+
+// HandshakeCompleteFunc is called after the handshake for a new connection to the server at addr has completed.
+type HandshakeCompleteFunc func(addr address.Address, negotiated HandshakeInfo)
+
 // ServerHeartbeatStartedEvent is an event generated when the heartbeat is started.
 type ServerHeartbeatStartedEvent struct {
 	ConnectionID string // The address this heartbeat was sent to with a unique identifier
@@ -181,10 +273,13 @@ type ServerMonitor struct {
 	ServerClosed             func(*ServerClosedEvent)
 	// TopologyDescriptionChanged is called when the topology is locked, so the callback should
 	// not attempt any operation that requires server selection on the same client.
-	TopologyDescriptionChanged func(*TopologyDescriptionChangedEvent)
-	TopologyOpening            func(*TopologyOpeningEvent)
-	TopologyClosed             func(*TopologyClosedEvent)
-	ServerHeartbeatStarted     func(*ServerHeartbeatStartedEvent)
-	ServerHeartbeatSucceeded   func(*ServerHeartbeatSucceededEvent)
-	ServerHeartbeatFailed      func(*ServerHeartbeatFailedEvent)
+	TopologyDescriptionChanged   func(*TopologyDescriptionChangedEvent)
+	TopologyOpening              func(*TopologyOpeningEvent)
+	TopologyClosed               func(*TopologyClosedEvent)
+	SplitBrainDetected           func(*SplitBrainDetectedEvent)
+	TopologyCompatibilityChanged func(*TopologyCompatibilityChangedEvent)
+	TopologyCompatibilityWarning func(*TopologyCompatibilityWarningEvent)
+	ServerHeartbeatStarted       func(*ServerHeartbeatStartedEvent)
+	ServerHeartbeatSucceeded     func(*ServerHeartbeatSucceededEvent)
+	ServerHeartbeatFailed        func(*ServerHeartbeatFailedEvent)
 }
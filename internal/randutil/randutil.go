@@ -41,6 +41,15 @@ func (lr *LockedRand) Intn(n int) int {
 	return x
 }
 
+// Int63n returns, as an int64, a non-negative pseudo-random number in the half-open interval [0,n).
+// It panics if n <= 0.
+func (lr *LockedRand) Int63n(n int64) int64 {
+	lr.mu.Lock()
+	x := lr.r.Int63n(n)
+	lr.mu.Unlock()
+	return x
+}
+
 // Shuffle pseudo-randomizes the order of elements. n is the number of elements. Shuffle panics if
 // n < 0. swap swaps the elements with indexes i and j.
 //
@@ -30,6 +30,11 @@ type batchCursor interface {
 	Close(context.Context) error
 }
 
+// batchSizer is implemented by batch cursors whose batchSize for future getMores can be changed.
+type batchSizer interface {
+	SetBatchSize(int32)
+}
+
 // changeStreamCursor is the interface implemented by batch cursors that also provide the functionality for retrieving
 // a postBatchResumeToken from commands and allows for the cursor to be killed rather than closed
 type changeStreamCursor interface {
@@ -114,6 +114,7 @@ func (bw *bulkWrite) runBatch(ctx context.Context, batch bulkWriteBatch) (BulkWr
 			batchErr.WriteConcernError = convertDriverWriteConcernError(writeErr.WriteConcernError)
 		}
 		batchRes.InsertedCount = int64(res.N)
+		batchRes.OperationTime = res.OperationTime
 	case *DeleteOneModel, *DeleteManyModel:
 		res, err := bw.runDelete(ctx, batch)
 		if err != nil {
@@ -126,6 +127,7 @@ func (bw *bulkWrite) runBatch(ctx context.Context, batch bulkWriteBatch) (BulkWr
 			batchErr.WriteConcernError = convertDriverWriteConcernError(writeErr.WriteConcernError)
 		}
 		batchRes.DeletedCount = int64(res.N)
+		batchRes.OperationTime = res.OperationTime
 	case *ReplaceOneModel, *UpdateOneModel, *UpdateManyModel:
 		res, err := bw.runUpdate(ctx, batch)
 		if err != nil {
@@ -140,6 +142,7 @@ func (bw *bulkWrite) runBatch(ctx context.Context, batch bulkWriteBatch) (BulkWr
 		batchRes.MatchedCount = int64(res.N)
 		batchRes.ModifiedCount = int64(res.NModified)
 		batchRes.UpsertedCount = int64(len(res.Upserted))
+		batchRes.OperationTime = res.OperationTime
 		for _, upsert := range res.Upserted {
 			batchRes.UpsertedIDs[int64(batch.indexes[upsert.Index])] = upsert.ID
 		}
@@ -177,7 +180,8 @@ func (bw *bulkWrite) runInsert(ctx context.Context, batch bulkWriteBatch) (opera
 		ServerSelector(bw.selector).ClusterClock(bw.collection.client.clock).
 		Database(bw.collection.db.name).Collection(bw.collection.name).
 		Deployment(bw.collection.client.deployment).Crypt(bw.collection.client.cryptFLE).
-		ServerAPI(bw.collection.client.serverAPI)
+		ServerAPI(bw.collection.client.serverAPI).
+		MaxTimeMSCeiling(bw.collection.client.maxTimeMSCeiling).DefaultMaxTime(bw.collection.client.defaultMaxTime)
 	if bw.bypassDocumentValidation != nil && *bw.bypassDocumentValidation {
 		op = op.BypassDocumentValidation(*bw.bypassDocumentValidation)
 	}
@@ -227,7 +231,8 @@ func (bw *bulkWrite) runDelete(ctx context.Context, batch bulkWriteBatch) (opera
 		ServerSelector(bw.selector).ClusterClock(bw.collection.client.clock).
 		Database(bw.collection.db.name).Collection(bw.collection.name).
 		Deployment(bw.collection.client.deployment).Crypt(bw.collection.client.cryptFLE).Hint(hasHint).
-		ServerAPI(bw.collection.client.serverAPI)
+		ServerAPI(bw.collection.client.serverAPI).
+		MaxTimeMSCeiling(bw.collection.client.maxTimeMSCeiling).DefaultMaxTime(bw.collection.client.defaultMaxTime)
 	if bw.ordered != nil {
 		op = op.Ordered(*bw.ordered)
 	}
@@ -308,7 +313,8 @@ func (bw *bulkWrite) runUpdate(ctx context.Context, batch bulkWriteBatch) (opera
 		ServerSelector(bw.selector).ClusterClock(bw.collection.client.clock).
 		Database(bw.collection.db.name).Collection(bw.collection.name).
 		Deployment(bw.collection.client.deployment).Crypt(bw.collection.client.cryptFLE).Hint(hasHint).
-		ArrayFilters(hasArrayFilters).ServerAPI(bw.collection.client.serverAPI)
+		ArrayFilters(hasArrayFilters).ServerAPI(bw.collection.client.serverAPI).
+		MaxTimeMSCeiling(bw.collection.client.maxTimeMSCeiling).DefaultMaxTime(bw.collection.client.defaultMaxTime)
 	if bw.ordered != nil {
 		op = op.Ordered(*bw.ordered)
 	}
@@ -477,6 +483,9 @@ func (bw *bulkWrite) mergeResults(newResult BulkWriteResult) {
 	bw.result.ModifiedCount += newResult.ModifiedCount
 	bw.result.DeletedCount += newResult.DeletedCount
 	bw.result.UpsertedCount += newResult.UpsertedCount
+	if newResult.OperationTime != nil {
+		bw.result.OperationTime = newResult.OperationTime
+	}
 
 	for index, upsertID := range newResult.UpsertedIDs {
 		bw.result.UpsertedIDs[index] = upsertID
@@ -11,7 +11,9 @@ import (
 	"crypto/tls"
 	"errors"
 	"fmt"
+	"net/url"
 	"strings"
+	"sync/atomic"
 	"time"
 
 	"go.mongodb.org/mongo-driver/bson"
@@ -25,6 +27,7 @@ import (
 	"go.mongodb.org/mongo-driver/x/bsonx/bsoncore"
 	"go.mongodb.org/mongo-driver/x/mongo/driver"
 	"go.mongodb.org/mongo-driver/x/mongo/driver/auth"
+	"go.mongodb.org/mongo-driver/x/mongo/driver/dns"
 	"go.mongodb.org/mongo-driver/x/mongo/driver/ocsp"
 	"go.mongodb.org/mongo-driver/x/mongo/driver/operation"
 	"go.mongodb.org/mongo-driver/x/mongo/driver/session"
@@ -48,21 +51,25 @@ var (
 // The Client type opens and closes connections automatically and maintains a pool of idle connections. For
 // connection pool configuration options, see documentation for the ClientOptions type in the mongo/options package.
 type Client struct {
-	id              uuid.UUID
-	topologyOptions []topology.Option
-	deployment      driver.Deployment
-	localThreshold  time.Duration
-	retryWrites     bool
-	retryReads      bool
-	clock           *session.ClusterClock
-	readPreference  *readpref.ReadPref
-	readConcern     *readconcern.ReadConcern
-	writeConcern    *writeconcern.WriteConcern
-	registry        *bsoncodec.Registry
-	monitor         *event.CommandMonitor
-	serverAPI       *driver.ServerAPIOptions
-	serverMonitor   *event.ServerMonitor
-	sessionPool     *session.Pool
+	id               uuid.UUID
+	topologyOptions  []topology.Option
+	deployment       driver.Deployment
+	localThreshold   time.Duration
+	retryWrites      bool
+	retryReads       bool
+	primaryFallback  bool
+	escalateReadPref bool
+	maxTimeMSCeiling time.Duration
+	defaultMaxTime   time.Duration
+	clock            *session.ClusterClock
+	readPreference   *readpref.ReadPref
+	readConcern      *readconcern.ReadConcern
+	writeConcern     *writeconcern.WriteConcern
+	registry         *bsoncodec.Registry
+	monitor          *event.CommandMonitor
+	serverAPI        *driver.ServerAPIOptions
+	serverMonitor    *event.ServerMonitor
+	sessionPool      *session.Pool
 
 	// client-side encryption fields
 	keyVaultClientFLE *Client
@@ -147,11 +154,16 @@ func NewClient(opts ...*options.ClientOptions) (*Client, error) {
 // If the Client was created using the NewClient function, this method must be called before a Client can be used.
 //
 // Connect starts background goroutines to monitor the state of the deployment and does not do any I/O in the main
-// goroutine. The Client.Ping method can be used to verify that the connection was created successfully.
+// goroutine. The Client.Ping method can be used to verify that the connection was created successfully. If ctx is done
+// before monitoring has been started for the seed servers, Connect returns the ctx error.
 func (c *Client) Connect(ctx context.Context) error {
-	if connector, ok := c.deployment.(driver.Connector); ok {
-		err := connector.Connect()
-		if err != nil {
+	switch connector := c.deployment.(type) {
+	case driver.ContextConnector:
+		if err := connector.ConnectContext(ctx); err != nil {
+			return replaceErrors(err)
+		}
+	case driver.Connector:
+		if err := connector.Connect(); err != nil {
 			return replaceErrors(err)
 		}
 	}
@@ -238,6 +250,18 @@ func (c *Client) Disconnect(ctx context.Context) error {
 	return nil
 }
 
+// BeginDrain prepares the Client for a graceful shutdown. After BeginDrain is called, operations that need a new
+// connection will fail with ErrClientDraining, but operations that have already checked out a connection are allowed
+// to complete. BeginDrain should be followed by a call to Disconnect with a Context deadline, which waits for in-flight
+// operations to return their connections before closing them.
+//
+// BeginDrain has no effect if the Client was created with a custom deployment that does not support draining.
+func (c *Client) BeginDrain() {
+	if drainer, ok := c.deployment.(driver.Drainer); ok {
+		drainer.Drain()
+	}
+}
+
 // Ping sends a ping command to verify that the client can connect to the deployment.
 //
 // The rp parameter is used to determine which server is selected for the operation.
@@ -319,6 +343,61 @@ func (c *Client) StartSession(opts ...*options.SessionOptions) (Session, error)
 	}, nil
 }
 
+// BorrowSession checks a session out of the client's session pool so it can be reused across a logical unit of work.
+// Every operation executed with the returned Session (e.g. through NewSessionContext or WithSession) uses the same
+// lsid until the Session is handed back to the pool using ReturnSession.
+//
+// Unlike a Session created by StartSession, a borrowed Session is tracked until it is handed back with ReturnSession,
+// so returning it more than once or returning a Session that was not borrowed is reported as an error.
+//
+// The opts parameter is processed in the same way as the opts parameter for StartSession.
+func (c *Client) BorrowSession(opts ...*options.SessionOptions) (Session, error) {
+	sess, err := c.StartSession(opts...)
+	if err != nil {
+		return nil, err
+	}
+
+	atomic.StoreInt32(&sess.(*sessionImpl).borrowState, borrowActive)
+	return sess, nil
+}
+
+// ReturnSession hands a Session obtained from BorrowSession back to the client's session pool. Any in-progress
+// transaction on the Session is aborted. The Session must not be used after it has been returned and operations
+// executed with it will fail with session.ErrSessionEnded.
+//
+// This method returns ErrWrongClient if the Session was not created by this Client, session.ErrSessionEnded if the
+// Session has already been returned or ended, and ErrSessionNotBorrowed if the Session was not obtained from
+// BorrowSession.
+func (c *Client) ReturnSession(ctx context.Context, sess Session) error {
+	if ctx == nil {
+		ctx = context.Background()
+	}
+
+	xs, ok := sess.(XSession)
+	if !ok {
+		return ErrWrongClient
+	}
+	clientSession := xs.ClientSession()
+	if err := c.validSession(clientSession); err != nil {
+		return err
+	}
+
+	// The compare-and-swap ensures that only one of several concurrent calls hands the session back to the pool.
+	impl, ok := sess.(*sessionImpl)
+	if !ok || !atomic.CompareAndSwapInt32(&impl.borrowState, borrowActive, borrowReturned) {
+		if (ok && atomic.LoadInt32(&impl.borrowState) == borrowReturned) || clientSession.Terminated {
+			return session.ErrSessionEnded
+		}
+		return ErrSessionNotBorrowed
+	}
+	if clientSession.Terminated {
+		return session.ErrSessionEnded
+	}
+
+	sess.EndSession(ctx)
+	return nil
+}
+
 func (c *Client) endSessions(ctx context.Context) {
 	if c.sessionPool == nil {
 		return
@@ -389,6 +468,14 @@ func (c *Client) configure(opts *options.ClientOptions) error {
 		}),
 	)
 
+	// DNSResolver
+	if opts.DNSResolver != nil {
+		resolver := dns.NewResolver(opts.DNSResolver)
+		topologyOpts = append(topologyOpts, topology.WithDNSResolver(func(*dns.Resolver) *dns.Resolver {
+			return resolver
+		}))
+	}
+
 	// AppName
 	var appName string
 	if opts.AppName != nil {
@@ -398,7 +485,7 @@ func (c *Client) configure(opts *options.ClientOptions) error {
 			return appName
 		}))
 	}
-	// Compressors & ZlibLevel
+	// Compressors, ZlibLevel & CompressionMinSize
 	var comps []string
 	if len(opts.Compressors) > 0 {
 		comps = opts.Compressors
@@ -422,6 +509,11 @@ func (c *Client) configure(opts *options.ClientOptions) error {
 			}
 		}
 
+		if opts.CompressionMinSize != nil {
+			minSize := *opts.CompressionMinSize
+			connOpts = append(connOpts, topology.WithCompressionMinSize(func(int) int { return minSize }))
+		}
+
 		serverOpts = append(serverOpts, topology.WithCompressionOptions(
 			func(opts ...string) []string { return append(opts, comps...) },
 		))
@@ -495,12 +587,51 @@ func (c *Client) configure(opts *options.ClientOptions) error {
 			func(time.Duration) time.Duration { return *opts.ConnectTimeout },
 		))
 	}
+	// ConnectionTag
+	if opts.ConnectionTag != nil {
+		connOpts = append(connOpts, topology.WithConnectionTag(
+			func(string) string { return *opts.ConnectionTag },
+		))
+	}
 	// Dialer
 	if opts.Dialer != nil {
 		connOpts = append(connOpts, topology.WithDialer(
 			func(topology.Dialer) topology.Dialer { return opts.Dialer },
 		))
 	}
+	// Proxy
+	if opts.Proxy != nil {
+		// The URL has already been validated by opts.Validate.
+		u, _ := url.Parse(opts.Proxy.URL)
+		proxy := topology.SOCKS5Proxy{Address: u.Host}
+		if opts.Proxy.Auth != nil {
+			proxy.Username = opts.Proxy.Auth.Username
+			proxy.Password = opts.Proxy.Auth.Password
+		} else if u.User != nil {
+			proxy.Username = u.User.Username()
+			proxy.Password, _ = u.User.Password()
+		}
+		connOpts = append(connOpts, topology.WithSOCKS5Proxy(
+			func(topology.SOCKS5Proxy) topology.SOCKS5Proxy { return proxy },
+		))
+	}
+	// HandshakeCompleteCallback
+	if opts.HandshakeCompleteCallback != nil {
+		connOpts = append(connOpts, topology.WithHandshakeCompleteCallback(
+			func(event.HandshakeCompleteFunc) event.HandshakeCompleteFunc { return opts.HandshakeCompleteCallback },
+		))
+	}
+	// TCPKeepAlive
+	if opts.TCPKeepAlive != nil {
+		keepAlive := topology.TCPKeepAlive{
+			Idle:     opts.TCPKeepAlive.Idle,
+			Interval: opts.TCPKeepAlive.Interval,
+			Count:    opts.TCPKeepAlive.Count,
+		}
+		connOpts = append(connOpts, topology.WithTCPKeepAlive(
+			func(topology.TCPKeepAlive) topology.TCPKeepAlive { return keepAlive },
+		))
+	}
 	// Direct
 	if opts.Direct != nil && *opts.Direct {
 		topologyOpts = append(topologyOpts, topology.WithMode(
@@ -553,6 +684,24 @@ func (c *Client) configure(opts *options.ClientOptions) error {
 			topology.WithMaxConnecting(func(uint64) uint64 { return *opts.MaxConnecting }),
 		)
 	}
+	// PoolFairness
+	if opts.PoolFairness != nil {
+		fairness := topology.PoolFairnessFIFO
+		if *opts.PoolFairness == options.PoolFairnessBestEffort {
+			fairness = topology.PoolFairnessBestEffort
+		}
+		serverOpts = append(
+			serverOpts,
+			topology.WithPoolFairness(func(topology.PoolFairness) topology.PoolFairness { return fairness }),
+		)
+	}
+	// WaitQueueTimeout
+	if opts.WaitQueueTimeout != nil {
+		serverOpts = append(
+			serverOpts,
+			topology.WithWaitQueueTimeout(func(time.Duration) time.Duration { return *opts.WaitQueueTimeout }),
+		)
+	}
 	// PoolMonitor
 	if opts.PoolMonitor != nil {
 		serverOpts = append(
@@ -561,10 +710,14 @@ func (c *Client) configure(opts *options.ClientOptions) error {
 		)
 	}
 	// Monitor
-	if opts.Monitor != nil {
-		c.monitor = opts.Monitor
+	monitor := opts.Monitor
+	if opts.SlowOperationThreshold != nil && opts.SlowOperationCallback != nil {
+		monitor = newSlowOperationMonitor(monitor, *opts.SlowOperationThreshold, opts.SlowOperationCallback)
+	}
+	if monitor != nil {
+		c.monitor = monitor
 		connOpts = append(connOpts, topology.WithMonitor(
-			func(*event.CommandMonitor) *event.CommandMonitor { return opts.Monitor },
+			func(*event.CommandMonitor) *event.CommandMonitor { return monitor },
 		))
 	}
 	// ServerMonitor
@@ -610,6 +763,22 @@ func (c *Client) configure(opts *options.ClientOptions) error {
 	if opts.RetryReads != nil {
 		c.retryReads = *opts.RetryReads
 	}
+	// PrimaryFallback
+	if opts.PrimaryFallback != nil {
+		c.primaryFallback = *opts.PrimaryFallback
+	}
+	// EscalateReadPreference
+	if opts.EscalateReadPreference != nil {
+		c.escalateReadPref = *opts.EscalateReadPreference
+	}
+	// MaxTimeMSCeiling
+	if opts.MaxTimeMSCeiling != nil {
+		c.maxTimeMSCeiling = *opts.MaxTimeMSCeiling
+	}
+	// DefaultMaxTime
+	if opts.DefaultMaxTime != nil {
+		c.defaultMaxTime = *opts.DefaultMaxTime
+	}
 	// ServerSelectionTimeout
 	if opts.ServerSelectionTimeout != nil {
 		topologyOpts = append(topologyOpts, topology.WithServerSelectionTimeout(
@@ -829,6 +998,9 @@ func convertToDriverAPIOptions(s *options.ServerAPIOptions) *driver.ServerAPIOpt
 	if s.DeprecationErrors != nil {
 		driverOpts.SetDeprecationErrors(*s.DeprecationErrors)
 	}
+	if s.DeprecationCallback != nil {
+		driverOpts.SetDeprecationCallback(s.DeprecationCallback)
+	}
 	return driverOpts
 }
 
@@ -935,6 +1107,103 @@ func (c *Client) ListDatabaseNames(ctx context.Context, filter interface{}, opts
 	return names, nil
 }
 
+// CurrentOps returns the operations currently in progress on the deployment by running an aggregation with a
+// $currentOp stage against the admin database. Idle connections are not included, and the stage is run with
+// idleSessions set to false so idle sessions are not included either. This requires MongoDB 4.0 or later.
+//
+// The opts parameter can be used to specify options for this operation (see the options.CurrentOpsOptions
+// documentation).
+//
+// For more information about the aggregation stage, see
+// https://docs.mongodb.com/manual/reference/operator/aggregation/currentOp/.
+func (c *Client) CurrentOps(ctx context.Context, opts ...*options.CurrentOpsOptions) ([]CurrentOp, error) {
+	if ctx == nil {
+		ctx = context.Background()
+	}
+	co := options.MergeCurrentOpsOptions(opts...)
+
+	stage := bson.D{{"idleSessions", false}}
+	if co.AllUsers != nil {
+		stage = append(stage, bson.E{"allUsers", *co.AllUsers})
+	}
+	match := bson.D{}
+	if co.Namespace != nil {
+		match = append(match, bson.E{"ns", *co.Namespace})
+	}
+	if co.MinRunningTime != nil {
+		micros := int64(*co.MinRunningTime / time.Microsecond)
+		match = append(match, bson.E{"microsecs_running", bson.D{{"$gte", micros}}})
+	}
+	pipeline := Pipeline{{{"$currentOp", stage}}}
+	if len(match) > 0 {
+		pipeline = append(pipeline, bson.D{{"$match", match}})
+	}
+	return c.currentOps(ctx, pipeline)
+}
+
+// KillOpsByComment kills every operation in progress on the deployment whose command was run with the given comment
+// and returns the number of operations that were killed. Operations belonging to all users are considered, and a
+// getMore is matched by the comment of the command that created its cursor. Matching operations are found with
+// $currentOp and killed with the killOp command.
+//
+// If a killOp command fails, the number of operations killed so far is returned along with the error. Note that
+// killOp succeeds for operations that have already completed, so the returned count is an upper bound on the number
+// of operations that were actually interrupted.
+//
+// For more information about the command, see https://docs.mongodb.com/manual/reference/command/killOp/.
+func (c *Client) KillOpsByComment(ctx context.Context, comment string) (int, error) {
+	if ctx == nil {
+		ctx = context.Background()
+	}
+
+	pipeline := Pipeline{
+		{{"$currentOp", bson.D{{"allUsers", true}}}},
+		{{"$match", bson.D{{"$or", bson.A{
+			bson.D{{"command.comment", comment}},
+			bson.D{{"cursor.originatingCommand.comment", comment}},
+		}}}}},
+	}
+	ops, err := c.currentOps(ctx, pipeline)
+	if err != nil {
+		return 0, err
+	}
+
+	admin := c.Database("admin")
+	var killed int
+	for _, op := range ops {
+		err = admin.RunCommand(ctx, bson.D{{"killOp", 1}, {"op", op.OpID}}).Err()
+		if err != nil {
+			return killed, err
+		}
+		killed++
+	}
+	return killed, nil
+}
+
+// currentOps runs the given $currentOp pipeline against the admin database and decodes the results.
+func (c *Client) currentOps(ctx context.Context, pipeline Pipeline) ([]CurrentOp, error) {
+	cursor, err := c.Database("admin").Aggregate(ctx, pipeline)
+	if err != nil {
+		return nil, err
+	}
+	defer cursor.Close(ctx)
+
+	ops := make([]CurrentOp, 0)
+	for cursor.Next(ctx) {
+		var op CurrentOp
+		if err = cursor.Decode(&op); err != nil {
+			return nil, err
+		}
+		op.Raw = make(bson.Raw, len(cursor.Current))
+		copy(op.Raw, cursor.Current)
+		ops = append(ops, op)
+	}
+	if err = cursor.Err(); err != nil {
+		return nil, err
+	}
+	return ops, nil
+}
+
 // WithSession creates a new SessionContext from the ctx and sess parameters and uses it to call the fn callback. The
 // SessionContext must be used as the Context parameter for any operations in the fn callback that should be executed
 // under the session.
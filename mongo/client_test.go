@@ -10,6 +10,7 @@ import (
 	"context"
 	"errors"
 	"math"
+	"sync"
 	"testing"
 	"time"
 
@@ -25,6 +26,7 @@ import (
 	"go.mongodb.org/mongo-driver/tag"
 	"go.mongodb.org/mongo-driver/x/mongo/driver"
 	"go.mongodb.org/mongo-driver/x/mongo/driver/session"
+	"go.mongodb.org/mongo-driver/x/mongo/driver/topology"
 )
 
 var bgCtx = context.Background()
@@ -49,6 +51,35 @@ func (md mockDeployment) Kind() description.TopologyKind {
 	return description.Single
 }
 
+// drainDeployment is a deployment whose server rejects connection requests once Drain is called.
+type drainDeployment struct {
+	mockDeployment
+	draining bool
+}
+
+func (dd *drainDeployment) SelectServer(context.Context, description.ServerSelector) (driver.Server, error) {
+	return drainServer{dd}, nil
+}
+
+func (dd *drainDeployment) Drain() {
+	dd.draining = true
+}
+
+type drainServer struct {
+	dd *drainDeployment
+}
+
+func (ds drainServer) Connection(context.Context) (driver.Connection, error) {
+	if ds.dd.draining {
+		return nil, topology.ErrPoolDraining
+	}
+	return nil, errors.New("no connection available")
+}
+
+func (ds drainServer) MinRTT() time.Duration {
+	return 0
+}
+
 func TestClient(t *testing.T) {
 	t.Run("new client", func(t *testing.T) {
 		client := setupClient()
@@ -94,6 +125,116 @@ func TestClient(t *testing.T) {
 		_, err = client.ListDatabaseNames(bgCtx, nil)
 		assert.Equal(t, ErrNilDocument, err, "expected error %v, got %v", ErrNilDocument, err)
 	})
+	t.Run("borrow session", func(t *testing.T) {
+		t.Run("lsid reused across operations", func(t *testing.T) {
+			// Sessions are only reused by the pool if the deployment reports a session timeout.
+			descChan := make(chan description.Topology, 1)
+			descChan <- description.Topology{SessionTimeoutMinutes: 30}
+			client := setupClient()
+			client.sessionPool = session.NewPool(descChan)
+
+			sess, err := client.BorrowSession()
+			assert.Nil(t, err, "BorrowSession error: %v", err)
+			lsid := sess.ID()
+
+			for i := 0; i < 3; i++ {
+				sessCtx := NewSessionContext(bgCtx, sess)
+				got := bson.Raw(sessionFromContext(sessCtx).SessionID)
+				assert.Equal(t, lsid, got, "expected lsid %v for operation %d, got %v", lsid, i, got)
+			}
+
+			err = client.ReturnSession(bgCtx, sess)
+			assert.Nil(t, err, "ReturnSession error: %v", err)
+			assert.Equal(t, 0, client.NumberSessionsInProgress(), "expected no sessions in progress, got %d",
+				client.NumberSessionsInProgress())
+
+			// The returned server session should be handed out again by the pool.
+			next, err := client.BorrowSession()
+			assert.Nil(t, err, "BorrowSession error: %v", err)
+			assert.Equal(t, lsid, next.ID(), "expected lsid %v to be reused, got %v", lsid, next.ID())
+		})
+		t.Run("return twice", func(t *testing.T) {
+			client := setupClient()
+			client.sessionPool = session.NewPool(nil)
+
+			sess, err := client.BorrowSession()
+			assert.Nil(t, err, "BorrowSession error: %v", err)
+			err = client.ReturnSession(bgCtx, sess)
+			assert.Nil(t, err, "ReturnSession error: %v", err)
+			err = client.ReturnSession(bgCtx, sess)
+			assert.Equal(t, session.ErrSessionEnded, err, "expected error %v, got %v", session.ErrSessionEnded, err)
+		})
+		t.Run("concurrent return", func(t *testing.T) {
+			client := setupClient()
+			client.sessionPool = session.NewPool(nil)
+
+			sess, err := client.BorrowSession()
+			assert.Nil(t, err, "BorrowSession error: %v", err)
+
+			const numReturns = 10
+			errs := make(chan error, numReturns)
+			var wg sync.WaitGroup
+			for i := 0; i < numReturns; i++ {
+				wg.Add(1)
+				go func() {
+					defer wg.Done()
+					errs <- client.ReturnSession(bgCtx, sess)
+				}()
+			}
+			wg.Wait()
+			close(errs)
+
+			var returned int
+			for err := range errs {
+				if err == nil {
+					returned++
+					continue
+				}
+				assert.Equal(t, session.ErrSessionEnded, err, "expected error %v, got %v", session.ErrSessionEnded, err)
+			}
+			assert.Equal(t, 1, returned, "expected the session to be returned once, got %d", returned)
+		})
+		t.Run("not borrowed", func(t *testing.T) {
+			client := setupClient()
+			client.sessionPool = session.NewPool(nil)
+
+			sess, err := client.StartSession()
+			assert.Nil(t, err, "StartSession error: %v", err)
+			err = client.ReturnSession(bgCtx, sess)
+			assert.Equal(t, ErrSessionNotBorrowed, err, "expected error %v, got %v", ErrSessionNotBorrowed, err)
+			assert.False(t, sess.(XSession).ClientSession().Terminated, "expected the session not to be ended")
+			sess.EndSession(bgCtx)
+		})
+		t.Run("wrong client", func(t *testing.T) {
+			client := setupClient()
+			client.sessionPool = session.NewPool(nil)
+			other := setupClient()
+			other.sessionPool = session.NewPool(nil)
+
+			sess, err := other.BorrowSession()
+			assert.Nil(t, err, "BorrowSession error: %v", err)
+			err = client.ReturnSession(bgCtx, sess)
+			assert.Equal(t, ErrWrongClient, err, "expected error %v, got %v", ErrWrongClient, err)
+		})
+		t.Run("disconnected", func(t *testing.T) {
+			client := setupClient()
+			_, err := client.BorrowSession()
+			assert.Equal(t, ErrClientDisconnected, err, "expected error %v, got %v", ErrClientDisconnected, err)
+		})
+	})
+	t.Run("begin drain", func(t *testing.T) {
+		dd := &drainDeployment{}
+		client := setupClient(&options.ClientOptions{Deployment: dd})
+
+		err := client.Ping(bgCtx, nil)
+		assert.NotEqual(t, ErrClientDraining, err, "expected error other than %v before draining", ErrClientDraining)
+
+		client.BeginDrain()
+		assert.True(t, dd.draining, "expected deployment to be draining")
+
+		err = client.Ping(bgCtx, nil)
+		assert.Equal(t, ErrClientDraining, err, "expected error %v, got %v", ErrClientDraining, err)
+	})
 	t.Run("read preference", func(t *testing.T) {
 		t.Run("absent", func(t *testing.T) {
 			client := setupClient()
@@ -361,6 +502,18 @@ func TestClient(t *testing.T) {
 			assert.Equal(t, convertedAPIOptions, client.serverAPI,
 				"mismatch in serverAPI; expected %v, got %v", convertedAPIOptions, client.serverAPI)
 		})
+		t.Run("deprecation callback", func(t *testing.T) {
+			var deprecated string
+			serverAPIOptions := getServerAPIOptions().SetAPIDeprecationCallback(func(command string) {
+				deprecated = command
+			})
+			client, err := NewClient(options.Client().SetServerAPIOptions(serverAPIOptions))
+			assert.Nil(t, err, "unexpected error from NewClient: %v", err)
+			assert.NotNil(t, client.serverAPI.DeprecationCallback, "expected deprecation callback to be set")
+
+			client.serverAPI.DeprecationCallback("count")
+			assert.Equal(t, "count", deprecated, "expected callback to be called with %q, got %q", "count", deprecated)
+		})
 		t.Run("failure with unsupported version", func(t *testing.T) {
 			serverAPIOptions := options.ServerAPI("badVersion")
 			_, err := NewClient(options.Client().SetServerAPIOptions(serverAPIOptions))
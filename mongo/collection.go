@@ -10,12 +10,17 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"math"
+	"math/big"
+	"reflect"
+	"strconv"
 	"strings"
 	"time"
 
 	"go.mongodb.org/mongo-driver/bson"
 	"go.mongodb.org/mongo-driver/bson/bsoncodec"
 	"go.mongodb.org/mongo-driver/bson/bsontype"
+	"go.mongodb.org/mongo-driver/bson/primitive"
 	"go.mongodb.org/mongo-driver/mongo/description"
 	"go.mongodb.org/mongo-driver/mongo/options"
 	"go.mongodb.org/mongo-driver/mongo/readconcern"
@@ -38,6 +43,8 @@ type Collection struct {
 	readSelector   description.ServerSelector
 	writeSelector  description.ServerSelector
 	registry       *bsoncodec.Registry
+	validator      *jsonSchema
+	queryLogger    func(op string, filter bson.Raw)
 }
 
 // aggregateParams is used to store information to configure an Aggregate operation.
@@ -54,6 +61,7 @@ type aggregateParams struct {
 	readSelector   description.ServerSelector
 	writeSelector  description.ServerSelector
 	readPreference *readpref.ReadPref
+	queryLogger    func(op string, filter bson.Raw)
 	opts           []*options.AggregateOptions
 }
 
@@ -122,6 +130,8 @@ func (coll *Collection) copy() *Collection {
 		readSelector:   coll.readSelector,
 		writeSelector:  coll.writeSelector,
 		registry:       coll.registry,
+		validator:      coll.validator,
+		queryLogger:    coll.queryLogger,
 	}
 }
 
@@ -232,8 +242,114 @@ func (coll *Collection) BulkWrite(ctx context.Context, models []WriteModel,
 	return &op.result, replaceErrors(err)
 }
 
+// BulkWriteDryRun previews the effect of a bulk write without modifying any documents. For each model, it runs a
+// count of the documents matching the model's filter and collation, and reports the projected counts in the returned
+// BulkWriteDryRunResult. No write commands are sent to the server.
+//
+// The models parameter has the same requirements as the models parameter for BulkWrite. Each filter is counted
+// against the documents in the collection when BulkWriteDryRun runs, so the projection does not account for the
+// effect of earlier models in the slice on later ones, or for writes made by other clients before the bulk write is
+// executed. The counts are read using the Collection's read concern and read preference.
+func (coll *Collection) BulkWriteDryRun(ctx context.Context, models []WriteModel) (*BulkWriteDryRunResult, error) {
+	if len(models) == 0 {
+		return nil, ErrEmptySlice
+	}
+
+	if ctx == nil {
+		ctx = context.Background()
+	}
+
+	// countMatches counts the documents matching filter. If one is true, the count stops at 1.
+	countMatches := func(filter interface{}, collation *options.Collation, hint interface{}, one bool) (int64, error) {
+		if filter == nil {
+			return 0, ErrNilDocument
+		}
+		countOpts := options.Count()
+		if collation != nil {
+			countOpts.SetCollation(collation)
+		}
+		if hint != nil {
+			countOpts.SetHint(hint)
+		}
+		if one {
+			countOpts.SetLimit(1)
+		}
+		return coll.CountDocuments(ctx, filter, countOpts)
+	}
+	// applyUpdate adds the matched and upserted counts for an update or replace model to res.
+	applyUpdate := func(res *BulkWriteDryRunResult, matched int64, upsert *bool) {
+		res.MatchedCount += matched
+		res.ModifiedCount += matched
+		if matched == 0 && upsert != nil && *upsert {
+			res.UpsertedCount++
+		}
+	}
+
+	res := &BulkWriteDryRunResult{ModelMatchedCounts: make([]int64, len(models))}
+	for i, model := range models {
+		var matched int64
+		var err error
+		switch m := model.(type) {
+		case *InsertOneModel:
+			if m.Document == nil {
+				return nil, ErrNilDocument
+			}
+			res.InsertedCount++
+		case *DeleteOneModel:
+			matched, err = countMatches(m.Filter, m.Collation, m.Hint, true)
+			res.DeletedCount += matched
+		case *DeleteManyModel:
+			matched, err = countMatches(m.Filter, m.Collation, m.Hint, false)
+			res.DeletedCount += matched
+		case *ReplaceOneModel:
+			matched, err = countMatches(m.Filter, m.Collation, m.Hint, true)
+			applyUpdate(res, matched, m.Upsert)
+		case *UpdateOneModel:
+			matched, err = countMatches(m.Filter, m.Collation, m.Hint, true)
+			applyUpdate(res, matched, m.Upsert)
+		case *UpdateManyModel:
+			matched, err = countMatches(m.Filter, m.Collation, m.Hint, false)
+			applyUpdate(res, matched, m.Upsert)
+		case nil:
+			return nil, ErrNilDocument
+		default:
+			return nil, fmt.Errorf("unsupported write model type %T", model)
+		}
+		if err != nil {
+			return nil, err
+		}
+		res.ModelMatchedCounts[i] = matched
+	}
+	return res, nil
+}
+
+// BulkUpdateMap performs an unordered bulk write that applies one update per document. The updates parameter maps the
+// _id of each document to the update document to apply to it. It cannot be nil or empty. Each entry is converted to an
+// UpdateOneModel with a filter of {_id: <key>}, and the counts for all of the updates are aggregated in the returned
+// BulkWriteResult.
+//
+// Because the bulk write is unordered, the updates may be applied in any order and a failure for one document does not
+// prevent the remaining updates from being attempted. See the Collection.BulkWrite documentation for more information.
+func (coll *Collection) BulkUpdateMap(ctx context.Context, updates map[interface{}]bson.M) (*BulkWriteResult, error) {
+	if len(updates) == 0 {
+		return nil, ErrEmptySlice
+	}
+
+	models := make([]WriteModel, 0, len(updates))
+	for id, update := range updates {
+		if update == nil {
+			return nil, ErrNilDocument
+		}
+		models = append(models, NewUpdateOneModel().SetFilter(bson.D{{"_id", id}}).SetUpdate(update))
+	}
+
+	return coll.BulkWrite(ctx, models, options.BulkWrite().SetOrdered(false))
+}
+
+// insert inserts documents and returns the _id values of the inserted documents, the operation time, and the number
+// of documents removed by the DeduplicateByID option.
 func (coll *Collection) insert(ctx context.Context, documents []interface{},
-	opts ...*options.InsertManyOptions) ([]interface{}, error) {
+	opts ...*options.InsertManyOptions) ([]interface{}, *primitive.Timestamp, int, error) {
 
 	if ctx == nil {
 		ctx = context.Background()
@@ -246,23 +362,34 @@ func (coll *Collection) insert(ctx context.Context, documents []interface{},
 		var err error
 		docs[i], result[i], err = transformAndEnsureID(coll.registry, doc)
 		if err != nil {
-			return nil, err
+			return nil, nil, 0, err
 		}
 	}
+	if err := coll.validateDocuments(docs); err != nil {
+		return nil, nil, 0, err
+	}
+
+	imo := options.MergeInsertManyOptions(opts...)
+	// kept maps each document sent to its index in documents. It is nil if no documents were removed.
+	var kept []int
+	if imo.DeduplicateByID != nil && *imo.DeduplicateByID {
+		docs, result, kept = dedupeByID(docs, result)
+	}
+	removed := len(documents) - len(docs)
 
 	sess := sessionFromContext(ctx)
 	if sess == nil && coll.client.sessionPool != nil {
 		var err error
 		sess, err = session.NewClientSession(coll.client.sessionPool, coll.client.id, session.Implicit)
 		if err != nil {
-			return nil, err
+			return nil, nil, 0, err
 		}
 		defer sess.EndSession()
 	}
 
 	err := coll.client.validSession(sess)
 	if err != nil {
-		return nil, err
+		return nil, nil, 0, err
 	}
 
 	wc := coll.writeConcern
@@ -280,8 +407,8 @@ func (coll *Collection) insert(ctx context.Context, documents []interface{},
 		ServerSelector(selector).ClusterClock(coll.client.clock).
 		Database(coll.db.name).Collection(coll.name).
 		Deployment(coll.client.deployment).Crypt(coll.client.cryptFLE).Ordered(true).
-		ServerAPI(coll.client.serverAPI)
-	imo := options.MergeInsertManyOptions(opts...)
+		ServerAPI(coll.client.serverAPI).MaxTimeMSCeiling(coll.client.maxTimeMSCeiling).
+		DefaultMaxTime(coll.client.defaultMaxTime)
 	if imo.BypassDocumentValidation != nil && *imo.BypassDocumentValidation {
 		op = op.BypassDocumentValidation(*imo.BypassDocumentValidation)
 	}
@@ -295,9 +422,10 @@ func (coll *Collection) insert(ctx context.Context, documents []interface{},
 	op = op.Retry(retry)
 
 	err = op.Execute(ctx)
+	opTime := op.Result().OperationTime
 	wce, ok := err.(driver.WriteCommandError)
 	if !ok {
-		return result, err
+		return result, opTime, removed, err
 	}
 
 	// remove the ids that had writeErrors from result
@@ -312,7 +440,93 @@ func (coll *Collection) insert(ctx context.Context, documents []interface{},
 		result = append(result[:idIndex], result[idIndex+1:]...)
 	}
 
-	return result, err
+	// Report write errors against the caller's documents rather than the deduplicated batch.
+	if kept != nil {
+		for i := range wce.WriteErrors {
+			wce.WriteErrors[i].Index = int64(kept[wce.WriteErrors[i].Index])
+		}
+	}
+
+	return result, opTime, removed, err
+}
+
+// dedupeByID removes documents whose _id duplicates that of an earlier document, keeping the first occurrence. It
+// returns the remaining documents and _id values and, if any documents were removed, the index of each remaining
+// document in the original slice.
+func dedupeByID(docs []bsoncore.Document, ids []interface{}) ([]bsoncore.Document, []interface{}, []int) {
+	seen := make(map[string]struct{}, len(docs))
+	keptDocs := make([]bsoncore.Document, 0, len(docs))
+	keptIDs := make([]interface{}, 0, len(ids))
+	kept := make([]int, 0, len(docs))
+	for i, doc := range docs {
+		key := idKey(doc.Lookup("_id"))
+		if _, ok := seen[key]; ok {
+			continue
+		}
+		seen[key] = struct{}{}
+		keptDocs = append(keptDocs, doc)
+		keptIDs = append(keptIDs, ids[i])
+		kept = append(kept, i)
+	}
+	if len(kept) == len(docs) {
+		return docs, ids, nil
+	}
+	return keptDocs, keptIDs, kept
+}
+
+// idKey returns a string that is equal for two _id values if the server would consider them equal. Numeric values,
+// including Decimal128 values that are integers, are compared by value and everything else is compared by type and
+// encoded bytes.
+func idKey(id bsoncore.Value) string {
+	switch id.Type {
+	case bsontype.Int32:
+		if i, ok := id.Int32OK(); ok {
+			return "i" + strconv.FormatInt(int64(i), 10)
+		}
+	case bsontype.Int64:
+		if i, ok := id.Int64OK(); ok {
+			return "i" + strconv.FormatInt(i, 10)
+		}
+	case bsontype.Double:
+		if f, ok := id.DoubleOK(); ok {
+			if f == math.Trunc(f) && f >= math.MinInt64 && f < math.MaxInt64 {
+				return "i" + strconv.FormatInt(int64(f), 10)
+			}
+			return "d" + strconv.FormatFloat(f, 'g', -1, 64)
+		}
+	case bsontype.Decimal128:
+		if d, ok := id.Decimal128OK(); ok {
+			if i, ok := decimal128Int64(d); ok {
+				return "i" + strconv.FormatInt(i, 10)
+			}
+		}
+	}
+	return string(id.Type) + string(id.Data)
+}
+
+// decimal128Int64 returns the value of d as an int64 if d is an integer that fits in one.
+func decimal128Int64(d primitive.Decimal128) (int64, bool) {
+	bi, exp, err := d.BigInt()
+	if err != nil {
+		return 0, false
+	}
+	ten := big.NewInt(10)
+	for ; exp < 0; exp++ {
+		var rem big.Int
+		if bi.QuoRem(bi, ten, &rem); rem.Sign() != 0 {
+			return 0, false
+		}
+	}
+	for ; exp > 0; exp-- {
+		bi.Mul(bi, ten)
+		if !bi.IsInt64() {
+			return 0, false
+		}
+	}
+	if !bi.IsInt64() {
+		return 0, false
+	}
+	return bi.Int64(), true
 }
 
 // InsertOne executes an insert command to insert a single document into the collection.
@@ -333,13 +547,236 @@ func (coll *Collection) InsertOne(ctx context.Context, document interface{},
 	if ioOpts.BypassDocumentValidation != nil && *ioOpts.BypassDocumentValidation {
 		imOpts.SetBypassDocumentValidation(*ioOpts.BypassDocumentValidation)
 	}
-	res, err := coll.insert(ctx, []interface{}{document}, imOpts)
+	res, opTime, _, err := coll.insert(ctx, []interface{}{document}, imOpts)
 
 	rr, err := processWriteError(err)
 	if rr&rrOne == 0 {
 		return nil, err
 	}
-	return &InsertOneResult{InsertedID: res[0]}, err
+	return &InsertOneResult{InsertedID: res[0], OperationTime: opTime}, err
+}
+
+// SetClientSideValidator sets a $jsonSchema document that InsertOne and InsertMany use to validate documents before
+// sending them to the server. The schema can be a $jsonSchema document or a collection validator of the form
+// {$jsonSchema: <schema>}. If any document does not match the schema, no documents are inserted and a
+// ClientValidationErrors is returned with an entry for each non-conforming document. Passing a nil schema removes the
+// validator.
+//
+// The bsonType, type, required, properties, additionalProperties, enum, minimum, maximum, exclusiveMinimum,
+// exclusiveMaximum, minLength, maxLength, pattern, items, minItems, maxItems, minProperties, and maxProperties keywords
+// are supported. The title and description keywords are ignored. An error is returned if the schema uses any other
+// keyword. Client-side validation does not replace server-side validation.
+//
+// SetClientSideValidator must not be called concurrently with other operations on the Collection. Collections
+// created by Clone keep the validator.
+func (coll *Collection) SetClientSideValidator(schema bson.Raw) error {
+	if schema == nil {
+		coll.validator = nil
+		return nil
+	}
+
+	validator, err := newJSONSchema(schema)
+	if err != nil {
+		return err
+	}
+	coll.validator = validator
+	return nil
+}
+
+// SetQueryLogger sets a function that is called with the command name and filter of each query run against the
+// Collection, before the query is sent to the server. It is called by Find and FindOne with "find", by UpdateOne,
+// UpdateMany, UpdateByID, and ReplaceOne with "update", by DeleteOne and DeleteMany with "delete", by the FindOneAnd*
+// methods with "findAndModify", and by Aggregate with "aggregate". For Aggregate, filter is the pipeline encoded as a
+// BSON array. Passing nil removes the logger.
+//
+// The logger only applies to this Collection. It is kept by Collections created by Clone, but not by other Collections
+// for the same namespace. filter must not be modified or retained after the logger returns. SetQueryLogger must not be
+// called concurrently with other operations on the Collection.
+func (coll *Collection) SetQueryLogger(logger func(op string, filter bson.Raw)) {
+	coll.queryLogger = logger
+}
+
+// logQuery calls the query logger, if one is set, with op and filter.
+func (coll *Collection) logQuery(op string, filter bsoncore.Document) {
+	if coll.queryLogger != nil {
+		coll.queryLogger(op, bson.Raw(filter))
+	}
+}
+
+// validateDocuments checks docs against the client-side validator, if one is set.
+func (coll *Collection) validateDocuments(docs []bsoncore.Document) error {
+	if coll.validator == nil {
+		return nil
+	}
+
+	var errs ClientValidationErrors
+	for i, doc := range docs {
+		if violations := coll.validator.validate(doc); len(violations) > 0 {
+			errs = append(errs, ClientValidationError{Index: i, Violations: violations})
+		}
+	}
+	if len(errs) > 0 {
+		return errs
+	}
+	return nil
+}
+
+// ExpireAtField is the name of the field used by InsertWithExpiry and IndexView.CreateExpiryIndex to store the time at
+// which a document expires.
+const ExpireAtField = "expireAt"
+
+// InsertWithExpiry executes an insert command to insert a single document into the collection that expires at the
+// given time. The document is inserted with an ExpireAtField field set to at, replacing any existing value for that
+// field. Documents are only removed by the server if the collection has a TTL index on ExpireAtField with
+// expireAfterSeconds set to 0, which can be created using IndexView.CreateExpiryIndex.
+//
+// See the Collection.InsertOne documentation for more information about the document and opts parameters.
+//
+// For more information about TTL indexes, see https://docs.mongodb.com/manual/tutorial/expire-data/.
+func (coll *Collection) InsertWithExpiry(ctx context.Context, document interface{}, at time.Time,
+	opts ...*options.InsertOneOptions) (*InsertOneResult, error) {
+
+	if document == nil {
+		return nil, ErrNilDocument
+	}
+	doc, err := transformBsoncoreDocument(coll.registry, document, true, "document")
+	if err != nil {
+		return nil, err
+	}
+
+	elems, err := doc.Elements()
+	if err != nil {
+		return nil, err
+	}
+	idx, stamped := bsoncore.AppendDocumentStart(nil)
+	for _, elem := range elems {
+		if elem.Key() == ExpireAtField {
+			continue
+		}
+		stamped = append(stamped, elem...)
+	}
+	stamped = bsoncore.AppendDateTimeElement(stamped, ExpireAtField, int64(primitive.NewDateTimeFromTime(at)))
+	stamped, _ = bsoncore.AppendDocumentEnd(stamped, idx)
+
+	return coll.InsertOne(ctx, bson.Raw(stamped), opts...)
+}
+
+// InsertIfAbsent inserts document into the collection if no document matches filter and does nothing otherwise. The
+// operation is executed as an update command with upsert set to true and document as the value of a $setOnInsert
+// update, so the inserted document also contains the equality fields of filter. The returned bool reports whether a
+// document was inserted.
+//
+// The filter parameter must be a document containing query operators and cannot be nil. The document parameter cannot
+// be nil. If document contains an _id field, filter should either not constrain _id or constrain it to the same value.
+//
+// For more information about the command, see https://docs.mongodb.com/manual/reference/command/update/.
+func (coll *Collection) InsertIfAbsent(ctx context.Context, filter interface{}, document interface{}) (bool, error) {
+	if document == nil {
+		return false, ErrNilDocument
+	}
+	doc, err := transformBsoncoreDocument(coll.registry, document, true, "document")
+	if err != nil {
+		return false, err
+	}
+
+	update := bson.D{{"$setOnInsert", bson.Raw(doc)}}
+	res, err := coll.UpdateOne(ctx, filter, update, options.Update().SetUpsert(true))
+	if err != nil {
+		return false, err
+	}
+	return res.UpsertedID != nil, nil
+}
+
+// UpsertWithTimestamps updates a single document in the collection with an upsert, maintaining a creation timestamp
+// and a last update timestamp in the document. The update is extended with a $currentDate operator that sets
+// updatedField to the server's current date on every update and a $setOnInsert operator that sets createdField only
+// when a new document is inserted. Because $setOnInsert cannot take the server's date, createdField is set to the
+// client's current time, so the two timestamps of a newly inserted document can differ by the clock skew between the
+// client and the server.
+//
+// The filter parameter must be a document containing query operators and cannot be nil. The update parameter must be a
+// document containing update operators or nil to only update the timestamps. If update already contains $currentDate or
+// $setOnInsert operators, the timestamp fields are added to them. createdField and updatedField must be non-empty and
+// different.
+//
+// For more information about the command, see https://docs.mongodb.com/manual/reference/command/update/.
+func (coll *Collection) UpsertWithTimestamps(ctx context.Context, filter interface{}, update interface{},
+	createdField, updatedField string) (*UpdateResult, error) {
+
+	if createdField == "" || updatedField == "" {
+		return nil, errors.New("createdField and updatedField must not be empty")
+	}
+	if createdField == updatedField {
+		return nil, fmt.Errorf("createdField and updatedField must be different, both are %q", createdField)
+	}
+
+	stamped, err := timestampUpdate(coll.registry, update, createdField, updatedField, time.Now())
+	if err != nil {
+		return nil, err
+	}
+	return coll.UpdateOne(ctx, filter, bson.Raw(stamped), options.Update().SetUpsert(true))
+}
+
+// timestampUpdate returns update with updatedField added to its $currentDate operator and createdField set to now in
+// its $setOnInsert operator. See Collection.UpsertWithTimestamps.
+func timestampUpdate(registry *bsoncodec.Registry, update interface{}, createdField, updatedField string,
+	now time.Time) (bsoncore.Document, error) {
+
+	var elems []bsoncore.Element
+	if update != nil {
+		u, err := transformBsoncoreDocument(registry, update, true, "update")
+		if err != nil {
+			return nil, err
+		}
+		if err = ensureDollarKey(u); err != nil {
+			return nil, err
+		}
+		if elems, err = u.Elements(); err != nil {
+			return nil, err
+		}
+	}
+
+	appendOperator := func(dst []byte, op string, existing bsoncore.Document, appendField func([]byte) []byte) []byte {
+		var idx int32
+		idx, dst = bsoncore.AppendDocumentElementStart(dst, op)
+		if existing != nil {
+			// Copy the existing fields without the enclosing length and terminating null byte.
+			dst = append(dst, existing[4:len(existing)-1]...)
+		}
+		dst = appendField(dst)
+		dst, _ = bsoncore.AppendDocumentEnd(dst, idx)
+		return dst
+	}
+	appendCurrentDate := func(dst []byte) []byte {
+		return bsoncore.AppendBooleanElement(dst, updatedField, true)
+	}
+	appendCreated := func(dst []byte) []byte {
+		return bsoncore.AppendDateTimeElement(dst, createdField, int64(primitive.NewDateTimeFromTime(now)))
+	}
+
+	var currentDate, setOnInsert bsoncore.Document
+	idx, stamped := bsoncore.AppendDocumentStart(nil)
+	for _, elem := range elems {
+		key := elem.Key()
+		if key != "$currentDate" && key != "$setOnInsert" {
+			stamped = append(stamped, elem...)
+			continue
+		}
+		doc, ok := elem.Value().DocumentOK()
+		if !ok {
+			return nil, fmt.Errorf("%s must be a document, got %v", key, elem.Value().Type)
+		}
+		if key == "$currentDate" {
+			currentDate = doc
+		} else {
+			setOnInsert = doc
+		}
+	}
+	stamped = appendOperator(stamped, "$currentDate", currentDate, appendCurrentDate)
+	stamped = appendOperator(stamped, "$setOnInsert", setOnInsert, appendCreated)
+	stamped, _ = bsoncore.AppendDocumentEnd(stamped, idx)
+
+	return stamped, nil
 }
 
 // InsertMany executes an insert command to insert multiple documents into the collection. If write errors occur
@@ -360,13 +797,13 @@ func (coll *Collection) InsertMany(ctx context.Context, documents []interface{},
 		return nil, ErrEmptySlice
 	}
 
-	result, err := coll.insert(ctx, documents, opts...)
+	result, opTime, removed, err := coll.insert(ctx, documents, opts...)
 	rr, err := processWriteError(err)
 	if rr&rrMany == 0 {
 		return nil, err
 	}
 
-	imResult := &InsertManyResult{InsertedIDs: result}
+	imResult := &InsertManyResult{InsertedIDs: result, OperationTime: opTime, DuplicatesRemoved: removed}
 	writeException, ok := err.(WriteException)
 	if !ok {
 		return imResult, err
@@ -399,6 +836,7 @@ func (coll *Collection) delete(ctx context.Context, filter interface{}, deleteOn
 	if err != nil {
 		return nil, err
 	}
+	coll.logQuery("delete", f)
 
 	sess := sessionFromContext(ctx)
 	if sess == nil && coll.client.sessionPool != nil {
@@ -450,7 +888,8 @@ func (coll *Collection) delete(ctx context.Context, filter interface{}, deleteOn
 		ServerSelector(selector).ClusterClock(coll.client.clock).
 		Database(coll.db.name).Collection(coll.name).
 		Deployment(coll.client.deployment).Crypt(coll.client.cryptFLE).Ordered(true).
-		ServerAPI(coll.client.serverAPI)
+		ServerAPI(coll.client.serverAPI).MaxTimeMSCeiling(coll.client.maxTimeMSCeiling).
+		DefaultMaxTime(coll.client.defaultMaxTime)
 	if do.Hint != nil {
 		op = op.Hint(true)
 	}
@@ -472,7 +911,8 @@ func (coll *Collection) delete(ctx context.Context, filter interface{}, deleteOn
 	if rr&expectedRr == 0 {
 		return nil, err
 	}
-	return &DeleteResult{DeletedCount: int64(op.Result().N)}, err
+	opRes := op.Result()
+	return &DeleteResult{DeletedCount: int64(opRes.N), OperationTime: opRes.OperationTime}, err
 }
 
 // DeleteOne executes a delete command to delete at most one document from the collection.
@@ -523,6 +963,7 @@ func (coll *Collection) updateOrReplace(ctx context.Context, filter bsoncore.Doc
 	if err != nil {
 		return nil, err
 	}
+	coll.logQuery("update", filter)
 
 	sess := sessionFromContext(ctx)
 	if sess == nil && coll.client.sessionPool != nil {
@@ -554,7 +995,8 @@ func (coll *Collection) updateOrReplace(ctx context.Context, filter bsoncore.Doc
 		ServerSelector(selector).ClusterClock(coll.client.clock).
 		Database(coll.db.name).Collection(coll.name).
 		Deployment(coll.client.deployment).Crypt(coll.client.cryptFLE).Hint(uo.Hint != nil).
-		ArrayFilters(uo.ArrayFilters != nil).Ordered(true).ServerAPI(coll.client.serverAPI)
+		ArrayFilters(uo.ArrayFilters != nil).Ordered(true).ServerAPI(coll.client.serverAPI).
+		MaxTimeMSCeiling(coll.client.maxTimeMSCeiling).DefaultMaxTime(coll.client.defaultMaxTime)
 	if uo.Let != nil {
 		let, err := transformBsoncoreDocument(coll.registry, uo.Let, true, "let")
 		if err != nil {
@@ -584,6 +1026,7 @@ func (coll *Collection) updateOrReplace(ctx context.Context, filter bsoncore.Doc
 		MatchedCount:  int64(opRes.N),
 		ModifiedCount: int64(opRes.NModified),
 		UpsertedCount: int64(len(opRes.Upserted)),
+		OperationTime: opRes.OperationTime,
 	}
 	if len(opRes.Upserted) > 0 {
 		res.UpsertedID = opRes.Upserted[0].ID
@@ -745,6 +1188,7 @@ func (coll *Collection) Aggregate(ctx context.Context, pipeline interface{},
 		readSelector:   coll.readSelector,
 		writeSelector:  coll.writeSelector,
 		readPreference: coll.readPreference,
+		queryLogger:    coll.queryLogger,
 		opts:           opts,
 	}
 	return aggregate(a)
@@ -760,6 +1204,9 @@ func aggregate(a aggregateParams) (cur *Cursor, err error) {
 	if err != nil {
 		return nil, err
 	}
+	if a.queryLogger != nil {
+		a.queryLogger("aggregate", bson.Raw(pipelineArr))
+	}
 
 	sess := sessionFromContext(a.ctx)
 	// Always close any created implicit sessions if aggregate returns an error.
@@ -813,7 +1260,10 @@ func aggregate(a aggregateParams) (cur *Cursor, err error) {
 		Deployment(a.client.deployment).
 		Crypt(a.client.cryptFLE).
 		ServerAPI(a.client.serverAPI).
-		HasOutputStage(hasOutputStage)
+		MaxTimeMSCeiling(a.client.maxTimeMSCeiling).DefaultMaxTime(a.client.defaultMaxTime).
+		HasOutputStage(hasOutputStage).
+		PrimaryFallback(a.client.primaryFallback && !hasOutputStage).LocalThreshold(a.client.localThreshold).
+		EscalateReadPreference(a.client.escalateReadPref && !hasOutputStage)
 
 	if ao.AllowDiskUse != nil {
 		op.AllowDiskUse(*ao.AllowDiskUse)
@@ -874,6 +1324,11 @@ func aggregate(a aggregateParams) (cur *Cursor, err error) {
 	op = op.Retry(retry)
 
 	err = op.Execute(a.ctx)
+	autoAllowDiskUse := ao.AutoAllowDiskUseOnMemoryError != nil && *ao.AutoAllowDiskUseOnMemoryError &&
+		(ao.AllowDiskUse == nil || !*ao.AllowDiskUse) && !sess.TransactionRunning()
+	if autoAllowDiskUse && isMemoryLimitError(err) {
+		err = op.AllowDiskUse(true).Execute(a.ctx)
+	}
 	if err != nil {
 		if wce, ok := err.(driver.WriteCommandError); ok && wce.WriteConcernError != nil {
 			return nil, *convertDriverWriteConcernError(wce.WriteConcernError)
@@ -931,7 +1386,10 @@ func (coll *Collection) CountDocuments(ctx context.Context, filter interface{},
 	selector := makeReadPrefSelector(sess, coll.readSelector, coll.client.localThreshold)
 	op := operation.NewAggregate(pipelineArr).Session(sess).ReadConcern(rc).ReadPreference(coll.readPreference).
 		CommandMonitor(coll.client.monitor).ServerSelector(selector).ClusterClock(coll.client.clock).Database(coll.db.name).
-		Collection(coll.name).Deployment(coll.client.deployment).Crypt(coll.client.cryptFLE).ServerAPI(coll.client.serverAPI)
+		Collection(coll.name).Deployment(coll.client.deployment).Crypt(coll.client.cryptFLE).ServerAPI(coll.client.serverAPI).
+		PrimaryFallback(coll.client.primaryFallback).MaxTimeMSCeiling(coll.client.maxTimeMSCeiling).
+		DefaultMaxTime(coll.client.defaultMaxTime).LocalThreshold(coll.client.localThreshold).
+		EscalateReadPreference(coll.client.escalateReadPref)
 	if countOpts.Collation != nil {
 		op.Collation(bsoncore.Document(countOpts.Collation.ToDocument()))
 	}
@@ -1013,7 +1471,10 @@ func (coll *Collection) EstimatedDocumentCount(ctx context.Context,
 	op := operation.NewCount().Session(sess).ClusterClock(coll.client.clock).
 		Database(coll.db.name).Collection(coll.name).CommandMonitor(coll.client.monitor).
 		Deployment(coll.client.deployment).ReadConcern(rc).ReadPreference(coll.readPreference).
-		ServerSelector(selector).Crypt(coll.client.cryptFLE).ServerAPI(coll.client.serverAPI)
+		ServerSelector(selector).Crypt(coll.client.cryptFLE).ServerAPI(coll.client.serverAPI).
+		PrimaryFallback(coll.client.primaryFallback).MaxTimeMSCeiling(coll.client.maxTimeMSCeiling).
+		DefaultMaxTime(coll.client.defaultMaxTime).LocalThreshold(coll.client.localThreshold).
+		EscalateReadPreference(coll.client.escalateReadPref)
 
 	co := options.MergeEstimatedDocumentCountOptions(opts...)
 	if co.MaxTime != nil {
@@ -1030,6 +1491,208 @@ func (coll *Collection) EstimatedDocumentCount(ctx context.Context,
 	return op.Result().N, replaceErrors(err)
 }
 
+// Stats returns storage statistics for the collection by running an aggregation with a $collStats stage. For a
+// sharded collection, the statistics reported by each shard are summed.
+//
+// For more information about the stage, see https://docs.mongodb.com/manual/reference/operator/aggregation/collStats/.
+func (coll *Collection) Stats(ctx context.Context) (CollStats, error) {
+	if ctx == nil {
+		ctx = context.Background()
+	}
+
+	pipeline := Pipeline{{{"$collStats", bson.D{{"storageStats", bson.D{}}}}}}
+	cursor, err := coll.Aggregate(ctx, pipeline)
+	if err != nil {
+		return CollStats{}, err
+	}
+	defer cursor.Close(ctx)
+
+	var stats CollStats
+	var found bool
+	for cursor.Next(ctx) {
+		var shard struct {
+			StorageStats CollStats `bson:"storageStats"`
+		}
+		if err = cursor.Decode(&shard); err != nil {
+			return CollStats{}, err
+		}
+
+		found = true
+		stats.Size += shard.StorageStats.Size
+		stats.StorageSize += shard.StorageStats.StorageSize
+		stats.TotalIndexSize += shard.StorageStats.TotalIndexSize
+		stats.Count += shard.StorageStats.Count
+	}
+	if err = cursor.Err(); err != nil {
+		return CollStats{}, err
+	}
+	if !found {
+		return CollStats{}, ErrNoDocuments
+	}
+
+	if stats.Count > 0 {
+		stats.AvgObjSize = float64(stats.Size) / float64(stats.Count)
+	}
+	return stats, nil
+}
+
+// ShardKey returns the shard key pattern of the collection, e.g. {region: 1, _id: "hashed"}. The shard key is read from
+// the config.collections collection, so the Client must be connected to a sharded cluster through a mongos.
+// ErrNotSharded is returned if the collection is not sharded.
+func (coll *Collection) ShardKey(ctx context.Context) (bson.D, error) {
+	if ctx == nil {
+		ctx = context.Background()
+	}
+
+	configColl := coll.client.Database("config").Collection("collections")
+	res := configColl.FindOne(ctx, bson.D{{"_id", coll.Database().Name() + "." + coll.Name()}})
+
+	var entry struct {
+		Key     bson.D `bson:"key"`
+		Dropped bool   `bson:"dropped"`
+	}
+	if err := res.Decode(&entry); err != nil {
+		if err == ErrNoDocuments {
+			return nil, ErrNotSharded
+		}
+		return nil, err
+	}
+	// Before 5.0, the entry for a dropped collection is kept with dropped set to true.
+	if entry.Dropped || len(entry.Key) == 0 {
+		return nil, ErrNotSharded
+	}
+	return entry.Key, nil
+}
+
+// CreatedAt returns a best-effort creation time for the collection. MongoDB does not store the creation time of a
+// collection, so it is derived from the following metadata, in order:
+//
+// 1. For a sharded collection, the config.collections entry. On MongoDB 5.0+, its timestamp field is used. On earlier
+// versions, the time of its lastmodEpoch ObjectID is used. Both record when the collection was sharded, which can be
+// later than when it was created.
+//
+// 2. If the OplogMaxTime option is set, the time of the most recent create entry for the collection's namespace in the
+// oplog of a replica set. The oplog has no index for this search, so it can scan the whole oplog and is stopped by
+// the server after OplogMaxTime. The entry is only available while it is still in the oplog and if the user is
+// allowed to read the local database. If the collection was dropped and created again, the time of the last creation
+// is returned.
+//
+// The returned bool is false if none of the metadata is available, e.g. on a standalone server. Server errors from
+// the metadata queries, such as authorization errors or the oplog search exceeding OplogMaxTime, are treated as the
+// metadata being unavailable.
+func (coll *Collection) CreatedAt(ctx context.Context, opts ...*options.CreatedAtOptions) (time.Time, bool, error) {
+	if ctx == nil {
+		ctx = context.Background()
+	}
+	ns := coll.Database().Name() + "." + coll.Name()
+
+	var entry struct {
+		Timestamp    primitive.Timestamp `bson:"timestamp"`
+		LastmodEpoch primitive.ObjectID  `bson:"lastmodEpoch"`
+		Dropped      bool                `bson:"dropped"`
+	}
+	configColl := coll.client.Database("config").Collection("collections")
+	err := configColl.FindOne(ctx, bson.D{{"_id", ns}}).Decode(&entry)
+	switch {
+	case err == nil && !entry.Dropped && !entry.Timestamp.IsZero():
+		return entry.Timestamp.Time(), true, nil
+	case err == nil && !entry.Dropped && !entry.LastmodEpoch.IsZero():
+		return entry.LastmodEpoch.Timestamp(), true, nil
+	case err != nil && err != ErrNoDocuments && !isServerError(err):
+		return time.Time{}, false, err
+	}
+
+	cao := options.MergeCreatedAtOptions(opts...)
+	if cao.OplogMaxTime == nil {
+		return time.Time{}, false, nil
+	}
+
+	var oplogEntry struct {
+		TS primitive.Timestamp `bson:"ts"`
+	}
+	oplog := coll.client.Database("local").Collection("oplog.rs")
+	filter := bson.D{
+		{"op", "c"},
+		{"ns", coll.Database().Name() + ".$cmd"},
+		{"o.create", coll.Name()},
+	}
+	findOpts := options.FindOne().SetSort(bson.D{{"$natural", -1}}).SetMaxTime(*cao.OplogMaxTime)
+	err = oplog.FindOne(ctx, filter, findOpts).Decode(&oplogEntry)
+	switch {
+	case err == nil:
+		return oplogEntry.TS.Time(), true, nil
+	case err == ErrNoDocuments || isServerError(err):
+		return time.Time{}, false, nil
+	default:
+		return time.Time{}, false, err
+	}
+}
+
+// GetComment returns the comment of the collection. MongoDB does not have a collection-level comment, so the comment is
+// read from a top-level $comment operator in the collection's validator, which is persisted with the collection options
+// and does not change which documents are valid. It can be set with the Validator option when the collection is created
+// or with a collMod command, for example {collMod: <collection>, validator: {$comment: "orders", qty: {$gte: 0}}}.
+// The driver does not provide a setter because adding a validator enables validation on the collection and changing
+// an existing validator can't be done atomically.
+//
+// GetComment runs a listCollections command. An empty string is returned if the collection has no comment. An error is
+// returned if the collection does not exist.
+func (coll *Collection) GetComment(ctx context.Context) (string, error) {
+	if ctx == nil {
+		ctx = context.Background()
+	}
+
+	specs, err := coll.db.ListCollectionSpecifications(ctx, bson.D{{"name", coll.name}})
+	if err != nil {
+		return "", err
+	}
+	if len(specs) == 0 {
+		return "", fmt.Errorf("collection %q does not exist", coll.db.name+"."+coll.name)
+	}
+	comment, ok := specs[0].Options.Lookup("validator", "$comment").StringValueOK()
+	if !ok {
+		return "", nil
+	}
+	return comment, nil
+}
+
+// isServerError returns true if err is an error returned by the server.
+func isServerError(err error) bool {
+	_, ok := err.(ServerError)
+	return ok
+}
+
+// ValidatePipeline checks that an aggregation pipeline can be parsed and planned by the server without processing
+// any documents. It runs an explain command with "queryPlanner" verbosity for the pipeline and returns any error
+// reported by the server, such as an unrecognized stage name or invalid stage arguments. A nil error means the server
+// accepted the pipeline.
+//
+// The pipeline parameter must be an array of documents, each representing an aggregation stage. The pipeline cannot
+// be nil but can be empty. The stage documents must all be non-nil. For a pipeline of bson.D documents, the
+// mongo.Pipeline type can be used.
+//
+// For more information about the command, see https://docs.mongodb.com/manual/reference/command/explain/.
+func (coll *Collection) ValidatePipeline(ctx context.Context, pipeline interface{}) error {
+	if ctx == nil {
+		ctx = context.Background()
+	}
+
+	pipelineArr, _, err := transformAggregatePipeline(coll.registry, pipeline)
+	if err != nil {
+		return err
+	}
+
+	cmd := bson.D{
+		{"explain", bson.D{
+			{"aggregate", coll.name},
+			{"pipeline", bson.RawValue{Type: bsontype.Array, Value: pipelineArr}},
+			{"cursor", bson.D{}},
+		}},
+		{"verbosity", "queryPlanner"},
+	}
+	return coll.db.RunCommand(ctx, cmd).Err()
+}
+
 // Distinct executes a distinct command to find the unique values for a specified field in the collection.
 //
 // The fieldName parameter specifies the field name for which distinct values should be returned.
@@ -1079,7 +1742,10 @@ func (coll *Collection) Distinct(ctx context.Context, fieldName string, filter i
 		Session(sess).ClusterClock(coll.client.clock).
 		Database(coll.db.name).Collection(coll.name).CommandMonitor(coll.client.monitor).
 		Deployment(coll.client.deployment).ReadConcern(rc).ReadPreference(coll.readPreference).
-		ServerSelector(selector).Crypt(coll.client.cryptFLE).ServerAPI(coll.client.serverAPI)
+		ServerSelector(selector).Crypt(coll.client.cryptFLE).ServerAPI(coll.client.serverAPI).
+		PrimaryFallback(coll.client.primaryFallback).MaxTimeMSCeiling(coll.client.maxTimeMSCeiling).
+		DefaultMaxTime(coll.client.defaultMaxTime).LocalThreshold(coll.client.localThreshold).
+		EscalateReadPreference(coll.client.escalateReadPref)
 
 	if option.Collation != nil {
 		op.Collation(bsoncore.Document(option.Collation.ToDocument()))
@@ -1140,6 +1806,7 @@ func (coll *Collection) Find(ctx context.Context, filter interface{},
 	if err != nil {
 		return nil, err
 	}
+	coll.logQuery("find", f)
 
 	sess := sessionFromContext(ctx)
 	// Always close any created implicit sessions if Find returns an error.
@@ -1171,7 +1838,10 @@ func (coll *Collection) Find(ctx context.Context, filter interface{},
 		Session(sess).ReadConcern(rc).ReadPreference(coll.readPreference).
 		CommandMonitor(coll.client.monitor).ServerSelector(selector).
 		ClusterClock(coll.client.clock).Database(coll.db.name).Collection(coll.name).
-		Deployment(coll.client.deployment).Crypt(coll.client.cryptFLE).ServerAPI(coll.client.serverAPI)
+		Deployment(coll.client.deployment).Crypt(coll.client.cryptFLE).ServerAPI(coll.client.serverAPI).
+		PrimaryFallback(coll.client.primaryFallback).MaxTimeMSCeiling(coll.client.maxTimeMSCeiling).
+		DefaultMaxTime(coll.client.defaultMaxTime).LocalThreshold(coll.client.localThreshold).
+		EscalateReadPreference(coll.client.escalateReadPref)
 
 	fo := options.MergeFindOptions(opts...)
 	cursorOpts := coll.client.createBaseCursorOptions()
@@ -1340,6 +2010,87 @@ func (coll *Collection) FindOne(ctx context.Context, filter interface{},
 	return &SingleResult{cur: cursor, reg: coll.registry, err: replaceErrors(err)}
 }
 
+// existingIDsBatchSize is the maximum number of _id values queried by each find command run by ExistingIDs.
+const existingIDsBatchSize = 1000
+
+// ExistingIDs reports which of the given _id values exist in the collection. The returned map has an entry for every
+// value in ids that is true if a document with that _id exists and false otherwise. The values are queried with find
+// commands that use an $in filter on _id, at most 1000 values at a time, and project only the _id
+// field, so the matching documents are never transferred in full.
+//
+// The ids parameter cannot be nil or empty, and its values must be comparable so they can be used as map keys. Numeric
+// values are matched by their numeric value like the server does, so an int32 value of 3 is reported as present if a
+// document has an int64 _id of 3 or a double _id of 3.0. Non-integral Decimal128 values are only matched by _id values
+// of the same type. All other values are reported as present only if a document's _id has the same BSON type and value
+// that the value is marshalled to. In particular, if the collection has a default collation, the server compares string
+// _id values using it, but a matching _id is only reported for the id that it is equal to byte for byte, so an id that
+// only matches under the collation is reported as missing.
+func (coll *Collection) ExistingIDs(ctx context.Context, ids []interface{}) (map[interface{}]bool, error) {
+	if len(ids) == 0 {
+		return nil, ErrEmptySlice
+	}
+	if ctx == nil {
+		ctx = context.Background()
+	}
+
+	// Index the ids by their marshalled BSON type and value so they can be matched to the _id values returned by the
+	// server. Several ids can marshal to the same value, e.g. values of different Go types with the same BSON encoding.
+	present := make(map[interface{}]bool, len(ids))
+	byValue := make(map[string][]interface{}, len(ids))
+	var values []bson.RawValue
+	for _, id := range ids {
+		if id == nil || !reflect.TypeOf(id).Comparable() {
+			return nil, fmt.Errorf("id %v of type %T cannot be used as a map key", id, id)
+		}
+		if _, ok := present[id]; ok {
+			continue
+		}
+		present[id] = false
+
+		t, data, err := bson.MarshalValueWithRegistry(coll.registry, id)
+		if err != nil {
+			return nil, err
+		}
+		key := idKey(bsoncore.Value{Type: t, Data: data})
+		if _, ok := byValue[key]; !ok {
+			values = append(values, bson.RawValue{Type: t, Value: data})
+		}
+		byValue[key] = append(byValue[key], id)
+	}
+
+	projection := options.Find().SetProjection(bson.D{{"_id", 1}})
+	for start := 0; start < len(values); start += existingIDsBatchSize {
+		end := start + existingIDsBatchSize
+		if end > len(values) {
+			end = len(values)
+		}
+		in := make(bson.A, 0, end-start)
+		for _, v := range values[start:end] {
+			in = append(in, v)
+		}
+
+		cursor, err := coll.Find(ctx, bson.D{{"_id", bson.D{{"$in", in}}}}, projection)
+		if err != nil {
+			return nil, err
+		}
+		for cursor.Next(ctx) {
+			id, err := cursor.Current.LookupErr("_id")
+			if err != nil {
+				continue
+			}
+			for _, match := range byValue[idKey(bsoncore.Value{Type: id.Type, Data: id.Value})] {
+				present[match] = true
+			}
+		}
+		err = cursor.Err()
+		_ = cursor.Close(ctx)
+		if err != nil {
+			return nil, err
+		}
+	}
+	return present, nil
+}
+
 func (coll *Collection) findAndModify(ctx context.Context, op *operation.FindAndModify) *SingleResult {
 	if ctx == nil {
 		ctx = context.Background()
@@ -1413,7 +2164,8 @@ func (coll *Collection) FindOneAndDelete(ctx context.Context, filter interface{}
 		return &SingleResult{err: err}
 	}
 	fod := options.MergeFindOneAndDeleteOptions(opts...)
-	op := operation.NewFindAndModify(f).Remove(true).ServerAPI(coll.client.serverAPI)
+	op := operation.NewFindAndModify(f).Remove(true).ServerAPI(coll.client.serverAPI).
+		MaxTimeMSCeiling(coll.client.maxTimeMSCeiling).DefaultMaxTime(coll.client.defaultMaxTime)
 	if fod.Collation != nil {
 		op = op.Collation(bsoncore.Document(fod.Collation.ToDocument()))
 	}
@@ -1449,6 +2201,7 @@ func (coll *Collection) FindOneAndDelete(ctx context.Context, filter interface{}
 		op = op.Let(let)
 	}
 
+	coll.logQuery("findAndModify", f)
 	return coll.findAndModify(ctx, op)
 }
 
@@ -1483,7 +2236,8 @@ func (coll *Collection) FindOneAndReplace(ctx context.Context, filter interface{
 
 	fo := options.MergeFindOneAndReplaceOptions(opts...)
 	op := operation.NewFindAndModify(f).Update(bsoncore.Value{Type: bsontype.EmbeddedDocument, Data: r}).
-		ServerAPI(coll.client.serverAPI)
+		ServerAPI(coll.client.serverAPI).MaxTimeMSCeiling(coll.client.maxTimeMSCeiling).
+		DefaultMaxTime(coll.client.defaultMaxTime)
 	if fo.BypassDocumentValidation != nil && *fo.BypassDocumentValidation {
 		op = op.BypassDocumentValidation(*fo.BypassDocumentValidation)
 	}
@@ -1528,6 +2282,7 @@ func (coll *Collection) FindOneAndReplace(ctx context.Context, filter interface{
 		op = op.Let(let)
 	}
 
+	coll.logQuery("findAndModify", f)
 	return coll.findAndModify(ctx, op)
 }
 
@@ -1559,7 +2314,8 @@ func (coll *Collection) FindOneAndUpdate(ctx context.Context, filter interface{}
 	}
 
 	fo := options.MergeFindOneAndUpdateOptions(opts...)
-	op := operation.NewFindAndModify(f).ServerAPI(coll.client.serverAPI)
+	op := operation.NewFindAndModify(f).ServerAPI(coll.client.serverAPI).MaxTimeMSCeiling(coll.client.maxTimeMSCeiling).
+		DefaultMaxTime(coll.client.defaultMaxTime)
 
 	u, err := transformUpdateValue(coll.registry, update, true)
 	if err != nil {
@@ -1618,9 +2374,48 @@ func (coll *Collection) FindOneAndUpdate(ctx context.Context, filter interface{}
 		op = op.Let(let)
 	}
 
+	coll.logQuery("findAndModify", f)
 	return coll.findAndModify(ctx, op)
 }
 
+// IncrementAndGet atomically increments the numeric field of a single document in the collection by delta and returns
+// the value of the field after the update. The update is executed using FindOneAndUpdate with an $inc update.
+//
+// The filter parameter must be a document containing query operators and can be used to select the document to be
+// updated. It cannot be nil. If the filter does not match any documents, ErrNoDocuments is returned unless the upsert
+// option is set, in which case a new document is inserted with the field set to delta.
+//
+// The opts parameter can be used to specify options for the operation (see the options.FindOneAndUpdateOptions
+// documentation). The ReturnDocument and Projection options are always overridden.
+func (coll *Collection) IncrementAndGet(ctx context.Context, filter interface{}, field string, delta int64,
+	opts ...*options.FindOneAndUpdateOptions) (int64, error) {
+
+	if field == "" {
+		return 0, errors.New("field must not be empty")
+	}
+
+	fuOpts := make([]*options.FindOneAndUpdateOptions, 0, len(opts)+1)
+	fuOpts = append(fuOpts, opts...)
+	fuOpts = append(fuOpts, options.FindOneAndUpdate().
+		SetReturnDocument(options.After).
+		SetProjection(bson.D{{field, 1}}))
+	update := bson.D{{"$inc", bson.D{{field, delta}}}}
+	raw, err := coll.FindOneAndUpdate(ctx, filter, update, fuOpts...).DecodeBytes()
+	if err != nil {
+		return 0, err
+	}
+
+	val, err := raw.LookupErr(strings.Split(field, ".")...)
+	if err != nil {
+		return 0, fmt.Errorf("field %q not found in updated document: %v", field, err)
+	}
+	n, ok := val.AsInt64OK()
+	if !ok {
+		return 0, fmt.Errorf("field %q has non-numeric type %v", field, val.Type)
+	}
+	return n, nil
+}
+
 // Watch returns a change stream for all changes on the corresponding collection. See
 // https://docs.mongodb.com/manual/changeStreams/ for more information about change streams.
 //
@@ -1705,24 +2500,42 @@ func (coll *Collection) Drop(ctx context.Context) error {
 
 // makePinnedSelector makes a selector for a pinned session with a pinned server. Will attempt to do server selection on
 // the pinned server but if that fails it will go through a list of default selectors
-func makePinnedSelector(sess *session.Client, defaultSelector description.ServerSelector) description.ServerSelectorFunc {
-	return func(t description.Topology, svrs []description.Server) ([]description.Server, error) {
-		if sess != nil && sess.PinnedServer != nil {
-			// If there is a pinned server, try to find it in the list of candidates.
-			for _, candidate := range svrs {
-				if candidate.Addr == sess.PinnedServer.Addr {
-					return []description.Server{candidate}, nil
-				}
-			}
+func makePinnedSelector(sess *session.Client, defaultSelector description.ServerSelector) description.ServerSelector {
+	return &pinnedSelector{sess: sess, defaultSelector: defaultSelector}
+}
+
+// pinnedSelector is the selector returned by makePinnedSelector. It implements description.WrappingSelector so the
+// stages of defaultSelector stay visible to the driver and can be replaced when an operation is retried with a
+// different read preference.
+type pinnedSelector struct {
+	sess            *session.Client
+	defaultSelector description.ServerSelector
+}
 
-			return nil, nil
+func (ps *pinnedSelector) SelectServer(t description.Topology, svrs []description.Server) ([]description.Server, error) {
+	if ps.sess != nil && ps.sess.PinnedServer != nil {
+		// If there is a pinned server, try to find it in the list of candidates.
+		for _, candidate := range svrs {
+			if candidate.Addr == ps.sess.PinnedServer.Addr {
+				return []description.Server{candidate}, nil
+			}
 		}
 
-		return defaultSelector.SelectServer(t, svrs)
+		return nil, nil
 	}
+
+	return ps.defaultSelector.SelectServer(t, svrs)
+}
+
+func (ps *pinnedSelector) Unwrap() description.ServerSelector {
+	return ps.defaultSelector
+}
+
+func (ps *pinnedSelector) Wrap(selector description.ServerSelector) description.ServerSelector {
+	return makePinnedSelector(ps.sess, selector)
 }
 
-func makeReadPrefSelector(sess *session.Client, selector description.ServerSelector, localThreshold time.Duration) description.ServerSelectorFunc {
+func makeReadPrefSelector(sess *session.Client, selector description.ServerSelector, localThreshold time.Duration) description.ServerSelector {
 	if sess != nil && sess.TransactionRunning() {
 		selector = description.CompositeSelector([]description.ServerSelector{
 			description.ReadPrefSelector(sess.CurrentRp),
@@ -1733,7 +2546,7 @@ func makeReadPrefSelector(sess *session.Client, selector description.ServerSelec
 	return makePinnedSelector(sess, selector)
 }
 
-func makeOutputAggregateSelector(sess *session.Client, rp *readpref.ReadPref, localThreshold time.Duration) description.ServerSelectorFunc {
+func makeOutputAggregateSelector(sess *session.Client, rp *readpref.ReadPref, localThreshold time.Duration) description.ServerSelector {
 	if sess != nil && sess.TransactionRunning() {
 		// Use current transaction's read preference if available
 		rp = sess.CurrentRp
@@ -9,13 +9,18 @@ package mongo
 import (
 	"errors"
 	"testing"
+	"time"
 
 	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
 	"go.mongodb.org/mongo-driver/internal/testutil/assert"
+	"go.mongodb.org/mongo-driver/mongo/description"
 	"go.mongodb.org/mongo-driver/mongo/options"
 	"go.mongodb.org/mongo-driver/mongo/readconcern"
 	"go.mongodb.org/mongo-driver/mongo/readpref"
 	"go.mongodb.org/mongo-driver/mongo/writeconcern"
+	"go.mongodb.org/mongo-driver/x/bsonx/bsoncore"
+	"go.mongodb.org/mongo-driver/x/mongo/driver/session"
 )
 
 const (
@@ -213,4 +218,154 @@ func TestCollection(t *testing.T) {
 		_, err = coll.Watch(bgCtx, nil)
 		assert.Equal(t, aggErr, err, "expected error %v, got %v", aggErr, err)
 	})
+	t.Run("client-side validator", func(t *testing.T) {
+		schema, err := bson.Marshal(bson.D{{"$jsonSchema", bson.D{
+			{"bsonType", "object"},
+			{"required", bson.A{"name", "age"}},
+			{"properties", bson.D{
+				{"name", bson.D{{"bsonType", "string"}, {"minLength", 1}}},
+				{"age", bson.D{{"bsonType", "int"}, {"minimum", 0}}},
+			}},
+		}}})
+		assert.Nil(t, err, "Marshal error: %v", err)
+
+		coll := setupColl("foo")
+		err = coll.SetClientSideValidator(schema)
+		assert.Nil(t, err, "SetClientSideValidator error: %v", err)
+
+		valid := bson.D{{"name", "alice"}, {"age", int32(30)}}
+		missingAge := bson.D{{"name", "bob"}}
+		wrongType := bson.D{{"name", "carol"}, {"age", "thirty"}}
+
+		// Conforming documents pass validation and reach the server, which fails because the client is disconnected.
+		_, err = coll.InsertOne(bgCtx, valid)
+		assert.Equal(t, ErrClientDisconnected, err, "expected error %v, got %v", ErrClientDisconnected, err)
+		_, err = coll.InsertMany(bgCtx, []interface{}{valid, valid})
+		assert.Equal(t, ErrClientDisconnected, err, "expected error %v, got %v", ErrClientDisconnected, err)
+
+		_, err = coll.InsertOne(bgCtx, missingAge)
+		cves, ok := err.(ClientValidationErrors)
+		assert.True(t, ok, "expected error type %T, got %T", ClientValidationErrors{}, err)
+		assert.Equal(t, 1, len(cves), "expected 1 validation error, got %v", len(cves))
+		assert.Equal(t, 0, cves[0].Index, "expected index 0, got %v", cves[0].Index)
+
+		_, err = coll.InsertMany(bgCtx, []interface{}{valid, missingAge, valid, wrongType})
+		cves, ok = err.(ClientValidationErrors)
+		assert.True(t, ok, "expected error type %T, got %T", ClientValidationErrors{}, err)
+		assert.Equal(t, 2, len(cves), "expected 2 validation errors, got %v", len(cves))
+		assert.Equal(t, 1, cves[0].Index, "expected index 1, got %v", cves[0].Index)
+		assert.Equal(t, 3, cves[1].Index, "expected index 3, got %v", cves[1].Index)
+
+		err = coll.SetClientSideValidator(nil)
+		assert.Nil(t, err, "SetClientSideValidator error: %v", err)
+		_, err = coll.InsertOne(bgCtx, missingAge)
+		assert.Equal(t, ErrClientDisconnected, err, "expected error %v, got %v", ErrClientDisconnected, err)
+	})
+	t.Run("upsert with timestamps", func(t *testing.T) {
+		now := time.Date(2020, time.January, 2, 3, 4, 5, 0, time.UTC)
+
+		t.Run("adds operators", func(t *testing.T) {
+			update := bson.D{{"$set", bson.D{{"name", "alice"}}}}
+			doc, err := timestampUpdate(bson.DefaultRegistry, update, "createdAt", "updatedAt", now)
+			assert.Nil(t, err, "timestampUpdate error: %v", err)
+
+			want, err := bson.Marshal(bson.D{
+				{"$set", bson.D{{"name", "alice"}}},
+				{"$currentDate", bson.D{{"updatedAt", true}}},
+				{"$setOnInsert", bson.D{{"createdAt", primitive.NewDateTimeFromTime(now)}}},
+			})
+			assert.Nil(t, err, "Marshal error: %v", err)
+			assert.Equal(t, bson.Raw(want), bson.Raw(doc), "expected update %v, got %v", bson.Raw(want), bson.Raw(doc))
+		})
+		t.Run("extends existing operators", func(t *testing.T) {
+			update := bson.D{
+				{"$currentDate", bson.D{{"seenAt", true}}},
+				{"$setOnInsert", bson.D{{"name", "alice"}}},
+			}
+			doc, err := timestampUpdate(bson.DefaultRegistry, update, "createdAt", "updatedAt", now)
+			assert.Nil(t, err, "timestampUpdate error: %v", err)
+
+			want, err := bson.Marshal(bson.D{
+				{"$currentDate", bson.D{{"seenAt", true}, {"updatedAt", true}}},
+				{"$setOnInsert", bson.D{{"name", "alice"}, {"createdAt", primitive.NewDateTimeFromTime(now)}}},
+			})
+			assert.Nil(t, err, "Marshal error: %v", err)
+			assert.Equal(t, bson.Raw(want), bson.Raw(doc), "expected update %v, got %v", bson.Raw(want), bson.Raw(doc))
+		})
+		t.Run("nil update", func(t *testing.T) {
+			doc, err := timestampUpdate(bson.DefaultRegistry, nil, "createdAt", "updatedAt", now)
+			assert.Nil(t, err, "timestampUpdate error: %v", err)
+
+			want, err := bson.Marshal(bson.D{
+				{"$currentDate", bson.D{{"updatedAt", true}}},
+				{"$setOnInsert", bson.D{{"createdAt", primitive.NewDateTimeFromTime(now)}}},
+			})
+			assert.Nil(t, err, "Marshal error: %v", err)
+			assert.Equal(t, bson.Raw(want), bson.Raw(doc), "expected update %v, got %v", bson.Raw(want), bson.Raw(doc))
+		})
+		t.Run("invalid arguments", func(t *testing.T) {
+			coll := setupColl("foo")
+			_, err := coll.UpsertWithTimestamps(bgCtx, bson.D{}, nil, "", "updatedAt")
+			assert.NotNil(t, err, "expected error for empty field, got nil")
+			_, err = coll.UpsertWithTimestamps(bgCtx, bson.D{}, nil, "ts", "ts")
+			assert.NotNil(t, err, "expected error for identical fields, got nil")
+			_, err = coll.UpsertWithTimestamps(bgCtx, bson.D{}, bson.D{{"name", "alice"}}, "createdAt", "updatedAt")
+			assert.NotNil(t, err, "expected error for replacement document, got nil")
+		})
+	})
+	t.Run("existing ids invalid arguments", func(t *testing.T) {
+		coll := setupColl("foo")
+
+		_, err := coll.ExistingIDs(bgCtx, nil)
+		assert.Equal(t, ErrEmptySlice, err, "expected error %v, got %v", ErrEmptySlice, err)
+		_, err = coll.ExistingIDs(bgCtx, []interface{}{"a", bson.D{{"x", 1}}})
+		assert.NotNil(t, err, "expected error for non-comparable id, got nil")
+		_, err = coll.ExistingIDs(bgCtx, []interface{}{nil})
+		assert.NotNil(t, err, "expected error for nil id, got nil")
+
+		_, err = coll.ExistingIDs(bgCtx, []interface{}{"a"})
+		assert.Equal(t, ErrClientDisconnected, err, "expected error %v, got %v", ErrClientDisconnected, err)
+	})
+	t.Run("pinned selector", func(t *testing.T) {
+		primary := description.Server{Addr: "primary:27017", Kind: description.RSPrimary}
+		secondary := description.Server{Addr: "secondary:27017", Kind: description.RSSecondary}
+		topo := description.Topology{Kind: description.ReplicaSetWithPrimary, Servers: []description.Server{primary, secondary}}
+
+		// A pinned session selects its pinned server without applying the default selector.
+		pinned := makePinnedSelector(&session.Client{PinnedServer: &secondary}, description.WriteSelector())
+		got, err := pinned.SelectServer(topo, topo.Servers)
+		assert.Nil(t, err, "SelectServer error: %v", err)
+		assert.Equal(t, []description.Server{secondary}, got, "expected pinned server %v, got %v", secondary, got)
+		assert.True(t, description.IsWriteSelector(pinned), "expected pinned write selector to be a write selector")
+
+		unpinned := makePinnedSelector(nil, description.WriteSelector())
+		got, err = unpinned.SelectServer(topo, topo.Servers)
+		assert.Nil(t, err, "SelectServer error: %v", err)
+		assert.Equal(t, []description.Server{primary}, got, "expected primary %v, got %v", primary, got)
+	})
+	t.Run("id keys", func(t *testing.T) {
+		// idKey is used both to deduplicate inserted documents and to match the results of ExistingIDs.
+		key := func(val interface{}) string {
+			typ, data, err := bson.MarshalValue(val)
+			assert.Nil(t, err, "MarshalValue error: %v", err)
+			return idKey(bsoncore.Value{Type: typ, Data: data})
+		}
+		decimal := func(s string) primitive.Decimal128 {
+			d, err := primitive.ParseDecimal128(s)
+			assert.Nil(t, err, "ParseDecimal128 error: %v", err)
+			return d
+		}
+
+		equal := []interface{}{int32(3), int64(3), 3.0, decimal("3"), decimal("3.00"), decimal("0.3E+1")}
+		for _, val := range equal {
+			assert.Equal(t, key(int32(3)), key(val), "expected %v (%T) to have the same key as int32(3)", val, val)
+		}
+		different := []interface{}{int32(4), 3.5, decimal("3.5"), "3", int64(1 << 53)}
+		for _, val := range different {
+			assert.NotEqual(t, key(int32(3)), key(val), "expected %v (%T) to have a different key than int32(3)",
+				val, val)
+		}
+		assert.NotEqual(t, key(int64(1<<53+1)), key(float64(1<<53)),
+			"expected 2^53+1 to have a different key than the double 2^53")
+	})
 }
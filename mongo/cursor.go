@@ -11,6 +11,7 @@ import (
 	"errors"
 	"fmt"
 	"io"
+	"math"
 	"reflect"
 
 	"go.mongodb.org/mongo-driver/bson"
@@ -21,6 +22,10 @@ import (
 	"go.mongodb.org/mongo-driver/x/mongo/driver/session"
 )
 
+// ErrMaxBufferedBytesExceeded is returned by a Cursor when a batch received from the server is larger than the limit set
+// with SetMaxBufferedBytes.
+var ErrMaxBufferedBytesExceeded = errors.New("cursor batch exceeds the maximum number of buffered bytes")
+
 // Cursor is used to iterate over a stream of documents. Each document can be decoded into a Go type via the Decode
 // method or accessed as raw BSON via the Current field. This type is not goroutine safe and must not be used
 // concurrently by multiple goroutines.
@@ -35,7 +40,10 @@ type Cursor struct {
 	registry      *bsoncodec.Registry
 	clientSession *session.Client
 
-	err error
+	maxBufferedBytes int
+	// transform, if set, is applied to each document before it is returned by the Cursor.
+	transform func(context.Context, bson.Raw) (bson.Raw, error)
+	err       error
 }
 
 func newCursor(bc batchCursor, registry *bsoncodec.Registry) (*Cursor, error) {
@@ -153,8 +161,7 @@ func (c *Cursor) next(ctx context.Context, nonBlocking bool) bool {
 	case nil:
 		// Consume the next document in the current batch.
 		c.batchLength--
-		c.Current = bson.Raw(doc)
-		return true
+		return c.setCurrent(ctx, doc)
 	case io.EOF: // Need to do a getMore
 	default:
 		c.err = err
@@ -191,13 +198,16 @@ func (c *Cursor) next(ctx context.Context, nonBlocking bool) bool {
 
 		// Use the new batch to update the batch and batchLength fields. Consume the first document in the batch.
 		c.batch = c.bc.Batch()
+		if c.err = c.checkBatchSize(c.batch); c.err != nil {
+			return false
+		}
+		c.sizeGetMores(c.batch)
 		c.batchLength = c.batch.DocumentCount()
 		doc, err = c.batch.Next()
 		switch err {
 		case nil:
 			c.batchLength--
-			c.Current = bson.Raw(doc)
-			return true
+			return c.setCurrent(ctx, doc)
 		case io.EOF: // Empty batch so we continue
 		default:
 			c.err = err
@@ -206,6 +216,17 @@ func (c *Cursor) next(ctx context.Context, nonBlocking bool) bool {
 	}
 }
 
+// setCurrent sets Current to doc, applying the Cursor's transform if it has one. It returns false and sets the Cursor's
+// error if the transform fails.
+func (c *Cursor) setCurrent(ctx context.Context, doc bsoncore.Document) bool {
+	c.Current = bson.Raw(doc)
+	if c.transform == nil {
+		return true
+	}
+	c.Current, c.err = c.transform(ctx, c.Current)
+	return c.err == nil
+}
+
 // Decode will unmarshal the current document into val and return any errors from the unmarshalling process without any
 // modification. If val is nil or is a typed nil, an error will be returned.
 func (c *Cursor) Decode(val interface{}) error {
@@ -250,7 +271,7 @@ func (c *Cursor) All(ctx context.Context, results interface{}) error {
 
 	batch := c.batch // exhaust the current batch before iterating the batch cursor
 	for {
-		sliceVal, index, err = c.addFromBatch(sliceVal, elementType, batch, index)
+		sliceVal, index, err = c.addFromBatch(ctx, sliceVal, elementType, batch, index)
 		if err != nil {
 			return err
 		}
@@ -260,6 +281,10 @@ func (c *Cursor) All(ctx context.Context, results interface{}) error {
 		}
 
 		batch = c.bc.Batch()
+		if err = c.checkBatchSize(batch); err != nil {
+			return err
+		}
+		c.sizeGetMores(batch)
 	}
 
 	if err = replaceErrors(c.bc.Err()); err != nil {
@@ -276,10 +301,66 @@ func (c *Cursor) RemainingBatchLength() int {
 	return c.batchLength
 }
 
+// SetMaxBufferedBytes bounds the number of bytes of documents the Cursor holds in memory. The batchSize of each getMore
+// the Cursor sends is derived from n and the average size of the documents in the most recent batch, overriding the
+// batchSize option of the operation that created the Cursor. The initial batch is returned by that operation before
+// this method can be called, so its size is only bounded by the batchSize option.
+//
+// Batches are received from the server whole, so if a batch received after this call is still larger than n bytes,
+// e.g. because its documents are larger than those of the previous batch, Next, TryNext, and All stop and return
+// ErrMaxBufferedBytesExceeded rather than iterating it. This includes batches that contain a single document larger
+// than n bytes. A value of zero or less removes the bound, which is the default.
+func (c *Cursor) SetMaxBufferedBytes(n int) {
+	c.maxBufferedBytes = n
+	c.sizeGetMores(c.bc.Batch())
+}
+
+// BufferedBytes returns the number of bytes of documents in the current batch, including documents that have already
+// been iterated.
+func (c *Cursor) BufferedBytes() int {
+	if c.batch == nil {
+		return 0
+	}
+	return len(c.batch.Data)
+}
+
+// checkBatchSize returns ErrMaxBufferedBytesExceeded if batch is larger than the limit set with SetMaxBufferedBytes.
+func (c *Cursor) checkBatchSize(batch *bsoncore.DocumentSequence) error {
+	if c.maxBufferedBytes > 0 && batch != nil && len(batch.Data) > c.maxBufferedBytes {
+		return ErrMaxBufferedBytesExceeded
+	}
+	return nil
+}
+
+// sizeGetMores sets the batchSize of subsequent getMores so that a batch of documents the size of the documents in
+// batch fits within the limit set with SetMaxBufferedBytes. It does nothing if no limit is set, batch has no documents,
+// or the batch cursor does not support changing its batchSize.
+func (c *Cursor) sizeGetMores(batch *bsoncore.DocumentSequence) {
+	bs, ok := c.bc.(batchSizer)
+	if !ok || c.maxBufferedBytes <= 0 || batch == nil {
+		return
+	}
+	count := batch.DocumentCount()
+	if count == 0 {
+		return
+	}
+
+	// Round the average document size up so the derived batchSize errs on the side of smaller batches.
+	avgSize := (len(batch.Data) + count - 1) / count
+	size := c.maxBufferedBytes / avgSize
+	switch {
+	case size < 1:
+		size = 1
+	case size > math.MaxInt32:
+		size = math.MaxInt32
+	}
+	bs.SetBatchSize(int32(size))
+}
+
 // addFromBatch adds all documents from batch to sliceVal starting at the given index. It returns the new slice value,
 // the next empty index in the slice, and an error if one occurs.
-func (c *Cursor) addFromBatch(sliceVal reflect.Value, elemType reflect.Type, batch *bsoncore.DocumentSequence,
-	index int) (reflect.Value, int, error) {
+func (c *Cursor) addFromBatch(ctx context.Context, sliceVal reflect.Value, elemType reflect.Type,
+	batch *bsoncore.DocumentSequence, index int) (reflect.Value, int, error) {
 
 	docs, err := batch.Documents()
 	if err != nil {
@@ -287,6 +368,13 @@ func (c *Cursor) addFromBatch(sliceVal reflect.Value, elemType reflect.Type, bat
 	}
 
 	for _, doc := range docs {
+		raw := bson.Raw(doc)
+		if c.transform != nil {
+			if raw, err = c.transform(ctx, raw); err != nil {
+				return sliceVal, index, err
+			}
+		}
+
 		if sliceVal.Len() == index {
 			// slice is full
 			newElem := reflect.New(elemType)
@@ -295,7 +383,7 @@ func (c *Cursor) addFromBatch(sliceVal reflect.Value, elemType reflect.Type, bat
 		}
 
 		currElem := sliceVal.Index(index).Addr().Interface()
-		if err = bson.UnmarshalWithRegistry(c.registry, doc, currElem); err != nil {
+		if err = bson.UnmarshalWithRegistry(c.registry, raw, currElem); err != nil {
 			return sliceVal, index, err
 		}
 
@@ -18,9 +18,10 @@ import (
 )
 
 type testBatchCursor struct {
-	batches []*bsoncore.DocumentSequence
-	batch   *bsoncore.DocumentSequence
-	closed  bool
+	batches   []*bsoncore.DocumentSequence
+	batch     *bsoncore.DocumentSequence
+	closed    bool
+	batchSize int32
 }
 
 func newTestBatchCursor(numBatches, batchSize int) *testBatchCursor {
@@ -86,6 +87,10 @@ func (tbc *testBatchCursor) Close(context.Context) error {
 	return nil
 }
 
+func (tbc *testBatchCursor) SetBatchSize(size int32) {
+	tbc.batchSize = size
+}
+
 func TestCursor(t *testing.T) {
 	t.Run("loops until docs available", func(t *testing.T) {})
 	t.Run("returns false on context cancellation", func(t *testing.T) {})
@@ -179,6 +184,80 @@ func TestCursor(t *testing.T) {
 			assert.NotNil(t, err, "expected error, got: %v", err)
 		})
 	})
+	t.Run("TestMaxBufferedBytes", func(t *testing.T) {
+		// Each test document is 14 bytes, so a batch of 5 documents is 70 bytes.
+		t.Run("batches within the limit are iterated", func(t *testing.T) {
+			cursor, err := newCursor(newTestBatchCursor(2, 5), nil)
+			assert.Nil(t, err, "newCursor error: %v", err)
+			cursor.SetMaxBufferedBytes(70)
+
+			var count int
+			for cursor.Next(context.Background()) {
+				count++
+				assert.Equal(t, 70, cursor.BufferedBytes(), "expected 70 buffered bytes, got %v", cursor.BufferedBytes())
+			}
+			assert.Nil(t, cursor.Err(), "cursor error: %v", cursor.Err())
+			assert.Equal(t, 10, count, "expected 10 documents, got %v", count)
+		})
+		t.Run("Next errors if a batch exceeds the limit", func(t *testing.T) {
+			cursor, err := newCursor(newTestBatchCursor(1, 5), nil)
+			assert.Nil(t, err, "newCursor error: %v", err)
+			cursor.SetMaxBufferedBytes(69)
+
+			assert.False(t, cursor.Next(context.Background()), "expected Next to return false")
+			assert.Equal(t, ErrMaxBufferedBytesExceeded, cursor.Err(), "expected error %v, got %v",
+				ErrMaxBufferedBytesExceeded, cursor.Err())
+		})
+		t.Run("Next errors if a single document exceeds the limit", func(t *testing.T) {
+			cursor, err := newCursor(newTestBatchCursor(1, 1), nil)
+			assert.Nil(t, err, "newCursor error: %v", err)
+			cursor.SetMaxBufferedBytes(13)
+
+			assert.False(t, cursor.Next(context.Background()), "expected Next to return false")
+			assert.Equal(t, ErrMaxBufferedBytesExceeded, cursor.Err(), "expected error %v, got %v",
+				ErrMaxBufferedBytesExceeded, cursor.Err())
+		})
+		t.Run("All errors if a batch exceeds the limit", func(t *testing.T) {
+			tbc := newTestBatchCursor(2, 5)
+			cursor, err := newCursor(tbc, nil)
+			assert.Nil(t, err, "newCursor error: %v", err)
+			cursor.SetMaxBufferedBytes(69)
+
+			var docs []bson.D
+			err = cursor.All(context.Background(), &docs)
+			assert.Equal(t, ErrMaxBufferedBytesExceeded, err, "expected error %v, got %v", ErrMaxBufferedBytesExceeded, err)
+			assert.True(t, tbc.closed, "expected cursor to be closed")
+		})
+		t.Run("getMore batchSize is derived from the limit", func(t *testing.T) {
+			tbc := newTestBatchCursor(2, 5)
+			cursor, err := newCursor(tbc, nil)
+			assert.Nil(t, err, "newCursor error: %v", err)
+			cursor.SetMaxBufferedBytes(100)
+
+			assert.True(t, cursor.Next(context.Background()), "expected Next to return true")
+			assert.Equal(t, int32(7), tbc.batchSize, "expected batchSize 7, got %v", tbc.batchSize)
+		})
+		t.Run("getMore batchSize is at least one", func(t *testing.T) {
+			tbc := newTestBatchCursor(2, 5)
+			assert.True(t, tbc.Next(context.Background()), "expected Next to return true")
+			cursor, err := newCursor(tbc, nil)
+			assert.Nil(t, err, "newCursor error: %v", err)
+			cursor.SetMaxBufferedBytes(10)
+
+			assert.Equal(t, int32(1), tbc.batchSize, "expected batchSize 1, got %v", tbc.batchSize)
+		})
+		t.Run("zero removes the limit", func(t *testing.T) {
+			cursor, err := newCursor(newTestBatchCursor(1, 5), nil)
+			assert.Nil(t, err, "newCursor error: %v", err)
+			cursor.SetMaxBufferedBytes(1)
+			cursor.SetMaxBufferedBytes(0)
+
+			var docs []bson.D
+			err = cursor.All(context.Background(), &docs)
+			assert.Nil(t, err, "All error: %v", err)
+			assert.Equal(t, 5, len(docs), "expected 5 documents, got %v", len(docs))
+		})
+	})
 }
 
 func TestNewCursorFromDocuments(t *testing.T) {
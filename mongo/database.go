@@ -13,6 +13,7 @@ import (
 
 	"go.mongodb.org/mongo-driver/bson"
 	"go.mongodb.org/mongo-driver/bson/bsoncodec"
+	"go.mongodb.org/mongo-driver/mongo/address"
 	"go.mongodb.org/mongo-driver/mongo/description"
 	"go.mongodb.org/mongo-driver/mongo/options"
 	"go.mongodb.org/mongo-driver/mongo/readconcern"
@@ -160,6 +161,8 @@ func (db *Database) processRunCommand(ctx context.Context, cmd interface{},
 	readSelect := description.CompositeSelector([]description.ServerSelector{
 		description.ReadPrefSelector(ro.ReadPreference),
 		description.LatencySelector(db.client.localThreshold),
+		// The command can be a write, so it is not allowed to run while the topology has a compatibility error.
+		description.MayWriteSelector(),
 	})
 	if sess != nil && sess.PinnedServer != nil {
 		readSelect = makePinnedSelector(sess, readSelect)
@@ -245,6 +248,69 @@ func (db *Database) RunCommandCursor(ctx context.Context, runCommand interface{}
 	return cursor, replaceErrors(err)
 }
 
+// RunCommandOnServer executes the given command against the database on the server with the given address, such as
+// replSetStepDown on a replica set primary or compact on a secondary. The server is selected by address instead of by
+// read preference. If the server is not part of the topology, server selection waits until it is discovered and times
+// out after the client's server selection timeout. Servers behind a load balancer cannot be targeted by address. If the
+// session in ctx is pinned to a different server, e.g. a mongos during a sharded transaction,
+// ErrSessionPinnedToOtherServer is returned.
+//
+// The command is sent with a primaryPreferred read preference so it can run on secondaries. A different read
+// preference can be set with the RunCmdOptions.ReadPreference option. Within a transaction, the read preference must
+// be primary.
+//
+// The runCommand parameter must be a document for the command to be executed. It cannot be nil.
+// This must be an order-preserving type such as bson.D. Map types such as bson.M are not valid.
+//
+// The opts parameter can be used to specify options for this operation (see the options.RunCmdOptions documentation).
+func (db *Database) RunCommandOnServer(ctx context.Context, addr address.Address, runCommand interface{},
+	opts ...*options.RunCmdOptions) *SingleResult {
+
+	if ctx == nil {
+		ctx = context.Background()
+	}
+
+	sess := sessionFromContext(ctx)
+	if sess != nil && sess.PinnedServer != nil && sess.PinnedServer.Addr.Canonicalize() != addr.Canonicalize() {
+		return &SingleResult{err: ErrSessionPinnedToOtherServer}
+	}
+	if sess == nil || !sess.TransactionRunning() {
+		opts = append([]*options.RunCmdOptions{options.RunCmd().SetReadPreference(readpref.PrimaryPreferred())},
+			opts...)
+	}
+	op, sess, err := db.processRunCommand(ctx, runCommand, false, opts...)
+	defer closeImplicitSession(sess)
+	if err != nil {
+		return &SingleResult{err: err}
+	}
+
+	selector := description.CompositeSelector([]description.ServerSelector{
+		makeAddressSelector(addr),
+		description.MayWriteSelector(),
+	})
+	err = op.ServerSelector(makePinnedSelector(sess, selector)).Execute(ctx)
+	// The command can be a write, thus execute may return a write error
+	_, convErr := processWriteError(err)
+	return &SingleResult{
+		err: convErr,
+		rdr: bson.Raw(op.Result()),
+		reg: db.registry,
+	}
+}
+
+// makeAddressSelector returns a ServerSelector that only selects the server with the given address.
+func makeAddressSelector(addr address.Address) description.ServerSelector {
+	addr = addr.Canonicalize()
+	return description.ServerSelectorFunc(func(_ description.Topology, candidates []description.Server) ([]description.Server, error) {
+		for _, candidate := range candidates {
+			if candidate.Addr.Canonicalize() == addr {
+				return []description.Server{candidate}, nil
+			}
+		}
+		return nil, nil
+	})
+}
+
 // Drop drops the database on the server. This method ignores "namespace not found" errors so it is safe to drop
 // a database that does not exist on the server.
 func (db *Database) Drop(ctx context.Context) error {
@@ -660,3 +726,89 @@ func (db *Database) executeCreateOperation(ctx context.Context, op *operation.Cr
 
 	return replaceErrors(op.Execute(ctx))
 }
+
+// ShardCollection enables sharding for the database and shards the collection with the given name using shardKey,
+// e.g. {region: 1, _id: 1} or {_id: "hashed"}. It runs the enableSharding and shardCollection commands against the
+// admin database, so the Client must be connected to a sharded cluster through a mongos. Sharding already being
+// enabled for the database is not an error.
+//
+// The opts parameter can be used to specify options for the operation (see the options.ShardCollectionOptions
+// documentation).
+func (db *Database) ShardCollection(ctx context.Context, coll string, shardKey bson.D,
+	opts ...*options.ShardCollectionOptions) error {
+
+	if ctx == nil {
+		ctx = context.Background()
+	}
+	if len(shardKey) == 0 {
+		return errors.New("shard key must not be empty")
+	}
+
+	admin := db.client.Database("admin")
+	err := admin.RunCommand(ctx, bson.D{{"enableSharding", db.name}}).Err()
+	// Servers before 4.0 return an AlreadyInitialized error if sharding is already enabled for the database.
+	if cerr, ok := err.(CommandError); ok && cerr.Code == 23 {
+		err = nil
+	}
+	if err != nil {
+		return err
+	}
+
+	sco := options.MergeShardCollectionOptions(opts...)
+	cmd := bson.D{
+		{"shardCollection", db.name + "." + coll},
+		{"key", shardKey},
+	}
+	if sco.Unique != nil {
+		cmd = append(cmd, bson.E{"unique", *sco.Unique})
+	}
+	if sco.NumInitialChunks != nil {
+		cmd = append(cmd, bson.E{"numInitialChunks", *sco.NumInitialChunks})
+	}
+	return admin.RunCommand(ctx, cmd).Err()
+}
+
+// ProfilerEntries returns the documents in the database's system.profile collection that match filter, decoded as
+// ProfileEntry values. The database profiler must be enabled with the profile command for entries to be written. A
+// nil filter matches all entries.
+//
+// The opts parameter can be used to specify options for the underlying find operation (see the options.FindOptions
+// documentation), e.g. to sort the entries by ts or limit how many are returned.
+//
+// For more information about the profiler, see https://docs.mongodb.com/manual/reference/database-profiler/.
+func (db *Database) ProfilerEntries(ctx context.Context, filter interface{},
+	opts ...*options.FindOptions) ([]ProfileEntry, error) {
+
+	if ctx == nil {
+		ctx = context.Background()
+	}
+	if filter == nil {
+		filter = bson.D{}
+	}
+
+	cursor, err := db.Collection("system.profile").Find(ctx, filter, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return decodeProfileEntries(ctx, cursor)
+}
+
+// decodeProfileEntries decodes the documents returned by cursor as ProfileEntry values and closes the cursor.
+func decodeProfileEntries(ctx context.Context, cursor *Cursor) ([]ProfileEntry, error) {
+	defer cursor.Close(ctx)
+
+	entries := make([]ProfileEntry, 0)
+	for cursor.Next(ctx) {
+		var entry ProfileEntry
+		if err := cursor.Decode(&entry); err != nil {
+			return nil, err
+		}
+		entry.Raw = make(bson.Raw, len(cursor.Current))
+		copy(entry.Raw, cursor.Current)
+		entries = append(entries, entry)
+	}
+	if err := cursor.Err(); err != nil {
+		return nil, err
+	}
+	return entries, nil
+}
@@ -10,14 +10,19 @@ import (
 	"context"
 	"errors"
 	"testing"
+	"time"
 
 	"go.mongodb.org/mongo-driver/bson"
 	"go.mongodb.org/mongo-driver/bson/bsoncodec"
 	"go.mongodb.org/mongo-driver/internal/testutil/assert"
+	"go.mongodb.org/mongo-driver/mongo/address"
+	"go.mongodb.org/mongo-driver/mongo/description"
 	"go.mongodb.org/mongo-driver/mongo/options"
 	"go.mongodb.org/mongo-driver/mongo/readconcern"
 	"go.mongodb.org/mongo-driver/mongo/readpref"
 	"go.mongodb.org/mongo-driver/mongo/writeconcern"
+	"go.mongodb.org/mongo-driver/x/mongo/driver"
+	"go.mongodb.org/mongo-driver/x/mongo/driver/session"
 )
 
 func setupDb(name string, opts ...*options.DatabaseOptions) *Database {
@@ -25,6 +30,19 @@ func setupDb(name string, opts ...*options.DatabaseOptions) *Database {
 	return client.Database(name, opts...)
 }
 
+// selectorDeployment is a deployment that records the server selector passed to SelectServer and fails selection with
+// err.
+type selectorDeployment struct {
+	mockDeployment
+	selector description.ServerSelector
+	err      error
+}
+
+func (sd *selectorDeployment) SelectServer(_ context.Context, selector description.ServerSelector) (driver.Server, error) {
+	sd.selector = selector
+	return nil, sd.err
+}
+
 func compareDbs(t *testing.T, expected, got *Database) {
 	t.Helper()
 	assert.Equal(t, expected.readPreference, got.readPreference,
@@ -94,6 +112,63 @@ func TestDatabase(t *testing.T) {
 		_, err = db.ListCollections(bgCtx, bson.D{})
 		assert.Equal(t, ErrClientDisconnected, err, "expected error %v, got %v", ErrClientDisconnected, err)
 	})
+	t.Run("run command on server", func(t *testing.T) {
+		desc := description.Topology{
+			Kind: description.ReplicaSetWithPrimary,
+			Servers: []description.Server{
+				{Addr: "a:27017", Kind: description.RSPrimary},
+				{Addr: "b:27017", Kind: description.RSSecondary},
+				{Addr: "c:27017", Kind: description.RSSecondary},
+			},
+		}
+		errNoServer := errors.New("no server selected")
+
+		testCases := []struct {
+			name string
+			addr address.Address
+			want []description.Server
+		}{
+			{"primary", "a:27017", desc.Servers[:1]},
+			{"secondary", "b:27017", desc.Servers[1:2]},
+			{"address is canonicalized", "C:27017", desc.Servers[2:]},
+			{"unknown address", "d:27017", nil},
+		}
+		for _, tc := range testCases {
+			t.Run(tc.name, func(t *testing.T) {
+				sd := &selectorDeployment{err: errNoServer}
+				db := setupClient(&options.ClientOptions{Deployment: sd}).Database("foo")
+
+				err := db.RunCommandOnServer(bgCtx, tc.addr, bson.D{{"ping", 1}}).Err()
+				assert.Equal(t, errNoServer, err, "expected error %v, got %v", errNoServer, err)
+				assert.NotNil(t, sd.selector, "expected a server selector to be used")
+
+				got, err := sd.selector.SelectServer(desc, desc.Servers)
+				assert.Nil(t, err, "SelectServer error: %v", err)
+				assert.Equal(t, tc.want, got, "expected servers %v, got %v", tc.want, got)
+			})
+		}
+		t.Run("session pinned to another server", func(t *testing.T) {
+			sd := &selectorDeployment{err: errNoServer}
+			client := setupClient(&options.ClientOptions{Deployment: sd})
+			descChan := make(chan description.Topology, 1)
+			descChan <- description.Topology{SessionTimeoutMinutes: 30}
+			client.sessionPool = session.NewPool(descChan)
+			sess, err := client.StartSession()
+			assert.Nil(t, err, "StartSession error: %v", err)
+			defer sess.EndSession(bgCtx)
+			sess.(*sessionImpl).clientSession.PinnedServer = &desc.Servers[0]
+			sessCtx := NewSessionContext(bgCtx, sess)
+			db := client.Database("foo")
+
+			err = db.RunCommandOnServer(sessCtx, "b:27017", bson.D{{"ping", 1}}).Err()
+			assert.Equal(t, ErrSessionPinnedToOtherServer, err, "expected error %v, got %v",
+				ErrSessionPinnedToOtherServer, err)
+			assert.Nil(t, sd.selector, "expected no server selection")
+
+			err = db.RunCommandOnServer(sessCtx, "A:27017", bson.D{{"ping", 1}}).Err()
+			assert.Equal(t, errNoServer, err, "expected error %v, got %v", errNoServer, err)
+		})
+	})
 	t.Run("nil document error", func(t *testing.T) {
 		db := setupDb("foo")
 
@@ -110,4 +185,51 @@ func TestDatabase(t *testing.T) {
 		_, err = db.ListCollectionNames(context.Background(), nil)
 		assert.Equal(t, ErrNilDocument, err, "expected error %v, got %v", ErrNilDocument, err)
 	})
+	t.Run("decode profile entries", func(t *testing.T) {
+		ts := time.Date(2021, 6, 1, 12, 30, 0, 0, time.UTC)
+		command := bson.D{{"find", "orders"}, {"filter", bson.D{{"status", "open"}}}}
+		docs := []interface{}{
+			bson.D{
+				{"op", "query"},
+				{"ns", "shop.orders"},
+				{"command", command},
+				{"keysExamined", int32(0)},
+				{"docsExamined", int32(5000)},
+				{"millis", int32(153)},
+				{"planSummary", "COLLSCAN"},
+				{"ts", ts},
+			},
+			bson.D{
+				{"op", "insert"},
+				{"ns", "shop.orders"},
+				{"millis", int64(2)},
+			},
+		}
+		cursor, err := NewCursorFromDocuments(docs, nil, nil)
+		assert.Nil(t, err, "NewCursorFromDocuments error: %v", err)
+
+		entries, err := decodeProfileEntries(context.Background(), cursor)
+		assert.Nil(t, err, "decodeProfileEntries error: %v", err)
+		assert.Equal(t, 2, len(entries), "expected 2 entries, got %v", len(entries))
+
+		query := entries[0]
+		assert.Equal(t, "query", query.Op, "expected op 'query', got %q", query.Op)
+		assert.Equal(t, "shop.orders", query.Namespace, "expected ns 'shop.orders', got %q", query.Namespace)
+		assert.Equal(t, int64(153), query.Millis, "expected millis 153, got %v", query.Millis)
+		assert.True(t, ts.Equal(query.Timestamp), "expected ts %v, got %v", ts, query.Timestamp)
+		assert.Equal(t, "COLLSCAN", query.PlanSummary, "expected planSummary 'COLLSCAN', got %q", query.PlanSummary)
+		expectedCommand, err := bson.Marshal(command)
+		assert.Nil(t, err, "Marshal error: %v", err)
+		assert.Equal(t, bson.Raw(expectedCommand), query.Command, "expected command %v, got %v",
+			bson.Raw(expectedCommand), query.Command)
+		docsExamined := query.Raw.Lookup("docsExamined").Int32()
+		assert.Equal(t, int32(5000), docsExamined, "expected docsExamined 5000 in raw entry, got %v", docsExamined)
+
+		insert := entries[1]
+		assert.Equal(t, "insert", insert.Op, "expected op 'insert', got %q", insert.Op)
+		assert.Equal(t, int64(2), insert.Millis, "expected millis 2, got %v", insert.Millis)
+		assert.True(t, insert.Timestamp.IsZero(), "expected zero ts, got %v", insert.Timestamp)
+		assert.Nil(t, insert.Command, "expected nil command, got %v", insert.Command)
+		assert.Equal(t, "", insert.PlanSummary, "expected empty planSummary, got %q", insert.PlanSummary)
+	})
 }
@@ -7,6 +7,7 @@
 package description
 
 import (
+	"errors"
 	"testing"
 	"time"
 
@@ -96,6 +97,149 @@ func TestServerSelection(t *testing.T) {
 			})
 		}
 	})
+	t.Run("IsWriteSelector", func(t *testing.T) {
+		testCases := []struct {
+			name     string
+			selector ServerSelector
+			want     bool
+		}{
+			{"WriteSelector", WriteSelector(), true},
+			{"ReadPrefSelector", ReadPrefSelector(readpref.Primary()), false},
+			{"composite with WriteSelector", CompositeSelector([]ServerSelector{WriteSelector(), LatencySelector(time.Second)}), true},
+			{"composite without WriteSelector", CompositeSelector([]ServerSelector{LatencySelector(time.Second)}), false},
+			{"nested composite", CompositeSelector([]ServerSelector{CompositeSelector([]ServerSelector{WriteSelector()})}), true},
+			{"MayWriteSelector", MayWriteSelector(), true},
+			{"composite with MayWriteSelector", CompositeSelector([]ServerSelector{ReadPrefSelector(readpref.Secondary()), MayWriteSelector()}), true},
+		}
+
+		for _, tc := range testCases {
+			t.Run(tc.name, func(t *testing.T) {
+				got := IsWriteSelector(tc.selector)
+				assert.Equal(t, tc.want, got, "expected IsWriteSelector to return %v, got %v", tc.want, got)
+			})
+		}
+	})
+	t.Run("MayWriteSelector", func(t *testing.T) {
+		topo := Topology{Kind: ReplicaSet}
+		candidates := []Server{
+			{Addr: address.Address("primary:27017"), Kind: RSPrimary},
+			{Addr: address.Address("secondary:27017"), Kind: RSSecondary},
+		}
+		got, err := MayWriteSelector().SelectServer(topo, candidates)
+		assert.Nil(t, err, "SelectServer error: %v", err)
+		assert.Equal(t, candidates, got, "expected all candidates %v, got %v", candidates, got)
+	})
+	t.Run("SelectorStages", func(t *testing.T) {
+		write := WriteSelector()
+		latency := LatencySelector(time.Second)
+
+		stages := SelectorStages(write)
+		assert.Equal(t, 1, len(stages), "expected 1 stage, got %d", len(stages))
+
+		stages = SelectorStages(CompositeSelector([]ServerSelector{
+			CompositeSelector([]ServerSelector{write}),
+			latency,
+		}))
+		assert.Equal(t, 2, len(stages), "expected 2 stages, got %d", len(stages))
+		assert.True(t, IsWriteSelector(stages[0]), "expected first stage to be the write selector")
+		assert.False(t, IsLatencySelector(stages[0]), "expected first stage not to be a latency selector")
+		assert.True(t, IsLatencySelector(stages[1]), "expected second stage to be the latency selector")
+	})
+	t.Run("FirstMatching", func(t *testing.T) {
+		primary := Server{Addr: address.Address("localhost:27017"), Kind: RSPrimary}
+		near := Server{Addr: address.Address("localhost:27018"), Kind: RSSecondary, AverageRTT: 5 * time.Millisecond, AverageRTTSet: true}
+		far := Server{Addr: address.Address("localhost:27019"), Kind: RSSecondary, AverageRTT: 50 * time.Millisecond, AverageRTTSet: true}
+		selector := FirstMatching(
+			ReadPrefSelector(readpref.Primary()),
+			CompositeSelector([]ServerSelector{ReadPrefSelector(readpref.Secondary()), LatencySelector(15 * time.Millisecond)}),
+		)
+
+		testCases := []struct {
+			name string
+			desc Topology
+			want []Server
+		}{
+			{
+				"primary",
+				Topology{Kind: ReplicaSetWithPrimary, Servers: []Server{primary, near, far}},
+				[]Server{primary},
+			},
+			{
+				"no primary falls back to nearest secondary",
+				Topology{Kind: ReplicaSetNoPrimary, Servers: []Server{near, far}},
+				[]Server{near},
+			},
+			{
+				"no matches",
+				Topology{Kind: ReplicaSetNoPrimary, Servers: []Server{}},
+				[]Server{},
+			},
+		}
+		for _, tc := range testCases {
+			t.Run(tc.name, func(t *testing.T) {
+				result, err := selector.SelectServer(tc.desc, tc.desc.Servers)
+				noerr(t, err)
+				if diff := cmp.Diff(tc.want, result); diff != "" {
+					t.Errorf("Incorrect servers selected (-want +got):\n%s", diff)
+				}
+			})
+		}
+
+		t.Run("errors short-circuit", func(t *testing.T) {
+			selectErr := errors.New("selection failed")
+			var calls int
+			counting := ServerSelectorFunc(func(_ Topology, candidates []Server) ([]Server, error) {
+				calls++
+				return candidates, nil
+			})
+			failing := ServerSelectorFunc(func(Topology, []Server) ([]Server, error) {
+				return nil, selectErr
+			})
+			none := ServerSelectorFunc(func(Topology, []Server) ([]Server, error) {
+				return nil, nil
+			})
+
+			desc := Topology{Kind: ReplicaSetWithPrimary, Servers: []Server{primary}}
+			_, err := FirstMatching(none, failing, counting).SelectServer(desc, desc.Servers)
+			assert.Equal(t, selectErr, err, "expected error %v, got %v", selectErr, err)
+			assert.Equal(t, 0, calls, "expected later selectors to not be called, got %d calls", calls)
+
+			result, err := FirstMatching(counting, failing).SelectServer(desc, desc.Servers)
+			noerr(t, err)
+			assert.Equal(t, 1, len(result), "expected 1 server, got %d", len(result))
+		})
+	})
+	t.Run("TagSetSelector", func(t *testing.T) {
+		east := tag.Set{{Name: "region", Value: "us-east"}}
+		west := tag.Set{{Name: "region", Value: "us-west"}}
+		eastNear := Server{Addr: address.Address("east1:27017"), Kind: RSSecondary, Tags: east, AverageRTT: 20 * time.Millisecond, AverageRTTSet: true}
+		eastFar := Server{Addr: address.Address("east2:27017"), Kind: RSSecondary, Tags: east, AverageRTT: 80 * time.Millisecond, AverageRTTSet: true}
+		westNear := Server{Addr: address.Address("west1:27017"), Kind: RSSecondary, Tags: west, AverageRTT: 5 * time.Millisecond, AverageRTTSet: true}
+		untagged := Server{Addr: address.Address("other:27017"), Kind: RSSecondary, AverageRTT: 10 * time.Millisecond, AverageRTTSet: true}
+
+		testCases := []struct {
+			name     string
+			tagSets  []tag.Set
+			servers  []Server
+			expected []Server
+		}{
+			{"first tag set with matches wins", []tag.Set{east, west}, []Server{eastNear, eastFar, westNear}, []Server{eastNear}},
+			{"falls back to later tag sets", []tag.Set{east, west}, []Server{westNear, untagged}, []Server{westNear}},
+			{"empty tag set matches all servers", []tag.Set{east, {}}, []Server{westNear, untagged}, []Server{westNear, untagged}},
+			{"empty tag set list matches all servers", nil, []Server{eastNear, eastFar, westNear}, []Server{eastNear, westNear}},
+			{"no matches", []tag.Set{east}, []Server{westNear, untagged}, []Server{}},
+		}
+		for _, tc := range testCases {
+			t.Run(tc.name, func(t *testing.T) {
+				desc := Topology{Kind: ReplicaSetWithPrimary, Servers: tc.servers}
+				result, err := TagSetSelector(20*time.Millisecond, tc.tagSets...).SelectServer(desc, desc.Servers)
+				noerr(t, err)
+				if diff := cmp.Diff(tc.expected, result); diff != "" {
+					t.Errorf("Incorrect servers selected (-want +got):\n%s", diff)
+				}
+			})
+		}
+	})
 	t.Run("LatencySelector", func(t *testing.T) {
 		testCases := []struct {
 			name  string
@@ -367,6 +511,62 @@ func TestSelector_PrimaryPreferred_with_maxStaleness_and_no_primary(t *testing.T
 	require.Equal([]Server{readPrefTestSecondary2}, result)
 }
 
+func TestSelector_PrimaryPreferredWithMaxStaleness(t *testing.T) {
+	t.Parallel()
+
+	subject, err := readpref.PrimaryPreferredWithMaxStaleness(90 * time.Second)
+	require.NoError(t, err)
+
+	lastUpdate := time.Date(2017, 2, 11, 14, 0, 2, 0, time.UTC)
+	newServer := func(addr string, kind ServerKind, lastWrite time.Time) Server {
+		return Server{
+			Addr:              address.Address(addr),
+			HeartbeatInterval: 10 * time.Second,
+			LastWriteTime:     lastWrite,
+			LastUpdateTime:    lastUpdate,
+			Kind:              kind,
+			WireVersion:       &VersionRange{Min: 0, Max: 5},
+		}
+	}
+	primary := newServer("localhost:27017", RSPrimary, time.Date(2017, 2, 11, 14, 0, 0, 0, time.UTC))
+	fresh := newServer("localhost:27018", RSSecondary, time.Date(2017, 2, 11, 14, 0, 0, 0, time.UTC))
+	// Estimated staleness is 60s behind the freshest secondary plus the 10s heartbeat interval.
+	lagging := newServer("localhost:27019", RSSecondary, time.Date(2017, 2, 11, 13, 59, 0, 0, time.UTC))
+	// Estimated staleness is 120s behind the freshest secondary plus the 10s heartbeat interval.
+	stale := newServer("localhost:27020", RSSecondary, time.Date(2017, 2, 11, 13, 58, 0, 0, time.UTC))
+
+	t.Run("primary available", func(t *testing.T) {
+		topo := Topology{Kind: ReplicaSetWithPrimary, Servers: []Server{primary, fresh, lagging, stale}}
+
+		result, err := ReadPrefSelector(subject).SelectServer(topo, topo.Servers)
+		require.NoError(t, err)
+		require.Equal(t, []Server{primary}, result)
+	})
+	t.Run("no primary falls back to secondaries within max staleness", func(t *testing.T) {
+		topo := Topology{Kind: ReplicaSetNoPrimary, Servers: []Server{fresh, lagging, stale}}
+
+		result, err := ReadPrefSelector(subject).SelectServer(topo, topo.Servers)
+		require.NoError(t, err)
+		require.Equal(t, []Server{fresh, lagging}, result)
+	})
+	t.Run("no primary and only stale secondaries", func(t *testing.T) {
+		// The freshest secondary is never considered stale, so only the secondaries lagging behind it are filtered.
+		topo := Topology{Kind: ReplicaSetNoPrimary, Servers: []Server{stale, fresh}}
+
+		result, err := ReadPrefSelector(subject).SelectServer(topo, topo.Servers)
+		require.NoError(t, err)
+		require.Equal(t, []Server{fresh}, result)
+	})
+	t.Run("heartbeat interval too high", func(t *testing.T) {
+		slow := fresh
+		slow.HeartbeatInterval = 90 * time.Second
+		topo := Topology{Kind: ReplicaSetNoPrimary, Servers: []Server{slow}}
+
+		_, err := ReadPrefSelector(subject).SelectServer(topo, topo.Servers)
+		require.Error(t, err)
+	})
+}
+
 func TestSelector_SecondaryPreferred(t *testing.T) {
 	t.Parallel()
 
@@ -36,11 +36,13 @@ type Server struct {
 	AverageRTTSet         bool
 	Compression           []string // compression methods returned by server
 	CanonicalAddr         address.Address
+	CircuitBreakerOpen    bool // set while heartbeats are backed off after repeated failures
 	ElectionID            primitive.ObjectID
 	HeartbeatInterval     time.Duration
 	HelloOK               bool
 	Hosts                 []string
 	LastError             error
+	LastRTT               time.Duration // most recent round trip time sample, only valid if AverageRTTSet is true
 	LastUpdateTime        time.Time
 	LastWriteTime         time.Time
 	MaxBatchCount         uint32
@@ -370,7 +372,7 @@ func (s Server) String() string {
 	}
 
 	if s.AverageRTTSet {
-		str += fmt.Sprintf(", Average RTT: %d", s.AverageRTT)
+		str += fmt.Sprintf(", Average RTT: %d, Last RTT: %d", s.AverageRTT, s.LastRTT)
 	}
 
 	if s.LastError != nil {
@@ -59,6 +59,38 @@ func (cs *compositeSelector) SelectServer(t Topology, candidates []Server) ([]Se
 	return candidates, nil
 }
 
+type firstMatchingSelector struct {
+	selectors []ServerSelector
+}
+
+// FirstMatching combines multiple selectors into a single selector that applies each of them in order to the full
+// candidates list and returns the result of the first selector that matches at least one server. If a selector returns
+// an error, the error is returned and the remaining selectors are not applied. If no selector matches a server, an
+// empty list is returned.
+//
+// For example, the following selector selects the primary or, if there is no primary, the nearest secondaries:
+//
+//	FirstMatching(
+//		ReadPrefSelector(readpref.Primary()),
+//		CompositeSelector([]ServerSelector{ReadPrefSelector(readpref.Secondary()), LatencySelector(15 * time.Millisecond)}),
+//	)
+func FirstMatching(selectors ...ServerSelector) ServerSelector {
+	return &firstMatchingSelector{selectors: selectors}
+}
+
+func (fms *firstMatchingSelector) SelectServer(t Topology, candidates []Server) ([]Server, error) {
+	for _, sel := range fms.selectors {
+		selected, err := sel.SelectServer(t, candidates)
+		if err != nil {
+			return nil, err
+		}
+		if len(selected) > 0 {
+			return selected, nil
+		}
+	}
+	return []Server{}, nil
+}
+
 type latencySelector struct {
 	latency time.Duration
 }
@@ -109,66 +141,172 @@ func (ls *latencySelector) SelectServer(t Topology, candidates []Server) ([]Serv
 	}
 }
 
+type tagSetSelector struct {
+	tagSets []tag.Set
+	latency *latencySelector
+}
+
+// TagSetSelector creates a ServerSelector that selects servers by an ordered list of tag sets, e.g. to prefer servers
+// in the local region and fall back to other regions. The servers matching the first tag set that matches at least
+// one candidate are selected, and the latency window is then applied to them, so the fastest of the preferred servers
+// are used even if servers matched by later tag sets are faster. A server matches a tag set if it has all of the tags
+// in the set. An empty list of tag sets or an empty tag set matches all servers. A negative latency disables the
+// latency window.
+//
+// TagSetSelector does not consider the kind of the servers, so it should be combined with a selector that does. For
+// example, the following selector selects secondaries in us-east, falling back to secondaries in any region:
+//
+//	CompositeSelector([]ServerSelector{
+//		ReadPrefSelector(readpref.Secondary()),
+//		TagSetSelector(15*time.Millisecond, tag.Set{{Name: "region", Value: "us-east"}}, tag.Set{}),
+//	})
+func TagSetSelector(latency time.Duration, tagSets ...tag.Set) ServerSelector {
+	return &tagSetSelector{tagSets: tagSets, latency: &latencySelector{latency: latency}}
+}
+
+func (tss *tagSetSelector) SelectServer(t Topology, candidates []Server) ([]Server, error) {
+	return tss.latency.SelectServer(t, selectByTagSet(candidates, tss.tagSets))
+}
+
+type writeSelector struct{}
+
 // WriteSelector selects all the writable servers.
 func WriteSelector() ServerSelector {
-	return ServerSelectorFunc(func(t Topology, candidates []Server) ([]Server, error) {
-		switch t.Kind {
-		case Single, LoadBalanced:
-			return candidates, nil
-		default:
-			result := []Server{}
-			for _, candidate := range candidates {
-				switch candidate.Kind {
-				case Mongos, RSPrimary, Standalone:
-					result = append(result, candidate)
-				}
+	return writeSelector{}
+}
+
+func (writeSelector) SelectServer(t Topology, candidates []Server) ([]Server, error) {
+	switch t.Kind {
+	case Single, LoadBalanced:
+		return candidates, nil
+	default:
+		result := []Server{}
+		for _, candidate := range candidates {
+			switch candidate.Kind {
+			case Mongos, RSPrimary, Standalone:
+				result = append(result, candidate)
 			}
-			return result, nil
 		}
-	})
+		return result, nil
+	}
+}
+
+type mayWriteSelector struct{}
+
+// MayWriteSelector selects all the candidate servers. Adding it to a CompositeSelector marks the selector as one used
+// for an operation that may write, such as an arbitrary command, without restricting it to writable servers.
+func MayWriteSelector() ServerSelector {
+	return mayWriteSelector{}
+}
+
+func (mayWriteSelector) SelectServer(_ Topology, candidates []Server) ([]Server, error) {
+	return candidates, nil
+}
+
+// WrappingSelector is implemented by selectors that decide whether to delegate to another selector, such as a selector
+// that selects the server a session is pinned to and otherwise uses a default selector. IsWriteSelector looks through a
+// WrappingSelector to the selector it wraps.
+type WrappingSelector interface {
+	ServerSelector
+
+	// Unwrap returns the wrapped selector.
+	Unwrap() ServerSelector
+
+	// Wrap returns a selector that wraps the given selector in the same way.
+	Wrap(ServerSelector) ServerSelector
+}
+
+// IsWriteSelector reports whether the given selector is a WriteSelector or MayWriteSelector, or a CompositeSelector or
+// WrappingSelector that contains one. This can be used to determine whether server selection is being performed for a
+// write operation.
+func IsWriteSelector(selector ServerSelector) bool {
+	switch sel := selector.(type) {
+	case writeSelector, mayWriteSelector:
+		return true
+	case WrappingSelector:
+		return IsWriteSelector(sel.Unwrap())
+	case *compositeSelector:
+		for _, s := range sel.selectors {
+			if IsWriteSelector(s) {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// IsLatencySelector reports whether the given selector is a LatencySelector.
+func IsLatencySelector(selector ServerSelector) bool {
+	_, ok := selector.(*latencySelector)
+	return ok
+}
+
+// SelectorStages returns the selectors that the given selector applies in order. The selectors of a
+// CompositeSelector are returned in the order they were provided, with nested CompositeSelectors flattened. Any other
+// selector is returned as the only stage.
+func SelectorStages(selector ServerSelector) []ServerSelector {
+	cs, ok := selector.(*compositeSelector)
+	if !ok {
+		return []ServerSelector{selector}
+	}
+	var stages []ServerSelector
+	for _, s := range cs.selectors {
+		stages = append(stages, SelectorStages(s)...)
+	}
+	return stages
 }
 
 // ReadPrefSelector selects servers based on the provided read preference.
 func ReadPrefSelector(rp *readpref.ReadPref) ServerSelector {
-	return readPrefSelector(rp, false)
+	return &readPrefSelector{rp: rp}
 }
 
 // OutputAggregateSelector selects servers based on the provided read preference given that the underlying operation is
 // aggregate with an output stage.
 func OutputAggregateSelector(rp *readpref.ReadPref) ServerSelector {
-	return readPrefSelector(rp, true)
+	return &readPrefSelector{rp: rp, isOutputAggregate: true}
 }
 
-func readPrefSelector(rp *readpref.ReadPref, isOutputAggregate bool) ServerSelector {
-	return ServerSelectorFunc(func(t Topology, candidates []Server) ([]Server, error) {
-		if t.Kind == LoadBalanced {
-			// In LoadBalanced mode, there should only be one server in the topology and it must be selected. We check
-			// this before checking MaxStaleness support because there's no monitoring in this mode, so the candidate
-			// server wouldn't have a wire version set, which would result in an error.
-			return candidates, nil
-		}
+type readPrefSelector struct {
+	rp                *readpref.ReadPref
+	isOutputAggregate bool
+}
 
-		if _, set := rp.MaxStaleness(); set {
-			for _, s := range candidates {
-				if s.Kind != Unknown {
-					if err := maxStalenessSupported(s.WireVersion); err != nil {
-						return nil, err
-					}
+// IsReadPrefSelector reports whether the given selector is a ReadPrefSelector or an OutputAggregateSelector.
+func IsReadPrefSelector(selector ServerSelector) bool {
+	_, ok := selector.(*readPrefSelector)
+	return ok
+}
+
+func (rps *readPrefSelector) SelectServer(t Topology, candidates []Server) ([]Server, error) {
+	rp := rps.rp
+	if t.Kind == LoadBalanced {
+		// In LoadBalanced mode, there should only be one server in the topology and it must be selected. We check
+		// this before checking MaxStaleness support because there's no monitoring in this mode, so the candidate
+		// server wouldn't have a wire version set, which would result in an error.
+		return candidates, nil
+	}
+
+	if _, set := rp.MaxStaleness(); set {
+		for _, s := range candidates {
+			if s.Kind != Unknown {
+				if err := maxStalenessSupported(s.WireVersion); err != nil {
+					return nil, err
 				}
 			}
 		}
+	}
 
-		switch t.Kind {
-		case Single:
-			return candidates, nil
-		case ReplicaSetNoPrimary, ReplicaSetWithPrimary:
-			return selectForReplicaSet(rp, isOutputAggregate, t, candidates)
-		case Sharded:
-			return selectByKind(candidates, Mongos), nil
-		}
+	switch t.Kind {
+	case Single:
+		return candidates, nil
+	case ReplicaSetNoPrimary, ReplicaSetWithPrimary:
+		return selectForReplicaSet(rp, rps.isOutputAggregate, t, candidates)
+	case Sharded:
+		return selectByKind(candidates, Mongos), nil
+	}
 
-		return nil, nil
-	})
+	return nil, nil
 }
 
 // maxStalenessSupported returns an error if the given server version does not support max staleness.
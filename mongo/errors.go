@@ -26,6 +26,17 @@ var ErrUnacknowledgedWrite = errors.New("unacknowledged write")
 // ErrClientDisconnected is returned when disconnected Client is used to run an operation.
 var ErrClientDisconnected = errors.New("client is disconnected")
 
+// ErrClientDraining is returned when an operation needs a new connection from a Client that is draining. See
+// Client.BeginDrain for more information.
+var ErrClientDraining = errors.New("client is draining")
+
+// ErrNotSharded is returned by Collection.ShardKey when the collection is not sharded.
+var ErrNotSharded = errors.New("collection is not sharded")
+
+// ErrSessionPinnedToOtherServer is returned by Database.RunCommandOnServer when the session is pinned to a server other
+// than the requested one.
+var ErrSessionPinnedToOtherServer = errors.New("session is pinned to a different server")
+
 // ErrNilDocument is returned when a nil document is passed to a CRUD method.
 var ErrNilDocument = errors.New("document is nil")
 
@@ -46,9 +57,12 @@ func (e ErrMapForOrderedArgument) Error() string {
 }
 
 func replaceErrors(err error) error {
-	if err == topology.ErrTopologyClosed {
+	if err == topology.ErrTopologyClosed || err == topology.ErrTopologyNotConnected {
 		return ErrClientDisconnected
 	}
+	if err == topology.ErrPoolDraining {
+		return ErrClientDraining
+	}
 	if de, ok := err.(driver.Error); ok {
 		return CommandError{
 			Code:    de.Code,
@@ -137,6 +151,29 @@ func errorHasLabel(err error, label string) bool {
 	return false
 }
 
+// memoryLimitErrorCodes are the codes of errors returned by the server when a query or aggregation stage exceeds its
+// memory limit and is not allowed to use disk.
+var memoryLimitErrorCodes = []int32{
+	292,   // QueryExceededMemoryLimitNoDiskUseAllowed
+	16819, // Sort exceeded memory limit
+	16945, // $group exceeded memory limit
+}
+
+// isMemoryLimitError returns true if err is a server error caused by exceeding the memory limit for a query or
+// aggregation stage.
+func isMemoryLimitError(err error) bool {
+	de, ok := err.(driver.Error)
+	if !ok {
+		return false
+	}
+	for _, code := range memoryLimitErrorCodes {
+		if de.Code == code {
+			return true
+		}
+	}
+	return strings.Contains(strings.ToLower(de.Message), "exceeded memory limit")
+}
+
 // IsNetworkError returns true if err is a network error
 func IsNetworkError(err error) bool {
 	return errorHasLabel(err, "NetworkError")
@@ -337,6 +374,33 @@ func writeErrorsFromDriverWriteErrors(errs driver.WriteErrors) WriteErrors {
 	return wes
 }
 
+// ClientValidationError is an error returned when a document does not match the schema set with
+// Collection.SetClientSideValidator. This error type is only returned as part of a ClientValidationErrors.
+type ClientValidationError struct {
+	// The index of the document in the slice passed to InsertMany. This is always 0 for InsertOne.
+	Index int
+
+	// A description of each way the document does not match the schema.
+	Violations []string
+}
+
+func (cve ClientValidationError) Error() string {
+	return fmt.Sprintf("document %d failed client-side validation: %s", cve.Index, strings.Join(cve.Violations, "; "))
+}
+
+// ClientValidationErrors is a group of ClientValidationErrors for the documents passed to an insert operation that do
+// not match the collection's client-side validator.
+type ClientValidationErrors []ClientValidationError
+
+// Error implements the error interface.
+func (cve ClientValidationErrors) Error() string {
+	errs := make([]error, len(cve))
+	for i := 0; i < len(cve); i++ {
+		errs[i] = cve[i]
+	}
+	return "client-side validation errors: " + joinBatchErrors(errs)
+}
+
 // WriteConcernError represents a write concern failure during execution of a write operation. This error type is only
 // returned as part of a WriteException or a BulkWriteException.
 type WriteConcernError struct {
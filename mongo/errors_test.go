@@ -6,6 +6,7 @@ import (
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/x/mongo/driver"
 )
 
 func TestErrorMessages(t *testing.T) {
@@ -64,3 +65,30 @@ func TestErrorMessages(t *testing.T) {
 		})
 	}
 }
+
+func TestIsMemoryLimitError(t *testing.T) {
+	cases := []struct {
+		desc     string
+		err      error
+		expected bool
+	}{
+		{"QueryExceededMemoryLimitNoDiskUseAllowed", driver.Error{Code: 292}, true},
+		{"sort memory limit", driver.Error{Code: 16819}, true},
+		{"group memory limit", driver.Error{Code: 16945}, true},
+		{
+			"memory limit message",
+			driver.Error{Code: 1, Message: "PlanExecutor error :: Exceeded memory limit for $bucketAuto"},
+			true,
+		},
+		{"other server error", driver.Error{Code: 2, Message: "bad value"}, false},
+		{"non-server error", WriteException{}, false},
+		{"nil", nil, false},
+	}
+
+	for _, tc := range cases {
+		tc := tc
+		t.Run(tc.desc, func(t *testing.T) {
+			assert.Equal(t, tc.expected, isMemoryLimitError(tc.err))
+		})
+	}
+}
@@ -0,0 +1,66 @@
+// Copyright (C) MongoDB, Inc. 2017-present.
+//
+// Licensed under the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License. You may obtain
+// a copy of the License at http://www.apache.org/licenses/LICENSE-2.0
+
+package mongo
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/bsontype"
+)
+
+// DecodeFacetResult decodes the result of an aggregation whose final stage is $facet into val and closes the cursor.
+// A $facet stage produces a single document with one array per facet, keyed by facet name, so val is typically a
+// pointer to a struct with a slice field for each facet:
+//
+//	var result struct {
+//		ByCategory []CategoryCount `bson:"byCategory"`
+//		Total      []struct {
+//			Count int64 `bson:"count"`
+//		} `bson:"total"`
+//	}
+//	err := mongo.DecodeFacetResult(ctx, cursor, &result)
+//
+// Facets that are not present in val are ignored and fields of val that do not match a facet are left unchanged.
+// ErrNoDocuments is returned if the cursor has no documents. An error is returned if the cursor has more than one
+// document or if any value in the document is not an array, which indicates that the pipeline did not end with $facet.
+func DecodeFacetResult(ctx context.Context, cursor *Cursor, val interface{}) error {
+	if cursor == nil {
+		return errors.New("cursor must not be nil")
+	}
+	defer cursor.Close(ctx)
+
+	if !cursor.Next(ctx) {
+		if err := cursor.Err(); err != nil {
+			return err
+		}
+		return ErrNoDocuments
+	}
+	doc := make(bson.Raw, len(cursor.Current))
+	copy(doc, cursor.Current)
+
+	if cursor.Next(ctx) {
+		return errors.New("expected a single $facet result document, got more than one")
+	}
+	if err := cursor.Err(); err != nil {
+		return err
+	}
+
+	elems, err := doc.Elements()
+	if err != nil {
+		return err
+	}
+	for _, elem := range elems {
+		if t := elem.Value().Type; t != bsontype.Array {
+			return fmt.Errorf("expected facet %q to be an array, got BSON type %s", elem.Key(), t)
+		}
+	}
+
+	return bson.UnmarshalWithRegistry(cursor.registry, doc, val)
+}
@@ -0,0 +1,90 @@
+// Copyright (C) MongoDB, Inc. 2017-present.
+//
+// Licensed under the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License. You may obtain
+// a copy of the License at http://www.apache.org/licenses/LICENSE-2.0
+
+package mongo
+
+import (
+	"context"
+	"testing"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/internal/testutil/assert"
+)
+
+func TestDecodeFacetResult(t *testing.T) {
+	type categoryCount struct {
+		ID    string `bson:"_id"`
+		Count int32  `bson:"count"`
+	}
+	type priceBucket struct {
+		Min float64 `bson:"min"`
+		Max float64 `bson:"max"`
+	}
+	type facets struct {
+		ByCategory []categoryCount `bson:"byCategory"`
+		ByPrice    []priceBucket   `bson:"byPrice"`
+	}
+	facetDoc := bson.D{
+		{"byCategory", bson.A{
+			bson.D{{"_id", "books"}, {"count", int32(3)}},
+			bson.D{{"_id", "games"}, {"count", int32(1)}},
+		}},
+		{"byPrice", bson.A{
+			bson.D{{"min", 0.0}, {"max", 10.0}},
+		}},
+	}
+
+	t.Run("two facets", func(t *testing.T) {
+		cur, err := NewCursorFromDocuments([]interface{}{facetDoc}, nil, nil)
+		assert.Nil(t, err, "NewCursorFromDocuments error: %v", err)
+
+		var got facets
+		err = DecodeFacetResult(context.Background(), cur, &got)
+		assert.Nil(t, err, "DecodeFacetResult error: %v", err)
+
+		expected := facets{
+			ByCategory: []categoryCount{{"books", 3}, {"games", 1}},
+			ByPrice:    []priceBucket{{0, 10}},
+		}
+		assert.Equal(t, expected, got, "expected result %v, got %v", expected, got)
+	})
+	t.Run("empty facet", func(t *testing.T) {
+		doc := bson.D{{"byCategory", bson.A{}}, {"byPrice", bson.A{}}}
+		cur, err := NewCursorFromDocuments([]interface{}{doc}, nil, nil)
+		assert.Nil(t, err, "NewCursorFromDocuments error: %v", err)
+
+		var got facets
+		err = DecodeFacetResult(context.Background(), cur, &got)
+		assert.Nil(t, err, "DecodeFacetResult error: %v", err)
+		assert.Equal(t, 0, len(got.ByCategory), "expected no categories, got %v", got.ByCategory)
+		assert.Equal(t, 0, len(got.ByPrice), "expected no price buckets, got %v", got.ByPrice)
+	})
+	t.Run("no documents", func(t *testing.T) {
+		cur, err := NewCursorFromDocuments(nil, nil, nil)
+		assert.Nil(t, err, "NewCursorFromDocuments error: %v", err)
+
+		var got facets
+		err = DecodeFacetResult(context.Background(), cur, &got)
+		assert.Equal(t, ErrNoDocuments, err, "expected error %v, got %v", ErrNoDocuments, err)
+	})
+	t.Run("multiple documents", func(t *testing.T) {
+		cur, err := NewCursorFromDocuments([]interface{}{facetDoc, facetDoc}, nil, nil)
+		assert.Nil(t, err, "NewCursorFromDocuments error: %v", err)
+
+		var got facets
+		err = DecodeFacetResult(context.Background(), cur, &got)
+		assert.NotNil(t, err, "expected DecodeFacetResult error, got nil")
+	})
+	t.Run("non-array value", func(t *testing.T) {
+		doc := bson.D{{"byCategory", bson.A{}}, {"total", int32(4)}}
+		cur, err := NewCursorFromDocuments([]interface{}{doc}, nil, nil)
+		assert.Nil(t, err, "NewCursorFromDocuments error: %v", err)
+
+		var got facets
+		err = DecodeFacetResult(context.Background(), cur, &got)
+		assert.NotNil(t, err, "expected DecodeFacetResult error, got nil")
+	})
+}
@@ -0,0 +1,112 @@
+// Copyright (C) MongoDB, Inc. 2017-present.
+//
+// Licensed under the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License. You may obtain
+// a copy of the License at http://www.apache.org/licenses/LICENSE-2.0
+
+// Package filter provides helpers for building query filter documents. Each helper returns a bson.D that can be passed
+// anywhere a filter is accepted and composed with the logical helpers:
+//
+//	f := filter.And(
+//		filter.Eq("status", "active"),
+//		filter.Or(filter.Gt("age", 21), filter.In("role", "admin", "owner")),
+//	)
+//	cursor, err := coll.Find(ctx, f)
+//
+// The helpers only build documents. They do not validate field names or values.
+package filter // import "go.mongodb.org/mongo-driver/mongo/filter"
+
+import (
+	"go.mongodb.org/mongo-driver/bson"
+)
+
+// Eq returns a filter that matches documents where the value of field equals value. The filter has the form
+// {field: {$eq: value}}.
+func Eq(field string, value interface{}) bson.D {
+	return fieldOp(field, "$eq", value)
+}
+
+// Ne returns a filter that matches documents where the value of field does not equal value. The filter has the form
+// {field: {$ne: value}}.
+func Ne(field string, value interface{}) bson.D {
+	return fieldOp(field, "$ne", value)
+}
+
+// Gt returns a filter that matches documents where the value of field is greater than value. The filter has the form
+// {field: {$gt: value}}.
+func Gt(field string, value interface{}) bson.D {
+	return fieldOp(field, "$gt", value)
+}
+
+// Gte returns a filter that matches documents where the value of field is greater than or equal to value. The filter
+// has the form {field: {$gte: value}}.
+func Gte(field string, value interface{}) bson.D {
+	return fieldOp(field, "$gte", value)
+}
+
+// Lt returns a filter that matches documents where the value of field is less than value. The filter has the form
+// {field: {$lt: value}}.
+func Lt(field string, value interface{}) bson.D {
+	return fieldOp(field, "$lt", value)
+}
+
+// Lte returns a filter that matches documents where the value of field is less than or equal to value. The filter has
+// the form {field: {$lte: value}}.
+func Lte(field string, value interface{}) bson.D {
+	return fieldOp(field, "$lte", value)
+}
+
+// In returns a filter that matches documents where the value of field equals any of the given values. The filter has
+// the form {field: {$in: [values...]}}.
+func In(field string, values ...interface{}) bson.D {
+	return fieldOp(field, "$in", array(values))
+}
+
+// Nin returns a filter that matches documents where the value of field equals none of the given values or field does
+// not exist. The filter has the form {field: {$nin: [values...]}}.
+func Nin(field string, values ...interface{}) bson.D {
+	return fieldOp(field, "$nin", array(values))
+}
+
+// Exists returns a filter that matches documents that contain field if exists is true, or documents that do not contain
+// field if exists is false. The filter has the form {field: {$exists: exists}}.
+func Exists(field string, exists bool) bson.D {
+	return fieldOp(field, "$exists", exists)
+}
+
+// And returns a filter that matches documents that match all of the given filters. The filter has the form
+// {$and: [filters...]}.
+func And(filters ...bson.D) bson.D {
+	return logicalOp("$and", filters)
+}
+
+// Or returns a filter that matches documents that match at least one of the given filters. The filter has the form
+// {$or: [filters...]}.
+func Or(filters ...bson.D) bson.D {
+	return logicalOp("$or", filters)
+}
+
+// Nor returns a filter that matches documents that match none of the given filters. The filter has the form
+// {$nor: [filters...]}.
+func Nor(filters ...bson.D) bson.D {
+	return logicalOp("$nor", filters)
+}
+
+func fieldOp(field, op string, value interface{}) bson.D {
+	return bson.D{{field, bson.D{{op, value}}}}
+}
+
+func logicalOp(op string, filters []bson.D) bson.D {
+	arr := make(bson.A, 0, len(filters))
+	for _, f := range filters {
+		arr = append(arr, f)
+	}
+	return bson.D{{op, arr}}
+}
+
+// array copies values into a bson.A so a nil slice is encoded as an empty array rather than null.
+func array(values []interface{}) bson.A {
+	arr := make(bson.A, len(values))
+	copy(arr, values)
+	return arr
+}
@@ -0,0 +1,80 @@
+// Copyright (C) MongoDB, Inc. 2017-present.
+//
+// Licensed under the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License. You may obtain
+// a copy of the License at http://www.apache.org/licenses/LICENSE-2.0
+
+package filter
+
+import (
+	"testing"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/internal/testutil/assert"
+)
+
+func TestFilter(t *testing.T) {
+	testCases := []struct {
+		name     string
+		filter   bson.D
+		expected bson.D
+	}{
+		{"Eq", Eq("status", "active"), bson.D{{"status", bson.D{{"$eq", "active"}}}}},
+		{"Ne", Ne("status", "active"), bson.D{{"status", bson.D{{"$ne", "active"}}}}},
+		{"Gt", Gt("age", 21), bson.D{{"age", bson.D{{"$gt", 21}}}}},
+		{"Gte", Gte("age", 21), bson.D{{"age", bson.D{{"$gte", 21}}}}},
+		{"Lt", Lt("age", 65), bson.D{{"age", bson.D{{"$lt", 65}}}}},
+		{"Lte", Lte("age", 65), bson.D{{"age", bson.D{{"$lte", 65}}}}},
+		{"In", In("role", "admin", "owner"), bson.D{{"role", bson.D{{"$in", bson.A{"admin", "owner"}}}}}},
+		{"In with no values", In("role"), bson.D{{"role", bson.D{{"$in", bson.A{}}}}}},
+		{"Nin", Nin("role", "guest"), bson.D{{"role", bson.D{{"$nin", bson.A{"guest"}}}}}},
+		{"Exists", Exists("deletedAt", false), bson.D{{"deletedAt", bson.D{{"$exists", false}}}}},
+		{
+			"And",
+			And(Eq("status", "active"), Gte("age", 21)),
+			bson.D{{"$and", bson.A{
+				bson.D{{"status", bson.D{{"$eq", "active"}}}},
+				bson.D{{"age", bson.D{{"$gte", 21}}}},
+			}}},
+		},
+		{
+			"Or",
+			Or(Lt("age", 18), Gt("age", 65)),
+			bson.D{{"$or", bson.A{
+				bson.D{{"age", bson.D{{"$lt", 18}}}},
+				bson.D{{"age", bson.D{{"$gt", 65}}}},
+			}}},
+		},
+		{
+			"Nor",
+			Nor(Exists("email", false)),
+			bson.D{{"$nor", bson.A{
+				bson.D{{"email", bson.D{{"$exists", false}}}},
+			}}},
+		},
+		{
+			"nested",
+			And(
+				Eq("status", "active"),
+				Or(Gt("age", 21), In("role", "admin", "owner")),
+			),
+			bson.D{{"$and", bson.A{
+				bson.D{{"status", bson.D{{"$eq", "active"}}}},
+				bson.D{{"$or", bson.A{
+					bson.D{{"age", bson.D{{"$gt", 21}}}},
+					bson.D{{"role", bson.D{{"$in", bson.A{"admin", "owner"}}}}},
+				}}},
+			}}},
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			got, err := bson.Marshal(tc.filter)
+			assert.Nil(t, err, "Marshal error: %v", err)
+			expected, err := bson.Marshal(tc.expected)
+			assert.Nil(t, err, "Marshal error: %v", err)
+			assert.Equal(t, bson.Raw(expected), bson.Raw(got), "expected filter %v, got %v", bson.Raw(expected),
+				bson.Raw(got))
+		})
+	}
+}
@@ -0,0 +1,110 @@
+// Copyright (C) MongoDB, Inc. 2017-present.
+//
+// Licensed under the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License. You may obtain
+// a copy of the License at http://www.apache.org/licenses/LICENSE-2.0
+
+package mongo
+
+import (
+	"context"
+	"errors"
+	"io"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+// FindStream executes a find command like Collection.Find and returns a reader over the matching documents. See
+// options.StreamFormat for the formats documents can be written in.
+//
+// Batches are only fetched from the server as the reader is consumed, so at most one batch of documents is held in
+// memory at a time. The BatchSize find option can be used to bound the size of a batch. ctx is used for all getMore
+// commands run while reading. The returned reader must be closed to release the underlying cursor.
+func (coll *Collection) FindStream(ctx context.Context, filter interface{},
+	opts ...*options.FindStreamOptions) (io.ReadCloser, error) {
+
+	if ctx == nil {
+		ctx = context.Background()
+	}
+
+	fso := options.MergeFindStreamOptions(opts...)
+	var findOpts []*options.FindOptions
+	if fso.FindOptions != nil {
+		findOpts = append(findOpts, fso.FindOptions)
+	}
+	cursor, err := coll.Find(ctx, filter, findOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return newFindStream(ctx, cursor, fso), nil
+}
+
+var errFindStreamClosed = errors.New("read from a closed find stream")
+
+// findStream is an io.ReadCloser that writes the documents returned by a cursor in a StreamFormat.
+type findStream struct {
+	ctx       context.Context
+	cursor    *Cursor
+	format    options.StreamFormat
+	canonical bool
+	buf       []byte
+	err       error
+}
+
+func newFindStream(ctx context.Context, cursor *Cursor, fso *options.FindStreamOptions) *findStream {
+	fs := &findStream{ctx: ctx, cursor: cursor}
+	if fso.Format != nil {
+		fs.format = *fso.Format
+	}
+	if fso.Canonical != nil {
+		fs.canonical = *fso.Canonical
+	}
+	return fs
+}
+
+// Read implements the io.Reader interface. The next document is only read from the cursor once the previous one has
+// been fully consumed.
+func (fs *findStream) Read(p []byte) (int, error) {
+	for len(fs.buf) == 0 {
+		if fs.err != nil {
+			return 0, fs.err
+		}
+		if !fs.cursor.Next(fs.ctx) {
+			fs.err = fs.cursor.Err()
+			if fs.err == nil {
+				fs.err = io.EOF
+			}
+			_ = fs.cursor.Close(fs.ctx)
+			continue
+		}
+		fs.buf, fs.err = fs.encode(fs.cursor.Current)
+	}
+
+	n := copy(p, fs.buf)
+	fs.buf = fs.buf[n:]
+	return n, nil
+}
+
+// encode returns the bytes that doc is written as. The returned slice does not share memory with doc, which is
+// overwritten when the cursor advances.
+func (fs *findStream) encode(doc bson.Raw) ([]byte, error) {
+	if fs.format != options.ExtJSONStream {
+		return append([]byte(nil), doc...), nil
+	}
+
+	b, err := bson.MarshalExtJSONWithRegistry(fs.cursor.registry, doc, fs.canonical, false)
+	if err != nil {
+		return nil, err
+	}
+	return append(b, '\n'), nil
+}
+
+// Close implements the io.Closer interface. It closes the underlying cursor.
+func (fs *findStream) Close() error {
+	fs.buf = nil
+	if fs.err == nil {
+		fs.err = errFindStreamClosed
+	}
+	return fs.cursor.Close(fs.ctx)
+}
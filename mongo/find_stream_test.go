@@ -0,0 +1,94 @@
+// Copyright (C) MongoDB, Inc. 2017-present.
+//
+// Licensed under the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License. You may obtain
+// a copy of the License at http://www.apache.org/licenses/LICENSE-2.0
+
+package mongo
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"io/ioutil"
+	"testing"
+	"testing/iotest"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/internal/testutil/assert"
+	"go.mongodb.org/mongo-driver/mongo/options"
+	"go.mongodb.org/mongo-driver/x/bsonx/bsoncore"
+)
+
+func TestFindStream(t *testing.T) {
+	docs := []interface{}{
+		bson.D{{"_id", int32(1)}, {"name", "Ada"}},
+		bson.D{{"_id", int32(2)}, {"name", "Grace"}, {"tags", bson.A{"a", "b"}}},
+		bson.D{{"_id", int32(3)}, {"score", 4.5}},
+	}
+	newStream := func(t *testing.T, opts ...*options.FindStreamOptions) *findStream {
+		t.Helper()
+
+		cursor, err := NewCursorFromDocuments(docs, nil, nil)
+		assert.Nil(t, err, "NewCursorFromDocuments error: %v", err)
+		return newFindStream(context.Background(), cursor, options.MergeFindStreamOptions(opts...))
+	}
+	assertDocs := func(t *testing.T, got []bson.D) {
+		t.Helper()
+
+		assert.Equal(t, len(docs), len(got), "expected %d documents, got %d", len(docs), len(got))
+		for i, doc := range got {
+			assert.Equal(t, docs[i], doc, "expected document %v, got %v", docs[i], doc)
+		}
+	}
+
+	t.Run("BSON", func(t *testing.T) {
+		fs := newStream(t)
+		defer fs.Close()
+
+		// Read one byte at a time so documents are split across reads.
+		b, err := ioutil.ReadAll(iotest.OneByteReader(fs))
+		assert.Nil(t, err, "ReadAll error: %v", err)
+
+		var got []bson.D
+		for len(b) > 0 {
+			length, _, ok := bsoncore.ReadLength(b)
+			assert.True(t, ok, "expected a length prefix, got %v", b)
+			var doc bson.D
+			err = bson.Unmarshal(b[:length], &doc)
+			assert.Nil(t, err, "Unmarshal error: %v", err)
+			got = append(got, doc)
+			b = b[length:]
+		}
+		assertDocs(t, got)
+	})
+	t.Run("extended JSON", func(t *testing.T) {
+		for _, canonical := range []bool{false, true} {
+			fs := newStream(t, options.FindStream().SetFormat(options.ExtJSONStream).SetCanonical(canonical))
+
+			var got []bson.D
+			scanner := bufio.NewScanner(fs)
+			for scanner.Scan() {
+				line := scanner.Bytes()
+				hasType := bytes.Contains(line, []byte("$numberInt"))
+				assert.Equal(t, canonical, hasType, "expected canonical output %v, got %s", canonical, line)
+
+				var doc bson.D
+				err := bson.UnmarshalExtJSON(line, canonical, &doc)
+				assert.Nil(t, err, "UnmarshalExtJSON error: %v", err)
+				got = append(got, doc)
+			}
+			assert.Nil(t, scanner.Err(), "Scan error: %v", scanner.Err())
+			assertDocs(t, got)
+			_ = fs.Close()
+		}
+	})
+	t.Run("read after close", func(t *testing.T) {
+		fs := newStream(t)
+		err := fs.Close()
+		assert.Nil(t, err, "Close error: %v", err)
+
+		_, err = fs.Read(make([]byte, 16))
+		assert.Equal(t, errFindStreamClosed, err, "expected error %v, got %v", errFindStreamClosed, err)
+	})
+}
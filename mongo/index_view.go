@@ -36,6 +36,9 @@ var ErrNonStringIndexName = errors.New("index name must be a string")
 // ErrMultipleIndexDrop is returned if multiple indexes would be dropped from a call to IndexView.DropOne.
 var ErrMultipleIndexDrop = errors.New("multiple indexes would be dropped")
 
+// ErrIndexBuildNotFound is returned from IndexView.BuildProgress if no build of the index is in progress.
+var ErrIndexBuildNotFound = errors.New("index build not found")
+
 // IndexView is a type that can be used to create, drop, and list indexes on a collection. An IndexView for a collection
 // can be created by a call to Collection.Indexes().
 type IndexView struct {
@@ -166,6 +169,21 @@ func (iv IndexView) CreateOne(ctx context.Context, model IndexModel, opts ...*op
 	return names[0], nil
 }
 
+// CreateExpiryIndex executes a createIndexes command to create a TTL index on the ExpireAtField field with
+// expireAfterSeconds set to 0 and returns the name of the new index. With this index, each document expires at the time
+// stored in its ExpireAtField field, which can be set using Collection.InsertWithExpiry. Documents without the field
+// never expire.
+//
+// For more information about expiring documents at a specific time, see
+// https://docs.mongodb.com/manual/tutorial/expire-data/#expire-documents-at-a-specific-clock-time.
+func (iv IndexView) CreateExpiryIndex(ctx context.Context, opts ...*options.CreateIndexesOptions) (string, error) {
+	model := IndexModel{
+		Keys:    bson.D{{ExpireAtField, 1}},
+		Options: options.Index().SetExpireAfterSeconds(0),
+	}
+	return iv.CreateOne(ctx, model, opts...)
+}
+
 // CreateMany executes a createIndexes command to create multiple indexes on the collection and returns the names of
 // the new indexes.
 //
@@ -448,6 +466,86 @@ func (iv IndexView) DropAll(ctx context.Context, opts ...*options.DropIndexesOpt
 	return iv.drop(ctx, "*", opts...)
 }
 
+// DropAllExceptID lists the indexes on the collection and drops every index other than the default "_id_" index, one
+// at a time, using a dropIndexes operation for each. It returns the number of indexes that were dropped. If an error
+// occurs, the returned count contains the number of indexes dropped before the error.
+//
+// The opts parameter can be used to specify options for the dropIndexes operations (see the
+// options.DropIndexesOptions documentation).
+func (iv IndexView) DropAllExceptID(ctx context.Context, opts ...*options.DropIndexesOptions) (int, error) {
+	specs, err := iv.ListSpecifications(ctx)
+	if err != nil {
+		return 0, err
+	}
+
+	var dropped int
+	for _, spec := range specs {
+		if spec.Name == "_id_" {
+			continue
+		}
+		if _, err = iv.DropOne(ctx, spec.Name, opts...); err != nil {
+			return dropped, err
+		}
+		dropped++
+	}
+	return dropped, nil
+}
+
+// BuildProgress reports how far an in-progress build of the index with the given name has got, as a percentage between
+// 0 and 100. It finds the build by running an aggregation with a $currentOp stage against the admin database and
+// computes the percentage from the progress the server reports for the build's current phase. A build that has
+// started but not reported any progress yet is at 0 percent. ErrIndexBuildNotFound is returned if no build of the
+// index is in progress, which is also the case once the build has finished.
+//
+// For more information about the stage, see
+// https://docs.mongodb.com/manual/reference/operator/aggregation/currentOp/.
+func (iv IndexView) BuildProgress(ctx context.Context, indexName string) (float64, error) {
+	if ctx == nil {
+		ctx = context.Background()
+	}
+
+	db := iv.coll.db.name
+	pipeline := Pipeline{
+		{{"$currentOp", bson.D{{"allUsers", true}}}},
+		{{"$match", bson.D{
+			{"ns", bson.D{{"$in", bson.A{db + "." + iv.coll.name, db + ".$cmd"}}}},
+			{"command.createIndexes", iv.coll.name},
+			{"command.indexes.name", indexName},
+		}}},
+	}
+	ops, err := iv.coll.client.currentOps(ctx, pipeline)
+	if err != nil {
+		return 0, err
+	}
+	if len(ops) == 0 {
+		return 0, ErrIndexBuildNotFound
+	}
+
+	// A build can show up as more than one operation, e.g. the createIndexes command waiting for the build and the
+	// thread running it. Only the latter reports progress.
+	for _, op := range ops {
+		var build struct {
+			Progress *struct {
+				Done  float64
+				Total float64
+			}
+		}
+		if err = bson.Unmarshal(op.Raw, &build); err != nil {
+			return 0, err
+		}
+		if build.Progress == nil || build.Progress.Total <= 0 {
+			continue
+		}
+
+		percent := build.Progress.Done / build.Progress.Total * 100
+		if percent > 100 {
+			percent = 100
+		}
+		return percent, nil
+	}
+	return 0, nil
+}
+
 func getOrGenerateIndexName(keySpecDocument bsoncore.Document, model IndexModel) (string, error) {
 	if model.Options != nil && model.Options.Name != nil {
 		return *model.Options.Name, nil
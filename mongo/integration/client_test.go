@@ -13,6 +13,7 @@ import (
 	"os"
 	"reflect"
 	"strings"
+	"sync"
 	"testing"
 	"time"
 
@@ -331,6 +332,214 @@ func TestClient(t *testing.T) {
 			_ = client.Disconnect(context.Background())
 		})
 	})
+	var slowOps []event.CommandStartedEvent
+	slowOpClientOpts := options.Client().
+		SetSlowOperationThreshold(0).
+		SetSlowOperationCallback(func(evt event.CommandStartedEvent, _ time.Duration) {
+			slowOps = append(slowOps, evt)
+		})
+	slowOpOpts := mtest.NewOptions().ClientType(mtest.Mock).ClientOptions(slowOpClientOpts)
+	mt.RunOpts("slow operation callback", slowOpOpts, func(mt *mtest.T) {
+		mt.Run("reports command", func(mt *mtest.T) {
+			slowOps = nil
+			mt.AddMockResponses(mtest.CreateSuccessResponse())
+			err := mt.Client.Ping(context.Background(), nil)
+			assert.Nil(mt, err, "Ping error: %v", err)
+
+			assert.Equal(mt, 1, len(slowOps), "expected 1 slow operation, got %v", len(slowOps))
+			assert.Equal(mt, "ping", slowOps[0].CommandName, "expected command 'ping', got %q", slowOps[0].CommandName)
+			assert.NotNil(mt, mt.GetStartedEvent(), "expected command monitor to receive started event")
+		})
+		mt.Run("redacts sensitive commands", func(mt *mtest.T) {
+			slowOps = nil
+			mt.AddMockResponses(mtest.CreateSuccessResponse())
+			err := mt.DB.RunCommand(context.Background(), bson.D{{"saslStart", 1}, {"payload", "secret"}}).Err()
+			assert.Nil(mt, err, "RunCommand error: %v", err)
+
+			assert.Equal(mt, 1, len(slowOps), "expected 1 slow operation, got %v", len(slowOps))
+			assert.Equal(mt, 0, len(slowOps[0].Command), "expected redacted command, got %v", slowOps[0].Command)
+		})
+	})
+	defaultMaxTimeOpts := mtest.NewOptions().ClientType(mtest.Mock).
+		ClientOptions(options.Client().SetDefaultMaxTime(30 * time.Second))
+	mt.RunOpts("default max time", defaultMaxTimeOpts, func(mt *mtest.T) {
+		getMaxTimeMS := func(mt *mtest.T) (int64, bool) {
+			mt.Helper()
+
+			evt := mt.GetStartedEvent()
+			val, err := evt.Command.LookupErr("maxTimeMS")
+			if err != nil {
+				return 0, false
+			}
+			return val.AsInt64(), true
+		}
+
+		mt.Run("applied to commands", func(mt *mtest.T) {
+			mt.AddMockResponses(
+				mtest.CreateCursorResponse(0, "db.coll", mtest.FirstBatch),
+				mtest.CreateSuccessResponse(bson.E{"n", 1}),
+			)
+
+			_, err := mt.Coll.Find(context.Background(), bson.D{})
+			assert.Nil(mt, err, "Find error: %v", err)
+			maxTimeMS, ok := getMaxTimeMS(mt)
+			assert.True(mt, ok, "expected find command to contain maxTimeMS")
+			assert.Equal(mt, int64(30000), maxTimeMS, "expected maxTimeMS 30000, got %v", maxTimeMS)
+
+			_, err = mt.Coll.InsertOne(context.Background(), bson.D{{"x", 1}})
+			assert.Nil(mt, err, "InsertOne error: %v", err)
+			maxTimeMS, ok = getMaxTimeMS(mt)
+			assert.True(mt, ok, "expected insert command to contain maxTimeMS")
+			assert.Equal(mt, int64(30000), maxTimeMS, "expected maxTimeMS 30000, got %v", maxTimeMS)
+		})
+		mt.Run("overridden per operation", func(mt *mtest.T) {
+			mt.AddMockResponses(mtest.CreateCursorResponse(0, "db.coll", mtest.FirstBatch))
+
+			_, err := mt.Coll.Find(context.Background(), bson.D{}, options.Find().SetMaxTime(5*time.Second))
+			assert.Nil(mt, err, "Find error: %v", err)
+			maxTimeMS, ok := getMaxTimeMS(mt)
+			assert.True(mt, ok, "expected find command to contain maxTimeMS")
+			assert.Equal(mt, int64(5000), maxTimeMS, "expected maxTimeMS 5000, got %v", maxTimeMS)
+		})
+		mt.Run("not applied to other commands", func(mt *mtest.T) {
+			mt.AddMockResponses(mtest.CreateSuccessResponse())
+
+			err := mt.Client.Ping(context.Background(), nil)
+			assert.Nil(mt, err, "Ping error: %v", err)
+			_, ok := getMaxTimeMS(mt)
+			assert.False(mt, ok, "expected ping command not to contain maxTimeMS")
+		})
+	})
+	mt.RunOpts("current ops", mtest.NewOptions().ClientType(mtest.Mock), func(mt *mtest.T) {
+		mt.Run("typed fields", func(mt *mtest.T) {
+			mt.AddMockResponses(mtest.CreateCursorResponse(0, "admin.$cmd.aggregate", mtest.FirstBatch,
+				bson.D{
+					{"type", "op"},
+					{"desc", "conn12"},
+					{"active", true},
+					{"opid", int32(4521)},
+					{"secs_running", int64(3)},
+					{"microsecs_running", int64(3250000)},
+					{"op", "query"},
+					{"ns", "db.coll"},
+					{"command", bson.D{{"find", "coll"}, {"filter", bson.D{{"x", 1}}}}},
+					{"client", "127.0.0.1:52341"},
+				},
+				bson.D{
+					{"shard", "shard01"},
+					{"active", false},
+					{"opid", "shard01:17"},
+					{"op", "none"},
+				},
+			))
+
+			ops, err := mt.Client.CurrentOps(context.Background())
+			assert.Nil(mt, err, "CurrentOps error: %v", err)
+			assert.Equal(mt, 2, len(ops), "expected 2 operations, got %v", len(ops))
+
+			op := ops[0]
+			assert.Equal(mt, int32(4521), op.OpID, "expected opid 4521, got %v", op.OpID)
+			assert.True(mt, op.Active, "expected operation to be active")
+			assert.Equal(mt, "query", op.Op, "expected op 'query', got %q", op.Op)
+			assert.Equal(mt, int64(3), op.SecsRunning, "expected secs_running 3, got %v", op.SecsRunning)
+			assert.Equal(mt, int64(3250000), op.MicrosecsRunning, "expected microsecs_running 3250000, got %v",
+				op.MicrosecsRunning)
+			assert.Equal(mt, "db.coll", op.Namespace, "expected ns 'db.coll', got %q", op.Namespace)
+			assert.Equal(mt, "coll", op.Command.Lookup("find").StringValue(), "expected find command, got %v", op.Command)
+			assert.Equal(mt, "127.0.0.1:52341", op.Client, "expected client '127.0.0.1:52341', got %q", op.Client)
+			assert.Equal(mt, "conn12", op.Desc, "expected desc 'conn12', got %q", op.Desc)
+			assert.Equal(mt, "op", op.Raw.Lookup("type").StringValue(), "expected raw document, got %v", op.Raw)
+
+			op = ops[1]
+			assert.Equal(mt, "shard01:17", op.OpID, "expected opid 'shard01:17', got %v", op.OpID)
+			assert.False(mt, op.Active, "expected operation to be inactive")
+
+			evt := mt.GetStartedEvent()
+			assert.Equal(mt, "admin", evt.DatabaseName, "expected database 'admin', got %q", evt.DatabaseName)
+			pipeline, err := evt.Command.Lookup("pipeline").Array().Values()
+			assert.Nil(mt, err, "Values error: %v", err)
+			assert.Equal(mt, 1, len(pipeline), "expected 1 pipeline stage, got %v", len(pipeline))
+			idleSessions, err := pipeline[0].Document().LookupErr("$currentOp", "idleSessions")
+			assert.Nil(mt, err, "expected $currentOp stage with idleSessions, got %v", pipeline[0])
+			assert.False(mt, idleSessions.Boolean(), "expected idleSessions false, got %v", idleSessions)
+		})
+		mt.Run("filters", func(mt *mtest.T) {
+			mt.AddMockResponses(mtest.CreateCursorResponse(0, "admin.$cmd.aggregate", mtest.FirstBatch))
+
+			opts := options.CurrentOps().
+				SetAllUsers(true).
+				SetNamespace("db.coll").
+				SetMinRunningTime(1500 * time.Millisecond)
+			ops, err := mt.Client.CurrentOps(context.Background(), opts)
+			assert.Nil(mt, err, "CurrentOps error: %v", err)
+			assert.Equal(mt, 0, len(ops), "expected no operations, got %v", len(ops))
+
+			evt := mt.GetStartedEvent()
+			got := evt.Command.Lookup("pipeline")
+			expected := bson.A{
+				bson.D{{"$currentOp", bson.D{{"idleSessions", false}, {"allUsers", true}}}},
+				bson.D{{"$match", bson.D{
+					{"ns", "db.coll"},
+					{"microsecs_running", bson.D{{"$gte", int64(1500000)}}},
+				}}},
+			}
+			_, expectedBytes, err := bson.MarshalValue(expected)
+			assert.Nil(mt, err, "MarshalValue error: %v", err)
+			assert.Equal(mt, bson.Raw(expectedBytes), bson.Raw(got.Value), "expected pipeline %v, got %v",
+				bson.Raw(expectedBytes), got)
+		})
+	})
+	mt.RunOpts("kill ops by comment", mtest.NewOptions().ClientType(mtest.Mock), func(mt *mtest.T) {
+		currentOpsResponse := mtest.CreateCursorResponse(0, "admin.$cmd.aggregate", mtest.FirstBatch,
+			bson.D{{"opid", int32(11)}, {"op", "query"}, {"command", bson.D{{"find", "coll"}, {"comment", "req-42"}}}},
+			bson.D{
+				{"opid", "shard01:12"},
+				{"op", "getmore"},
+				{"cursor", bson.D{{"originatingCommand", bson.D{{"find", "coll"}, {"comment", "req-42"}}}}},
+			},
+		)
+
+		mt.Run("kills matching operations", func(mt *mtest.T) {
+			mt.AddMockResponses(currentOpsResponse, mtest.CreateSuccessResponse(), mtest.CreateSuccessResponse())
+
+			killed, err := mt.Client.KillOpsByComment(context.Background(), "req-42")
+			assert.Nil(mt, err, "KillOpsByComment error: %v", err)
+			assert.Equal(mt, 2, killed, "expected 2 operations killed, got %v", killed)
+
+			evt := mt.GetStartedEvent()
+			assert.Equal(mt, "aggregate", evt.CommandName, "expected command 'aggregate', got %q", evt.CommandName)
+			match := evt.Command.Lookup("pipeline").Array().Index(1).Value().Document().Lookup("$match", "$or")
+			expectedMatch := bson.A{
+				bson.D{{"command.comment", "req-42"}},
+				bson.D{{"cursor.originatingCommand.comment", "req-42"}},
+			}
+			_, expectedBytes, err := bson.MarshalValue(expectedMatch)
+			assert.Nil(mt, err, "MarshalValue error: %v", err)
+			assert.Equal(mt, bson.Raw(expectedBytes), bson.Raw(match.Value), "expected $match %v, got %v",
+				bson.Raw(expectedBytes), match)
+
+			for _, opid := range []interface{}{int32(11), "shard01:12"} {
+				evt = mt.GetStartedEvent()
+				assert.Equal(mt, "killOp", evt.CommandName, "expected command 'killOp', got %q", evt.CommandName)
+				assert.Equal(mt, "admin", evt.DatabaseName, "expected database 'admin', got %q", evt.DatabaseName)
+				var cmd struct {
+					Op interface{}
+				}
+				err = bson.Unmarshal(evt.Command, &cmd)
+				assert.Nil(mt, err, "Unmarshal error: %v", err)
+				assert.Equal(mt, opid, cmd.Op, "expected op %v, got %v", opid, cmd.Op)
+			}
+		})
+		mt.Run("killOp error", func(mt *mtest.T) {
+			killOpErr := mtest.CommandError{Code: 13, Name: "Unauthorized", Message: "not authorized"}
+			mt.AddMockResponses(currentOpsResponse, mtest.CreateSuccessResponse(),
+				mtest.CreateCommandErrorResponse(killOpErr))
+
+			killed, err := mt.Client.KillOpsByComment(context.Background(), "req-42")
+			assert.NotNil(mt, err, "expected KillOpsByComment error, got nil")
+			assert.Equal(mt, 1, killed, "expected 1 operation killed, got %v", killed)
+		})
+	})
 	mt.RunOpts("disconnect", noClientOpts, func(mt *mtest.T) {
 		mt.Run("nil context", func(mt *mtest.T) {
 			err := mt.Client.Disconnect(nil)
@@ -599,6 +808,43 @@ func TestClient(t *testing.T) {
 		assert.Equal(t, 0, closed, "expected no connections to be closed")
 	})
 
+	mt.Run("slow operations are reported", func(mt *mtest.T) {
+		if testing.Short() {
+			t.Skip("skipping integration test in short mode")
+		}
+
+		// Reset the client with a dialer that delays all network round trips by 300ms so every command exceeds the
+		// 250ms slow operation threshold.
+		var mu sync.Mutex
+		var slowOps []event.CommandStartedEvent
+		var durations []time.Duration
+		mt.ResetClient(options.Client().
+			SetDialer(newSlowConnDialer(300 * time.Millisecond)).
+			SetSlowOperationThreshold(250 * time.Millisecond).
+			SetSlowOperationCallback(func(evt event.CommandStartedEvent, d time.Duration) {
+				mu.Lock()
+				defer mu.Unlock()
+				slowOps = append(slowOps, evt)
+				durations = append(durations, d)
+			}))
+
+		err := mt.Client.Ping(context.Background(), readpref.Primary())
+		assert.Nil(mt, err, "Ping error: %v", err)
+
+		mu.Lock()
+		defer mu.Unlock()
+		var found bool
+		for i, evt := range slowOps {
+			if evt.CommandName != "ping" {
+				continue
+			}
+			found = true
+			assert.True(mt, durations[i] >= 250*time.Millisecond,
+				"expected duration of at least 250ms, got %v", durations[i])
+		}
+		assert.True(mt, found, "expected slow 'ping' command to be reported")
+	})
+
 	// Test that OP_MSG is used for authentication-related commands on 3.6+ (WV 6+). Do not test when API version is
 	// set, as handshakes will always use OP_MSG.
 	opMsgOpts := mtest.NewOptions().ClientType(mtest.Proxy).MinServerVersion("3.6").Auth(true).RequireAPIVersion(false)
@@ -8,6 +8,7 @@ package integration
 
 import (
 	"context"
+	"io/ioutil"
 	"os"
 	"strings"
 	"testing"
@@ -119,6 +120,357 @@ func TestCollection(t *testing.T) {
 			}
 		})
 	})
+	mt.RunOpts("insert with expiry", mtest.NewOptions().ClientType(mtest.Mock), func(mt *mtest.T) {
+		expireAt := time.Date(2030, time.January, 1, 0, 0, 0, 0, time.UTC)
+
+		mt.Run("stamps field", func(mt *mtest.T) {
+			mt.AddMockResponses(mtest.CreateSuccessResponse())
+
+			id := primitive.NewObjectID()
+			res, err := mt.Coll.InsertWithExpiry(context.Background(), bson.D{{"_id", id}, {"x", 1}}, expireAt)
+			assert.Nil(mt, err, "InsertWithExpiry error: %v", err)
+			assert.Equal(mt, id, res.InsertedID, "expected inserted ID %v, got %v", id, res.InsertedID)
+
+			doc := mt.GetStartedEvent().Command.Lookup("documents", "0").Document()
+			got := doc.Lookup(mongo.ExpireAtField).Time().UTC()
+			assert.Equal(mt, expireAt, got, "expected %v to be %v, got %v", mongo.ExpireAtField, expireAt, got)
+			x := doc.Lookup("x").Int32()
+			assert.Equal(mt, int32(1), x, "expected x to be 1, got %v", x)
+		})
+		mt.Run("replaces existing field", func(mt *mtest.T) {
+			mt.AddMockResponses(mtest.CreateSuccessResponse())
+
+			doc := bson.D{{"x", 1}, {mongo.ExpireAtField, "not a date"}}
+			_, err := mt.Coll.InsertWithExpiry(context.Background(), doc, expireAt)
+			assert.Nil(mt, err, "InsertWithExpiry error: %v", err)
+
+			sent := mt.GetStartedEvent().Command.Lookup("documents", "0").Document()
+			elems, err := sent.Elements()
+			assert.Nil(mt, err, "Elements error: %v", err)
+			var count int
+			for _, elem := range elems {
+				if elem.Key() == mongo.ExpireAtField {
+					count++
+				}
+			}
+			assert.Equal(mt, 1, count, "expected 1 %v field, got %v", mongo.ExpireAtField, count)
+			got := sent.Lookup(mongo.ExpireAtField).Time().UTC()
+			assert.Equal(mt, expireAt, got, "expected %v to be %v, got %v", mongo.ExpireAtField, expireAt, got)
+		})
+		mt.Run("nil document", func(mt *mtest.T) {
+			_, err := mt.Coll.InsertWithExpiry(context.Background(), nil, expireAt)
+			assert.Equal(mt, mongo.ErrNilDocument, err, "expected error %v, got %v", mongo.ErrNilDocument, err)
+		})
+	})
+	mt.RunOpts("operation time", mtest.NewOptions().ClientType(mtest.Mock), func(mt *mtest.T) {
+		opTime := primitive.Timestamp{T: 1234, I: 5}
+		opTimeElem := bson.E{"operationTime", opTime}
+
+		mt.Run("insert one", func(mt *mtest.T) {
+			mt.AddMockResponses(mtest.CreateSuccessResponse(bson.E{"n", 1}, opTimeElem))
+
+			res, err := mt.Coll.InsertOne(context.Background(), bson.D{{"x", 1}})
+			assert.Nil(mt, err, "InsertOne error: %v", err)
+			assert.NotNil(mt, res.OperationTime, "expected operationTime to be populated, got nil")
+			assert.Equal(mt, opTime, *res.OperationTime, "expected operationTime %v, got %v", opTime, *res.OperationTime)
+		})
+		mt.Run("insert many", func(mt *mtest.T) {
+			mt.AddMockResponses(mtest.CreateSuccessResponse(bson.E{"n", 2}, opTimeElem))
+
+			res, err := mt.Coll.InsertMany(context.Background(), []interface{}{bson.D{{"x", 1}}, bson.D{{"x", 2}}})
+			assert.Nil(mt, err, "InsertMany error: %v", err)
+			assert.NotNil(mt, res.OperationTime, "expected operationTime to be populated, got nil")
+			assert.Equal(mt, opTime, *res.OperationTime, "expected operationTime %v, got %v", opTime, *res.OperationTime)
+		})
+		mt.Run("update one", func(mt *mtest.T) {
+			mt.AddMockResponses(mtest.CreateSuccessResponse(bson.E{"n", 1}, bson.E{"nModified", 1}, opTimeElem))
+
+			res, err := mt.Coll.UpdateOne(context.Background(), bson.D{}, bson.D{{"$set", bson.D{{"x", 1}}}})
+			assert.Nil(mt, err, "UpdateOne error: %v", err)
+			assert.NotNil(mt, res.OperationTime, "expected operationTime to be populated, got nil")
+			assert.Equal(mt, opTime, *res.OperationTime, "expected operationTime %v, got %v", opTime, *res.OperationTime)
+		})
+		mt.Run("delete one", func(mt *mtest.T) {
+			mt.AddMockResponses(mtest.CreateSuccessResponse(bson.E{"n", 1}, opTimeElem))
+
+			res, err := mt.Coll.DeleteOne(context.Background(), bson.D{})
+			assert.Nil(mt, err, "DeleteOne error: %v", err)
+			assert.NotNil(mt, res.OperationTime, "expected operationTime to be populated, got nil")
+			assert.Equal(mt, opTime, *res.OperationTime, "expected operationTime %v, got %v", opTime, *res.OperationTime)
+		})
+		mt.Run("bulk write", func(mt *mtest.T) {
+			mt.AddMockResponses(mtest.CreateSuccessResponse(bson.E{"n", 1}, opTimeElem))
+
+			models := []mongo.WriteModel{mongo.NewInsertOneModel().SetDocument(bson.D{{"x", 1}})}
+			res, err := mt.Coll.BulkWrite(context.Background(), models)
+			assert.Nil(mt, err, "BulkWrite error: %v", err)
+			assert.NotNil(mt, res.OperationTime, "expected operationTime to be populated, got nil")
+			assert.Equal(mt, opTime, *res.OperationTime, "expected operationTime %v, got %v", opTime, *res.OperationTime)
+		})
+		mt.Run("not reported", func(mt *mtest.T) {
+			mt.AddMockResponses(mtest.CreateSuccessResponse(bson.E{"n", 1}))
+
+			res, err := mt.Coll.InsertOne(context.Background(), bson.D{{"x", 1}})
+			assert.Nil(mt, err, "InsertOne error: %v", err)
+			assert.Nil(mt, res.OperationTime, "expected operationTime to be nil, got %v", res.OperationTime)
+		})
+	})
+	mt.RunOpts("insert many deduplicate by id", mtest.NewOptions().ClientType(mtest.Mock), func(mt *mtest.T) {
+		docs := []interface{}{
+			bson.D{{"_id", int32(1)}, {"x", 1}},
+			bson.D{{"_id", "a"}},
+			bson.D{{"_id", int64(1)}, {"x", 2}},
+			bson.D{{"x", 3}},
+			bson.D{{"_id", 1.0}, {"x", 4}},
+			bson.D{{"_id", "b"}},
+			bson.D{{"_id", "a"}},
+		}
+		opts := options.InsertMany().SetOrdered(false).SetDeduplicateByID(true)
+
+		mt.Run("duplicates removed", func(mt *mtest.T) {
+			mt.AddMockResponses(mtest.CreateSuccessResponse(bson.E{"n", 4}))
+
+			res, err := mt.Coll.InsertMany(context.Background(), docs, opts)
+			assert.Nil(mt, err, "InsertMany error: %v", err)
+			assert.Equal(mt, 3, res.DuplicatesRemoved, "expected 3 duplicates removed, got %v", res.DuplicatesRemoved)
+			assert.Equal(mt, 4, len(res.InsertedIDs), "expected 4 inserted IDs, got %v", len(res.InsertedIDs))
+			assert.Equal(mt, int32(1), res.InsertedIDs[0], "expected first inserted ID 1, got %v", res.InsertedIDs[0])
+			assert.Equal(mt, "a", res.InsertedIDs[1], "expected second inserted ID 'a', got %v", res.InsertedIDs[1])
+			assert.Equal(mt, "b", res.InsertedIDs[3], "expected fourth inserted ID 'b', got %v", res.InsertedIDs[3])
+
+			sent, err := mt.GetStartedEvent().Command.Lookup("documents").Array().Values()
+			assert.Nil(mt, err, "Values error: %v", err)
+			assert.Equal(mt, 4, len(sent), "expected 4 documents sent, got %v", len(sent))
+			x := sent[0].Document().Lookup("x").Int32()
+			assert.Equal(mt, int32(1), x, "expected first occurrence to be kept, got x=%v", x)
+		})
+		mt.Run("write error indexes refer to input", func(mt *mtest.T) {
+			mt.AddMockResponses(mtest.CreateWriteErrorsResponse(mtest.WriteError{
+				Index:   3,
+				Code:    11000,
+				Message: "E11000 duplicate key error",
+			}))
+
+			res, err := mt.Coll.InsertMany(context.Background(), docs, opts)
+			bwe, ok := err.(mongo.BulkWriteException)
+			assert.True(mt, ok, "expected error type %T, got %T", mongo.BulkWriteException{}, err)
+			assert.Equal(mt, 1, len(bwe.WriteErrors), "expected 1 write error, got %v", len(bwe.WriteErrors))
+			assert.Equal(mt, 5, bwe.WriteErrors[0].Index, "expected write error index 5, got %v",
+				bwe.WriteErrors[0].Index)
+			assert.Equal(mt, 3, res.DuplicatesRemoved, "expected 3 duplicates removed, got %v", res.DuplicatesRemoved)
+			assert.Equal(mt, 3, len(res.InsertedIDs), "expected 3 inserted IDs, got %v", len(res.InsertedIDs))
+		})
+		mt.Run("disabled by default", func(mt *mtest.T) {
+			mt.AddMockResponses(mtest.CreateSuccessResponse(bson.E{"n", 7}))
+
+			res, err := mt.Coll.InsertMany(context.Background(), docs, options.InsertMany().SetOrdered(false))
+			assert.Nil(mt, err, "InsertMany error: %v", err)
+			assert.Equal(mt, 0, res.DuplicatesRemoved, "expected no duplicates removed, got %v", res.DuplicatesRemoved)
+			sent, err := mt.GetStartedEvent().Command.Lookup("documents").Array().Values()
+			assert.Nil(mt, err, "Values error: %v", err)
+			assert.Equal(mt, 7, len(sent), "expected 7 documents sent, got %v", len(sent))
+		})
+	})
+	mt.RunOpts("query logger", mtest.NewOptions().ClientType(mtest.Mock), func(mt *mtest.T) {
+		type query struct {
+			op     string
+			filter bson.Raw
+		}
+		var logged []query
+		mt.Coll.SetQueryLogger(func(op string, filter bson.Raw) {
+			logged = append(logged, query{op, append(bson.Raw(nil), filter...)})
+		})
+		ns := mt.Coll.Database().Name() + "." + mt.Coll.Name()
+		filter := bson.D{{"x", 1}}
+
+		mt.AddMockResponses(
+			mtest.CreateCursorResponse(0, ns, mtest.FirstBatch),
+			mtest.CreateSuccessResponse(bson.E{"n", 1}, bson.E{"nModified", 1}),
+			mtest.CreateSuccessResponse(bson.E{"n", 1}),
+			mtest.CreateSuccessResponse(bson.E{"value", nil}),
+			mtest.CreateCursorResponse(0, ns, mtest.FirstBatch),
+		)
+		_, err := mt.Coll.Find(context.Background(), filter)
+		assert.Nil(mt, err, "Find error: %v", err)
+		_, err = mt.Coll.UpdateOne(context.Background(), filter, bson.D{{"$set", bson.D{{"y", 1}}}})
+		assert.Nil(mt, err, "UpdateOne error: %v", err)
+		_, err = mt.Coll.DeleteMany(context.Background(), filter)
+		assert.Nil(mt, err, "DeleteMany error: %v", err)
+		err = mt.Coll.FindOneAndDelete(context.Background(), filter).Err()
+		assert.Equal(mt, mongo.ErrNoDocuments, err, "expected error %v, got %v", mongo.ErrNoDocuments, err)
+		_, err = mt.Coll.Aggregate(context.Background(), mongo.Pipeline{{{"$match", filter}}})
+		assert.Nil(mt, err, "Aggregate error: %v", err)
+
+		wantOps := []string{"find", "update", "delete", "findAndModify", "aggregate"}
+		assert.Equal(mt, len(wantOps), len(logged), "expected %v logged queries, got %v", len(wantOps), len(logged))
+		for i, op := range wantOps {
+			assert.Equal(mt, op, logged[i].op, "expected query %v to be %q, got %q", i, op, logged[i].op)
+			if op == "aggregate" {
+				continue
+			}
+			x := logged[i].filter.Lookup("x").Int32()
+			assert.Equal(mt, int32(1), x, "expected %q filter to have x=1, got %v", op, logged[i].filter)
+		}
+		stage := logged[4].filter.Index(0).Value().Document().Lookup("$match", "x").Int32()
+		assert.Equal(mt, int32(1), stage, "expected logged pipeline to contain $match stage, got %v", logged[4].filter)
+
+		// The logger is scoped to the Collection it was set on.
+		logged = nil
+		mt.AddMockResponses(mtest.CreateCursorResponse(0, ns, mtest.FirstBatch))
+		_, err = mt.DB.Collection(mt.Coll.Name()).Find(context.Background(), filter)
+		assert.Nil(mt, err, "Find error: %v", err)
+		assert.Equal(mt, 0, len(logged), "expected no logged queries, got %v", logged)
+	})
+	mt.RunOpts("find stream", mtest.NewOptions().ClientType(mtest.Mock), func(mt *mtest.T) {
+		ns := mt.Coll.Database().Name() + "." + mt.Coll.Name()
+		docs := []bson.D{
+			{{"_id", int32(1)}, {"x", "a"}},
+			{{"_id", int32(2)}, {"x", "b"}},
+			{{"_id", int32(3)}, {"x", "c"}},
+		}
+		mt.AddMockResponses(
+			mtest.CreateCursorResponse(1, ns, mtest.FirstBatch, docs[0], docs[1]),
+			mtest.CreateCursorResponse(0, ns, mtest.NextBatch, docs[2]),
+		)
+
+		mt.ClearEvents()
+		opts := options.FindStream().SetFindOptions(options.Find().SetBatchSize(2))
+		stream, err := mt.Coll.FindStream(context.Background(), bson.D{}, opts)
+		assert.Nil(mt, err, "FindStream error: %v", err)
+		defer stream.Close()
+
+		evt := mt.GetStartedEvent()
+		assert.Equal(mt, "find", evt.CommandName, "expected command 'find', got %q", evt.CommandName)
+		assert.Nil(mt, mt.GetStartedEvent(), "expected no getMore before the stream is read")
+
+		b, err := ioutil.ReadAll(stream)
+		assert.Nil(mt, err, "ReadAll error: %v", err)
+		evt = mt.GetStartedEvent()
+		assert.NotNil(mt, evt, "expected a getMore event")
+		assert.Equal(mt, "getMore", evt.CommandName, "expected command 'getMore', got %q", evt.CommandName)
+
+		var got []bson.D
+		for len(b) > 0 {
+			length, _, ok := bsoncore.ReadLength(b)
+			assert.True(mt, ok, "expected a length prefix, got %v", b)
+			var doc bson.D
+			err = bson.Unmarshal(b[:length], &doc)
+			assert.Nil(mt, err, "Unmarshal error: %v", err)
+			got = append(got, doc)
+			b = b[length:]
+		}
+		assert.Equal(mt, docs, got, "expected documents %v, got %v", docs, got)
+	})
+	mt.RunOpts("migrating collection", mtest.NewOptions().ClientType(mtest.Mock), func(mt *mtest.T) {
+		migrations := map[int]mongo.MigrateFunc{
+			1: func(doc bson.M) (bson.M, error) {
+				doc["fullName"] = doc["name"]
+				delete(doc, "name")
+				return doc, nil
+			},
+			2: func(doc bson.M) (bson.M, error) {
+				doc["active"] = true
+				return doc, nil
+			},
+		}
+		ns := mt.Coll.Database().Name() + "." + mt.Coll.Name()
+		oldDoc := bson.D{{"_id", 1}, {"schemaVersion", 1}, {"name", "Ada"}}
+
+		type person struct {
+			ID            int32  `bson:"_id"`
+			SchemaVersion int32  `bson:"schemaVersion"`
+			FullName      string `bson:"fullName"`
+			Active        bool   `bson:"active"`
+		}
+		want := person{ID: 1, SchemaVersion: 3, FullName: "Ada", Active: true}
+
+		mt.Run("find one migrates old document", func(mt *mtest.T) {
+			mc, err := mongo.NewMigratingCollection(mt.Coll, migrations)
+			assert.Nil(mt, err, "NewMigratingCollection error: %v", err)
+			assert.Equal(mt, 3, mc.LatestVersion(), "expected latest version 3, got %v", mc.LatestVersion())
+
+			mt.ClearEvents()
+			mt.AddMockResponses(mtest.CreateCursorResponse(0, ns, mtest.FirstBatch, oldDoc))
+			var got person
+			err = mc.FindOne(context.Background(), bson.D{{"_id", 1}}).Decode(&got)
+			assert.Nil(mt, err, "FindOne error: %v", err)
+			assert.Equal(mt, want, got, "expected document %v, got %v", want, got)
+
+			assert.NotNil(mt, mt.GetStartedEvent(), "expected a find event")
+			assert.Nil(mt, mt.GetStartedEvent(), "expected migrated document to not be persisted")
+		})
+		mt.Run("find migrates and persists documents", func(mt *mtest.T) {
+			mc, err := mongo.NewMigratingCollection(mt.Coll, migrations, options.MigratingCollection().SetPersist(true))
+			assert.Nil(mt, err, "NewMigratingCollection error: %v", err)
+
+			current := bson.D{{"_id", 2}, {"schemaVersion", 3}, {"fullName", "Grace"}, {"active", false}}
+			mt.AddMockResponses(
+				mtest.CreateCursorResponse(0, ns, mtest.FirstBatch, oldDoc, current),
+				mtest.CreateSuccessResponse(bson.E{"n", 1}, bson.E{"nModified", 1}),
+			)
+			mt.ClearEvents()
+			cursor, err := mc.Find(context.Background(), bson.D{})
+			assert.Nil(mt, err, "Find error: %v", err)
+			var got []person
+			err = cursor.All(context.Background(), &got)
+			assert.Nil(mt, err, "All error: %v", err)
+			wantDocs := []person{want, {ID: 2, SchemaVersion: 3, FullName: "Grace"}}
+			assert.Equal(mt, wantDocs, got, "expected documents %v, got %v", wantDocs, got)
+
+			_ = mt.GetStartedEvent() // find
+			evt := mt.GetStartedEvent()
+			assert.NotNil(mt, evt, "expected an update event")
+			assert.Equal(mt, "update", evt.CommandName, "expected command 'update', got %q", evt.CommandName)
+			update := evt.Command.Lookup("updates").Array().Index(0).Value().Document()
+			filterVersion := update.Lookup("q", "schemaVersion").Int32()
+			assert.Equal(mt, int32(1), filterVersion, "expected filter on schemaVersion 1, got %v", filterVersion)
+			persistedVersion := update.Lookup("u", "schemaVersion").Int32()
+			assert.Equal(mt, int32(3), persistedVersion, "expected persisted schemaVersion 3, got %v", persistedVersion)
+			assert.Nil(mt, mt.GetStartedEvent(), "expected only the outdated document to be persisted")
+		})
+		mt.Run("errors", func(mt *mtest.T) {
+			mc, err := mongo.NewMigratingCollection(mt.Coll, migrations)
+			assert.Nil(mt, err, "NewMigratingCollection error: %v", err)
+
+			testCases := []struct {
+				name string
+				doc  bson.D
+			}{
+				{"version too old", bson.D{{"_id", 1}, {"name", "Ada"}}},
+				{"non-integer version", bson.D{{"_id", 1}, {"schemaVersion", "one"}}},
+			}
+			for _, tc := range testCases {
+				mt.AddMockResponses(mtest.CreateCursorResponse(0, ns, mtest.FirstBatch, tc.doc))
+				err = mc.FindOne(context.Background(), bson.D{}).Err()
+				assert.NotNil(mt, err, "expected FindOne error for %v, got nil", tc.name)
+			}
+
+			mt.AddMockResponses(mtest.CreateCursorResponse(0, ns, mtest.FirstBatch, oldDoc, testCases[0].doc))
+			cursor, err := mc.Find(context.Background(), bson.D{})
+			assert.Nil(mt, err, "Find error: %v", err)
+			assert.True(mt, cursor.Next(context.Background()), "expected the first document to be migrated")
+			assert.False(mt, cursor.Next(context.Background()), "expected iteration to stop at the second document")
+			assert.NotNil(mt, cursor.Err(), "expected cursor error for the second document, got nil")
+
+			_, err = mongo.NewMigratingCollection(mt.Coll, map[int]mongo.MigrateFunc{
+				0: migrations[1],
+				2: migrations[2],
+			})
+			assert.NotNil(mt, err, "expected NewMigratingCollection error for non-contiguous versions, got nil")
+		})
+		mt.Run("projection with persist", func(mt *mtest.T) {
+			mc, err := mongo.NewMigratingCollection(mt.Coll, migrations, options.MigratingCollection().SetPersist(true))
+			assert.Nil(mt, err, "NewMigratingCollection error: %v", err)
+
+			mt.ClearEvents()
+			projection := bson.D{{"name", 1}}
+			err = mc.FindOne(context.Background(), bson.D{}, options.FindOne().SetProjection(projection)).Err()
+			assert.NotNil(mt, err, "expected FindOne error for a projection with persist, got nil")
+			_, err = mc.Find(context.Background(), bson.D{}, options.Find().SetProjection(projection))
+			assert.NotNil(mt, err, "expected Find error for a projection with persist, got nil")
+			assert.Nil(mt, mt.GetStartedEvent(), "expected no commands to be sent")
+		})
+	})
 	mt.RunOpts("insert many", noClientOpts, func(mt *mtest.T) {
 		mt.Run("success", func(mt *mtest.T) {
 			want1 := int32(11)
@@ -803,6 +1155,59 @@ func TestCollection(t *testing.T) {
 				return mt.Coll.Aggregate(context.Background(), mongo.Pipeline{}, options.Aggregate().SetBatchSize(3))
 			})
 		})
+		mt.RunOpts("auto allowDiskUse on memory error", mtest.NewOptions().ClientType(mtest.Mock), func(mt *mtest.T) {
+			ns := mt.Coll.Database().Name() + "." + mt.Coll.Name()
+			memoryErr := mtest.CreateCommandErrorResponse(mtest.CommandError{
+				Code:    292,
+				Name:    "QueryExceededMemoryLimitNoDiskUseAllowed",
+				Message: "Exceeded memory limit for $group, but didn't allow external spilling",
+			})
+			allowDiskUse := func(evt *event.CommandStartedEvent) bool {
+				adu, ok := evt.Command.Lookup("allowDiskUse").BooleanOK()
+				return ok && adu
+			}
+
+			mt.Run("retries with allowDiskUse", func(mt *mtest.T) {
+				mt.AddMockResponses(memoryErr, mtest.CreateCursorResponse(0, ns, mtest.FirstBatch, bson.D{{"x", 1}}))
+				mt.ClearEvents()
+
+				opts := options.Aggregate().SetAutoAllowDiskUseOnMemoryError(true)
+				cursor, err := mt.Coll.Aggregate(context.Background(), mongo.Pipeline{}, opts)
+				assert.Nil(mt, err, "Aggregate error: %v", err)
+				assert.True(mt, cursor.Next(context.Background()), "expected Next true, got false")
+
+				first := mt.GetStartedEvent()
+				assert.Equal(mt, "aggregate", first.CommandName, "expected command 'aggregate', got %q", first.CommandName)
+				assert.False(mt, allowDiskUse(first), "expected first attempt to not set allowDiskUse")
+				second := mt.GetStartedEvent()
+				assert.NotNil(mt, second, "expected a second aggregate attempt")
+				assert.Equal(mt, "aggregate", second.CommandName, "expected command 'aggregate', got %q", second.CommandName)
+				assert.True(mt, allowDiskUse(second), "expected retry to set allowDiskUse to true")
+			})
+			mt.Run("does not retry without the option", func(mt *mtest.T) {
+				mt.AddMockResponses(memoryErr)
+				mt.ClearEvents()
+
+				_, err := mt.Coll.Aggregate(context.Background(), mongo.Pipeline{})
+				cmdErr, ok := err.(mongo.CommandError)
+				assert.True(mt, ok, "expected error type %T, got %T", mongo.CommandError{}, err)
+				assert.Equal(mt, int32(292), cmdErr.Code, "expected error code 292, got %v", cmdErr.Code)
+
+				assert.NotNil(mt, mt.GetStartedEvent(), "expected an aggregate event")
+				assert.Nil(mt, mt.GetStartedEvent(), "expected aggregate to not be retried")
+			})
+			mt.Run("does not retry other errors", func(mt *mtest.T) {
+				mt.AddMockResponses(mtest.CreateCommandErrorResponse(mtest.CommandError{Code: 2, Name: "BadValue", Message: "bad value"}))
+				mt.ClearEvents()
+
+				opts := options.Aggregate().SetAutoAllowDiskUseOnMemoryError(true)
+				_, err := mt.Coll.Aggregate(context.Background(), mongo.Pipeline{}, opts)
+				assert.NotNil(mt, err, "expected Aggregate error, got nil")
+
+				assert.NotNil(mt, mt.GetStartedEvent(), "expected an aggregate event")
+				assert.Nil(mt, mt.GetStartedEvent(), "expected aggregate to not be retried")
+			})
+		})
 		mt.Run("CustomOptions", func(mt *mtest.T) {
 			// Custom options should be a BSON map of option names to Marshalable option values.
 			// We use "allowDiskUse" as an example.
@@ -880,6 +1285,254 @@ func TestCollection(t *testing.T) {
 			})
 		}
 	})
+	mt.RunOpts("validate pipeline", mtest.NewOptions().ClientType(mtest.Mock), func(mt *mtest.T) {
+		mt.Run("valid pipeline", func(mt *mtest.T) {
+			mt.AddMockResponses(mtest.CreateSuccessResponse(bson.E{"queryPlanner", bson.D{}}))
+
+			pipeline := mongo.Pipeline{{{"$match", bson.D{{"x", 1}}}}}
+			err := mt.Coll.ValidatePipeline(context.Background(), pipeline)
+			assert.Nil(mt, err, "ValidatePipeline error: %v", err)
+
+			evt := mt.GetStartedEvent()
+			assert.Equal(mt, "explain", evt.CommandName, "expected command 'explain', got %q", evt.CommandName)
+			verbosity := evt.Command.Lookup("verbosity").StringValue()
+			assert.Equal(mt, "queryPlanner", verbosity, "expected verbosity 'queryPlanner', got %q", verbosity)
+			aggColl := evt.Command.Lookup("explain", "aggregate").StringValue()
+			assert.Equal(mt, mt.Coll.Name(), aggColl, "expected aggregate %q, got %q", mt.Coll.Name(), aggColl)
+			_, err = evt.Command.LookupErr("explain", "pipeline", "0", "$match")
+			assert.Nil(mt, err, "expected $match stage in explained pipeline, got %v", evt.Command)
+		})
+		mt.Run("invalid pipeline", func(mt *mtest.T) {
+			mt.AddMockResponses(mtest.CreateCommandErrorResponse(mtest.CommandError{
+				Code:    40324,
+				Name:    "Location40324",
+				Message: "Unrecognized pipeline stage name: '$bogus'",
+			}))
+
+			pipeline := mongo.Pipeline{{{"$bogus", bson.D{}}}}
+			err := mt.Coll.ValidatePipeline(context.Background(), pipeline)
+			cmdErr, ok := err.(mongo.CommandError)
+			assert.True(mt, ok, "expected error type %T, got %T", mongo.CommandError{}, err)
+			assert.Equal(mt, int32(40324), cmdErr.Code, "expected code 40324, got %v", cmdErr.Code)
+		})
+		mt.Run("malformed pipeline", func(mt *mtest.T) {
+			err := mt.Coll.ValidatePipeline(context.Background(), "not a pipeline")
+			assert.NotNil(mt, err, "expected ValidatePipeline error, got nil")
+		})
+	})
+	mt.RunOpts("shard key", mtest.NewOptions().ClientType(mtest.Mock), func(mt *mtest.T) {
+		mt.Run("sharded", func(mt *mtest.T) {
+			key := bson.D{{"region", int32(1)}, {"_id", "hashed"}}
+			mt.AddMockResponses(mtest.CreateCursorResponse(0, "config.collections", mtest.FirstBatch, bson.D{
+				{"_id", mt.Coll.Database().Name() + "." + mt.Coll.Name()},
+				{"key", key},
+				{"unique", false},
+			}))
+
+			got, err := mt.Coll.ShardKey(context.Background())
+			assert.Nil(mt, err, "ShardKey error: %v", err)
+			assert.Equal(mt, key, got, "expected shard key %v, got %v", key, got)
+
+			evt := mt.GetStartedEvent()
+			assert.Equal(mt, "find", evt.CommandName, "expected command 'find', got %q", evt.CommandName)
+			assert.Equal(mt, "config", evt.DatabaseName, "expected database 'config', got %q", evt.DatabaseName)
+			ns := evt.Command.Lookup("filter", "_id").StringValue()
+			expectedNs := mt.Coll.Database().Name() + "." + mt.Coll.Name()
+			assert.Equal(mt, expectedNs, ns, "expected filter on %q, got %q", expectedNs, ns)
+		})
+		mt.Run("unsharded", func(mt *mtest.T) {
+			mt.AddMockResponses(mtest.CreateCursorResponse(0, "config.collections", mtest.FirstBatch))
+
+			_, err := mt.Coll.ShardKey(context.Background())
+			assert.Equal(mt, mongo.ErrNotSharded, err, "expected error %v, got %v", mongo.ErrNotSharded, err)
+		})
+		mt.Run("dropped", func(mt *mtest.T) {
+			mt.AddMockResponses(mtest.CreateCursorResponse(0, "config.collections", mtest.FirstBatch, bson.D{
+				{"_id", mt.Coll.Database().Name() + "." + mt.Coll.Name()},
+				{"key", bson.D{{"x", int32(1)}}},
+				{"dropped", true},
+			}))
+
+			_, err := mt.Coll.ShardKey(context.Background())
+			assert.Equal(mt, mongo.ErrNotSharded, err, "expected error %v, got %v", mongo.ErrNotSharded, err)
+		})
+	})
+	mt.RunOpts("shard key of sharded cluster", mtest.NewOptions().Topologies(mtest.Sharded), func(mt *mtest.T) {
+		mt.Run("unsharded collection", func(mt *mtest.T) {
+			_, err := mt.Coll.InsertOne(context.Background(), bson.D{{"x", 1}})
+			assert.Nil(mt, err, "InsertOne error: %v", err)
+
+			_, err = mt.Coll.ShardKey(context.Background())
+			assert.Equal(mt, mongo.ErrNotSharded, err, "expected error %v, got %v", mongo.ErrNotSharded, err)
+		})
+		mt.Run("sharded collection", func(mt *mtest.T) {
+			admin := mt.Client.Database("admin")
+			// enableSharding is not required on newer servers, so its error is ignored.
+			_ = admin.RunCommand(context.Background(), bson.D{{"enableSharding", mt.DB.Name()}}).Err()
+			ns := mt.DB.Name() + "." + mt.Coll.Name()
+			key := bson.D{{"x", int32(1)}}
+			err := admin.RunCommand(context.Background(), bson.D{{"shardCollection", ns}, {"key", key}}).Err()
+			assert.Nil(mt, err, "shardCollection error: %v", err)
+
+			got, err := mt.Coll.ShardKey(context.Background())
+			assert.Nil(mt, err, "ShardKey error: %v", err)
+			assert.Equal(mt, key, got, "expected shard key %v, got %v", key, got)
+		})
+	})
+	mt.RunOpts("created at", mtest.NewOptions().ClientType(mtest.Mock), func(mt *mtest.T) {
+		created := time.Date(2021, time.March, 4, 5, 6, 7, 0, time.UTC)
+		ns := func(mt *mtest.T) string {
+			return mt.Coll.Database().Name() + "." + mt.Coll.Name()
+		}
+
+		mt.Run("sharded collection timestamp", func(mt *mtest.T) {
+			mt.AddMockResponses(mtest.CreateCursorResponse(0, "config.collections", mtest.FirstBatch, bson.D{
+				{"_id", ns(mt)},
+				{"lastmodEpoch", primitive.NewObjectIDFromTimestamp(created.Add(time.Hour))},
+				{"timestamp", primitive.NewTimestampFromTime(created, 1)},
+			}))
+
+			got, ok, err := mt.Coll.CreatedAt(context.Background())
+			assert.Nil(mt, err, "CreatedAt error: %v", err)
+			assert.True(mt, ok, "expected creation time to be available")
+			assert.True(mt, created.Equal(got), "expected creation time %v, got %v", created, got)
+		})
+		mt.Run("sharded collection lastmodEpoch", func(mt *mtest.T) {
+			mt.AddMockResponses(mtest.CreateCursorResponse(0, "config.collections", mtest.FirstBatch, bson.D{
+				{"_id", ns(mt)},
+				{"lastmodEpoch", primitive.NewObjectIDFromTimestamp(created)},
+			}))
+
+			got, ok, err := mt.Coll.CreatedAt(context.Background())
+			assert.Nil(mt, err, "CreatedAt error: %v", err)
+			assert.True(mt, ok, "expected creation time to be available")
+			assert.True(mt, created.Equal(got), "expected creation time %v, got %v", created, got)
+		})
+		mt.Run("oplog", func(mt *mtest.T) {
+			mt.AddMockResponses(
+				mtest.CreateCursorResponse(0, "config.collections", mtest.FirstBatch),
+				mtest.CreateCursorResponse(0, "local.oplog.rs", mtest.FirstBatch, bson.D{
+					{"ts", primitive.NewTimestampFromTime(created, 1)},
+					{"op", "c"},
+					{"ns", mt.Coll.Database().Name() + ".$cmd"},
+					{"o", bson.D{{"create", mt.Coll.Name()}}},
+				}),
+			)
+
+			mt.ClearEvents()
+			opts := options.CreatedAt().SetOplogMaxTime(time.Second)
+			got, ok, err := mt.Coll.CreatedAt(context.Background(), opts)
+			assert.Nil(mt, err, "CreatedAt error: %v", err)
+			assert.True(mt, ok, "expected creation time to be available")
+			assert.True(mt, created.Equal(got), "expected creation time %v, got %v", created, got)
+
+			_ = mt.GetStartedEvent()
+			evt := mt.GetStartedEvent()
+			assert.Equal(mt, "local", evt.DatabaseName, "expected database 'local', got %q", evt.DatabaseName)
+			create := evt.Command.Lookup("filter", "o.create").StringValue()
+			assert.Equal(mt, mt.Coll.Name(), create, "expected filter on %q, got %q", mt.Coll.Name(), create)
+			maxTimeMS := evt.Command.Lookup("maxTimeMS").Int64()
+			assert.Equal(mt, int64(1000), maxTimeMS, "expected maxTimeMS 1000, got %v", maxTimeMS)
+		})
+		mt.Run("oplog not searched by default", func(mt *mtest.T) {
+			mt.AddMockResponses(mtest.CreateCursorResponse(0, "config.collections", mtest.FirstBatch))
+
+			mt.ClearEvents()
+			_, ok, err := mt.Coll.CreatedAt(context.Background())
+			assert.Nil(mt, err, "CreatedAt error: %v", err)
+			assert.False(mt, ok, "expected creation time to be unavailable")
+
+			_ = mt.GetStartedEvent()
+			assert.Nil(mt, mt.GetStartedEvent(), "expected the oplog not to be searched")
+		})
+		mt.Run("unavailable", func(mt *mtest.T) {
+			mt.AddMockResponses(
+				mtest.CreateCursorResponse(0, "config.collections", mtest.FirstBatch),
+				mtest.CreateCommandErrorResponse(mtest.CommandError{
+					Code:    13,
+					Name:    "Unauthorized",
+					Message: "not authorized on local to execute command",
+				}),
+			)
+
+			opts := options.CreatedAt().SetOplogMaxTime(time.Second)
+			_, ok, err := mt.Coll.CreatedAt(context.Background(), opts)
+			assert.Nil(mt, err, "CreatedAt error: %v", err)
+			assert.False(mt, ok, "expected creation time to be unavailable")
+		})
+	})
+	mt.RunOpts("created at of new collection", mtest.NewOptions().Topologies(mtest.ReplicaSet), func(mt *mtest.T) {
+		before := time.Now().Add(-time.Second)
+		coll := mt.CreateCollection(mtest.Collection{Name: "createdAtColl"}, true)
+		after := time.Now().Add(time.Second)
+
+		got, ok, err := coll.CreatedAt(context.Background(), options.CreatedAt().SetOplogMaxTime(10*time.Second))
+		assert.Nil(mt, err, "CreatedAt error: %v", err)
+		assert.True(mt, ok, "expected creation time to be available")
+		assert.True(mt, !got.Before(before) && !got.After(after), "expected creation time between %v and %v, got %v",
+			before, after, got)
+	})
+	mt.RunOpts("stats", mtest.NewOptions().ClientType(mtest.Mock), func(mt *mtest.T) {
+		mt.Run("single shard", func(mt *mtest.T) {
+			storageStats := bson.D{
+				{"size", int32(2000)},
+				{"count", int32(40)},
+				{"avgObjSize", int32(50)},
+				{"storageSize", int64(8192)},
+				{"totalIndexSize", int64(4096)},
+			}
+			mt.AddMockResponses(mtest.CreateCursorResponse(0, "foo.bar", mtest.FirstBatch, bson.D{
+				{"ns", "foo.bar"},
+				{"storageStats", storageStats},
+			}))
+
+			stats, err := mt.Coll.Stats(context.Background())
+			assert.Nil(mt, err, "Stats error: %v", err)
+			expected := mongo.CollStats{
+				Size:           2000,
+				StorageSize:    8192,
+				TotalIndexSize: 4096,
+				Count:          40,
+				AvgObjSize:     50,
+			}
+			assert.Equal(mt, expected, stats, "expected stats %v, got %v", expected, stats)
+
+			evt := mt.GetStartedEvent()
+			assert.Equal(mt, "aggregate", evt.CommandName, "expected command 'aggregate', got %q", evt.CommandName)
+			stage := evt.Command.Lookup("pipeline", "0").Document()
+			_, err = stage.LookupErr("$collStats", "storageStats")
+			assert.Nil(mt, err, "expected $collStats stage with storageStats, got %v", stage)
+		})
+		mt.Run("multiple shards", func(mt *mtest.T) {
+			shardStats := func(size, count int64) bson.D {
+				return bson.D{{"storageStats", bson.D{
+					{"size", size},
+					{"count", count},
+					{"storageSize", size * 2},
+					{"totalIndexSize", size / 2},
+				}}}
+			}
+			mt.AddMockResponses(mtest.CreateCursorResponse(0, "foo.bar", mtest.FirstBatch,
+				shardStats(1000, 10), shardStats(3000, 30)))
+
+			stats, err := mt.Coll.Stats(context.Background())
+			assert.Nil(mt, err, "Stats error: %v", err)
+			expected := mongo.CollStats{
+				Size:           4000,
+				StorageSize:    8000,
+				TotalIndexSize: 2000,
+				Count:          40,
+				AvgObjSize:     100,
+			}
+			assert.Equal(mt, expected, stats, "expected stats %v, got %v", expected, stats)
+		})
+		mt.Run("no results", func(mt *mtest.T) {
+			mt.AddMockResponses(mtest.CreateCursorResponse(0, "foo.bar", mtest.FirstBatch))
+
+			_, err := mt.Coll.Stats(context.Background())
+			assert.Equal(mt, mongo.ErrNoDocuments, err, "expected error %v, got %v", mongo.ErrNoDocuments, err)
+		})
+	})
 	mt.RunOpts("distinct", noClientOpts, func(mt *mtest.T) {
 		all := []interface{}{int32(1), int32(2), int32(3), int32(4), int32(5)}
 		last3 := []interface{}{int32(3), int32(4), int32(5)}
@@ -1868,6 +2521,426 @@ func TestCollection(t *testing.T) {
 			}
 		})
 	})
+	mt.RunOpts("bulk update map", mtest.NewOptions().ClientType(mtest.Mock), func(mt *mtest.T) {
+		mt.Run("success", func(mt *mtest.T) {
+			mt.AddMockResponses(mtest.CreateSuccessResponse(bson.E{"n", 2}, bson.E{"nModified", 2}))
+
+			updates := map[interface{}]bson.M{
+				int32(1): {"$set": bson.M{"x": 1}},
+				int32(2): {"$inc": bson.M{"y": 1}},
+			}
+			res, err := mt.Coll.BulkUpdateMap(context.Background(), updates)
+			assert.Nil(mt, err, "BulkUpdateMap error: %v", err)
+			assert.Equal(mt, int64(2), res.MatchedCount, "expected matched count 2, got %v", res.MatchedCount)
+			assert.Equal(mt, int64(2), res.ModifiedCount, "expected modified count 2, got %v", res.ModifiedCount)
+
+			evt := mt.GetStartedEvent()
+			assert.Equal(mt, "update", evt.CommandName, "expected command 'update', got %q", evt.CommandName)
+			ordered := evt.Command.Lookup("ordered").Boolean()
+			assert.False(mt, ordered, "expected ordered to be false")
+
+			stmts, err := evt.Command.Lookup("updates").Array().Values()
+			assert.Nil(mt, err, "Values error: %v", err)
+			assert.Equal(mt, len(updates), len(stmts), "expected %v update statements, got %v", len(updates), len(stmts))
+			for _, stmt := range stmts {
+				doc := stmt.Document()
+				id := doc.Lookup("q", "_id").Int32()
+				expected, err := bson.Marshal(updates[id])
+				assert.Nil(mt, err, "Marshal error: %v", err)
+				got := doc.Lookup("u").Document()
+				assert.Equal(mt, bson.Raw(expected), got, "expected update %v for _id %v, got %v", bson.Raw(expected), id, got)
+				multi, ok := doc.Lookup("multi").BooleanOK()
+				assert.False(mt, ok && multi, "expected multi to be false for _id %v", id)
+			}
+		})
+		mt.Run("empty map", func(mt *mtest.T) {
+			_, err := mt.Coll.BulkUpdateMap(context.Background(), nil)
+			assert.Equal(mt, mongo.ErrEmptySlice, err, "expected error %v, got %v", mongo.ErrEmptySlice, err)
+		})
+		mt.Run("nil update", func(mt *mtest.T) {
+			_, err := mt.Coll.BulkUpdateMap(context.Background(), map[interface{}]bson.M{int32(1): nil})
+			assert.Equal(mt, mongo.ErrNilDocument, err, "expected error %v, got %v", mongo.ErrNilDocument, err)
+		})
+	})
+	mt.RunOpts("bulk write dry run", noClientOpts, func(mt *mtest.T) {
+		docs := []interface{}{
+			bson.D{{"_id", int32(1)}, {"x", int32(1)}},
+			bson.D{{"_id", int32(2)}, {"x", int32(1)}},
+			bson.D{{"_id", int32(3)}, {"x", int32(2)}},
+		}
+		_, err := mt.Coll.InsertMany(context.Background(), docs)
+		assert.Nil(mt, err, "InsertMany error: %v", err)
+
+		models := []mongo.WriteModel{
+			mongo.NewInsertOneModel().SetDocument(bson.D{{"_id", int32(4)}}),
+			mongo.NewUpdateManyModel().SetFilter(bson.D{{"x", int32(1)}}).SetUpdate(bson.D{{"$inc", bson.D{{"x", 1}}}}),
+			mongo.NewUpdateOneModel().SetFilter(bson.D{{"x", int32(1)}}).SetUpdate(bson.D{{"$set", bson.D{{"y", 1}}}}),
+			mongo.NewReplaceOneModel().SetFilter(bson.D{{"x", int32(5)}}).SetReplacement(bson.D{{"x", 6}}).SetUpsert(true),
+			mongo.NewDeleteOneModel().SetFilter(bson.D{{"x", int32(2)}}),
+			mongo.NewDeleteManyModel().SetFilter(bson.D{}),
+		}
+		res, err := mt.Coll.BulkWriteDryRun(context.Background(), models)
+		assert.Nil(mt, err, "BulkWriteDryRun error: %v", err)
+		expected := &mongo.BulkWriteDryRunResult{
+			InsertedCount:      1,
+			MatchedCount:       3,
+			ModifiedCount:      3,
+			DeletedCount:       4,
+			UpsertedCount:      1,
+			ModelMatchedCounts: []int64{0, 2, 1, 0, 1, 3},
+		}
+		assert.Equal(mt, expected, res, "expected result %+v, got %+v", expected, res)
+
+		// The collection must not have been modified.
+		count, err := mt.Coll.CountDocuments(context.Background(), bson.D{{"x", int32(1)}})
+		assert.Nil(mt, err, "CountDocuments error: %v", err)
+		assert.Equal(mt, int64(2), count, "expected 2 documents with x=1, got %v", count)
+		count, err = mt.Coll.CountDocuments(context.Background(), bson.D{})
+		assert.Nil(mt, err, "CountDocuments error: %v", err)
+		assert.Equal(mt, int64(3), count, "expected 3 documents, got %v", count)
+	})
+	mt.RunOpts("bulk write dry run commands", mtest.NewOptions().ClientType(mtest.Mock), func(mt *mtest.T) {
+		mt.Run("only counts are run", func(mt *mtest.T) {
+			ns := mt.Coll.Database().Name() + "." + mt.Coll.Name()
+			mt.AddMockResponses(
+				mtest.CreateCursorResponse(0, ns, mtest.FirstBatch, bson.D{{"n", int32(5)}}),
+				mtest.CreateCursorResponse(0, ns, mtest.FirstBatch, bson.D{{"n", int32(1)}}),
+			)
+
+			models := []mongo.WriteModel{
+				mongo.NewUpdateManyModel().SetFilter(bson.D{{"x", 1}}).SetUpdate(bson.D{{"$set", bson.D{{"y", 1}}}}),
+				mongo.NewDeleteOneModel().SetFilter(bson.D{{"x", 2}}),
+			}
+			res, err := mt.Coll.BulkWriteDryRun(context.Background(), models)
+			assert.Nil(mt, err, "BulkWriteDryRun error: %v", err)
+			assert.Equal(mt, int64(5), res.MatchedCount, "expected matched count 5, got %v", res.MatchedCount)
+			assert.Equal(mt, int64(5), res.ModifiedCount, "expected modified count 5, got %v", res.ModifiedCount)
+			assert.Equal(mt, int64(1), res.DeletedCount, "expected deleted count 1, got %v", res.DeletedCount)
+
+			for i := 0; i < len(models); i++ {
+				evt := mt.GetStartedEvent()
+				assert.Equal(mt, "aggregate", evt.CommandName, "expected command 'aggregate', got %q", evt.CommandName)
+			}
+			evt := mt.GetStartedEvent()
+			assert.Nil(mt, evt, "expected no more commands, got %v", evt)
+		})
+		mt.Run("empty models", func(mt *mtest.T) {
+			_, err := mt.Coll.BulkWriteDryRun(context.Background(), nil)
+			assert.Equal(mt, mongo.ErrEmptySlice, err, "expected error %v, got %v", mongo.ErrEmptySlice, err)
+		})
+		mt.Run("nil filter", func(mt *mtest.T) {
+			models := []mongo.WriteModel{mongo.NewDeleteManyModel()}
+			_, err := mt.Coll.BulkWriteDryRun(context.Background(), models)
+			assert.Equal(mt, mongo.ErrNilDocument, err, "expected error %v, got %v", mongo.ErrNilDocument, err)
+		})
+	})
+	mt.RunOpts("keyset paginate", mtest.NewOptions().ClientType(mtest.Mock), func(mt *mtest.T) {
+		assertFindFilter := func(mt *mtest.T, expected bson.D) {
+			mt.Helper()
+
+			evt := mt.GetStartedEvent()
+			assert.Equal(mt, "find", evt.CommandName, "expected command 'find', got %q", evt.CommandName)
+			expectedFilter, err := bson.Marshal(expected)
+			assert.Nil(mt, err, "Marshal error: %v", err)
+			filter := evt.Command.Lookup("filter").Document()
+			assert.Equal(mt, bson.Raw(expectedFilter), filter, "expected filter %v, got %v", bson.Raw(expectedFilter), filter)
+			limit := evt.Command.Lookup("limit").Int64()
+			assert.Equal(mt, int64(2), limit, "expected limit 2, got %v", limit)
+		}
+
+		mt.Run("pages with ties", func(mt *mtest.T) {
+			ns := mt.Coll.Database().Name() + "." + mt.Coll.Name()
+			mt.AddMockResponses(mtest.CreateCursorResponse(0, ns, mtest.FirstBatch,
+				bson.D{{"_id", int32(1)}, {"score", int32(10)}},
+				bson.D{{"_id", int32(2)}, {"score", int32(20)}},
+			))
+			page, next, err := mongo.KeysetPaginate(context.Background(), mt.Coll, "score", nil, 2)
+			assert.Nil(mt, err, "KeysetPaginate error: %v", err)
+			assert.Equal(mt, 2, len(page), "expected 2 documents, got %v", len(page))
+			assertFindFilter(mt, bson.D{})
+
+			// The second page starts with another document that has score 20, which must not be skipped.
+			mt.AddMockResponses(mtest.CreateCursorResponse(0, ns, mtest.FirstBatch,
+				bson.D{{"_id", int32(3)}, {"score", int32(20)}},
+				bson.D{{"_id", int32(4)}, {"score", int32(30)}},
+			))
+			page, next, err = mongo.KeysetPaginate(context.Background(), mt.Coll, "score", next, 2)
+			assert.Nil(mt, err, "KeysetPaginate error: %v", err)
+			assert.Equal(mt, 2, len(page), "expected 2 documents, got %v", len(page))
+			assertFindFilter(mt, bson.D{{"$or", bson.A{
+				bson.D{{"score", bson.D{{"$gt", int32(20)}}}},
+				bson.D{{"score", int32(20)}, {"_id", bson.D{{"$gt", int32(2)}}}},
+			}}})
+
+			cursor, ok := next.(mongo.KeysetCursor)
+			assert.True(mt, ok, "expected cursor value of type mongo.KeysetCursor, got %T", next)
+			assert.Equal(mt, int32(30), cursor.Value.Int32(), "expected cursor value 30, got %v", cursor.Value)
+			assert.Equal(mt, int32(4), cursor.ID.Int32(), "expected cursor _id 4, got %v", cursor.ID)
+
+			mt.AddMockResponses(mtest.CreateCursorResponse(0, ns, mtest.FirstBatch))
+			page, last, err := mongo.KeysetPaginate(context.Background(), mt.Coll, "score", next, 2)
+			assert.Nil(mt, err, "KeysetPaginate error: %v", err)
+			assert.Equal(mt, 0, len(page), "expected 0 documents, got %v", len(page))
+			assert.Equal(mt, next, last, "expected cursor value %v, got %v", next, last)
+		})
+		mt.Run("plain last value", func(mt *mtest.T) {
+			ns := mt.Coll.Database().Name() + "." + mt.Coll.Name()
+			mt.AddMockResponses(mtest.CreateCursorResponse(0, ns, mtest.FirstBatch))
+			_, _, err := mongo.KeysetPaginate(context.Background(), mt.Coll, "score", int32(50), 2)
+			assert.Nil(mt, err, "KeysetPaginate error: %v", err)
+			assertFindFilter(mt, bson.D{{"score", bson.D{{"$gt", int32(50)}}}})
+
+			evt := mt.GetStartedEvent()
+			assert.Nil(mt, evt, "expected no more events, got %v", evt)
+		})
+		mt.Run("invalid arguments", func(mt *mtest.T) {
+			_, _, err := mongo.KeysetPaginate(context.Background(), mt.Coll, "", nil, 2)
+			assert.NotNil(mt, err, "expected error for empty sort field, got nil")
+			_, _, err = mongo.KeysetPaginate(context.Background(), mt.Coll, "score", nil, 0)
+			assert.NotNil(mt, err, "expected error for non-positive page size, got nil")
+		})
+	})
+	mt.RunOpts("insert if absent", mtest.NewOptions().ClientType(mtest.Mock), func(mt *mtest.T) {
+		mt.Run("inserts once", func(mt *mtest.T) {
+			filter := bson.D{{"name", "alice"}}
+			doc := bson.D{{"name", "alice"}, {"createdBy", "test"}}
+			upserted := mtest.CreateSuccessResponse(
+				bson.E{"n", 1},
+				bson.E{"nModified", 0},
+				bson.E{"upserted", bson.A{bson.D{{"index", 0}, {"_id", "alice-id"}}}},
+			)
+			matched := mtest.CreateSuccessResponse(bson.E{"n", 1}, bson.E{"nModified", 0})
+			mt.AddMockResponses(upserted, matched, matched)
+
+			for i, expected := range []bool{true, false, false} {
+				mt.ClearEvents()
+				inserted, err := mt.Coll.InsertIfAbsent(context.Background(), filter, doc)
+				assert.Nil(mt, err, "InsertIfAbsent error on call %d: %v", i, err)
+				assert.Equal(mt, expected, inserted, "expected inserted %v on call %d, got %v", expected, i, inserted)
+
+				evt := mt.GetStartedEvent()
+				assert.Equal(mt, "update", evt.CommandName, "expected command 'update', got %q", evt.CommandName)
+				stmt := evt.Command.Lookup("updates", "0").Document()
+				upsert := stmt.Lookup("upsert").Boolean()
+				assert.True(mt, upsert, "expected 'upsert' to be true")
+				setOnInsert, err := stmt.LookupErr("u", "$setOnInsert")
+				assert.Nil(mt, err, "expected '$setOnInsert' in update, got %v", stmt)
+				name := setOnInsert.Document().Lookup("name").StringValue()
+				assert.Equal(mt, "alice", name, "expected name 'alice', got %q", name)
+			}
+		})
+		mt.Run("nil document", func(mt *mtest.T) {
+			_, err := mt.Coll.InsertIfAbsent(context.Background(), bson.D{}, nil)
+			assert.Equal(mt, mongo.ErrNilDocument, err, "expected error %v, got %v", mongo.ErrNilDocument, err)
+		})
+	})
+	mt.RunOpts("comment", noClientOpts, func(mt *mtest.T) {
+		_, err := mt.Coll.InsertOne(context.Background(), bson.D{{"x", 1}})
+		assert.Nil(mt, err, "InsertOne error: %v", err)
+
+		comment, err := mt.Coll.GetComment(context.Background())
+		assert.Nil(mt, err, "GetComment error: %v", err)
+		assert.Equal(mt, "", comment, "expected no comment, got %q", comment)
+
+		validator := bson.D{{"$comment", "orders placed through the web shop"}, {"x", bson.D{{"$type", "number"}}}}
+		collMod := bson.D{{"collMod", mt.Coll.Name()}, {"validator", validator}}
+		err = mt.DB.RunCommand(context.Background(), collMod).Err()
+		assert.Nil(mt, err, "collMod error: %v", err)
+		comment, err = mt.Coll.GetComment(context.Background())
+		assert.Nil(mt, err, "GetComment error: %v", err)
+		assert.Equal(mt, "orders placed through the web shop", comment,
+			"expected comment 'orders placed through the web shop', got %q", comment)
+	})
+	mt.RunOpts("comment mock", mtest.NewOptions().ClientType(mtest.Mock), func(mt *mtest.T) {
+		listResponse := func(mt *mtest.T, options bson.D) bson.D {
+			return mtest.CreateCursorResponse(0, mt.Coll.Database().Name()+".$cmd.listCollections", mtest.FirstBatch,
+				bson.D{
+					{"name", mt.Coll.Name()},
+					{"type", "collection"},
+					{"options", options},
+				})
+		}
+
+		mt.Run("read back", func(mt *mtest.T) {
+			validator := bson.D{{"$comment", "inventory"}, {"qty", bson.D{{"$gte", 0}}}}
+			mt.AddMockResponses(listResponse(mt, bson.D{{"validator", validator}}))
+
+			comment, err := mt.Coll.GetComment(context.Background())
+			assert.Nil(mt, err, "GetComment error: %v", err)
+			assert.Equal(mt, "inventory", comment, "expected comment 'inventory', got %q", comment)
+		})
+		mt.Run("no validator", func(mt *mtest.T) {
+			mt.AddMockResponses(listResponse(mt, bson.D{}))
+
+			comment, err := mt.Coll.GetComment(context.Background())
+			assert.Nil(mt, err, "GetComment error: %v", err)
+			assert.Equal(mt, "", comment, "expected no comment, got %q", comment)
+		})
+		mt.Run("missing collection", func(mt *mtest.T) {
+			mt.AddMockResponses(mtest.CreateCursorResponse(0, mt.Coll.Database().Name()+".$cmd.listCollections",
+				mtest.FirstBatch))
+
+			_, err := mt.Coll.GetComment(context.Background())
+			assert.NotNil(mt, err, "expected GetComment error, got nil")
+		})
+	})
+	mt.RunOpts("upsert with timestamps", mtest.NewOptions().ClientType(mtest.Mock), func(mt *mtest.T) {
+		mt.Run("sets createdAt only on insert", func(mt *mtest.T) {
+			filter := bson.D{{"name", "alice"}}
+			update := bson.D{{"$set", bson.D{{"visits", 1}}}}
+			upserted := mtest.CreateSuccessResponse(
+				bson.E{"n", 1},
+				bson.E{"nModified", 0},
+				bson.E{"upserted", bson.A{bson.D{{"index", 0}, {"_id", "alice-id"}}}},
+			)
+			modified := mtest.CreateSuccessResponse(bson.E{"n", 1}, bson.E{"nModified", 1})
+			mt.AddMockResponses(upserted, modified)
+
+			for i, inserted := range []bool{true, false} {
+				mt.ClearEvents()
+				res, err := mt.Coll.UpsertWithTimestamps(context.Background(), filter, update, "createdAt", "updatedAt")
+				assert.Nil(mt, err, "UpsertWithTimestamps error on call %d: %v", i, err)
+				assert.Equal(mt, inserted, res.UpsertedID != nil, "expected inserted %v on call %d, got upserted ID %v",
+					inserted, i, res.UpsertedID)
+
+				evt := mt.GetStartedEvent()
+				assert.Equal(mt, "update", evt.CommandName, "expected command 'update', got %q", evt.CommandName)
+				stmt := evt.Command.Lookup("updates", "0").Document()
+				upsert := stmt.Lookup("upsert").Boolean()
+				assert.True(mt, upsert, "expected 'upsert' to be true")
+				visits := stmt.Lookup("u", "$set", "visits").Int32()
+				assert.Equal(mt, int32(1), visits, "expected visits 1, got %v", visits)
+				// updatedAt is set by the server on every update, createdAt only when the upsert inserts a document.
+				currentDate, err := stmt.LookupErr("u", "$currentDate", "updatedAt")
+				assert.Nil(mt, err, "expected 'updatedAt' in $currentDate, got %v", stmt)
+				assert.True(mt, currentDate.Boolean(), "expected $currentDate updatedAt to be true")
+				createdAt, err := stmt.LookupErr("u", "$setOnInsert", "createdAt")
+				assert.Nil(mt, err, "expected 'createdAt' in $setOnInsert, got %v", stmt)
+				assert.Equal(mt, bson.TypeDateTime, createdAt.Type, "expected createdAt type %v, got %v",
+					bson.TypeDateTime, createdAt.Type)
+				_, err = stmt.LookupErr("u", "$set", "createdAt")
+				assert.NotNil(mt, err, "expected createdAt not to be in $set, got %v", stmt)
+			}
+		})
+	})
+	mt.RunOpts("existing ids", mtest.NewOptions().ClientType(mtest.Mock), func(mt *mtest.T) {
+		ns := mt.DB.Name() + "." + mt.Coll.Name()
+
+		mt.Run("reports present and missing ids", func(mt *mtest.T) {
+			oid := primitive.NewObjectID()
+			mt.AddMockResponses(mtest.CreateCursorResponse(0, ns, mtest.FirstBatch,
+				bson.D{{"_id", oid}},
+				bson.D{{"_id", "b"}},
+				bson.D{{"_id", int32(3)}},
+			))
+
+			ids := []interface{}{oid, "b", int32(3), "missing", int32(4)}
+			present, err := mt.Coll.ExistingIDs(context.Background(), ids)
+			assert.Nil(mt, err, "ExistingIDs error: %v", err)
+			expected := map[interface{}]bool{oid: true, "b": true, int32(3): true, "missing": false, int32(4): false}
+			assert.Equal(mt, expected, present, "expected presence %v, got %v", expected, present)
+
+			evt := mt.GetStartedEvent()
+			assert.Equal(mt, "find", evt.CommandName, "expected command 'find', got %q", evt.CommandName)
+			in, err := evt.Command.Lookup("filter", "_id", "$in").Array().Values()
+			assert.Nil(mt, err, "Values error: %v", err)
+			assert.Equal(mt, len(ids), len(in), "expected %d values in $in, got %d", len(ids), len(in))
+			projection := evt.Command.Lookup("projection")
+			expectedProjection := bson.Raw(bsoncore.NewDocumentBuilder().AppendInt32("_id", 1).Build())
+			assert.Equal(mt, expectedProjection, projection.Document(), "expected projection %v, got %v",
+				expectedProjection, projection.Document())
+		})
+		mt.Run("matches numeric ids by value", func(mt *mtest.T) {
+			mt.AddMockResponses(mtest.CreateCursorResponse(0, ns, mtest.FirstBatch, bson.D{{"_id", int32(3)}}))
+
+			present, err := mt.Coll.ExistingIDs(context.Background(), []interface{}{int64(3), 3.0, 3.5})
+			assert.Nil(mt, err, "ExistingIDs error: %v", err)
+			expected := map[interface{}]bool{int64(3): true, 3.0: true, 3.5: false}
+			assert.Equal(mt, expected, present, "expected presence %v, got %v", expected, present)
+		})
+		mt.Run("queries large sets in chunks", func(mt *mtest.T) {
+			mt.AddMockResponses(
+				mtest.CreateCursorResponse(0, ns, mtest.FirstBatch, bson.D{{"_id", int32(0)}}),
+				mtest.CreateCursorResponse(0, ns, mtest.FirstBatch, bson.D{{"_id", int32(1200)}}),
+			)
+
+			ids := make([]interface{}, 1500)
+			for i := range ids {
+				ids[i] = int32(i)
+			}
+			present, err := mt.Coll.ExistingIDs(context.Background(), ids)
+			assert.Nil(mt, err, "ExistingIDs error: %v", err)
+			assert.Equal(mt, len(ids), len(present), "expected %d entries, got %d", len(ids), len(present))
+			for id, ok := range present {
+				expected := id == int32(0) || id == int32(1200)
+				assert.Equal(mt, expected, ok, "expected presence %v for id %v, got %v", expected, id, ok)
+			}
+
+			for _, expected := range []int{1000, 500} {
+				evt := mt.GetStartedEvent()
+				assert.Equal(mt, "find", evt.CommandName, "expected command 'find', got %q", evt.CommandName)
+				in, err := evt.Command.Lookup("filter", "_id", "$in").Array().Values()
+				assert.Nil(mt, err, "Values error: %v", err)
+				assert.Equal(mt, expected, len(in), "expected %d values in $in, got %d", expected, len(in))
+			}
+		})
+		mt.Run("empty ids", func(mt *mtest.T) {
+			_, err := mt.Coll.ExistingIDs(context.Background(), nil)
+			assert.Equal(mt, mongo.ErrEmptySlice, err, "expected error %v, got %v", mongo.ErrEmptySlice, err)
+		})
+	})
+	mt.RunOpts("increment and get", mtest.NewOptions().ClientType(mtest.Mock), func(mt *mtest.T) {
+		mt.Run("existing document", func(mt *mtest.T) {
+			mt.AddMockResponses(mtest.CreateSuccessResponse(
+				bson.E{"lastErrorObject", bson.D{{"n", 1}, {"updatedExisting", true}}},
+				bson.E{"value", bson.D{{"_id", "views"}, {"count", int64(42)}}},
+			))
+			n, err := mt.Coll.IncrementAndGet(context.Background(), bson.D{{"_id", "views"}}, "count", 2)
+			assert.Nil(mt, err, "IncrementAndGet error: %v", err)
+			assert.Equal(mt, int64(42), n, "expected value 42, got %v", n)
+
+			evt := mt.GetStartedEvent()
+			assert.Equal(mt, "findAndModify", evt.CommandName, "expected command 'findAndModify', got %q", evt.CommandName)
+			expectedUpdate, err := bson.Marshal(bson.D{{"$inc", bson.D{{"count", int64(2)}}}})
+			assert.Nil(mt, err, "Marshal error: %v", err)
+			update := evt.Command.Lookup("update").Document()
+			assert.Equal(mt, bson.Raw(expectedUpdate), update, "expected update %v, got %v", bson.Raw(expectedUpdate), update)
+			newDoc := evt.Command.Lookup("new").Boolean()
+			assert.True(mt, newDoc, "expected 'new' to be true")
+			_, err = evt.Command.LookupErr("upsert")
+			assert.NotNil(mt, err, "expected 'upsert' not to be set")
+		})
+		mt.Run("upsert", func(mt *mtest.T) {
+			mt.AddMockResponses(mtest.CreateSuccessResponse(
+				bson.E{"lastErrorObject", bson.D{{"n", 1}, {"updatedExisting", false}, {"upserted", "visits"}}},
+				bson.E{"value", bson.D{{"_id", "visits"}, {"count", int32(1)}}},
+			))
+			opts := options.FindOneAndUpdate().SetUpsert(true)
+			n, err := mt.Coll.IncrementAndGet(context.Background(), bson.D{{"_id", "visits"}}, "count", 1, opts)
+			assert.Nil(mt, err, "IncrementAndGet error: %v", err)
+			assert.Equal(mt, int64(1), n, "expected value 1, got %v", n)
+
+			evt := mt.GetStartedEvent()
+			upsert := evt.Command.Lookup("upsert").Boolean()
+			assert.True(mt, upsert, "expected 'upsert' to be true")
+		})
+		mt.Run("no matching document", func(mt *mtest.T) {
+			mt.AddMockResponses(mtest.CreateSuccessResponse(
+				bson.E{"lastErrorObject", bson.D{{"n", 0}, {"updatedExisting", false}}},
+				bson.E{"value", nil},
+			))
+			_, err := mt.Coll.IncrementAndGet(context.Background(), bson.D{{"_id", "missing"}}, "count", 1)
+			assert.Equal(mt, mongo.ErrNoDocuments, err, "expected error %v, got %v", mongo.ErrNoDocuments, err)
+		})
+		mt.Run("non-numeric field", func(mt *mtest.T) {
+			mt.AddMockResponses(mtest.CreateSuccessResponse(
+				bson.E{"lastErrorObject", bson.D{{"n", 1}, {"updatedExisting", true}}},
+				bson.E{"value", bson.D{{"_id", "views"}, {"count", "many"}}},
+			))
+			_, err := mt.Coll.IncrementAndGet(context.Background(), bson.D{{"_id", "views"}}, "count", 1)
+			assert.NotNil(mt, err, "expected IncrementAndGet error, got nil")
+		})
+	})
 }
 
 func initCollection(mt *mtest.T, coll *mongo.Collection) {
@@ -11,6 +11,7 @@ import (
 	"errors"
 	"fmt"
 	"reflect"
+	"strings"
 	"testing"
 
 	"go.mongodb.org/mongo-driver/bson"
@@ -19,6 +20,7 @@ import (
 	"go.mongodb.org/mongo-driver/internal"
 	"go.mongodb.org/mongo-driver/internal/testutil/assert"
 	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/address"
 	"go.mongodb.org/mongo-driver/mongo/integration/mtest"
 	"go.mongodb.org/mongo-driver/mongo/options"
 	"go.mongodb.org/mongo-driver/mongo/readpref"
@@ -122,6 +124,21 @@ func TestDatabase(t *testing.T) {
 			assert.Equal(mt, mongo.ErrMapForOrderedArgument{"cmd"}, err, "expected error %v, got %v", mongo.ErrMapForOrderedArgument{"cmd"}, err)
 		})
 	})
+	rsOpts := mtest.NewOptions().Topologies(mtest.ReplicaSet)
+	mt.RunOpts("run command on server", rsOpts, func(mt *mtest.T) {
+		for _, host := range mtest.ClusterConnString().Hosts {
+			addr := address.Address(host).Canonicalize()
+			mt.ClearEvents()
+
+			err := mt.DB.RunCommandOnServer(context.Background(), addr, bson.D{{"ping", 1}}).Err()
+			assert.Nil(mt, err, "RunCommandOnServer error for %v: %v", addr, err)
+
+			evt := mt.GetStartedEvent()
+			assert.Equal(mt, "ping", evt.CommandName, "expected command 'ping', got %q", evt.CommandName)
+			assert.True(mt, strings.HasPrefix(evt.ConnectionID, string(addr)+"["),
+				"expected command to be sent to %v, got connection %v", addr, evt.ConnectionID)
+		}
+	})
 
 	dropOpts := mtest.NewOptions().DatabaseName("dropDb")
 	mt.RunOpts("drop", dropOpts, func(mt *mtest.T) {
@@ -580,6 +597,118 @@ func TestDatabase(t *testing.T) {
 			assert.Equal(mt, locale, collation["locale"], "expected locale %v, got %v", locale, collation["locale"])
 		})
 	})
+	mt.RunOpts("shard collection", mtest.NewOptions().ClientType(mtest.Mock), func(mt *mtest.T) {
+		assertCommand := func(mt *mtest.T, expected bson.D) {
+			mt.Helper()
+
+			evt := mt.GetStartedEvent()
+			assert.NotNil(mt, evt, "expected CommandStartedEvent, got nil")
+			assert.Equal(mt, "admin", evt.DatabaseName, "expected database 'admin', got %q", evt.DatabaseName)
+			for _, elem := range expected {
+				got, err := evt.Command.LookupErr(elem.Key)
+				assert.Nil(mt, err, "expected key %q in command %v", elem.Key, evt.Command)
+
+				wantType, wantData, err := bson.MarshalValue(elem.Value)
+				assert.Nil(mt, err, "MarshalValue error: %v", err)
+				want := bson.RawValue{Type: wantType, Value: wantData}
+				assert.True(mt, want.Equal(got), "expected %q to be %v, got %v", elem.Key, want, got)
+			}
+		}
+
+		mt.Run("hashed key", func(mt *mtest.T) {
+			mt.AddMockResponses(mtest.CreateSuccessResponse(), mtest.CreateSuccessResponse())
+			mt.ClearEvents()
+
+			key := bson.D{{"_id", "hashed"}}
+			opts := options.ShardCollection().SetNumInitialChunks(4)
+			err := mt.DB.ShardCollection(context.Background(), "coll", key, opts)
+			assert.Nil(mt, err, "ShardCollection error: %v", err)
+
+			assertCommand(mt, bson.D{{"enableSharding", mt.DB.Name()}})
+			assertCommand(mt, bson.D{
+				{"shardCollection", mt.DB.Name() + ".coll"},
+				{"key", key},
+				{"numInitialChunks", int32(4)},
+			})
+			assert.Nil(mt, mt.GetStartedEvent(), "expected no more commands")
+		})
+		mt.Run("unique key", func(mt *mtest.T) {
+			mt.AddMockResponses(mtest.CreateSuccessResponse(), mtest.CreateSuccessResponse())
+			mt.ClearEvents()
+
+			key := bson.D{{"region", 1}, {"_id", 1}}
+			err := mt.DB.ShardCollection(context.Background(), "coll", key, options.ShardCollection().SetUnique(true))
+			assert.Nil(mt, err, "ShardCollection error: %v", err)
+
+			assertCommand(mt, bson.D{{"enableSharding", mt.DB.Name()}})
+			assertCommand(mt, bson.D{
+				{"shardCollection", mt.DB.Name() + ".coll"},
+				{"key", key},
+				{"unique", true},
+			})
+		})
+		mt.Run("sharding already enabled", func(mt *mtest.T) {
+			alreadyEnabled := mtest.CreateCommandErrorResponse(mtest.CommandError{
+				Code:    23,
+				Name:    "AlreadyInitialized",
+				Message: "sharding already enabled for database",
+			})
+			mt.AddMockResponses(alreadyEnabled, mtest.CreateSuccessResponse())
+			mt.ClearEvents()
+
+			err := mt.DB.ShardCollection(context.Background(), "coll", bson.D{{"_id", 1}})
+			assert.Nil(mt, err, "ShardCollection error: %v", err)
+
+			assertCommand(mt, bson.D{{"enableSharding", mt.DB.Name()}})
+			assertCommand(mt, bson.D{{"shardCollection", mt.DB.Name() + ".coll"}})
+		})
+		mt.Run("enableSharding error", func(mt *mtest.T) {
+			unauthorized := mtest.CreateCommandErrorResponse(mtest.CommandError{
+				Code:    13,
+				Name:    "Unauthorized",
+				Message: "not authorized",
+			})
+			mt.AddMockResponses(unauthorized)
+			mt.ClearEvents()
+
+			err := mt.DB.ShardCollection(context.Background(), "coll", bson.D{{"_id", 1}})
+			cerr, ok := err.(mongo.CommandError)
+			assert.True(mt, ok, "expected error type %T, got %T", mongo.CommandError{}, err)
+			assert.Equal(mt, int32(13), cerr.Code, "expected error code 13, got %v", cerr.Code)
+
+			assertCommand(mt, bson.D{{"enableSharding", mt.DB.Name()}})
+			assert.Nil(mt, mt.GetStartedEvent(), "expected shardCollection not to be run")
+		})
+		mt.Run("empty shard key", func(mt *mtest.T) {
+			err := mt.DB.ShardCollection(context.Background(), "coll", nil)
+			assert.NotNil(mt, err, "expected ShardCollection error, got nil")
+		})
+	})
+	mt.RunOpts("profiler entries", mtest.NewOptions().ClientType(mtest.Mock), func(mt *mtest.T) {
+		ns := mt.DB.Name() + ".system.profile"
+		mt.AddMockResponses(mtest.CreateCursorResponse(0, ns, mtest.FirstBatch, bson.D{
+			{"op", "query"},
+			{"ns", mt.DB.Name() + ".orders"},
+			{"millis", int32(120)},
+			{"planSummary", "COLLSCAN"},
+		}))
+		mt.ClearEvents()
+
+		filter := bson.D{{"millis", bson.D{{"$gt", 100}}}}
+		entries, err := mt.DB.ProfilerEntries(context.Background(), filter, options.Find().SetSort(bson.D{{"ts", -1}}))
+		assert.Nil(mt, err, "ProfilerEntries error: %v", err)
+		assert.Equal(mt, 1, len(entries), "expected 1 entry, got %v", len(entries))
+		assert.Equal(mt, int64(120), entries[0].Millis, "expected millis 120, got %v", entries[0].Millis)
+		assert.Equal(mt, "COLLSCAN", entries[0].PlanSummary, "expected planSummary 'COLLSCAN', got %q",
+			entries[0].PlanSummary)
+
+		evt := mt.GetStartedEvent()
+		assert.Equal(mt, "find", evt.CommandName, "expected command 'find', got %q", evt.CommandName)
+		collName := evt.Command.Lookup("find").StringValue()
+		assert.Equal(mt, "system.profile", collName, "expected collection 'system.profile', got %q", collName)
+		_, err = evt.Command.LookupErr("sort")
+		assert.Nil(mt, err, "expected sort in command %v", evt.Command)
+	})
 }
 
 func getCollectionOptions(mt *mtest.T, collectionName string) bson.M {
@@ -320,6 +320,21 @@ func TestIndexView(t *testing.T) {
 			})
 		})
 	})
+	mt.RunOpts("create expiry index", mtest.NewOptions().ClientType(mtest.Mock), func(mt *mtest.T) {
+		mt.AddMockResponses(mtest.CreateSuccessResponse())
+
+		indexName, err := mt.Coll.Indexes().CreateExpiryIndex(context.Background())
+		assert.Nil(mt, err, "CreateExpiryIndex error: %v", err)
+		expectedName := mongo.ExpireAtField + "_1"
+		assert.Equal(mt, expectedName, indexName, "expected name %q, got %q", expectedName, indexName)
+
+		idx := mt.GetStartedEvent().Command.Lookup("indexes", "0").Document()
+		keys := idx.Lookup("key").Document()
+		expectedKeys := bson.Raw(bsoncore.NewDocumentBuilder().AppendInt32(mongo.ExpireAtField, 1).Build())
+		assert.Equal(mt, expectedKeys, keys, "expected keys %v, got %v", expectedKeys, keys)
+		expireAfter := idx.Lookup("expireAfterSeconds").Int32()
+		assert.Equal(mt, int32(0), expireAfter, "expected expireAfterSeconds 0, got %v", expireAfter)
+	})
 	mt.Run("create many", func(mt *mtest.T) {
 		mt.Run("success", func(mt *mtest.T) {
 			iv := mt.Coll.Indexes()
@@ -633,6 +648,109 @@ func TestIndexView(t *testing.T) {
 		}
 		assert.Nil(mt, cursor.Err(), "cursor error: %v", cursor.Err())
 	})
+	mt.Run("drop all except id", func(mt *mtest.T) {
+		iv := mt.Coll.Indexes()
+		names, err := iv.CreateMany(context.Background(), []mongo.IndexModel{
+			{
+				Keys: bson.D{{"foo", -1}},
+			},
+			{
+				Keys: bson.D{{"bar", 1}, {"baz", -1}},
+			},
+		})
+		assert.Nil(mt, err, "CreateMany error: %v", err)
+		assert.Equal(mt, 2, len(names), "expected 2 index names, got %v", len(names))
+
+		dropped, err := iv.DropAllExceptID(context.Background())
+		assert.Nil(mt, err, "DropAllExceptID error: %v", err)
+		assert.Equal(mt, 2, dropped, "expected 2 indexes to be dropped, got %v", dropped)
+
+		specs, err := iv.ListSpecifications(context.Background())
+		assert.Nil(mt, err, "ListSpecifications error: %v", err)
+		assert.Equal(mt, 1, len(specs), "expected 1 index, got %v", len(specs))
+		assert.Equal(mt, "_id_", specs[0].Name, "expected index %q, got %q", "_id_", specs[0].Name)
+	})
+	mt.RunOpts("drop all except id commands", mtest.NewOptions().ClientType(mtest.Mock), func(mt *mtest.T) {
+		ns := mt.Coll.Database().Name() + "." + mt.Coll.Name()
+		mt.AddMockResponses(
+			mtest.CreateCursorResponse(0, ns, mtest.FirstBatch,
+				bson.D{{"v", 2}, {"key", bson.D{{"_id", 1}}}, {"name", "_id_"}},
+				bson.D{{"v", 2}, {"key", bson.D{{"foo", -1}}}, {"name", "foo_-1"}},
+				bson.D{{"v", 2}, {"key", bson.D{{"bar", 1}}}, {"name", "bar_1"}},
+			),
+			mtest.CreateSuccessResponse(bson.E{"nIndexesWas", 3}),
+			mtest.CreateSuccessResponse(bson.E{"nIndexesWas", 2}),
+		)
+
+		dropped, err := mt.Coll.Indexes().DropAllExceptID(context.Background())
+		assert.Nil(mt, err, "DropAllExceptID error: %v", err)
+		assert.Equal(mt, 2, dropped, "expected 2 indexes to be dropped, got %v", dropped)
+
+		_ = mt.GetStartedEvent() // listIndexes
+		for _, name := range []string{"foo_-1", "bar_1"} {
+			evt := mt.GetStartedEvent()
+			assert.Equal(mt, "dropIndexes", evt.CommandName, "expected command %q, got %q", "dropIndexes", evt.CommandName)
+			got := evt.Command.Lookup("index").StringValue()
+			assert.Equal(mt, name, got, "expected index %q to be dropped, got %q", name, got)
+		}
+		assert.Nil(mt, mt.GetStartedEvent(), "expected no more commands")
+	})
+	mt.RunOpts("build progress", mtest.NewOptions().ClientType(mtest.Mock), func(mt *mtest.T) {
+		ns := mt.Coll.Database().Name() + "." + mt.Coll.Name()
+		createIndexes := bson.D{
+			{"createIndexes", mt.Coll.Name()},
+			{"indexes", bson.A{bson.D{{"key", bson.D{{"x", 1}}}, {"name", "x_1"}}}},
+		}
+
+		mt.Run("in progress", func(mt *mtest.T) {
+			mt.AddMockResponses(mtest.CreateCursorResponse(0, "admin.$cmd.aggregate", mtest.FirstBatch,
+				bson.D{
+					{"opid", int32(100)},
+					{"op", "command"},
+					{"ns", ns},
+					{"command", createIndexes},
+					{"msg", "Index Build: waiting for index build to complete"},
+				},
+				bson.D{
+					{"opid", int32(101)},
+					{"op", "command"},
+					{"ns", ns},
+					{"command", createIndexes},
+					{"msg", "Index Build: scanning collection Index Build: scanning collection: 2500/10000 25%"},
+					{"progress", bson.D{{"done", int32(2500)}, {"total", int64(10000)}}},
+				},
+			))
+
+			percent, err := mt.Coll.Indexes().BuildProgress(context.Background(), "x_1")
+			assert.Nil(mt, err, "BuildProgress error: %v", err)
+			assert.Equal(mt, 25.0, percent, "expected 25 percent, got %v", percent)
+
+			evt := mt.GetStartedEvent()
+			assert.Equal(mt, "aggregate", evt.CommandName, "expected command 'aggregate', got %q", evt.CommandName)
+			assert.Equal(mt, "admin", evt.DatabaseName, "expected database 'admin', got %q", evt.DatabaseName)
+			match := evt.Command.Lookup("pipeline").Array().Index(1).Value().Document().Lookup("$match").Document()
+			name := match.Lookup("command.indexes.name").StringValue()
+			assert.Equal(mt, "x_1", name, "expected match on index name 'x_1', got %q", name)
+			coll := match.Lookup("command.createIndexes").StringValue()
+			assert.Equal(mt, mt.Coll.Name(), coll, "expected match on collection %q, got %q", mt.Coll.Name(), coll)
+		})
+		mt.Run("not started scanning", func(mt *mtest.T) {
+			mt.AddMockResponses(mtest.CreateCursorResponse(0, "admin.$cmd.aggregate", mtest.FirstBatch,
+				bson.D{{"opid", int32(100)}, {"op", "command"}, {"ns", ns}, {"command", createIndexes}},
+			))
+
+			percent, err := mt.Coll.Indexes().BuildProgress(context.Background(), "x_1")
+			assert.Nil(mt, err, "BuildProgress error: %v", err)
+			assert.Equal(mt, 0.0, percent, "expected 0 percent, got %v", percent)
+		})
+		mt.Run("not found", func(mt *mtest.T) {
+			mt.AddMockResponses(mtest.CreateCursorResponse(0, "admin.$cmd.aggregate", mtest.FirstBatch))
+
+			_, err := mt.Coll.Indexes().BuildProgress(context.Background(), "x_1")
+			assert.Equal(mt, mongo.ErrIndexBuildNotFound, err, "expected error %v, got %v", mongo.ErrIndexBuildNotFound,
+				err)
+		})
+	})
 }
 
 func getIndexDoc(mt *mtest.T, iv mongo.IndexView, expectedKeyDoc bson.D) bson.D {
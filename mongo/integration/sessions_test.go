@@ -288,6 +288,10 @@ func TestSessions(t *testing.T) {
 		assert.Equal(mt, findID, getMoreID, "expected session ID %v, got %v", findID, getMoreID)
 	})
 
+	txnOpts := mtest.NewOptions().RunOn(
+		mtest.RunOnBlock{Topology: []mtest.TopologyKind{mtest.ReplicaSet}, MinServerVersion: "4.0"},
+		mtest.RunOnBlock{Topology: []mtest.TopologyKind{mtest.Sharded}, MinServerVersion: "4.2"},
+	)
 	mt.Run("imperative API", func(mt *mtest.T) {
 		mt.Run("round trip Session object", func(mt *mtest.T) {
 			// Rountrip a Session object through NewSessionContext/ContextFromSession and assert that it is correctly
@@ -305,10 +309,6 @@ func TestSessions(t *testing.T) {
 			assert.Equal(mt, sess.ID(), gotSess.ID(), "expected Session ID %v, got %v", sess.ID(), gotSess.ID())
 		})
 
-		txnOpts := mtest.NewOptions().RunOn(
-			mtest.RunOnBlock{Topology: []mtest.TopologyKind{mtest.ReplicaSet}, MinServerVersion: "4.0"},
-			mtest.RunOnBlock{Topology: []mtest.TopologyKind{mtest.Sharded}, MinServerVersion: "4.2"},
-		)
 		mt.RunOpts("run transaction", txnOpts, func(mt *mtest.T) {
 			// Test that the imperative sessions API can be used to run a transaction.
 
@@ -341,6 +341,39 @@ func TestSessions(t *testing.T) {
 			assertCollectionCount(mt, int64(numDocs))
 		})
 	})
+	mt.RunOpts("write with outbox", txnOpts, func(mt *mtest.T) {
+		// Collections can't be created inside a transaction before 4.4, so create both up front.
+		mt.CreateCollection(mtest.Collection{Name: mt.Coll.Name()}, true)
+		outbox := mt.CreateCollection(mtest.Collection{Name: "outbox"}, true)
+
+		assertOutboxCount := func(mt *mtest.T, expected int64) {
+			mt.Helper()
+
+			count, err := outbox.CountDocuments(context.Background(), bson.D{})
+			assert.Nil(mt, err, "CountDocuments error: %v", err)
+			assert.Equal(mt, expected, count, "expected outbox count %v, got %v", expected, count)
+		}
+
+		sess, err := mt.Client.StartSession()
+		assert.Nil(mt, err, "StartSession error: %v", err)
+		defer sess.EndSession(context.Background())
+
+		// Both documents are committed together.
+		res, err := mongo.WriteWithOutbox(context.Background(), sess, mt.Coll, bson.D{{"_id", 1}, {"x", 1}},
+			outbox, bson.D{{"_id", 1}, {"event", "created"}})
+		assert.Nil(mt, err, "WriteWithOutbox error: %v", err)
+		assert.Equal(mt, int32(1), res.InsertedID, "expected inserted ID 1, got %v", res.InsertedID)
+		assertCollectionCount(mt, 1)
+		assertOutboxCount(mt, 1)
+
+		// A failed outbox write rolls back the business write.
+		_, err = mongo.WriteWithOutbox(context.Background(), sess, mt.Coll, bson.D{{"_id", 2}, {"x", 2}},
+			outbox, bson.D{{"_id", 1}, {"event", "duplicate"}})
+		assert.NotNil(mt, err, "expected WriteWithOutbox error, got nil")
+		assert.True(mt, mongo.IsDuplicateKeyError(err), "expected duplicate key error, got %v", err)
+		assertCollectionCount(mt, 1)
+		assertOutboxCount(mt, 1)
+	})
 }
 
 func assertCollectionCount(mt *mtest.T, expectedCount int64) {
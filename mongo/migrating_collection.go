@@ -0,0 +1,208 @@
+// Copyright (C) MongoDB, Inc. 2017-present.
+//
+// Licensed under the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License. You may obtain
+// a copy of the License at http://www.apache.org/licenses/LICENSE-2.0
+
+package mongo
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+// errPersistProjection is returned when a projection is used with a MigratingCollection that persists migrated
+// documents.
+var errPersistProjection = errors.New("a projection cannot be used with the Persist option")
+
+// MigrateFunc upgrades a document from one schema version to the next. It may modify and return doc or return a new
+// document. The version field of the returned document is set by the MigratingCollection.
+type MigrateFunc func(doc bson.M) (bson.M, error)
+
+// MigratingCollection is a handle to a collection whose documents store a schema version and are upgraded to the
+// latest version when they are read. Migrations are registered by the version they upgrade from: the function for
+// version n upgrades a document from version n to version n+1. The latest version is one more than the highest
+// registered version. Documents without a version field are at version 0.
+//
+// Documents are decoded into a bson.M to be migrated, so the order of their fields is not preserved. Only documents
+// read with FindOne and Find are migrated; all other operations should be run on the underlying Collection.
+type MigratingCollection struct {
+	coll       *Collection
+	migrations map[int]MigrateFunc
+	first      int
+	latest     int
+	field      string
+	persist    bool
+}
+
+// NewMigratingCollection creates a MigratingCollection that reads documents from coll and upgrades them with the given
+// migrations. The registered versions must be non-negative and contiguous.
+func NewMigratingCollection(coll *Collection, migrations map[int]MigrateFunc,
+	opts ...*options.MigratingCollectionOptions) (*MigratingCollection, error) {
+
+	if coll == nil {
+		return nil, errors.New("collection must not be nil")
+	}
+	if len(migrations) == 0 {
+		return nil, errors.New("at least one migration must be registered")
+	}
+
+	mc := &MigratingCollection{
+		coll:       coll,
+		migrations: make(map[int]MigrateFunc, len(migrations)),
+		first:      -1,
+		field:      options.DefaultSchemaVersionField,
+	}
+	for version, fn := range migrations {
+		if version < 0 {
+			return nil, fmt.Errorf("migration version must be non-negative, got %d", version)
+		}
+		if fn == nil {
+			return nil, fmt.Errorf("migration for version %d must not be nil", version)
+		}
+		mc.migrations[version] = fn
+		if mc.first == -1 || version < mc.first {
+			mc.first = version
+		}
+		if version+1 > mc.latest {
+			mc.latest = version + 1
+		}
+	}
+	if len(mc.migrations) != mc.latest-mc.first {
+		return nil, fmt.Errorf("migrations must be registered for every version from %d to %d", mc.first,
+			mc.latest-1)
+	}
+
+	mco := options.MergeMigratingCollectionOptions(opts...)
+	if mco.VersionField != nil {
+		if *mco.VersionField == "" {
+			return nil, errors.New("version field must not be empty")
+		}
+		mc.field = *mco.VersionField
+	}
+	if mco.Persist != nil {
+		mc.persist = *mco.Persist
+	}
+	return mc, nil
+}
+
+// Collection returns the underlying Collection.
+func (mc *MigratingCollection) Collection() *Collection {
+	return mc.coll
+}
+
+// LatestVersion returns the schema version that documents are upgraded to.
+func (mc *MigratingCollection) LatestVersion() int {
+	return mc.latest
+}
+
+// FindOne executes a find command like Collection.FindOne and upgrades the returned document to the latest schema
+// version. If the Persist option is set, an upgraded document is written back to the collection. An error is
+// returned if the document's version field is not an integer or is lower than the first registered version, or if
+// a migration fails. A projection cannot be used if the Persist option is set, because the projected document would
+// replace the stored one.
+func (mc *MigratingCollection) FindOne(ctx context.Context, filter interface{},
+	opts ...*options.FindOneOptions) *SingleResult {
+
+	if ctx == nil {
+		ctx = context.Background()
+	}
+	for _, opt := range opts {
+		if opt != nil && opt.Projection != nil && mc.persist {
+			return &SingleResult{err: errPersistProjection}
+		}
+	}
+
+	doc, err := mc.coll.FindOne(ctx, filter, opts...).DecodeBytes()
+	if err != nil {
+		return &SingleResult{err: err}
+	}
+	if doc, err = mc.migrate(ctx, doc); err != nil {
+		return &SingleResult{err: err}
+	}
+	return &SingleResult{rdr: doc, reg: mc.coll.registry}
+}
+
+// Find executes a find command like Collection.Find and returns a Cursor that upgrades each document to the latest
+// schema version as it is iterated. If the Persist option is set, upgraded documents are written back to the
+// collection as they are iterated, using the Context passed to Next, TryNext, or All. If a document can't be
+// migrated or persisted, iteration stops and the error is returned by the Cursor's Err method.
+//
+// A projection that excludes the version field causes documents to be treated as version 0. A projection cannot be
+// used if the Persist option is set, because the projected documents would replace the stored ones.
+func (mc *MigratingCollection) Find(ctx context.Context, filter interface{},
+	opts ...*options.FindOptions) (*Cursor, error) {
+
+	if ctx == nil {
+		ctx = context.Background()
+	}
+	for _, opt := range opts {
+		if opt != nil && opt.Projection != nil && mc.persist {
+			return nil, errPersistProjection
+		}
+	}
+
+	cursor, err := mc.coll.Find(ctx, filter, opts...)
+	if err != nil {
+		return nil, err
+	}
+	cursor.transform = mc.migrate
+	return cursor, nil
+}
+
+// migrate upgrades doc to the latest schema version and persists it if required. doc is returned unchanged if it is
+// already at the latest version.
+func (mc *MigratingCollection) migrate(ctx context.Context, doc bson.Raw) (bson.Raw, error) {
+	version := 0
+	versionVal, err := doc.LookupErr(mc.field)
+	if err == nil {
+		v, ok := versionVal.AsInt64OK()
+		if !ok {
+			return nil, fmt.Errorf("schema version field %q must be an integer, got %v", mc.field, versionVal.Type)
+		}
+		version = int(v)
+	}
+	if version >= mc.latest {
+		return doc, nil
+	}
+	if version < mc.first {
+		return nil, fmt.Errorf("no migration registered for schema version %d", version)
+	}
+
+	var m bson.M
+	if err = bson.UnmarshalWithRegistry(mc.coll.registry, doc, &m); err != nil {
+		return nil, err
+	}
+	for v := version; v < mc.latest; v++ {
+		if m, err = mc.migrations[v](m); err != nil {
+			return nil, fmt.Errorf("error migrating document from schema version %d: %v", v, err)
+		}
+		if m == nil {
+			return nil, fmt.Errorf("migration from schema version %d returned a nil document", v)
+		}
+		m[mc.field] = int32(v + 1)
+	}
+	migrated, err := bson.MarshalWithRegistry(mc.coll.registry, m)
+	if err != nil {
+		return nil, err
+	}
+
+	if mc.persist {
+		if id, ok := m["_id"]; ok {
+			// Only replace the document if it has not been migrated or changed version since it was read.
+			var versionFilter interface{} = bson.D{{"$exists", false}}
+			if versionVal.Type != 0 {
+				versionFilter = versionVal
+			}
+			filter := bson.D{{"_id", id}, {mc.field, versionFilter}}
+			if _, err = mc.coll.ReplaceOne(ctx, filter, migrated); err != nil {
+				return nil, err
+			}
+		}
+	}
+	return migrated, nil
+}
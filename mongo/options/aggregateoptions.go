@@ -18,6 +18,11 @@ type AggregateOptions struct {
 	// the server. The default value is false.
 	AllowDiskUse *bool
 
+	// If true and AllowDiskUse is not true, an aggregation that fails because it exceeded the server's memory limit is
+	// run again with AllowDiskUse set to true. The aggregation is not run again if it is part of a transaction. The
+	// default value is false.
+	AutoAllowDiskUseOnMemoryError *bool
+
 	// The maximum number of documents to be included in each batch returned by the server.
 	BatchSize *int32
 
@@ -72,6 +77,12 @@ func (ao *AggregateOptions) SetAllowDiskUse(b bool) *AggregateOptions {
 	return ao
 }
 
+// SetAutoAllowDiskUseOnMemoryError sets the value for the AutoAllowDiskUseOnMemoryError field.
+func (ao *AggregateOptions) SetAutoAllowDiskUseOnMemoryError(b bool) *AggregateOptions {
+	ao.AutoAllowDiskUseOnMemoryError = &b
+	return ao
+}
+
 // SetBatchSize sets the value for the BatchSize field.
 func (ao *AggregateOptions) SetBatchSize(i int32) *AggregateOptions {
 	ao.BatchSize = &i
@@ -140,6 +151,9 @@ func MergeAggregateOptions(opts ...*AggregateOptions) *AggregateOptions {
 		if ao.AllowDiskUse != nil {
 			aggOpts.AllowDiskUse = ao.AllowDiskUse
 		}
+		if ao.AutoAllowDiskUseOnMemoryError != nil {
+			aggOpts.AutoAllowDiskUseOnMemoryError = ao.AutoAllowDiskUseOnMemoryError
+		}
 		if ao.BatchSize != nil {
 			aggOpts.BatchSize = ao.BatchSize
 		}
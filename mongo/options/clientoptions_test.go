@@ -22,6 +22,7 @@ import (
 	"go.mongodb.org/mongo-driver/event"
 	"go.mongodb.org/mongo-driver/internal"
 	"go.mongodb.org/mongo-driver/internal/testutil/assert"
+	"go.mongodb.org/mongo-driver/mongo/address"
 	"go.mongodb.org/mongo-driver/mongo/readconcern"
 	"go.mongodb.org/mongo-driver/mongo/readpref"
 	"go.mongodb.org/mongo-driver/mongo/writeconcern"
@@ -61,8 +62,11 @@ func TestClientOptions(t *testing.T) {
 			{"AppName", (*ClientOptions).SetAppName, "example-application", "AppName", true},
 			{"Auth", (*ClientOptions).SetAuth, Credential{Username: "foo", Password: "bar"}, "Auth", true},
 			{"Compressors", (*ClientOptions).SetCompressors, []string{"zstd", "snappy", "zlib"}, "Compressors", true},
+			{"CompressionMinSize", (*ClientOptions).SetCompressionMinSize, 1024, "CompressionMinSize", true},
 			{"ConnectTimeout", (*ClientOptions).SetConnectTimeout, 5 * time.Second, "ConnectTimeout", true},
+			{"ConnectionTag", (*ClientOptions).SetConnectionTag, "reporting", "ConnectionTag", true},
 			{"Dialer", (*ClientOptions).SetDialer, testDialer{Num: 12345}, "Dialer", true},
+			{"DNSResolver", (*ClientOptions).SetDNSResolver, &net.Resolver{PreferGo: true}, "DNSResolver", false},
 			{"HeartbeatInterval", (*ClientOptions).SetHeartbeatInterval, 5 * time.Second, "HeartbeatInterval", true},
 			{"Hosts", (*ClientOptions).SetHosts, []string{"localhost:27017", "localhost:27018", "localhost:27019"}, "Hosts", true},
 			{"LocalThreshold", (*ClientOptions).SetLocalThreshold, 5 * time.Second, "LocalThreshold", true},
@@ -70,7 +74,13 @@ func TestClientOptions(t *testing.T) {
 			{"MaxPoolSize", (*ClientOptions).SetMaxPoolSize, uint64(250), "MaxPoolSize", true},
 			{"MinPoolSize", (*ClientOptions).SetMinPoolSize, uint64(10), "MinPoolSize", true},
 			{"MaxConnecting", (*ClientOptions).SetMaxConnecting, uint64(10), "MaxConnecting", true},
+			{"MaxTimeMSCeiling", (*ClientOptions).SetMaxTimeMSCeiling, 5 * time.Second, "MaxTimeMSCeiling", true},
+			{"DefaultMaxTime", (*ClientOptions).SetDefaultMaxTime, 30 * time.Second, "DefaultMaxTime", true},
+			{"PoolFairness", (*ClientOptions).SetPoolFairness, PoolFairnessBestEffort, "PoolFairness", true},
+			{"WaitQueueTimeout", (*ClientOptions).SetWaitQueueTimeout, 500 * time.Millisecond, "WaitQueueTimeout", true},
 			{"PoolMonitor", (*ClientOptions).SetPoolMonitor, &event.PoolMonitor{}, "PoolMonitor", false},
+			{"PrimaryFallback", (*ClientOptions).SetPrimaryFallbackOnReadError, true, "PrimaryFallback", true},
+			{"EscalateReadPreference", (*ClientOptions).SetEscalateReadPreferenceOnRetry, true, "EscalateReadPreference", true},
 			{"Monitor", (*ClientOptions).SetMonitor, &event.CommandMonitor{}, "Monitor", false},
 			{"ReadConcern", (*ClientOptions).SetReadConcern, readconcern.Majority(), "ReadConcern", false},
 			{"ReadPreference", (*ClientOptions).SetReadPreference, readpref.SecondaryPreferred(), "ReadPreference", false},
@@ -79,6 +89,7 @@ func TestClientOptions(t *testing.T) {
 			{"RetryWrites", (*ClientOptions).SetRetryWrites, true, "RetryWrites", true},
 			{"ServerSelectionTimeout", (*ClientOptions).SetServerSelectionTimeout, 5 * time.Second, "ServerSelectionTimeout", true},
 			{"Direct", (*ClientOptions).SetDirect, true, "Direct", true},
+			{"SlowOperationThreshold", (*ClientOptions).SetSlowOperationThreshold, 5 * time.Second, "SlowOperationThreshold", true},
 			{"SocketTimeout", (*ClientOptions).SetSocketTimeout, 5 * time.Second, "SocketTimeout", true},
 			{"TLSConfig", (*ClientOptions).SetTLSConfig, &tls.Config{}, "TLSConfig", false},
 			{"WriteConcern", (*ClientOptions).SetWriteConcern, writeconcern.New(writeconcern.WMajority()), "WriteConcern", false},
@@ -144,6 +155,7 @@ func TestClientOptions(t *testing.T) {
 					cmp.Comparer(func(r1, r2 *bsoncodec.Registry) bool { return r1 == r2 }),
 					cmp.Comparer(func(cfg1, cfg2 *tls.Config) bool { return cfg1 == cfg2 }),
 					cmp.Comparer(func(fp1, fp2 *event.PoolMonitor) bool { return fp1 == fp2 }),
+					cmp.Comparer(func(r1, r2 *net.Resolver) bool { return r1 == r2 }),
 				) {
 					t.Errorf("Field not set properly. got %v; want %v", got.Interface(), want.Interface())
 				}
@@ -158,6 +170,7 @@ func TestClientOptions(t *testing.T) {
 				cmp.Comparer(func(r1, r2 *bsoncodec.Registry) bool { return r1 == r2 }),
 				cmp.Comparer(func(cfg1, cfg2 *tls.Config) bool { return cfg1 == cfg2 }),
 				cmp.Comparer(func(fp1, fp2 *event.PoolMonitor) bool { return fp1 == fp2 }),
+				cmp.Comparer(func(r1, r2 *net.Resolver) bool { return r1 == r2 }),
 				cmp.AllowUnexported(ClientOptions{}),
 			); diff != "" {
 				t.Errorf("diff:\n%s", diff)
@@ -186,6 +199,40 @@ func TestClientOptions(t *testing.T) {
 				t.Errorf("Merged client options do not match. got %v; want %v", got.uri, opt1.uri)
 			}
 		})
+
+		t.Run("SetTCPKeepAlive", func(t *testing.T) {
+			opts := Client().SetTCPKeepAlive(time.Minute, 10*time.Second, 5)
+			want := &TCPKeepAlive{Idle: time.Minute, Interval: 10 * time.Second, Count: 5}
+			assert.Equal(t, want, opts.TCPKeepAlive, "expected TCPKeepAlive %v, got %v", want, opts.TCPKeepAlive)
+
+			got := MergeClientOptions(opts, Client())
+			assert.Equal(t, want, got.TCPKeepAlive, "expected merged TCPKeepAlive %v, got %v", want, got.TCPKeepAlive)
+		})
+		t.Run("SetProxy", func(t *testing.T) {
+			auth := &ProxyAuth{Username: "user", Password: "pencil"}
+			opts := Client().SetProxy("socks5://localhost:1080", auth)
+			want := &Proxy{URL: "socks5://localhost:1080", Auth: auth}
+			assert.Equal(t, want, opts.Proxy, "expected Proxy %v, got %v", want, opts.Proxy)
+			assert.Nil(t, opts.Validate(), "Validate error: %v", opts.Validate())
+
+			got := MergeClientOptions(opts, Client())
+			assert.Equal(t, want, got.Proxy, "expected merged Proxy %v, got %v", want, got.Proxy)
+
+			for _, proxyURL := range []string{"http://localhost:1080", "socks5://localhost", "socks5://%zz"} {
+				err := Client().SetProxy(proxyURL, nil).Validate()
+				assert.NotNil(t, err, "expected Validate error for proxy URL %q, got nil", proxyURL)
+			}
+		})
+		t.Run("SetHandshakeCompleteCallback", func(t *testing.T) {
+			var called bool
+			opts := Client().SetHandshakeCompleteCallback(func(address.Address, event.HandshakeInfo) { called = true })
+			assert.NotNil(t, opts.HandshakeCompleteCallback, "expected HandshakeCompleteCallback to be set")
+
+			got := MergeClientOptions(opts, Client())
+			assert.NotNil(t, got.HandshakeCompleteCallback, "expected merged HandshakeCompleteCallback to be set")
+			got.HandshakeCompleteCallback("localhost:27017", event.HandshakeInfo{})
+			assert.True(t, called, "expected merged HandshakeCompleteCallback to call the configured function")
+		})
 	})
 	t.Run("ApplyURI", func(t *testing.T) {
 		baseClient := func() *ClientOptions {
@@ -349,6 +396,11 @@ func TestClientOptions(t *testing.T) {
 				"mongodb://localhost/?maxConnecting=10",
 				baseClient().SetMaxConnecting(10),
 			},
+			{
+				"WaitQueueTimeout",
+				"mongodb://localhost/?waitQueueTimeoutMS=500",
+				baseClient().SetWaitQueueTimeout(500 * time.Millisecond),
+			},
 			{
 				"ReadConcern",
 				"mongodb://localhost/?readConcernLevel=linearizable",
@@ -656,6 +708,38 @@ func TestClientOptions(t *testing.T) {
 			})
 		}
 	})
+	t.Run("DNS resolver", func(t *testing.T) {
+		newResolver := func(dialed *bool) *net.Resolver {
+			return &net.Resolver{
+				PreferGo: true,
+				Dial: func(context.Context, string, string) (net.Conn, error) {
+					*dialed = true
+					return nil, errors.New("dial failed")
+				},
+			}
+		}
+
+		t.Run("used to parse the URI", func(t *testing.T) {
+			var dialed bool
+			err := Client().SetDNSResolver(newResolver(&dialed)).ApplyURI("mongodb+srv://test.example.com").Validate()
+			assert.NotNil(t, err, "expected Validate error, got nil")
+			assert.True(t, dialed, "expected the configured resolver to be used")
+		})
+		t.Run("set after an SRV URI", func(t *testing.T) {
+			var dialed bool
+			opts := Client()
+			opts.cs = &connstring.ConnString{Scheme: connstring.SchemeMongoDBSRV}
+			opts.SetDNSResolver(newResolver(&dialed))
+			err := opts.Validate()
+			assert.NotNil(t, err, "expected Validate error, got nil")
+		})
+		t.Run("set after a non-SRV URI", func(t *testing.T) {
+			var dialed bool
+			err := Client().ApplyURI("mongodb://localhost").SetDNSResolver(newResolver(&dialed)).Validate()
+			assert.Nil(t, err, "Validate error: %v", err)
+			assert.False(t, dialed, "expected the resolver not to be used")
+		})
+	})
 }
 
 func createCertPool(t *testing.T, paths ...string) *x509.CertPool {
@@ -0,0 +1,45 @@
+// Copyright (C) MongoDB, Inc. 2017-present.
+//
+// Licensed under the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License. You may obtain
+// a copy of the License at http://www.apache.org/licenses/LICENSE-2.0
+
+package options
+
+import "time"
+
+// CreatedAtOptions represents options that can be used to configure a Collection.CreatedAt operation.
+type CreatedAtOptions struct {
+	// If set, the oplog of a replica set is searched for the collection's create entry when the creation time is not
+	// available from the sharding metadata, and the search is stopped by the server after this amount of time. The
+	// oplog is not indexed for this search, so it can scan the whole oplog on a busy deployment. The default is to not
+	// search the oplog.
+	OplogMaxTime *time.Duration
+}
+
+// CreatedAt creates a new CreatedAtOptions instance.
+func CreatedAt() *CreatedAtOptions {
+	return &CreatedAtOptions{}
+}
+
+// SetOplogMaxTime sets the value for the OplogMaxTime field.
+func (co *CreatedAtOptions) SetOplogMaxTime(d time.Duration) *CreatedAtOptions {
+	co.OplogMaxTime = &d
+	return co
+}
+
+// MergeCreatedAtOptions combines the given CreatedAtOptions instances into a single *CreatedAtOptions in a
+// last-one-wins fashion.
+func MergeCreatedAtOptions(opts ...*CreatedAtOptions) *CreatedAtOptions {
+	co := CreatedAt()
+	for _, opt := range opts {
+		if opt == nil {
+			continue
+		}
+		if opt.OplogMaxTime != nil {
+			co.OplogMaxTime = opt.OplogMaxTime
+		}
+	}
+
+	return co
+}
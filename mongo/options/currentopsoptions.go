@@ -0,0 +1,69 @@
+// Copyright (C) MongoDB, Inc. 2017-present.
+//
+// Licensed under the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License. You may obtain
+// a copy of the License at http://www.apache.org/licenses/LICENSE-2.0
+
+package options
+
+import "time"
+
+// CurrentOpsOptions represents options that can be used to configure a CurrentOps operation.
+type CurrentOpsOptions struct {
+	// If true, operations for all users are returned. Otherwise, only the operations of the current user are
+	// returned. Reporting the operations of all users requires the inprog privilege. The default value is false.
+	AllUsers *bool
+
+	// If set, only operations on this namespace (e.g. "db.collection") are returned. The default is to return
+	// operations on all namespaces.
+	Namespace *string
+
+	// If set, only operations that have been running for at least this long are returned. The default is to return
+	// operations regardless of how long they have been running.
+	MinRunningTime *time.Duration
+}
+
+// CurrentOps creates a new CurrentOpsOptions instance.
+func CurrentOps() *CurrentOpsOptions {
+	return &CurrentOpsOptions{}
+}
+
+// SetAllUsers sets the value for the AllUsers field.
+func (co *CurrentOpsOptions) SetAllUsers(b bool) *CurrentOpsOptions {
+	co.AllUsers = &b
+	return co
+}
+
+// SetNamespace sets the value for the Namespace field.
+func (co *CurrentOpsOptions) SetNamespace(ns string) *CurrentOpsOptions {
+	co.Namespace = &ns
+	return co
+}
+
+// SetMinRunningTime sets the value for the MinRunningTime field.
+func (co *CurrentOpsOptions) SetMinRunningTime(d time.Duration) *CurrentOpsOptions {
+	co.MinRunningTime = &d
+	return co
+}
+
+// MergeCurrentOpsOptions combines the given CurrentOpsOptions instances into a single *CurrentOpsOptions in a
+// last-one-wins fashion.
+func MergeCurrentOpsOptions(opts ...*CurrentOpsOptions) *CurrentOpsOptions {
+	co := CurrentOps()
+	for _, opt := range opts {
+		if opt == nil {
+			continue
+		}
+		if opt.AllUsers != nil {
+			co.AllUsers = opt.AllUsers
+		}
+		if opt.Namespace != nil {
+			co.Namespace = opt.Namespace
+		}
+		if opt.MinRunningTime != nil {
+			co.MinRunningTime = opt.MinRunningTime
+		}
+	}
+
+	return co
+}
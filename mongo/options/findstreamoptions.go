@@ -0,0 +1,78 @@
+// Copyright (C) MongoDB, Inc. 2017-present.
+//
+// Licensed under the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License. You may obtain
+// a copy of the License at http://www.apache.org/licenses/LICENSE-2.0
+
+package options
+
+// StreamFormat specifies how documents are written by a stream returned from Collection.FindStream. See BSONStream
+// and ExtJSONStream.
+type StreamFormat int8
+
+const (
+	// BSONStream specifies that documents are written as consecutive BSON documents. Each BSON document starts with
+	// its length, so the stream can be split back into documents without any additional framing.
+	BSONStream StreamFormat = iota
+	// ExtJSONStream specifies that documents are written as Extended JSON, one document per line.
+	ExtJSONStream
+)
+
+// FindStreamOptions represents options that can be used to configure a FindStream operation.
+type FindStreamOptions struct {
+	// The format documents are written in. The default value is BSONStream.
+	Format *StreamFormat
+
+	// If true, documents are written as canonical Extended JSON rather than relaxed Extended JSON. This option is
+	// only used if Format is ExtJSONStream. The default value is false.
+	Canonical *bool
+
+	// The options for the underlying find operation. The default value is nil, which means that the default find
+	// options are used.
+	FindOptions *FindOptions
+}
+
+// FindStream creates a new FindStreamOptions instance.
+func FindStream() *FindStreamOptions {
+	return &FindStreamOptions{}
+}
+
+// SetFormat sets the value for the Format field.
+func (fso *FindStreamOptions) SetFormat(f StreamFormat) *FindStreamOptions {
+	fso.Format = &f
+	return fso
+}
+
+// SetCanonical sets the value for the Canonical field.
+func (fso *FindStreamOptions) SetCanonical(b bool) *FindStreamOptions {
+	fso.Canonical = &b
+	return fso
+}
+
+// SetFindOptions sets the value for the FindOptions field.
+func (fso *FindStreamOptions) SetFindOptions(opts *FindOptions) *FindStreamOptions {
+	fso.FindOptions = opts
+	return fso
+}
+
+// MergeFindStreamOptions combines the given FindStreamOptions instances into a single FindStreamOptions in a
+// last-one-wins fashion.
+func MergeFindStreamOptions(opts ...*FindStreamOptions) *FindStreamOptions {
+	fso := FindStream()
+	for _, opt := range opts {
+		if opt == nil {
+			continue
+		}
+		if opt.Format != nil {
+			fso.Format = opt.Format
+		}
+		if opt.Canonical != nil {
+			fso.Canonical = opt.Canonical
+		}
+		if opt.FindOptions != nil {
+			fso.FindOptions = opt.FindOptions
+		}
+	}
+
+	return fso
+}
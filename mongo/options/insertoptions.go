@@ -52,6 +52,11 @@ type InsertManyOptions struct {
 
 	// If true, no writes will be executed after one fails. The default value is true.
 	Ordered *bool
+
+	// If true, documents whose _id is the same as that of an earlier document in the batch are removed before the
+	// batch is sent, keeping the first occurrence. Numeric _id values are compared by value, so 1 and 1.0 are
+	// duplicates; all other values are compared by their BSON encoding. The default value is false.
+	DeduplicateByID *bool
 }
 
 // InsertMany creates a new InsertManyOptions instance.
@@ -73,6 +78,12 @@ func (imo *InsertManyOptions) SetOrdered(b bool) *InsertManyOptions {
 	return imo
 }
 
+// SetDeduplicateByID sets the value for the DeduplicateByID field.
+func (imo *InsertManyOptions) SetDeduplicateByID(b bool) *InsertManyOptions {
+	imo.DeduplicateByID = &b
+	return imo
+}
+
 // MergeInsertManyOptions combines the given InsertManyOptions instances into a single InsertManyOptions in a last one
 // wins fashion.
 func MergeInsertManyOptions(opts ...*InsertManyOptions) *InsertManyOptions {
@@ -87,6 +98,9 @@ func MergeInsertManyOptions(opts ...*InsertManyOptions) *InsertManyOptions {
 		if imo.Ordered != nil {
 			imOpts.Ordered = imo.Ordered
 		}
+		if imo.DeduplicateByID != nil {
+			imOpts.DeduplicateByID = imo.DeduplicateByID
+		}
 	}
 
 	return imOpts
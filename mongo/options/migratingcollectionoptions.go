@@ -0,0 +1,58 @@
+// Copyright (C) MongoDB, Inc. 2017-present.
+//
+// Licensed under the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License. You may obtain
+// a copy of the License at http://www.apache.org/licenses/LICENSE-2.0
+
+package options
+
+// DefaultSchemaVersionField is the default name of the field used by a MigratingCollection to store the schema version
+// of a document.
+const DefaultSchemaVersionField = "schemaVersion"
+
+// MigratingCollectionOptions represents options that can be used to configure a MigratingCollection.
+type MigratingCollectionOptions struct {
+	// The name of the field that stores the schema version of a document. The default value is
+	// DefaultSchemaVersionField.
+	VersionField *string
+
+	// If true, documents that are migrated when read are written back to the collection so they are only migrated
+	// once. The default value is false.
+	Persist *bool
+}
+
+// MigratingCollection creates a new MigratingCollectionOptions instance.
+func MigratingCollection() *MigratingCollectionOptions {
+	return &MigratingCollectionOptions{}
+}
+
+// SetVersionField sets the value for the VersionField field.
+func (mco *MigratingCollectionOptions) SetVersionField(field string) *MigratingCollectionOptions {
+	mco.VersionField = &field
+	return mco
+}
+
+// SetPersist sets the value for the Persist field.
+func (mco *MigratingCollectionOptions) SetPersist(b bool) *MigratingCollectionOptions {
+	mco.Persist = &b
+	return mco
+}
+
+// MergeMigratingCollectionOptions combines the given MigratingCollectionOptions instances into a single
+// *MigratingCollectionOptions in a last-one-wins fashion.
+func MergeMigratingCollectionOptions(opts ...*MigratingCollectionOptions) *MigratingCollectionOptions {
+	mco := MigratingCollection()
+	for _, opt := range opts {
+		if opt == nil {
+			continue
+		}
+		if opt.VersionField != nil {
+			mco.VersionField = opt.VersionField
+		}
+		if opt.Persist != nil {
+			mco.Persist = opt.Persist
+		}
+	}
+
+	return mco
+}
@@ -20,9 +20,10 @@ import (
 // The user must specify a ServerAPIVersion if including ServerAPIOptions in their client. That version
 // must also be currently supported by the driver. This version of the driver supports API version "1".
 type ServerAPIOptions struct {
-	ServerAPIVersion  ServerAPIVersion
-	Strict            *bool
-	DeprecationErrors *bool
+	ServerAPIVersion    ServerAPIVersion
+	Strict              *bool
+	DeprecationErrors   *bool
+	DeprecationCallback func(command string)
 }
 
 // ServerAPI creates a new ServerAPIOptions configured with the provided serverAPIversion.
@@ -42,6 +43,15 @@ func (s *ServerAPIOptions) SetDeprecationErrors(deprecationErrors bool) *ServerA
 	return s
 }
 
+// SetAPIDeprecationCallback specifies a function that is called with the command name whenever the server reports that
+// a command used a feature that is deprecated in the API version. The server only reports deprecated usage, as an
+// APIDeprecationError, if DeprecationErrors is set to true, so this option has no effect otherwise. The callback is
+// called before the error is returned from the operation and must not block.
+func (s *ServerAPIOptions) SetAPIDeprecationCallback(callback func(command string)) *ServerAPIOptions {
+	s.DeprecationCallback = callback
+	return s
+}
+
 // ServerAPIVersion represents an API version that can be used in ServerAPIOptions.
 type ServerAPIVersion string
 
@@ -0,0 +1,53 @@
+// Copyright (C) MongoDB, Inc. 2017-present.
+//
+// Licensed under the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License. You may obtain
+// a copy of the License at http://www.apache.org/licenses/LICENSE-2.0
+
+package options
+
+// ShardCollectionOptions represents options that can be used to configure a ShardCollection operation.
+type ShardCollectionOptions struct {
+	// If true, the shard key is unique. The shard key must not be hashed if this is true. The default value is false.
+	Unique *bool
+
+	// The number of chunks to create initially when sharding an empty collection with a hashed shard key. The default
+	// is to let the server choose.
+	NumInitialChunks *int32
+}
+
+// ShardCollection creates a new ShardCollectionOptions instance.
+func ShardCollection() *ShardCollectionOptions {
+	return &ShardCollectionOptions{}
+}
+
+// SetUnique sets the value for the Unique field.
+func (sco *ShardCollectionOptions) SetUnique(b bool) *ShardCollectionOptions {
+	sco.Unique = &b
+	return sco
+}
+
+// SetNumInitialChunks sets the value for the NumInitialChunks field.
+func (sco *ShardCollectionOptions) SetNumInitialChunks(n int32) *ShardCollectionOptions {
+	sco.NumInitialChunks = &n
+	return sco
+}
+
+// MergeShardCollectionOptions combines the given ShardCollectionOptions instances into a single
+// *ShardCollectionOptions in a last-one-wins fashion.
+func MergeShardCollectionOptions(opts ...*ShardCollectionOptions) *ShardCollectionOptions {
+	sco := ShardCollection()
+	for _, opt := range opts {
+		if opt == nil {
+			continue
+		}
+		if opt.Unique != nil {
+			sco.Unique = opt.Unique
+		}
+		if opt.NumInitialChunks != nil {
+			sco.NumInitialChunks = opt.NumInitialChunks
+		}
+	}
+
+	return sco
+}
@@ -0,0 +1,52 @@
+// Copyright (C) MongoDB, Inc. 2017-present.
+//
+// Licensed under the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License. You may obtain
+// a copy of the License at http://www.apache.org/licenses/LICENSE-2.0
+
+package mongo
+
+import (
+	"context"
+	"errors"
+)
+
+// WriteWithOutbox inserts businessDoc into businessColl and event into outboxColl in a single transaction, so that
+// either both documents are committed or neither is. This implements the transactional outbox pattern: a separate
+// process can read outboxColl and publish the events it contains knowing that each one corresponds to a committed
+// business write.
+//
+// The transaction is run with sess.WithTransaction, so it is retried on transient errors using the session's default
+// transaction options. Both collections must belong to the Client that created sess. The InsertOneResult for
+// businessDoc is returned if the transaction commits.
+//
+// Transactions require a replica set or sharded cluster. Before MongoDB 4.4, both collections must already exist
+// because collections cannot be created inside a transaction.
+func WriteWithOutbox(ctx context.Context, sess Session, businessColl *Collection, businessDoc interface{},
+	outboxColl *Collection, event interface{}) (*InsertOneResult, error) {
+
+	if sess == nil {
+		return nil, errors.New("session must not be nil")
+	}
+	if businessColl == nil || outboxColl == nil {
+		return nil, errors.New("business and outbox collections must not be nil")
+	}
+	if businessDoc == nil || event == nil {
+		return nil, ErrNilDocument
+	}
+
+	res, err := sess.WithTransaction(ctx, func(sc SessionContext) (interface{}, error) {
+		res, err := businessColl.InsertOne(sc, businessDoc)
+		if err != nil {
+			return nil, err
+		}
+		if _, err = outboxColl.InsertOne(sc, event); err != nil {
+			return nil, err
+		}
+		return res, nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return res.(*InsertOneResult), nil
+}
@@ -0,0 +1,103 @@
+// Copyright (C) MongoDB, Inc. 2017-present.
+//
+// Licensed under the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License. You may obtain
+// a copy of the License at http://www.apache.org/licenses/LICENSE-2.0
+
+package mongo
+
+import (
+	"context"
+	"errors"
+	"strings"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+// KeysetCursor is the position of the last document of a page returned by KeysetPaginate. It holds both the value of
+// the sort field and the _id of that document so documents that share the same sort value are not skipped between
+// pages.
+type KeysetCursor struct {
+	Value bson.RawValue
+	ID    bson.RawValue
+}
+
+// KeysetPaginate returns a page of at most pageSize documents from coll, sorted in ascending order by sortField, that
+// come after lastValue. The returned cursor value should be passed as lastValue to retrieve the next page.
+//
+// If lastValue is nil, the first page is returned. If lastValue is a KeysetCursor returned by a previous call, the
+// page starts after that document, with ties on sortField broken by _id. Any other lastValue is treated as a plain
+// sort value and the filter {sortField: {$gt: lastValue}} is used.
+//
+// If the page is empty, the returned cursor value is lastValue.
+func KeysetPaginate(ctx context.Context, coll *Collection, sortField string, lastValue interface{},
+	pageSize int64) ([]bson.Raw, interface{}, error) {
+
+	if sortField == "" {
+		return nil, nil, errors.New("sort field must not be empty")
+	}
+	if pageSize <= 0 {
+		return nil, nil, errors.New("page size must be positive")
+	}
+
+	findOpts := options.Find().
+		SetSort(keysetSort(sortField)).
+		SetLimit(pageSize)
+	cursor, err := coll.Find(ctx, keysetFilter(sortField, lastValue), findOpts)
+	if err != nil {
+		return nil, nil, err
+	}
+	defer cursor.Close(ctx)
+
+	var page []bson.Raw
+	for cursor.Next(ctx) {
+		doc := make(bson.Raw, len(cursor.Current))
+		copy(doc, cursor.Current)
+		page = append(page, doc)
+	}
+	if err := cursor.Err(); err != nil {
+		return nil, nil, err
+	}
+
+	if len(page) == 0 {
+		return page, lastValue, nil
+	}
+	last := page[len(page)-1]
+	next := KeysetCursor{
+		Value: last.Lookup(strings.Split(sortField, ".")...),
+		ID:    last.Lookup("_id"),
+	}
+	return page, next, nil
+}
+
+// keysetSort returns the sort document used by KeysetPaginate. _id is used as a tie-breaker unless it is the sort field.
+func keysetSort(sortField string) bson.D {
+	if sortField == "_id" {
+		return bson.D{{"_id", 1}}
+	}
+	return bson.D{{sortField, 1}, {"_id", 1}}
+}
+
+// keysetFilter returns the filter that selects the documents after lastValue.
+func keysetFilter(sortField string, lastValue interface{}) bson.D {
+	switch lv := lastValue.(type) {
+	case nil:
+		return bson.D{}
+	case KeysetCursor:
+		if sortField == "_id" {
+			return bson.D{{"_id", bson.D{{"$gt", lv.ID}}}}
+		}
+		return bson.D{{"$or", bson.A{
+			bson.D{{sortField, bson.D{{"$gt", lv.Value}}}},
+			bson.D{{sortField, lv.Value}, {"_id", bson.D{{"$gt", lv.ID}}}},
+		}}}
+	case *KeysetCursor:
+		if lv == nil {
+			return bson.D{}
+		}
+		return keysetFilter(sortField, *lv)
+	default:
+		return bson.D{{sortField, bson.D{{"$gt", lastValue}}}}
+	}
+}
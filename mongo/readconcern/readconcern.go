@@ -45,6 +45,12 @@ func Linearizable() *ReadConcern {
 
 // Available specifies that the query should return data from the instance with no guarantee
 // that the data has been written to a majority of the replica set members (i.e. may be rolled back).
+//
+// On sharded clusters, "available" provides the lowest latency reads because shards do not check
+// chunk ownership with the config servers. As a result, the query may return orphaned documents,
+// which are documents left on a shard by a failed or incomplete chunk migration, so the same
+// document can be returned more than once or a document the shard no longer owns can be returned.
+// Use Local or Majority if orphaned documents must be filtered out.
 func Available() *ReadConcern {
 	return New(Level("available"))
 }
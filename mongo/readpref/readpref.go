@@ -19,6 +19,9 @@ var (
 	errInvalidReadPreference = errors.New("can not specify tags, max staleness, or hedge with mode primary")
 )
 
+// minMaxStaleness is the smallest max staleness allowed by the server selection specification.
+const minMaxStaleness = 90 * time.Second
+
 var primary = ReadPref{mode: PrimaryMode}
 
 // Primary constructs a read preference with a PrimaryMode.
@@ -33,6 +36,20 @@ func PrimaryPreferred(opts ...Option) *ReadPref {
 	return rp
 }
 
+// PrimaryPreferredWithMaxStaleness constructs a read preference with a PrimaryPreferredMode and the given max
+// staleness. Reads are sent to the primary while it is available and otherwise fall back to the secondaries whose
+// estimated staleness is at most maxStaleness. The max staleness overrides any set by opts. An error is returned if
+// maxStaleness is less than 90 seconds, the smallest value allowed by the server selection specification. Server
+// selection additionally fails if maxStaleness is less than the heartbeat interval plus 10 seconds.
+func PrimaryPreferredWithMaxStaleness(maxStaleness time.Duration, opts ...Option) (*ReadPref, error) {
+	if maxStaleness < minMaxStaleness {
+		return nil, fmt.Errorf("max staleness (%s) must be greater than or equal to %s", maxStaleness, minMaxStaleness)
+	}
+
+	opts = append(opts[:len(opts):len(opts)], WithMaxStaleness(maxStaleness))
+	return New(PrimaryPreferredMode, opts...)
+}
+
 // SecondaryPreferred constructs a read preference with a SecondaryPreferredMode.
 func SecondaryPreferred(opts ...Option) *ReadPref {
 	// New only returns an error with a mode of Primary
@@ -49,6 +49,38 @@ func TestPrimaryPreferred_with_options(t *testing.T) {
 	require.Equal([]tag.Set{{tag.Tag{Name: "a", Value: "1"}, tag.Tag{Name: "b", Value: "2"}}}, subject.TagSets())
 }
 
+func TestPrimaryPreferredWithMaxStaleness(t *testing.T) {
+	t.Run("valid", func(t *testing.T) {
+		subject, err := PrimaryPreferredWithMaxStaleness(2*time.Minute, WithTags("a", "1"))
+		assert.Nil(t, err, "PrimaryPreferredWithMaxStaleness error: %v", err)
+
+		assert.Equal(t, PrimaryPreferredMode, subject.Mode(), "expected mode %v, got %v", PrimaryPreferredMode,
+			subject.Mode())
+		ms, set := subject.MaxStaleness()
+		assert.True(t, set, "expected max staleness to be set")
+		assert.Equal(t, 2*time.Minute, ms, "expected max staleness %v, got %v", 2*time.Minute, ms)
+		want := []tag.Set{{tag.Tag{Name: "a", Value: "1"}}}
+		assert.Equal(t, want, subject.TagSets(), "expected tag sets %v, got %v", want, subject.TagSets())
+	})
+	t.Run("overrides max staleness option", func(t *testing.T) {
+		subject, err := PrimaryPreferredWithMaxStaleness(2*time.Minute, WithMaxStaleness(time.Second))
+		assert.Nil(t, err, "PrimaryPreferredWithMaxStaleness error: %v", err)
+
+		ms, _ := subject.MaxStaleness()
+		assert.Equal(t, 2*time.Minute, ms, "expected max staleness %v, got %v", 2*time.Minute, ms)
+	})
+	t.Run("max staleness too low", func(t *testing.T) {
+		for _, ms := range []time.Duration{-1, 0, 89 * time.Second} {
+			_, err := PrimaryPreferredWithMaxStaleness(ms)
+			assert.NotNil(t, err, "expected error for max staleness %v, got nil", ms)
+		}
+	})
+	t.Run("invalid option", func(t *testing.T) {
+		_, err := PrimaryPreferredWithMaxStaleness(2*time.Minute, WithTags("a"))
+		assert.Equal(t, ErrInvalidTagSet, err, "expected error %v, got %v", ErrInvalidTagSet, err)
+	})
+}
+
 func TestSecondaryPreferred(t *testing.T) {
 	require := require.New(t)
 	subject := SecondaryPreferred()
@@ -8,6 +8,7 @@ package mongo
 
 import (
 	"fmt"
+	"time"
 
 	"go.mongodb.org/mongo-driver/bson"
 	"go.mongodb.org/mongo-driver/bson/primitive"
@@ -33,23 +34,124 @@ type BulkWriteResult struct {
 
 	// A map of operation index to the _id of each upserted document.
 	UpsertedIDs map[int64]interface{}
+
+	// The operationTime reported by the server for the last batch, or nil if the server did not report one.
+	OperationTime *primitive.Timestamp
+}
+
+// BulkWriteDryRunResult is the result type returned by a BulkWriteDryRun operation. The counts are projections based on
+// the documents in the collection when each model's filter is counted.
+type BulkWriteDryRunResult struct {
+	// The number of documents that would be inserted by InsertOneModels.
+	InsertedCount int64
+
+	// The number of documents that would be matched by filters in update and replace operations.
+	MatchedCount int64
+
+	// The number of documents that could be modified by update and replace operations. Whether a matched document is
+	// actually modified depends on its contents, so this is an upper bound equal to MatchedCount.
+	ModifiedCount int64
+
+	// The number of documents that would be deleted.
+	DeletedCount int64
+
+	// The number of documents that would be upserted by update and replace operations with Upsert set to true whose
+	// filters match no documents.
+	UpsertedCount int64
+
+	// The number of documents matched by the filter of each model, in the same order as the models passed to
+	// BulkWriteDryRun. For DeleteOneModel, ReplaceOneModel, and UpdateOneModel, the count is at most 1. For
+	// InsertOneModel, the count is 0.
+	ModelMatchedCounts []int64
 }
 
 // InsertOneResult is the result type returned by an InsertOne operation.
 type InsertOneResult struct {
 	// The _id of the inserted document. A value generated by the driver will be of type primitive.ObjectID.
 	InsertedID interface{}
+
+	// The operationTime reported by the server for the insert, or nil if the server did not report one.
+	OperationTime *primitive.Timestamp
 }
 
 // InsertManyResult is a result type returned by an InsertMany operation.
 type InsertManyResult struct {
 	// The _id values of the inserted documents. Values generated by the driver will be of type primitive.ObjectID.
 	InsertedIDs []interface{}
+
+	// The operationTime reported by the server for the last insert batch, or nil if the server did not report one.
+	OperationTime *primitive.Timestamp
+
+	// The number of documents that were not sent because their _id duplicated an earlier document in the batch. This
+	// is always 0 unless the DeduplicateByID option is set.
+	DuplicatesRemoved int
 }
 
 // DeleteResult is the result type returned by DeleteOne and DeleteMany operations.
 type DeleteResult struct {
-	DeletedCount int64 `bson:"n"` // The number of documents deleted.
+	DeletedCount  int64                `bson:"n"`                       // The number of documents deleted.
+	OperationTime *primitive.Timestamp `bson:"operationTime,omitempty"` // The operationTime reported by the server, or nil if none was reported.
+}
+
+// CurrentOp is a single in-progress operation returned by a CurrentOps operation. Fields that the server does not
+// report for an operation are left at their zero values.
+type CurrentOp struct {
+	// The ID of the operation, which can be passed to the killOp command. This is an int32 for operations on a mongod
+	// and a string of the form "<shard>:<opid>" for operations on a shard reported through a mongos.
+	OpID interface{} `bson:"opid"`
+
+	// Whether the operation has started.
+	Active bool `bson:"active"`
+
+	// The type of the operation, such as "query", "insert", "command", or "getmore".
+	Op string `bson:"op"`
+
+	// The number of whole seconds the operation has been running.
+	SecsRunning int64 `bson:"secs_running"`
+
+	// The number of microseconds the operation has been running.
+	MicrosecsRunning int64 `bson:"microsecs_running"`
+
+	// The namespace the operation targets, such as "db.collection".
+	Namespace string `bson:"ns"`
+
+	// The command document for the operation.
+	Command bson.Raw `bson:"command"`
+
+	// The host and port of the client that started the operation.
+	Client string `bson:"client"`
+
+	// A description of the connection or thread running the operation.
+	Desc string `bson:"desc"`
+
+	// The full document reported by the server for the operation.
+	Raw bson.Raw `bson:"-"`
+}
+
+// ProfileEntry is a single document written to the system.profile collection by the database profiler and returned
+// by a ProfilerEntries operation. Fields that the server does not report for an operation are left at their zero
+// values.
+type ProfileEntry struct {
+	// The type of the operation, such as "query", "insert", "command", or "getmore".
+	Op string `bson:"op"`
+
+	// The namespace the operation targets, such as "db.collection".
+	Namespace string `bson:"ns"`
+
+	// The number of milliseconds the operation took to run.
+	Millis int64 `bson:"millis"`
+
+	// The time at which the operation finished.
+	Timestamp time.Time `bson:"ts"`
+
+	// The command document for the operation.
+	Command bson.Raw `bson:"command"`
+
+	// A summary of the query plan used by the operation, such as "COLLSCAN" or "IXSCAN { name: 1 }".
+	PlanSummary string `bson:"planSummary"`
+
+	// The full document written by the profiler for the operation.
+	Raw bson.Raw `bson:"-"`
 }
 
 // ListDatabasesResult is a result of a ListDatabases operation.
@@ -75,6 +177,24 @@ func newListDatabasesResultFromOperation(res operation.ListDatabasesResult) List
 	return ldr
 }
 
+// CollStats contains storage statistics for a collection. This type is returned by Collection.Stats.
+type CollStats struct {
+	// The total uncompressed size in bytes of all documents in the collection.
+	Size int64 `bson:"size"`
+
+	// The amount of storage in bytes allocated to the collection.
+	StorageSize int64 `bson:"storageSize"`
+
+	// The total size in bytes of all indexes on the collection.
+	TotalIndexSize int64 `bson:"totalIndexSize"`
+
+	// The number of documents in the collection.
+	Count int64 `bson:"count"`
+
+	// The average size in bytes of a document in the collection.
+	AvgObjSize float64 `bson:"avgObjSize"`
+}
+
 // DatabaseSpecification contains information for a database. This type is returned as part of ListDatabasesResult.
 type DatabaseSpecification struct {
 	Name       string // The name of the database.
@@ -84,10 +204,11 @@ type DatabaseSpecification struct {
 
 // UpdateResult is the result type returned from UpdateOne, UpdateMany, and ReplaceOne operations.
 type UpdateResult struct {
-	MatchedCount  int64       // The number of documents matched by the filter.
-	ModifiedCount int64       // The number of documents modified by the operation.
-	UpsertedCount int64       // The number of documents upserted by the operation.
-	UpsertedID    interface{} // The _id field of the upserted document, or nil if no upsert was done.
+	MatchedCount  int64                // The number of documents matched by the filter.
+	ModifiedCount int64                // The number of documents modified by the operation.
+	UpsertedCount int64                // The number of documents upserted by the operation.
+	UpsertedID    interface{}          // The _id field of the upserted document, or nil if no upsert was done.
+	OperationTime *primitive.Timestamp // The operationTime reported by the server, or nil if none was reported.
 }
 
 // UnmarshalBSON implements the bson.Unmarshaler interface.
@@ -0,0 +1,441 @@
+// Copyright (C) MongoDB, Inc. 2017-present.
+//
+// Licensed under the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License. You may obtain
+// a copy of the License at http://www.apache.org/licenses/LICENSE-2.0
+
+package mongo
+
+import (
+	"bytes"
+	"fmt"
+	"regexp"
+	"strconv"
+	"unicode/utf8"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/bsontype"
+	"go.mongodb.org/mongo-driver/x/bsonx/bsoncore"
+)
+
+// jsonSchemaBSONTypes maps the aliases accepted by the $jsonSchema bsonType keyword to BSON types. The "number" alias
+// is handled separately because it matches several types.
+var jsonSchemaBSONTypes = map[string]bsontype.Type{
+	"double":              bsontype.Double,
+	"string":              bsontype.String,
+	"object":              bsontype.EmbeddedDocument,
+	"array":               bsontype.Array,
+	"binData":             bsontype.Binary,
+	"undefined":           bsontype.Undefined,
+	"objectId":            bsontype.ObjectID,
+	"bool":                bsontype.Boolean,
+	"date":                bsontype.DateTime,
+	"null":                bsontype.Null,
+	"regex":               bsontype.Regex,
+	"dbPointer":           bsontype.DBPointer,
+	"javascript":          bsontype.JavaScript,
+	"symbol":              bsontype.Symbol,
+	"javascriptWithScope": bsontype.CodeWithScope,
+	"int":                 bsontype.Int32,
+	"timestamp":           bsontype.Timestamp,
+	"long":                bsontype.Int64,
+	"decimal":             bsontype.Decimal128,
+	"minKey":              bsontype.MinKey,
+	"maxKey":              bsontype.MaxKey,
+}
+
+// jsonSchemaJSONTypes maps the values accepted by the $jsonSchema type keyword to BSON types. The "number" type is
+// handled separately because it matches several types.
+var jsonSchemaJSONTypes = map[string]bsontype.Type{
+	"object":  bsontype.EmbeddedDocument,
+	"array":   bsontype.Array,
+	"string":  bsontype.String,
+	"boolean": bsontype.Boolean,
+	"null":    bsontype.Null,
+}
+
+// jsonSchema is a parsed $jsonSchema document used to validate documents on the client. It supports the bsonType,
+// type, required, properties, additionalProperties, enum, minimum, maximum, exclusiveMinimum, exclusiveMaximum,
+// minLength, maxLength, pattern, items, minItems, maxItems, minProperties, and maxProperties keywords. The title and
+// description keywords are ignored.
+type jsonSchema struct {
+	types                []bsontype.Type
+	matchNumber          bool
+	required             []string
+	properties           map[string]*jsonSchema
+	additionalProperties *bool
+	enum                 []bsoncore.Value
+	minimum              *float64
+	maximum              *float64
+	exclusiveMinimum     bool
+	exclusiveMaximum     bool
+	minLength            *int64
+	maxLength            *int64
+	pattern              *regexp.Regexp
+	items                *jsonSchema
+	minItems             *int64
+	maxItems             *int64
+	minProperties        *int64
+	maxProperties        *int64
+}
+
+// newJSONSchema parses schema, which can either be a $jsonSchema document or a collection validator of the form
+// {$jsonSchema: <schema>}.
+func newJSONSchema(schema bson.Raw) (*jsonSchema, error) {
+	doc := bsoncore.Document(schema)
+	if err := doc.Validate(); err != nil {
+		return nil, err
+	}
+	elems, err := doc.Elements()
+	if err != nil {
+		return nil, err
+	}
+	if len(elems) == 1 && elems[0].Key() == "$jsonSchema" {
+		val := elems[0].Value()
+		if val.Type != bsontype.EmbeddedDocument {
+			return nil, fmt.Errorf("$jsonSchema must be a document, got %v", val.Type)
+		}
+		doc = val.Document()
+	}
+	return parseJSONSchema(doc)
+}
+
+func parseJSONSchema(doc bsoncore.Document) (*jsonSchema, error) {
+	elems, err := doc.Elements()
+	if err != nil {
+		return nil, err
+	}
+
+	js := &jsonSchema{}
+	for _, elem := range elems {
+		key, val := elem.Key(), elem.Value()
+		switch key {
+		case "bsonType":
+			err = js.parseTypes(key, val, jsonSchemaBSONTypes)
+		case "type":
+			err = js.parseTypes(key, val, jsonSchemaJSONTypes)
+		case "required":
+			js.required, err = parseSchemaStrings(key, val)
+		case "properties":
+			js.properties, err = parseSchemaProperties(val)
+		case "additionalProperties":
+			b, ok := val.BooleanOK()
+			if !ok {
+				return nil, fmt.Errorf("additionalProperties must be a boolean, got %v", val.Type)
+			}
+			js.additionalProperties = &b
+		case "enum":
+			arr, ok := val.ArrayOK()
+			if !ok {
+				return nil, fmt.Errorf("enum must be an array, got %v", val.Type)
+			}
+			js.enum, err = arr.Values()
+		case "minimum":
+			js.minimum, err = parseSchemaNumber(key, val)
+		case "maximum":
+			js.maximum, err = parseSchemaNumber(key, val)
+		case "exclusiveMinimum":
+			if js.exclusiveMinimum, err = parseSchemaBool(key, val); err != nil {
+				return nil, err
+			}
+		case "exclusiveMaximum":
+			if js.exclusiveMaximum, err = parseSchemaBool(key, val); err != nil {
+				return nil, err
+			}
+		case "minLength":
+			js.minLength, err = parseSchemaCount(key, val)
+		case "maxLength":
+			js.maxLength, err = parseSchemaCount(key, val)
+		case "pattern":
+			str, ok := val.StringValueOK()
+			if !ok {
+				return nil, fmt.Errorf("pattern must be a string, got %v", val.Type)
+			}
+			js.pattern, err = regexp.Compile(str)
+		case "items":
+			sub, ok := val.DocumentOK()
+			if !ok {
+				return nil, fmt.Errorf("items must be a document, got %v", val.Type)
+			}
+			js.items, err = parseJSONSchema(sub)
+		case "minItems":
+			js.minItems, err = parseSchemaCount(key, val)
+		case "maxItems":
+			js.maxItems, err = parseSchemaCount(key, val)
+		case "minProperties":
+			js.minProperties, err = parseSchemaCount(key, val)
+		case "maxProperties":
+			js.maxProperties, err = parseSchemaCount(key, val)
+		case "title", "description":
+		default:
+			return nil, fmt.Errorf("unsupported $jsonSchema keyword %q", key)
+		}
+		if err != nil {
+			return nil, err
+		}
+	}
+	return js, nil
+}
+
+func (js *jsonSchema) parseTypes(key string, val bsoncore.Value, aliases map[string]bsontype.Type) error {
+	names := []string{}
+	switch val.Type {
+	case bsontype.String:
+		names = append(names, val.StringValue())
+	case bsontype.Array:
+		var err error
+		if names, err = parseSchemaStrings(key, val); err != nil {
+			return err
+		}
+	default:
+		return fmt.Errorf("%s must be a string or an array of strings, got %v", key, val.Type)
+	}
+
+	for _, name := range names {
+		if name == "number" {
+			js.matchNumber = true
+			continue
+		}
+		t, ok := aliases[name]
+		if !ok {
+			return fmt.Errorf("unknown %s %q", key, name)
+		}
+		js.types = append(js.types, t)
+	}
+	return nil
+}
+
+func parseSchemaProperties(val bsoncore.Value) (map[string]*jsonSchema, error) {
+	doc, ok := val.DocumentOK()
+	if !ok {
+		return nil, fmt.Errorf("properties must be a document, got %v", val.Type)
+	}
+	elems, err := doc.Elements()
+	if err != nil {
+		return nil, err
+	}
+
+	props := make(map[string]*jsonSchema, len(elems))
+	for _, elem := range elems {
+		sub, ok := elem.Value().DocumentOK()
+		if !ok {
+			return nil, fmt.Errorf("schema for property %q must be a document, got %v", elem.Key(), elem.Value().Type)
+		}
+		if props[elem.Key()], err = parseJSONSchema(sub); err != nil {
+			return nil, err
+		}
+	}
+	return props, nil
+}
+
+func parseSchemaStrings(key string, val bsoncore.Value) ([]string, error) {
+	arr, ok := val.ArrayOK()
+	if !ok {
+		return nil, fmt.Errorf("%s must be an array of strings, got %v", key, val.Type)
+	}
+	vals, err := arr.Values()
+	if err != nil {
+		return nil, err
+	}
+
+	strs := make([]string, 0, len(vals))
+	for _, v := range vals {
+		str, ok := v.StringValueOK()
+		if !ok {
+			return nil, fmt.Errorf("%s must be an array of strings, found %v", key, v.Type)
+		}
+		strs = append(strs, str)
+	}
+	return strs, nil
+}
+
+func parseSchemaNumber(key string, val bsoncore.Value) (*float64, error) {
+	f, ok := schemaNumber(val)
+	if !ok {
+		return nil, fmt.Errorf("%s must be a number, got %v", key, val.Type)
+	}
+	return &f, nil
+}
+
+func parseSchemaCount(key string, val bsoncore.Value) (*int64, error) {
+	f, ok := schemaNumber(val)
+	if !ok || f < 0 || f != float64(int64(f)) {
+		return nil, fmt.Errorf("%s must be a non-negative integer, got %v", key, val)
+	}
+	n := int64(f)
+	return &n, nil
+}
+
+func parseSchemaBool(key string, val bsoncore.Value) (bool, error) {
+	b, ok := val.BooleanOK()
+	if !ok {
+		return false, fmt.Errorf("%s must be a boolean, got %v", key, val.Type)
+	}
+	return b, nil
+}
+
+// schemaNumber returns val as a float64 if it is a BSON double, int32, int64, or decimal128.
+func schemaNumber(val bsoncore.Value) (float64, bool) {
+	switch val.Type {
+	case bsontype.Double:
+		return val.Double(), true
+	case bsontype.Int32:
+		return float64(val.Int32()), true
+	case bsontype.Int64:
+		return float64(val.Int64()), true
+	case bsontype.Decimal128:
+		f, err := strconv.ParseFloat(val.Decimal128().String(), 64)
+		return f, err == nil
+	default:
+		return 0, false
+	}
+}
+
+// validate returns a description of each way doc does not match the schema. An empty result means doc is valid.
+func (js *jsonSchema) validate(doc bsoncore.Document) []string {
+	var violations []string
+	js.validateValue(bsoncore.Value{Type: bsontype.EmbeddedDocument, Data: doc}, "", &violations)
+	return violations
+}
+
+func (js *jsonSchema) validateValue(val bsoncore.Value, path string, violations *[]string) {
+	report := func(format string, args ...interface{}) {
+		msg := fmt.Sprintf(format, args...)
+		if path != "" {
+			msg = fmt.Sprintf("%s: %s", path, msg)
+		}
+		*violations = append(*violations, msg)
+	}
+
+	if !js.matchesType(val) {
+		report("value of type %v does not match the schema type", val.Type)
+		return
+	}
+	if len(js.enum) > 0 && !schemaEnumContains(js.enum, val) {
+		report("value %v is not one of the enum values", val)
+	}
+
+	if f, ok := schemaNumber(val); ok {
+		if js.minimum != nil && (f < *js.minimum || js.exclusiveMinimum && f == *js.minimum) {
+			report("value %v is less than the minimum %v", val, *js.minimum)
+		}
+		if js.maximum != nil && (f > *js.maximum || js.exclusiveMaximum && f == *js.maximum) {
+			report("value %v is greater than the maximum %v", val, *js.maximum)
+		}
+	}
+
+	switch val.Type {
+	case bsontype.String:
+		str := val.StringValue()
+		length := int64(utf8.RuneCountInString(str))
+		if js.minLength != nil && length < *js.minLength {
+			report("string length %d is less than minLength %d", length, *js.minLength)
+		}
+		if js.maxLength != nil && length > *js.maxLength {
+			report("string length %d is greater than maxLength %d", length, *js.maxLength)
+		}
+		if js.pattern != nil && !js.pattern.MatchString(str) {
+			report("string %q does not match pattern %q", str, js.pattern)
+		}
+	case bsontype.Array:
+		vals, err := val.Array().Values()
+		if err != nil {
+			report("invalid array: %v", err)
+			return
+		}
+		count := int64(len(vals))
+		if js.minItems != nil && count < *js.minItems {
+			report("array length %d is less than minItems %d", count, *js.minItems)
+		}
+		if js.maxItems != nil && count > *js.maxItems {
+			report("array length %d is greater than maxItems %d", count, *js.maxItems)
+		}
+		if js.items != nil {
+			for i, item := range vals {
+				js.items.validateValue(item, joinSchemaPath(path, strconv.Itoa(i)), violations)
+			}
+		}
+	case bsontype.EmbeddedDocument:
+		js.validateDocument(val.Document(), path, report, violations)
+	}
+}
+
+func (js *jsonSchema) validateDocument(doc bsoncore.Document, path string,
+	report func(string, ...interface{}), violations *[]string) {
+
+	elems, err := doc.Elements()
+	if err != nil {
+		report("invalid document: %v", err)
+		return
+	}
+
+	count := int64(len(elems))
+	if js.minProperties != nil && count < *js.minProperties {
+		report("document has %d fields, less than minProperties %d", count, *js.minProperties)
+	}
+	if js.maxProperties != nil && count > *js.maxProperties {
+		report("document has %d fields, more than maxProperties %d", count, *js.maxProperties)
+	}
+
+	present := make(map[string]struct{}, len(elems))
+	for _, elem := range elems {
+		key := elem.Key()
+		present[key] = struct{}{}
+
+		if sub, ok := js.properties[key]; ok {
+			sub.validateValue(elem.Value(), joinSchemaPath(path, key), violations)
+			continue
+		}
+		if js.additionalProperties != nil && !*js.additionalProperties {
+			report("field %q is not allowed by the schema", key)
+		}
+	}
+	for _, key := range js.required {
+		if _, ok := present[key]; !ok {
+			report("missing required field %q", key)
+		}
+	}
+}
+
+func (js *jsonSchema) matchesType(val bsoncore.Value) bool {
+	if len(js.types) == 0 && !js.matchNumber {
+		return true
+	}
+	if js.matchNumber {
+		switch val.Type {
+		case bsontype.Double, bsontype.Int32, bsontype.Int64, bsontype.Decimal128:
+			return true
+		}
+	}
+	for _, t := range js.types {
+		if val.Type == t {
+			return true
+		}
+	}
+	return false
+}
+
+// schemaEnumContains returns true if enum contains val. Numeric values are compared by value regardless of their
+// BSON type.
+func schemaEnumContains(enum []bsoncore.Value, val bsoncore.Value) bool {
+	f, isNumber := schemaNumber(val)
+	for _, e := range enum {
+		if isNumber {
+			if ef, ok := schemaNumber(e); ok && ef == f {
+				return true
+			}
+			continue
+		}
+		if e.Type == val.Type && bytes.Equal(e.Data, val.Data) {
+			return true
+		}
+	}
+	return false
+}
+
+func joinSchemaPath(path, key string) string {
+	if path == "" {
+		return key
+	}
+	return path + "." + key
+}
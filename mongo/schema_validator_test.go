@@ -0,0 +1,103 @@
+// Copyright (C) MongoDB, Inc. 2017-present.
+//
+// Licensed under the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License. You may obtain
+// a copy of the License at http://www.apache.org/licenses/LICENSE-2.0
+
+package mongo
+
+import (
+	"testing"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/internal/testutil/assert"
+	"go.mongodb.org/mongo-driver/x/bsonx/bsoncore"
+)
+
+func TestJSONSchema(t *testing.T) {
+	marshal := func(t *testing.T, val interface{}) bson.Raw {
+		t.Helper()
+		doc, err := bson.Marshal(val)
+		assert.Nil(t, err, "Marshal error: %v", err)
+		return doc
+	}
+
+	schema := bson.D{
+		{"bsonType", "object"},
+		{"required", bson.A{"sku", "price"}},
+		{"additionalProperties", false},
+		{"properties", bson.D{
+			{"_id", bson.D{{"bsonType", "objectId"}}},
+			{"sku", bson.D{{"bsonType", "string"}, {"pattern", "^[A-Z]{3}-[0-9]+$"}}},
+			{"price", bson.D{{"bsonType", "number"}, {"minimum", 0}, {"exclusiveMinimum", true}}},
+			{"status", bson.D{{"enum", bson.A{"active", "retired"}}}},
+			{"tags", bson.D{
+				{"bsonType", "array"},
+				{"maxItems", 2},
+				{"items", bson.D{{"bsonType", "string"}, {"maxLength", 5}}},
+			}},
+			{"dimensions", bson.D{
+				{"bsonType", "object"},
+				{"required", bson.A{"w"}},
+				{"properties", bson.D{{"w", bson.D{{"type", "number"}, {"maximum", 100}}}}},
+			}},
+		}},
+	}
+
+	testCases := []struct {
+		name       string
+		doc        bson.D
+		violations int
+	}{
+		{"conforming", bson.D{{"sku", "ABC-1"}, {"price", 9.99}}, 0},
+		{"conforming with optional fields", bson.D{
+			{"sku", "ABC-1"},
+			{"price", int64(5)},
+			{"status", "active"},
+			{"tags", bson.A{"new", "sale"}},
+			{"dimensions", bson.D{{"w", int32(10)}}},
+		}, 0},
+		{"missing required fields", bson.D{}, 2},
+		{"wrong type", bson.D{{"sku", 123}, {"price", 1.0}}, 1},
+		{"pattern mismatch", bson.D{{"sku", "abc"}, {"price", 1.0}}, 1},
+		{"exclusive minimum", bson.D{{"sku", "ABC-1"}, {"price", 0}}, 1},
+		{"enum mismatch", bson.D{{"sku", "ABC-1"}, {"price", 1.0}, {"status", "lost"}}, 1},
+		{"additional property", bson.D{{"sku", "ABC-1"}, {"price", 1.0}, {"color", "red"}}, 1},
+		{"array constraints", bson.D{{"sku", "ABC-1"}, {"price", 1.0}, {"tags", bson.A{"a", "b", "toolong"}}}, 2},
+		{"nested document", bson.D{{"sku", "ABC-1"}, {"price", 1.0}, {"dimensions", bson.D{{"h", 1}}}}, 1},
+		{"nested maximum", bson.D{{"sku", "ABC-1"}, {"price", 1.0}, {"dimensions", bson.D{{"w", 101}}}}, 1},
+	}
+	for _, validator := range []bson.Raw{marshal(t, schema), marshal(t, bson.D{{"$jsonSchema", schema}})} {
+		js, err := newJSONSchema(validator)
+		assert.Nil(t, err, "newJSONSchema error: %v", err)
+
+		for _, tc := range testCases {
+			t.Run(tc.name, func(t *testing.T) {
+				violations := js.validate(bsoncore.Document(marshal(t, tc.doc)))
+				assert.Equal(t, tc.violations, len(violations), "expected %v violations, got %v: %v", tc.violations,
+					len(violations), violations)
+			})
+		}
+	}
+
+	t.Run("invalid schemas", func(t *testing.T) {
+		testCases := []struct {
+			name   string
+			schema bson.D
+		}{
+			{"unsupported keyword", bson.D{{"oneOf", bson.A{}}}},
+			{"unknown bsonType", bson.D{{"bsonType", "integer"}}},
+			{"non-string required", bson.D{{"required", bson.A{1}}}},
+			{"invalid pattern", bson.D{{"pattern", "("}}},
+			{"negative minLength", bson.D{{"minLength", -1}}},
+			{"non-document property", bson.D{{"properties", bson.D{{"x", "string"}}}}},
+			{"non-document $jsonSchema", bson.D{{"$jsonSchema", "object"}}},
+		}
+		for _, tc := range testCases {
+			t.Run(tc.name, func(t *testing.T) {
+				_, err := newJSONSchema(marshal(t, tc.schema))
+				assert.NotNil(t, err, "expected newJSONSchema error, got nil")
+			})
+		}
+	})
+}
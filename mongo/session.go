@@ -26,8 +26,19 @@ import (
 // the method call is using.
 var ErrWrongClient = errors.New("session was not created by this client")
 
+// ErrSessionNotBorrowed is returned by Client.ReturnSession when the session was not obtained from
+// Client.BorrowSession.
+var ErrSessionNotBorrowed = errors.New("session was not borrowed from this client")
+
 var withTransactionTimeout = 120 * time.Second
 
+// These constants track whether a session was obtained from Client.BorrowSession and whether it has been returned.
+const (
+	borrowNone int32 = iota
+	borrowActive
+	borrowReturned
+)
+
 // SessionContext combines the context.Context and mongo.Session interfaces. It should be used as the Context arguments
 // to operations that should be executed in a session. This type is not goroutine safe and must not be used concurrently
 // by multiple goroutines.
@@ -145,7 +156,8 @@ type sessionImpl struct {
 	clientSession       *session.Client
 	client              *Client
 	deployment          driver.Deployment
-	didCommitAfterStart bool // true if commit was called after start with no other operations
+	didCommitAfterStart bool  // true if commit was called after start with no other operations
+	borrowState         int32 // one of the borrow* constants, accessed atomically
 }
 
 var _ Session = &sessionImpl{}
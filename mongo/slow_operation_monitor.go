@@ -0,0 +1,82 @@
+// Copyright (C) MongoDB, Inc. 2017-present.
+//
+// Licensed under the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License. You may obtain
+// a copy of the License at http://www.apache.org/licenses/LICENSE-2.0
+
+package mongo
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"go.mongodb.org/mongo-driver/event"
+)
+
+// slowOperationMonitor wraps a CommandMonitor and reports commands that take at least threshold to complete. The
+// started event for each in-progress command is kept until the command succeeds or fails.
+type slowOperationMonitor struct {
+	threshold time.Duration
+	callback  func(event.CommandStartedEvent, time.Duration)
+	next      *event.CommandMonitor
+
+	mu      sync.Mutex
+	started map[int64]event.CommandStartedEvent
+}
+
+// newSlowOperationMonitor returns a CommandMonitor that calls callback for commands that take at least threshold to
+// complete and forwards all events to next, which may be nil.
+func newSlowOperationMonitor(next *event.CommandMonitor, threshold time.Duration,
+	callback func(event.CommandStartedEvent, time.Duration)) *event.CommandMonitor {
+
+	som := &slowOperationMonitor{
+		threshold: threshold,
+		callback:  callback,
+		next:      next,
+		started:   make(map[int64]event.CommandStartedEvent),
+	}
+	return &event.CommandMonitor{
+		Started:   som.commandStarted,
+		Succeeded: som.commandSucceeded,
+		Failed:    som.commandFailed,
+	}
+}
+
+func (som *slowOperationMonitor) commandStarted(ctx context.Context, evt *event.CommandStartedEvent) {
+	som.mu.Lock()
+	som.started[evt.RequestID] = *evt
+	som.mu.Unlock()
+
+	if som.next != nil && som.next.Started != nil {
+		som.next.Started(ctx, evt)
+	}
+}
+
+func (som *slowOperationMonitor) commandSucceeded(ctx context.Context, evt *event.CommandSucceededEvent) {
+	som.commandFinished(evt.CommandFinishedEvent)
+
+	if som.next != nil && som.next.Succeeded != nil {
+		som.next.Succeeded(ctx, evt)
+	}
+}
+
+func (som *slowOperationMonitor) commandFailed(ctx context.Context, evt *event.CommandFailedEvent) {
+	som.commandFinished(evt.CommandFinishedEvent)
+
+	if som.next != nil && som.next.Failed != nil {
+		som.next.Failed(ctx, evt)
+	}
+}
+
+func (som *slowOperationMonitor) commandFinished(evt event.CommandFinishedEvent) {
+	som.mu.Lock()
+	started, ok := som.started[evt.RequestID]
+	delete(som.started, evt.RequestID)
+	som.mu.Unlock()
+
+	duration := time.Duration(evt.DurationNanos)
+	if ok && duration >= som.threshold {
+		som.callback(started, duration)
+	}
+}
@@ -0,0 +1,104 @@
+// Copyright (C) MongoDB, Inc. 2017-present.
+//
+// Licensed under the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License. You may obtain
+// a copy of the License at http://www.apache.org/licenses/LICENSE-2.0
+
+package mongo
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/event"
+	"go.mongodb.org/mongo-driver/internal/testutil/assert"
+)
+
+func TestSlowOperationMonitor(t *testing.T) {
+	threshold := 50 * time.Millisecond
+	cmd, err := bson.Marshal(bson.D{{"find", "coll"}})
+	assert.Nil(t, err, "Marshal error: %v", err)
+
+	type slowOperation struct {
+		started  event.CommandStartedEvent
+		duration time.Duration
+	}
+
+	testCases := []struct {
+		name     string
+		duration time.Duration
+		failed   bool
+		reported bool
+	}{
+		{"slow success", 2 * threshold, false, true},
+		{"slow failure", 2 * threshold, true, true},
+		{"at threshold", threshold, false, true},
+		{"fast success", threshold / 2, false, false},
+		{"fast failure", threshold / 2, true, false},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			var reported []slowOperation
+			var started, succeeded, failed int
+			next := &event.CommandMonitor{
+				Started:   func(context.Context, *event.CommandStartedEvent) { started++ },
+				Succeeded: func(context.Context, *event.CommandSucceededEvent) { succeeded++ },
+				Failed:    func(context.Context, *event.CommandFailedEvent) { failed++ },
+			}
+			monitor := newSlowOperationMonitor(next, threshold, func(evt event.CommandStartedEvent, d time.Duration) {
+				reported = append(reported, slowOperation{evt, d})
+			})
+
+			monitor.Started(context.Background(), &event.CommandStartedEvent{
+				Command:     cmd,
+				CommandName: "find",
+				RequestID:   1,
+			})
+			finished := event.CommandFinishedEvent{
+				CommandName:   "find",
+				RequestID:     1,
+				DurationNanos: tc.duration.Nanoseconds(),
+			}
+			if tc.failed {
+				monitor.Failed(context.Background(), &event.CommandFailedEvent{CommandFinishedEvent: finished})
+			} else {
+				monitor.Succeeded(context.Background(), &event.CommandSucceededEvent{CommandFinishedEvent: finished})
+			}
+
+			assert.Equal(t, 1, started, "expected 1 started event to be forwarded, got %v", started)
+			assert.Equal(t, 1, succeeded+failed, "expected 1 finished event to be forwarded, got %v", succeeded+failed)
+			if !tc.reported {
+				assert.Equal(t, 0, len(reported), "expected no slow operations, got %v", len(reported))
+				return
+			}
+			assert.Equal(t, 1, len(reported), "expected 1 slow operation, got %v", len(reported))
+			assert.Equal(t, "find", reported[0].started.CommandName,
+				"expected command 'find', got %q", reported[0].started.CommandName)
+			assert.Equal(t, bson.Raw(cmd), reported[0].started.Command,
+				"expected command %v, got %v", bson.Raw(cmd), reported[0].started.Command)
+			assert.Equal(t, tc.duration, reported[0].duration,
+				"expected duration %v, got %v", tc.duration, reported[0].duration)
+		})
+	}
+	t.Run("nil monitor", func(t *testing.T) {
+		var count int
+		monitor := newSlowOperationMonitor(nil, 0, func(event.CommandStartedEvent, time.Duration) { count++ })
+
+		monitor.Started(context.Background(), &event.CommandStartedEvent{RequestID: 2})
+		monitor.Succeeded(context.Background(), &event.CommandSucceededEvent{
+			CommandFinishedEvent: event.CommandFinishedEvent{RequestID: 2},
+		})
+		assert.Equal(t, 1, count, "expected 1 slow operation, got %v", count)
+	})
+	t.Run("unknown request", func(t *testing.T) {
+		var count int
+		monitor := newSlowOperationMonitor(nil, 0, func(event.CommandStartedEvent, time.Duration) { count++ })
+
+		monitor.Succeeded(context.Background(), &event.CommandSucceededEvent{
+			CommandFinishedEvent: event.CommandFinishedEvent{RequestID: 3},
+		})
+		assert.Equal(t, 0, count, "expected no slow operations, got %v", count)
+	})
+}
@@ -1,9 +1,14 @@
 package driver
 
 import (
+	"context"
 	"testing"
+	"time"
 
+	"go.mongodb.org/mongo-driver/bson/primitive"
 	"go.mongodb.org/mongo-driver/internal/testutil/assert"
+	"go.mongodb.org/mongo-driver/mongo/description"
+	"go.mongodb.org/mongo-driver/x/bsonx/bsoncore"
 )
 
 func TestBatchCursor(t *testing.T) {
@@ -18,4 +23,114 @@ func TestBatchCursor(t *testing.T) {
 		bc.SetBatchSize(size)
 		assert.Equal(t, size, bc.batchSize, "expected batchSize %v, got %v", size, bc.batchSize)
 	})
+	t.Run("load balanced cursor pins connection", func(t *testing.T) {
+		serviceID := primitive.NewObjectID()
+		conn := &mockPinnedConnection{
+			mockConnection: &mockConnection{
+				rDesc: description.Server{Kind: description.LoadBalancer, ServiceID: &serviceID},
+			},
+		}
+		server := &mockPinnedServer{conn: conn}
+		response := bsoncore.NewDocumentBuilder().
+			AppendDocument("cursor", bsoncore.NewDocumentBuilder().
+				AppendInt64("id", 42).
+				AppendString("ns", "db.coll").
+				AppendArray("firstBatch", bsoncore.NewArrayBuilder().Build()).
+				Build()).
+			AppendInt32("ok", 1).
+			Build()
+
+		curresp, err := NewCursorResponse(ResponseInfo{
+			ServerResponse:        response,
+			Server:                server,
+			Connection:            conn,
+			ConnectionDescription: conn.Description(),
+		})
+		assert.Nil(t, err, "NewCursorResponse error: %v", err)
+		assert.Equal(t, 1, conn.cursorPins, "expected 1 cursor pin, got %d", conn.cursorPins)
+		assert.True(t, curresp.Connection == conn, "expected cursor to be pinned to the connection used to create it")
+
+		bc, err := NewBatchCursor(curresp, nil, nil, CursorOptions{})
+		assert.Nil(t, err, "NewBatchCursor error: %v", err)
+
+		// getMore and killCursors commands must use the pinned connection rather than checking out a new one.
+		got, err := bc.getOperationDeployment().(Server).Connection(context.Background())
+		assert.Nil(t, err, "Connection error: %v", err)
+		assert.True(t, got == conn, "expected cursor commands to use the pinned connection")
+		gotServiceID := got.Description().ServiceID
+		assert.NotNil(t, gotServiceID, "expected pinned connection to have a service ID")
+		assert.Equal(t, serviceID, *gotServiceID, "expected service ID %v, got %v", serviceID, *gotServiceID)
+		assert.Equal(t, 0, server.checkOuts, "expected no connections to be checked out, got %d", server.checkOuts)
+
+		err = bc.unpinConnection()
+		assert.Nil(t, err, "unpinConnection error: %v", err)
+		assert.Equal(t, 0, conn.cursorPins, "expected cursor to be unpinned, got %d pins", conn.cursorPins)
+	})
+	t.Run("cursor is not pinned without a load balancer", func(t *testing.T) {
+		conn := &mockPinnedConnection{
+			mockConnection: &mockConnection{rDesc: description.Server{Kind: description.RSPrimary}},
+		}
+		response := bsoncore.NewDocumentBuilder().
+			AppendDocument("cursor", bsoncore.NewDocumentBuilder().
+				AppendInt64("id", 42).
+				AppendString("ns", "db.coll").
+				AppendArray("firstBatch", bsoncore.NewArrayBuilder().Build()).
+				Build()).
+			AppendInt32("ok", 1).
+			Build()
+
+		curresp, err := NewCursorResponse(ResponseInfo{
+			ServerResponse:        response,
+			Server:                &mockPinnedServer{conn: conn},
+			Connection:            conn,
+			ConnectionDescription: conn.Description(),
+		})
+		assert.Nil(t, err, "NewCursorResponse error: %v", err)
+		assert.Nil(t, curresp.Connection, "expected cursor not to be pinned, got %v", curresp.Connection)
+		assert.Equal(t, 0, conn.cursorPins, "expected no cursor pins, got %d", conn.cursorPins)
+	})
+}
+
+// mockPinnedConnection is a PinnedConnection that counts the cursors pinned to it.
+type mockPinnedConnection struct {
+	*mockConnection
+	cursorPins      int
+	transactionPins int
+}
+
+var _ PinnedConnection = (*mockPinnedConnection)(nil)
+
+func (m *mockPinnedConnection) PinToCursor() error {
+	m.cursorPins++
+	return nil
+}
+
+func (m *mockPinnedConnection) PinToTransaction() error {
+	m.transactionPins++
+	return nil
+}
+
+func (m *mockPinnedConnection) UnpinFromCursor() error {
+	m.cursorPins--
+	return nil
+}
+
+func (m *mockPinnedConnection) UnpinFromTransaction() error {
+	m.transactionPins--
+	return nil
 }
+
+// mockPinnedServer is a Server and ErrorProcessor that always returns the same connection.
+type mockPinnedServer struct {
+	conn      Connection
+	checkOuts int
+}
+
+func (m *mockPinnedServer) Connection(context.Context) (Connection, error) {
+	m.checkOuts++
+	return m.conn, nil
+}
+
+func (m *mockPinnedServer) MinRTT() time.Duration { return 0 }
+
+func (m *mockPinnedServer) ProcessError(error, Connection) ProcessErrorResult { return NoChange }
@@ -29,7 +29,16 @@ var random = randutil.NewLockedRand(rand.NewSource(time.Now().UnixNano()))
 // ParseAndValidate parses the provided URI into a ConnString object.
 // It check that all values are valid.
 func ParseAndValidate(s string) (ConnString, error) {
-	p := parser{dnsResolver: dns.DefaultResolver}
+	return ParseAndValidateWithResolver(s, dns.DefaultResolver)
+}
+
+// ParseAndValidateWithResolver is like ParseAndValidate, but uses the given resolver to look up the SRV and TXT records
+// of a mongodb+srv URI instead of the system resolver. It returns an error if resolver is nil.
+func ParseAndValidateWithResolver(s string, resolver *dns.Resolver) (ConnString, error) {
+	if resolver == nil {
+		return ConnString{}, errors.New("error parsing uri: resolver must not be nil")
+	}
+	p := parser{dnsResolver: resolver}
 	err := p.parse(s)
 	if err != nil {
 		return p.ConnString, internal.WrapErrorf(err, "error parsing uri")
@@ -125,6 +134,8 @@ type ConnString struct {
 	WString                            string
 	WNumber                            int
 	WNumberSet                         bool
+	WaitQueueTimeout                   time.Duration
+	WaitQueueTimeoutSet                bool
 	Username                           string
 	UsernameSet                        bool
 	ZlibLevel                          int
@@ -911,6 +922,13 @@ func (p *parser) addOption(pair string) error {
 		p.WString = value
 		p.WNumberSet = false
 
+	case "waitqueuetimeoutms":
+		n, err := strconv.Atoi(value)
+		if err != nil || n < 0 {
+			return fmt.Errorf("invalid value for %s: %s", key, value)
+		}
+		p.WaitQueueTimeout = time.Duration(n) * time.Millisecond
+		p.WaitQueueTimeoutSet = true
 	case "wtimeoutms":
 		n, err := strconv.Atoi(value)
 		if err != nil || n < 0 {
@@ -8,12 +8,14 @@ package connstring_test
 
 import (
 	"fmt"
+	"net"
 	"testing"
 	"time"
 
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 	"go.mongodb.org/mongo-driver/x/mongo/driver/connstring"
+	"go.mongodb.org/mongo-driver/x/mongo/driver/dns"
 )
 
 func TestAppName(t *testing.T) {
@@ -346,6 +348,33 @@ func TestMaxConnecting(t *testing.T) {
 	}
 }
 
+func TestWaitQueueTimeout(t *testing.T) {
+	tests := []struct {
+		s        string
+		expected time.Duration
+		err      bool
+	}{
+		{s: "waitQueueTimeoutMS=10", expected: 10 * time.Millisecond},
+		{s: "waitQueueTimeoutMS=0", expected: 0},
+		{s: "waitQueueTimeoutMS=-2", err: true},
+		{s: "waitQueueTimeoutMS=gsdge", err: true},
+	}
+
+	for _, test := range tests {
+		s := fmt.Sprintf("mongodb://localhost/?%s", test.s)
+		t.Run(s, func(t *testing.T) {
+			cs, err := connstring.ParseAndValidate(s)
+			if test.err {
+				require.Error(t, err)
+			} else {
+				require.NoError(t, err)
+				require.True(t, cs.WaitQueueTimeoutSet)
+				require.Equal(t, test.expected, cs.WaitQueueTimeout)
+			}
+		})
+	}
+}
+
 func TestReadPreference(t *testing.T) {
 	tests := []struct {
 		s        string
@@ -629,3 +658,28 @@ func TestCompressionOptions(t *testing.T) {
 		})
 	}
 }
+
+func TestParseAndValidateWithResolver(t *testing.T) {
+	resolver := &dns.Resolver{
+		LookupSRV: func(service, proto, name string) (string, []*net.SRV, error) {
+			if service != "mongodb" || proto != "tcp" || name != "cluster.example.com" {
+				return "", nil, &net.DNSError{Err: "no such host", Name: name}
+			}
+			return "", []*net.SRV{{"a.example.com.", 27017, 0, 0}, {"b.example.com.", 27018, 0, 0}}, nil
+		},
+		LookupTXT: func(name string) ([]string, error) {
+			return []string{"replicaSet=rs0"}, nil
+		},
+	}
+
+	cs, err := connstring.ParseAndValidateWithResolver("mongodb+srv://cluster.example.com/", resolver)
+	require.NoError(t, err)
+	assert.Equal(t, []string{"a.example.com:27017", "b.example.com:27018"}, cs.Hosts)
+	assert.Equal(t, "rs0", cs.ReplicaSet)
+
+	_, err = connstring.ParseAndValidateWithResolver("mongodb+srv://other.example.com/", resolver)
+	assert.Error(t, err)
+
+	_, err = connstring.ParseAndValidateWithResolver("mongodb+srv://cluster.example.com/", nil)
+	assert.Error(t, err)
+}
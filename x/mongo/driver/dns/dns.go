@@ -7,6 +7,7 @@
 package dns
 
 import (
+	"context"
 	"errors"
 	"fmt"
 	"net"
@@ -24,6 +25,19 @@ type Resolver struct {
 // DefaultResolver is a Resolver that uses the default Resolver from the net package.
 var DefaultResolver = &Resolver{net.LookupSRV, net.LookupTXT}
 
+// NewResolver returns a Resolver that performs lookups with the given net.Resolver, e.g. one that is configured to
+// send queries to a non-system DNS server.
+func NewResolver(r *net.Resolver) *Resolver {
+	return &Resolver{
+		LookupSRV: func(service, proto, name string) (string, []*net.SRV, error) {
+			return r.LookupSRV(context.Background(), service, proto, name)
+		},
+		LookupTXT: func(name string) ([]string, error) {
+			return r.LookupTXT(context.Background(), name)
+		},
+	}
+}
+
 // ParseHosts uses the srv string and service name to get the hosts.
 func (r *Resolver) ParseHosts(host string, srvName string, stopOnErr bool) ([]string, error) {
 	parsedHosts := strings.Split(host, ",")
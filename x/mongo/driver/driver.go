@@ -21,11 +21,22 @@ type Connector interface {
 	Connect() error
 }
 
+// ContextConnector represents a Connector whose initial connection can be bounded by a Context.
+type ContextConnector interface {
+	ConnectContext(context.Context) error
+}
+
 // Disconnector represents a type that can disconnect from a server.
 type Disconnector interface {
 	Disconnect(context.Context) error
 }
 
+// Drainer represents a type that can stop handing out new connections while allowing connections that are already in
+// use to be returned.
+type Drainer interface {
+	Drain()
+}
+
 // Subscription represents a subscription to topology updates. A subscriber can receive updates through the
 // Updates field.
 type Subscription struct {
@@ -90,6 +101,18 @@ type LocalAddresser interface {
 	LocalAddress() address.Address
 }
 
+// Tagger is a type that is able to supply the workload tag of a connection.
+type Tagger interface {
+	Tag() string
+}
+
+// PoolGenerationReporter is a type that is able to supply the generation of the connection pool that a connection was
+// created in. The generation is incremented each time the pool is cleared, so connections from older generations are
+// stale.
+type PoolGenerationReporter interface {
+	PoolGeneration() uint64
+}
+
 // Expirable represents an expirable object.
 type Expirable interface {
 	Expire() error
@@ -156,6 +179,7 @@ type HandshakeInformation struct {
 	SpeculativeAuthenticate bsoncore.Document
 	ServerConnectionID      *int32
 	SaslSupportedMechs      []string
+	HelloCommand            string // The name of the command used for the handshake
 }
 
 // Handshaker is the interface implemented by types that can perform a MongoDB
@@ -20,6 +20,7 @@ var (
 
 	unknownReplWriteConcernCode   = int32(79)
 	unsatisfiableWriteConcernCode = int32(100)
+	apiDeprecationErrorCode       = int32(363)
 )
 
 var (
@@ -103,6 +104,16 @@ func (wce WriteCommandError) Error() string {
 	return buf.String()
 }
 
+// AddLabel adds the specified label to the error if it is not already present.
+func (wce *WriteCommandError) AddLabel(label string) {
+	for _, l := range wce.Labels {
+		if l == label {
+			return
+		}
+	}
+	wce.Labels = append(wce.Labels, label)
+}
+
 // Retryable returns true if the error is retryable
 func (wce WriteCommandError) Retryable(wireVersion *description.VersionRange) bool {
 	for _, label := range wce.Labels {
@@ -250,6 +261,13 @@ func (e Error) HasErrorLabel(label string) bool {
 	return false
 }
 
+// AddLabel adds the specified label to the error if it is not already present.
+func (e *Error) AddLabel(label string) {
+	if !e.HasErrorLabel(label) {
+		e.Labels = append(e.Labels, label)
+	}
+}
+
 // RetryableRead returns true if the error is retryable for a read operation
 func (e Error) RetryableRead() bool {
 	for _, label := range e.Labels {
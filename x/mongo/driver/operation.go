@@ -81,6 +81,8 @@ type startedInformation struct {
 	serverConnID             *int32
 	redacted                 bool
 	serviceID                *primitive.ObjectID
+	connTag                  string
+	retryCount               int
 }
 
 // finishedInformation keeps track of all of the information necessary for monitoring success and failure events.
@@ -94,6 +96,10 @@ type finishedInformation struct {
 	startTime    time.Time
 	redacted     bool
 	serviceID    *primitive.ObjectID
+	connTag      string
+	retryCount   int
+	timings      *event.CommandTimings
+	attemptStart time.Time
 }
 
 // ResponseInfo contains the context required to parse a server response.
@@ -211,8 +217,42 @@ type Operation struct {
 	// read preference will not be added to the command on wire versions < 13.
 	IsOutputAggregate bool
 
+	// PrimaryFallback specifies whether a read operation that fails on a secondary with a retryable read error should
+	// be retried once against the primary. The fallback attempt does not count against the retries allowed by
+	// RetryMode.
+	PrimaryFallback bool
+
+	// EscalateReadPreference specifies whether a read operation that fails on a server other than the primary with a
+	// retryable read error should be retried with a primaryPreferred read preference. Only secondary,
+	// secondaryPreferred, and nearest read preferences are escalated. The escalated attempt counts against the retries
+	// allowed by RetryMode.
+	EscalateReadPreference bool
+
+	// LocalThreshold specifies the latency window used to select a server for a primary fallback or escalated read
+	// preference retry, and for operations that don't specify a Selector. If nil, a default of 15 milliseconds is used.
+	LocalThreshold *time.Duration
+
+	// MaxTimeMSCeiling enables deriving maxTimeMS from the deadline of the Context passed to Execute. If non-zero and
+	// the Context has a deadline, read and write commands that don't already specify maxTimeMS are sent with maxTimeMS
+	// set to the time remaining before the deadline, capped at MaxTimeMSCeiling.
+	MaxTimeMSCeiling time.Duration
+
+	// DefaultMaxTime is the maxTimeMS sent with read and write commands that don't already specify maxTimeMS, so no
+	// command runs unbounded on the server. If MaxTimeMSCeiling also derives a maxTimeMS from the Context deadline, the
+	// smaller of the two is sent.
+	DefaultMaxTime time.Duration
+
+	// ErrorLabelsFn is called with the name of the command and the error returned by each attempt to run it. The
+	// returned labels are added to the error before it is checked for retryability, which allows tests to inject labels
+	// such as RetryableWriteError. It is only called for Error and WriteCommandError errors.
+	ErrorLabelsFn func(cmdName string, err error) []string
+
 	// cmdName is only set when serializing OP_MSG and is used internally in readWireMessage.
 	cmdName string
+
+	// timings records the time spent in each phase of the current command. It is only set by Execute, and only if
+	// CommandMonitor is set.
+	timings *event.CommandTimings
 }
 
 // shouldEncrypt returns true if this operation should automatically be encrypted.
@@ -220,6 +260,55 @@ func (op Operation) shouldEncrypt() bool {
 	return op.Crypt != nil && !op.Crypt.BypassAutoEncryption()
 }
 
+// escalatableReadPreference returns true if rp prefers reading from secondaries, so a retry can escalate it to
+// primaryPreferred without weakening it.
+func escalatableReadPreference(rp *readpref.ReadPref) bool {
+	if rp == nil {
+		return false
+	}
+	switch rp.Mode() {
+	case readpref.SecondaryMode, readpref.SecondaryPreferredMode, readpref.NearestMode:
+		return true
+	}
+	return false
+}
+
+// localThreshold returns the latency window to use when the operation builds its own server selector.
+func (op Operation) localThreshold() time.Duration {
+	if op.LocalThreshold != nil {
+		return *op.LocalThreshold
+	}
+	return defaultLocalThreshold
+}
+
+// readPrefRetrySelector returns the selector to use when retrying the operation with the read preference rp. The stages
+// of op.Selector other than read preference and latency selection are kept so the retry only considers servers the
+// original selector allowed. If op.Selector is a description.WrappingSelector, such as the selector that keeps a
+// session pinned to its server, the retry selector is wrapped in the same way.
+func (op Operation) readPrefRetrySelector(rp *readpref.ReadPref) description.ServerSelector {
+	return retrySelector(op.Selector, rp, op.localThreshold())
+}
+
+func retrySelector(selector description.ServerSelector, rp *readpref.ReadPref,
+	localThreshold time.Duration) description.ServerSelector {
+
+	if ws, ok := selector.(description.WrappingSelector); ok {
+		return ws.Wrap(retrySelector(ws.Unwrap(), rp, localThreshold))
+	}
+
+	var stages []description.ServerSelector
+	if selector != nil {
+		for _, stage := range description.SelectorStages(selector) {
+			if description.IsReadPrefSelector(stage) || description.IsLatencySelector(stage) {
+				continue
+			}
+			stages = append(stages, stage)
+		}
+	}
+	stages = append(stages, description.ReadPrefSelector(rp), description.LatencySelector(localThreshold))
+	return description.CompositeSelector(stages)
+}
+
 // selectServer handles performing server selection for an operation.
 func (op Operation) selectServer(ctx context.Context) (Server, error) {
 	if err := op.Validate(); err != nil {
@@ -234,7 +323,7 @@ func (op Operation) selectServer(ctx context.Context) (Server, error) {
 		}
 		selector = description.CompositeSelector([]description.ServerSelector{
 			description.ReadPrefSelector(rp),
-			description.LatencySelector(defaultLocalThreshold),
+			description.LatencySelector(op.localThreshold()),
 		})
 	}
 
@@ -243,7 +332,11 @@ func (op Operation) selectServer(ctx context.Context) (Server, error) {
 
 // getServerAndConnection should be used to retrieve a Server and Connection to execute an operation.
 func (op Operation) getServerAndConnection(ctx context.Context) (Server, Connection, error) {
+	start := time.Now()
 	server, err := op.selectServer(ctx)
+	if op.timings != nil {
+		op.timings.ServerSelection = time.Since(start)
+	}
 	if err != nil {
 		return nil, nil, err
 	}
@@ -255,7 +348,11 @@ func (op Operation) getServerAndConnection(ctx context.Context) (Server, Connect
 	}
 
 	// Otherwise, default to checking out a connection from the server's pool.
+	start = time.Now()
 	conn, err := server.Connection(ctx)
+	if op.timings != nil {
+		op.timings.ConnectionCheckout = time.Since(start)
+	}
 	if err != nil {
 		return nil, nil, err
 	}
@@ -342,12 +439,15 @@ func (op Operation) Execute(ctx context.Context, scratch []byte) error {
 	retryEnabled := op.RetryMode != nil && op.RetryMode.Enabled()
 	retrySupported := false
 	first := true
+	fellBack := false
 	currIndex := 0
+	retryCount := 0
 
 	// resetForRetry records the error that caused the retry, decrements retries, and resets the
 	// retry loop variables to request a new server and a new connection for the next attempt.
 	resetForRetry := func(err error) {
 		retries--
+		retryCount++
 		prevErr = err
 		// If we got a connection, close it immediately to release pool resources for
 		// subsequent retries.
@@ -360,6 +460,12 @@ func (op Operation) Execute(ctx context.Context, scratch []byte) error {
 	}
 
 	for {
+		attemptStart := time.Now()
+		// Timings are only reported in command monitoring events, so they are only recorded if there is a monitor.
+		if op.CommandMonitor != nil {
+			op.timings = &event.CommandTimings{}
+		}
+
 		// If the server or connection are nil, try to select a new server and get a new connection.
 		if srvr == nil || conn == nil {
 			srvr, conn, err = op.getServerAndConnection(ctx)
@@ -467,6 +573,10 @@ func (op Operation) Execute(ctx context.Context, scratch []byte) error {
 		startedInfo.redacted = op.redactCommand(startedInfo.cmdName, startedInfo.cmd)
 		startedInfo.serviceID = conn.Description().ServiceID
 		startedInfo.serverConnID = conn.ServerConnectionID()
+		if tagger, ok := conn.(Tagger); ok {
+			startedInfo.connTag = tagger.Tag()
+		}
+		startedInfo.retryCount = retryCount
 		op.publishStartedEvent(ctx, startedInfo)
 
 		// get the moreToCome flag information before we compress
@@ -488,6 +598,10 @@ func (op Operation) Execute(ctx context.Context, scratch []byte) error {
 			serverConnID: startedInfo.serverConnID,
 			redacted:     startedInfo.redacted,
 			serviceID:    startedInfo.serviceID,
+			connTag:      startedInfo.connTag,
+			retryCount:   startedInfo.retryCount,
+			timings:      op.timings,
+			attemptStart: attemptStart,
 		}
 
 		// Check if there's enough time to perform a best-case network round trip before the Context
@@ -502,16 +616,24 @@ func (op Operation) Execute(ctx context.Context, scratch []byte) error {
 			if moreToCome {
 				roundTrip = op.moreToComeRoundTrip
 			}
+			roundTripStart := time.Now()
 			res, err = roundTrip(ctx, conn, wm)
+			if op.timings != nil {
+				op.timings.RoundTrip = time.Since(roundTripStart) - op.timings.Decode
+			}
 
 			if ep, ok := srvr.(ErrorProcessor); ok {
 				_ = ep.ProcessError(err, conn)
 			}
 		}
+		if op.ErrorLabelsFn != nil {
+			err = op.addErrorLabels(startedInfo.cmdName, err)
+		}
 
 		finishedInfo.response = res
 		finishedInfo.cmdErr = err
 		op.publishFinishedEvent(ctx, finishedInfo)
+		op.reportAPIDeprecation(startedInfo.cmdName, err)
 
 		var perr error
 		switch tt := err.(type) {
@@ -616,6 +738,18 @@ func (op Operation) Execute(ctx context.Context, scratch []byte) error {
 				retryableErr = tt.RetryableRead()
 			}
 
+			// If primary fallback is enabled and a read failed on a secondary, retry the read against the primary
+			// once. The fallback attempt does not use one of the remaining retries.
+			if op.PrimaryFallback && op.Type == Read && retryableErr && !fellBack && connDesc.Kind == description.RSSecondary {
+				fellBack = true
+				op.ReadPreference = readpref.Primary()
+				op.Selector = op.readPrefRetrySelector(op.ReadPreference)
+				remaining := retries
+				resetForRetry(tt)
+				retries = remaining
+				continue
+			}
+
 			// If retries are supported for the current operation on the first server description,
 			// the error is considered retryable, and there are retries remaining (negative retries
 			// means retry indefinitely), then retry the operation.
@@ -625,6 +759,11 @@ func (op Operation) Execute(ctx context.Context, scratch []byte) error {
 					op.Client.UpdateCommitTransactionWriteConcern()
 					op.WriteConcern = op.Client.CurrentWc
 				}
+				if op.EscalateReadPreference && op.Type == Read && connDesc.Kind != description.RSPrimary &&
+					escalatableReadPreference(op.ReadPreference) {
+					op.ReadPreference = readpref.PrimaryPreferred()
+					op.Selector = op.readPrefRetrySelector(op.ReadPreference)
+				}
 				resetForRetry(tt)
 				continue
 			}
@@ -727,6 +866,23 @@ func (op Operation) retryable(desc description.Server) bool {
 	return false
 }
 
+// addErrorLabels adds the labels returned by ErrorLabelsFn to err if it is an Error or WriteCommandError.
+func (op Operation) addErrorLabels(cmdName string, err error) error {
+	switch tt := err.(type) {
+	case Error:
+		for _, label := range op.ErrorLabelsFn(cmdName, err) {
+			tt.AddLabel(label)
+		}
+		return tt
+	case WriteCommandError:
+		for _, label := range op.ErrorLabelsFn(cmdName, err) {
+			tt.AddLabel(label)
+		}
+		return tt
+	}
+	return err
+}
+
 // roundTrip writes a wiremessage to the connection and then reads a wiremessage. The wm parameter
 // is reused when reading the wiremessage.
 func (op Operation) roundTrip(ctx context.Context, conn Connection, wm []byte) ([]byte, error) {
@@ -746,6 +902,11 @@ func (op Operation) readWireMessage(ctx context.Context, conn Connection, wm []b
 		return nil, op.networkError(err)
 	}
 
+	if op.timings != nil {
+		decodeStart := time.Now()
+		defer func() { op.timings.Decode = time.Since(decodeStart) }()
+	}
+
 	// If we're using a streamable connection, we set its streaming state based on the moreToCome flag in the server
 	// response.
 	if streamer, ok := conn.(StreamerConnection); ok {
@@ -816,6 +977,17 @@ func (op *Operation) moreToComeRoundTrip(ctx context.Context, conn Connection, w
 	return bsoncore.BuildDocument(nil, bsoncore.AppendInt32Element(nil, "ok", 1)), err
 }
 
+// reportAPIDeprecation calls the ServerAPI deprecation callback if err reports that the command used a deprecated
+// feature of the server API.
+func (op Operation) reportAPIDeprecation(cmdName string, err error) {
+	if op.ServerAPI == nil || op.ServerAPI.DeprecationCallback == nil {
+		return
+	}
+	if e, ok := err.(Error); ok && e.Code == apiDeprecationErrorCode {
+		op.ServerAPI.DeprecationCallback(cmdName)
+	}
+}
+
 // decompressWireMessage handles decompressing a wiremessage. If the wiremessage
 // is not compressed, this method will return the wiremessage.
 func (Operation) decompressWireMessage(wm []byte) ([]byte, error) {
@@ -868,7 +1040,7 @@ func (op Operation) createWireMessage(ctx context.Context, dst []byte,
 	// or less than 6, use OP_QUERY. Otherwise, use OP_MSG.
 	if desc.Kind != description.LoadBalanced && op.ServerAPI == nil &&
 		(desc.WireVersion == nil || desc.WireVersion.Max < wiremessage.OpmsgWireVersion) {
-		return op.createQueryWireMessage(dst, desc)
+		return op.createQueryWireMessage(ctx, dst, desc)
 	}
 	return op.createMsgWireMessage(ctx, dst, desc, conn)
 }
@@ -882,7 +1054,9 @@ func (op Operation) addBatchArray(dst []byte) []byte {
 	return dst
 }
 
-func (op Operation) createQueryWireMessage(dst []byte, desc description.SelectedServer) ([]byte, startedInformation, error) {
+func (op Operation) createQueryWireMessage(ctx context.Context, dst []byte,
+	desc description.SelectedServer) ([]byte, startedInformation, error) {
+
 	var info startedInformation
 	flags := op.secondaryOK(desc)
 	var wmindex int32
@@ -915,6 +1089,8 @@ func (op Operation) createQueryWireMessage(dst []byte, desc description.Selected
 		dst = op.addBatchArray(dst)
 	}
 
+	dst = op.addMaxTimeMS(ctx, dst, idx)
+
 	dst, err = op.addReadConcern(dst, desc)
 	if err != nil {
 		return dst, info, err
@@ -978,6 +1154,7 @@ func (op Operation) createMsgWireMessage(ctx context.Context, dst []byte, desc d
 	if err != nil {
 		return dst, info, err
 	}
+	dst = op.addMaxTimeMS(ctx, dst, idx)
 	dst, err = op.addReadConcern(dst, desc)
 	if err != nil {
 		return dst, info, err
@@ -1027,6 +1204,47 @@ func (op Operation) createMsgWireMessage(ctx context.Context, dst []byte, desc d
 	return bsoncore.UpdateLength(dst, wmindex, int32(len(dst[wmindex:]))), info, nil
 }
 
+// addMaxTimeMS adds a maxTimeMS field to the command that starts at index idx in dst if the operation is a read or
+// write and the command doesn't already specify maxTimeMS. The value is DefaultMaxTime or, if MaxTimeMSCeiling is set
+// and the Context has a deadline, the time remaining before the deadline capped at MaxTimeMSCeiling, whichever is
+// smaller. The command document must not have been ended yet.
+func (op Operation) addMaxTimeMS(ctx context.Context, dst []byte, idx int32) []byte {
+	if op.Type != Read && op.Type != Write {
+		return dst
+	}
+	deadline, hasDeadline := ctx.Deadline()
+	fromDeadline := op.MaxTimeMSCeiling > 0 && hasDeadline
+	if !fromDeadline && op.DefaultMaxTime <= 0 {
+		return dst
+	}
+
+	// Terminate a copy of the command so it can be checked for an existing maxTimeMS field.
+	cmd := make(bsoncore.Document, 0, len(dst[idx:])+1)
+	cmd = append(cmd, dst[idx:]...)
+	cmd = append(cmd, 0x00)
+	cmd = bsoncore.UpdateLength(cmd, 0, int32(len(cmd)))
+	if _, err := cmd.LookupErr("maxTimeMS"); err == nil {
+		return dst
+	}
+
+	limit := op.DefaultMaxTime
+	if fromDeadline {
+		remaining := time.Until(deadline)
+		if remaining > op.MaxTimeMSCeiling {
+			remaining = op.MaxTimeMSCeiling
+		}
+		if limit <= 0 || remaining < limit {
+			limit = remaining
+		}
+	}
+	maxTimeMS := int64(limit / time.Millisecond)
+	if maxTimeMS < 1 {
+		// A maxTimeMS of 0 means no limit, so use the smallest limit instead.
+		maxTimeMS = 1
+	}
+	return bsoncore.AppendInt64Element(dst, "maxTimeMS", maxTimeMS)
+}
+
 // addCommandFields adds the fields for a command to the wire message in dst. This assumes that the start of the document
 // has already been added and does not add the final 0 byte.
 func (op Operation) addCommandFields(ctx context.Context, dst []byte, desc description.SelectedServer) ([]byte, error) {
@@ -1553,6 +1771,8 @@ func (op Operation) publishStartedEvent(ctx context.Context, info startedInforma
 		ConnectionID:       info.connID,
 		ServerConnectionID: info.serverConnID,
 		ServiceID:          info.serviceID,
+		ConnectionTag:      info.connTag,
+		RetryCount:         info.retryCount,
 	}
 	op.CommandMonitor.Started(ctx, started)
 }
@@ -1581,6 +1801,12 @@ func (op Operation) publishFinishedEvent(ctx context.Context, info finishedInfor
 		DurationNanos:      durationNanos,
 		ServerConnectionID: info.serverConnID,
 		ServiceID:          info.serviceID,
+		ConnectionTag:      info.connTag,
+		RetryCount:         info.retryCount,
+	}
+	if info.timings != nil {
+		finished.Timings = *info.timings
+		finished.Timings.Total = time.Since(info.attemptStart)
 	}
 
 	if success {
@@ -9,6 +9,7 @@ package operation
 import (
 	"context"
 	"errors"
+	"time"
 
 	"go.mongodb.org/mongo-driver/bson/bsontype"
 	"go.mongodb.org/mongo-driver/event"
@@ -44,6 +45,11 @@ type Aggregate struct {
 	writeConcern             *writeconcern.WriteConcern
 	crypt                    driver.Crypt
 	serverAPI                *driver.ServerAPIOptions
+	maxTimeMSCeiling         time.Duration
+	defaultMaxTime           time.Duration
+	primaryFallback          bool
+	localThreshold           *time.Duration
+	escalateReadPref         bool
 	let                      bsoncore.Document
 	hasOutputStage           bool
 	customOptions            map[string]bsoncore.Value
@@ -106,6 +112,11 @@ func (a *Aggregate) Execute(ctx context.Context) error {
 		Crypt:                          a.crypt,
 		MinimumWriteConcernWireVersion: 5,
 		ServerAPI:                      a.serverAPI,
+		MaxTimeMSCeiling:               a.maxTimeMSCeiling,
+		DefaultMaxTime:                 a.defaultMaxTime,
+		PrimaryFallback:                a.primaryFallback,
+		LocalThreshold:                 a.localThreshold,
+		EscalateReadPreference:         a.escalateReadPref,
 		IsOutputAggregate:              a.hasOutputStage,
 	}.Execute(ctx, nil)
 
@@ -377,6 +388,62 @@ func (a *Aggregate) ServerAPI(serverAPI *driver.ServerAPIOptions) *Aggregate {
 	return a
 }
 
+// MaxTimeMSCeiling specifies the maximum maxTimeMS value to derive from the deadline of the Context passed to Execute.
+// If non-zero and the Context has a deadline, maxTimeMS is set to the time remaining before the deadline, capped at
+// this value, unless the command already specifies maxTimeMS.
+func (a *Aggregate) MaxTimeMSCeiling(ceiling time.Duration) *Aggregate {
+	if a == nil {
+		a = new(Aggregate)
+	}
+
+	a.maxTimeMSCeiling = ceiling
+	return a
+}
+
+// DefaultMaxTime specifies the maxTimeMS value to send if the command does not already specify maxTimeMS. If
+// MaxTimeMSCeiling also derives a maxTimeMS from the Context deadline, the smaller of the two is sent.
+func (a *Aggregate) DefaultMaxTime(maxTime time.Duration) *Aggregate {
+	if a == nil {
+		a = new(Aggregate)
+	}
+
+	a.defaultMaxTime = maxTime
+	return a
+}
+
+// PrimaryFallback specifies whether the operation should be retried against the primary if it fails on a secondary
+// with a retryable read error.
+func (a *Aggregate) PrimaryFallback(primaryFallback bool) *Aggregate {
+	if a == nil {
+		a = new(Aggregate)
+	}
+
+	a.primaryFallback = primaryFallback
+	return a
+}
+
+// LocalThreshold specifies the latency window to use when selecting a server for a primary fallback or escalated read
+// preference retry. If not set, a default of 15 milliseconds is used.
+func (a *Aggregate) LocalThreshold(threshold time.Duration) *Aggregate {
+	if a == nil {
+		a = new(Aggregate)
+	}
+
+	a.localThreshold = &threshold
+	return a
+}
+
+// EscalateReadPreference specifies whether the operation should be retried with a primaryPreferred read preference if
+// it fails on a server other than the primary with a retryable read error.
+func (a *Aggregate) EscalateReadPreference(escalate bool) *Aggregate {
+	if a == nil {
+		a = new(Aggregate)
+	}
+
+	a.escalateReadPref = escalate
+	return a
+}
+
 // Let specifies the let document to use. This option is only valid for server versions 5.0 and above.
 func (a *Aggregate) Let(let bsoncore.Document) *Aggregate {
 	if a == nil {
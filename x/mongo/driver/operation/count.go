@@ -10,6 +10,7 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"time"
 
 	"go.mongodb.org/mongo-driver/event"
 	"go.mongodb.org/mongo-driver/mongo/description"
@@ -22,21 +23,26 @@ import (
 
 // Count represents a count operation.
 type Count struct {
-	maxTimeMS      *int64
-	query          bsoncore.Document
-	session        *session.Client
-	clock          *session.ClusterClock
-	collection     string
-	monitor        *event.CommandMonitor
-	crypt          driver.Crypt
-	database       string
-	deployment     driver.Deployment
-	readConcern    *readconcern.ReadConcern
-	readPreference *readpref.ReadPref
-	selector       description.ServerSelector
-	retry          *driver.RetryMode
-	result         CountResult
-	serverAPI      *driver.ServerAPIOptions
+	maxTimeMS        *int64
+	query            bsoncore.Document
+	session          *session.Client
+	clock            *session.ClusterClock
+	collection       string
+	monitor          *event.CommandMonitor
+	crypt            driver.Crypt
+	database         string
+	deployment       driver.Deployment
+	readConcern      *readconcern.ReadConcern
+	readPreference   *readpref.ReadPref
+	selector         description.ServerSelector
+	retry            *driver.RetryMode
+	result           CountResult
+	serverAPI        *driver.ServerAPIOptions
+	maxTimeMSCeiling time.Duration
+	defaultMaxTime   time.Duration
+	primaryFallback  bool
+	localThreshold   *time.Duration
+	escalateReadPref bool
 }
 
 // CountResult represents a count result returned by the server.
@@ -106,20 +112,25 @@ func (c *Count) Execute(ctx context.Context) error {
 	}
 
 	err := driver.Operation{
-		CommandFn:         c.command,
-		ProcessResponseFn: c.processResponse,
-		RetryMode:         c.retry,
-		Type:              driver.Read,
-		Client:            c.session,
-		Clock:             c.clock,
-		CommandMonitor:    c.monitor,
-		Crypt:             c.crypt,
-		Database:          c.database,
-		Deployment:        c.deployment,
-		ReadConcern:       c.readConcern,
-		ReadPreference:    c.readPreference,
-		Selector:          c.selector,
-		ServerAPI:         c.serverAPI,
+		CommandFn:              c.command,
+		ProcessResponseFn:      c.processResponse,
+		RetryMode:              c.retry,
+		Type:                   driver.Read,
+		Client:                 c.session,
+		Clock:                  c.clock,
+		CommandMonitor:         c.monitor,
+		Crypt:                  c.crypt,
+		Database:               c.database,
+		Deployment:             c.deployment,
+		ReadConcern:            c.readConcern,
+		ReadPreference:         c.readPreference,
+		Selector:               c.selector,
+		ServerAPI:              c.serverAPI,
+		MaxTimeMSCeiling:       c.maxTimeMSCeiling,
+		DefaultMaxTime:         c.defaultMaxTime,
+		PrimaryFallback:        c.primaryFallback,
+		LocalThreshold:         c.localThreshold,
+		EscalateReadPreference: c.escalateReadPref,
 	}.Execute(ctx, nil)
 
 	// Swallow error if NamespaceNotFound(26) is returned from aggregate on non-existent namespace
@@ -313,3 +324,59 @@ func (c *Count) ServerAPI(serverAPI *driver.ServerAPIOptions) *Count {
 	c.serverAPI = serverAPI
 	return c
 }
+
+// MaxTimeMSCeiling specifies the maximum maxTimeMS value to derive from the deadline of the Context passed to Execute.
+// If non-zero and the Context has a deadline, maxTimeMS is set to the time remaining before the deadline, capped at
+// this value, unless the command already specifies maxTimeMS.
+func (c *Count) MaxTimeMSCeiling(ceiling time.Duration) *Count {
+	if c == nil {
+		c = new(Count)
+	}
+
+	c.maxTimeMSCeiling = ceiling
+	return c
+}
+
+// DefaultMaxTime specifies the maxTimeMS value to send if the command does not already specify maxTimeMS. If
+// MaxTimeMSCeiling also derives a maxTimeMS from the Context deadline, the smaller of the two is sent.
+func (c *Count) DefaultMaxTime(maxTime time.Duration) *Count {
+	if c == nil {
+		c = new(Count)
+	}
+
+	c.defaultMaxTime = maxTime
+	return c
+}
+
+// PrimaryFallback specifies whether the operation should be retried against the primary if it fails on a secondary
+// with a retryable read error.
+func (c *Count) PrimaryFallback(primaryFallback bool) *Count {
+	if c == nil {
+		c = new(Count)
+	}
+
+	c.primaryFallback = primaryFallback
+	return c
+}
+
+// LocalThreshold specifies the latency window to use when selecting a server for a primary fallback or escalated read
+// preference retry. If not set, a default of 15 milliseconds is used.
+func (c *Count) LocalThreshold(threshold time.Duration) *Count {
+	if c == nil {
+		c = new(Count)
+	}
+
+	c.localThreshold = &threshold
+	return c
+}
+
+// EscalateReadPreference specifies whether the operation should be retried with a primaryPreferred read preference if
+// it fails on a server other than the primary with a retryable read error.
+func (c *Count) EscalateReadPreference(escalate bool) *Count {
+	if c == nil {
+		c = new(Count)
+	}
+
+	c.escalateReadPref = escalate
+	return c
+}
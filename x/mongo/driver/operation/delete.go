@@ -10,7 +10,9 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"time"
 
+	"go.mongodb.org/mongo-driver/bson/primitive"
 	"go.mongodb.org/mongo-driver/event"
 	"go.mongodb.org/mongo-driver/mongo/description"
 	"go.mongodb.org/mongo-driver/mongo/writeconcern"
@@ -21,28 +23,32 @@ import (
 
 // Delete performs a delete operation
 type Delete struct {
-	deletes      []bsoncore.Document
-	ordered      *bool
-	session      *session.Client
-	clock        *session.ClusterClock
-	collection   string
-	monitor      *event.CommandMonitor
-	crypt        driver.Crypt
-	database     string
-	deployment   driver.Deployment
-	selector     description.ServerSelector
-	writeConcern *writeconcern.WriteConcern
-	retry        *driver.RetryMode
-	hint         *bool
-	result       DeleteResult
-	serverAPI    *driver.ServerAPIOptions
-	let          bsoncore.Document
+	deletes          []bsoncore.Document
+	ordered          *bool
+	session          *session.Client
+	clock            *session.ClusterClock
+	collection       string
+	monitor          *event.CommandMonitor
+	crypt            driver.Crypt
+	database         string
+	deployment       driver.Deployment
+	selector         description.ServerSelector
+	writeConcern     *writeconcern.WriteConcern
+	retry            *driver.RetryMode
+	hint             *bool
+	result           DeleteResult
+	serverAPI        *driver.ServerAPIOptions
+	maxTimeMSCeiling time.Duration
+	defaultMaxTime   time.Duration
+	let              bsoncore.Document
 }
 
 // DeleteResult represents a delete result returned by the server.
 type DeleteResult struct {
 	// Number of documents successfully deleted.
 	N int32
+	// The operationTime returned by the server for the last batch, if any.
+	OperationTime *primitive.Timestamp
 }
 
 func buildDeleteResult(response bsoncore.Document) (DeleteResult, error) {
@@ -59,6 +65,12 @@ func buildDeleteResult(response bsoncore.Document) (DeleteResult, error) {
 			if !ok {
 				return dr, fmt.Errorf("response field 'n' is type int32, but received BSON type %s", element.Value().Type)
 			}
+		case "operationTime":
+			t, i, ok := element.Value().TimestampOK()
+			if !ok {
+				return dr, fmt.Errorf("response field 'operationTime' is type timestamp, but received BSON type %s", element.Value().Type)
+			}
+			dr.OperationTime = &primitive.Timestamp{T: t, I: i}
 		}
 	}
 	return dr, nil
@@ -77,6 +89,9 @@ func (d *Delete) Result() DeleteResult { return d.result }
 func (d *Delete) processResponse(info driver.ResponseInfo) error {
 	dr, err := buildDeleteResult(info.ServerResponse)
 	d.result.N += dr.N
+	if dr.OperationTime != nil {
+		d.result.OperationTime = dr.OperationTime
+	}
 	return err
 }
 
@@ -106,6 +121,8 @@ func (d *Delete) Execute(ctx context.Context) error {
 		Selector:          d.selector,
 		WriteConcern:      d.writeConcern,
 		ServerAPI:         d.serverAPI,
+		MaxTimeMSCeiling:  d.maxTimeMSCeiling,
+		DefaultMaxTime:    d.defaultMaxTime,
 	}.Execute(ctx, nil)
 
 }
@@ -275,6 +292,29 @@ func (d *Delete) ServerAPI(serverAPI *driver.ServerAPIOptions) *Delete {
 	return d
 }
 
+// MaxTimeMSCeiling specifies the maximum maxTimeMS value to derive from the deadline of the Context passed to Execute.
+// If non-zero and the Context has a deadline, maxTimeMS is set to the time remaining before the deadline, capped at
+// this value, unless the command already specifies maxTimeMS.
+func (d *Delete) MaxTimeMSCeiling(ceiling time.Duration) *Delete {
+	if d == nil {
+		d = new(Delete)
+	}
+
+	d.maxTimeMSCeiling = ceiling
+	return d
+}
+
+// DefaultMaxTime specifies the maxTimeMS value to send if the command does not already specify maxTimeMS. If
+// MaxTimeMSCeiling also derives a maxTimeMS from the Context deadline, the smaller of the two is sent.
+func (d *Delete) DefaultMaxTime(maxTime time.Duration) *Delete {
+	if d == nil {
+		d = new(Delete)
+	}
+
+	d.defaultMaxTime = maxTime
+	return d
+}
+
 // Let specifies the let document to use. This option is only valid for server versions 5.0 and above.
 func (d *Delete) Let(let bsoncore.Document) *Delete {
 	if d == nil {
@@ -9,6 +9,7 @@ package operation
 import (
 	"context"
 	"errors"
+	"time"
 
 	"go.mongodb.org/mongo-driver/event"
 	"go.mongodb.org/mongo-driver/mongo/description"
@@ -21,23 +22,28 @@ import (
 
 // Distinct performs a distinct operation.
 type Distinct struct {
-	collation      bsoncore.Document
-	key            *string
-	maxTimeMS      *int64
-	query          bsoncore.Document
-	session        *session.Client
-	clock          *session.ClusterClock
-	collection     string
-	monitor        *event.CommandMonitor
-	crypt          driver.Crypt
-	database       string
-	deployment     driver.Deployment
-	readConcern    *readconcern.ReadConcern
-	readPreference *readpref.ReadPref
-	selector       description.ServerSelector
-	retry          *driver.RetryMode
-	result         DistinctResult
-	serverAPI      *driver.ServerAPIOptions
+	collation        bsoncore.Document
+	key              *string
+	maxTimeMS        *int64
+	query            bsoncore.Document
+	session          *session.Client
+	clock            *session.ClusterClock
+	collection       string
+	monitor          *event.CommandMonitor
+	crypt            driver.Crypt
+	database         string
+	deployment       driver.Deployment
+	readConcern      *readconcern.ReadConcern
+	readPreference   *readpref.ReadPref
+	selector         description.ServerSelector
+	retry            *driver.RetryMode
+	result           DistinctResult
+	serverAPI        *driver.ServerAPIOptions
+	maxTimeMSCeiling time.Duration
+	defaultMaxTime   time.Duration
+	primaryFallback  bool
+	localThreshold   *time.Duration
+	escalateReadPref bool
 }
 
 // DistinctResult represents a distinct result returned by the server.
@@ -85,20 +91,25 @@ func (d *Distinct) Execute(ctx context.Context) error {
 	}
 
 	return driver.Operation{
-		CommandFn:         d.command,
-		ProcessResponseFn: d.processResponse,
-		RetryMode:         d.retry,
-		Type:              driver.Read,
-		Client:            d.session,
-		Clock:             d.clock,
-		CommandMonitor:    d.monitor,
-		Crypt:             d.crypt,
-		Database:          d.database,
-		Deployment:        d.deployment,
-		ReadConcern:       d.readConcern,
-		ReadPreference:    d.readPreference,
-		Selector:          d.selector,
-		ServerAPI:         d.serverAPI,
+		CommandFn:              d.command,
+		ProcessResponseFn:      d.processResponse,
+		RetryMode:              d.retry,
+		Type:                   driver.Read,
+		Client:                 d.session,
+		Clock:                  d.clock,
+		CommandMonitor:         d.monitor,
+		Crypt:                  d.crypt,
+		Database:               d.database,
+		Deployment:             d.deployment,
+		ReadConcern:            d.readConcern,
+		ReadPreference:         d.readPreference,
+		Selector:               d.selector,
+		ServerAPI:              d.serverAPI,
+		MaxTimeMSCeiling:       d.maxTimeMSCeiling,
+		DefaultMaxTime:         d.defaultMaxTime,
+		PrimaryFallback:        d.primaryFallback,
+		LocalThreshold:         d.localThreshold,
+		EscalateReadPreference: d.escalateReadPref,
 	}.Execute(ctx, nil)
 
 }
@@ -283,3 +294,59 @@ func (d *Distinct) ServerAPI(serverAPI *driver.ServerAPIOptions) *Distinct {
 	d.serverAPI = serverAPI
 	return d
 }
+
+// MaxTimeMSCeiling specifies the maximum maxTimeMS value to derive from the deadline of the Context passed to Execute.
+// If non-zero and the Context has a deadline, maxTimeMS is set to the time remaining before the deadline, capped at
+// this value, unless the command already specifies maxTimeMS.
+func (d *Distinct) MaxTimeMSCeiling(ceiling time.Duration) *Distinct {
+	if d == nil {
+		d = new(Distinct)
+	}
+
+	d.maxTimeMSCeiling = ceiling
+	return d
+}
+
+// DefaultMaxTime specifies the maxTimeMS value to send if the command does not already specify maxTimeMS. If
+// MaxTimeMSCeiling also derives a maxTimeMS from the Context deadline, the smaller of the two is sent.
+func (d *Distinct) DefaultMaxTime(maxTime time.Duration) *Distinct {
+	if d == nil {
+		d = new(Distinct)
+	}
+
+	d.defaultMaxTime = maxTime
+	return d
+}
+
+// PrimaryFallback specifies whether the operation should be retried against the primary if it fails on a secondary
+// with a retryable read error.
+func (d *Distinct) PrimaryFallback(primaryFallback bool) *Distinct {
+	if d == nil {
+		d = new(Distinct)
+	}
+
+	d.primaryFallback = primaryFallback
+	return d
+}
+
+// LocalThreshold specifies the latency window to use when selecting a server for a primary fallback or escalated read
+// preference retry. If not set, a default of 15 milliseconds is used.
+func (d *Distinct) LocalThreshold(threshold time.Duration) *Distinct {
+	if d == nil {
+		d = new(Distinct)
+	}
+
+	d.localThreshold = &threshold
+	return d
+}
+
+// EscalateReadPreference specifies whether the operation should be retried with a primaryPreferred read preference if
+// it fails on a server other than the primary with a retryable read error.
+func (d *Distinct) EscalateReadPreference(escalate bool) *Distinct {
+	if d == nil {
+		d = new(Distinct)
+	}
+
+	d.escalateReadPref = escalate
+	return d
+}
@@ -9,6 +9,7 @@ package operation
 import (
 	"context"
 	"errors"
+	"time"
 
 	"go.mongodb.org/mongo-driver/bson/bsontype"
 	"go.mongodb.org/mongo-driver/event"
@@ -58,6 +59,11 @@ type Find struct {
 	retry               *driver.RetryMode
 	result              driver.CursorResponse
 	serverAPI           *driver.ServerAPIOptions
+	maxTimeMSCeiling    time.Duration
+	defaultMaxTime      time.Duration
+	primaryFallback     bool
+	localThreshold      *time.Duration
+	escalateReadPref    bool
 }
 
 // NewFind constructs and returns a new Find.
@@ -86,21 +92,26 @@ func (f *Find) Execute(ctx context.Context) error {
 	}
 
 	return driver.Operation{
-		CommandFn:         f.command,
-		ProcessResponseFn: f.processResponse,
-		RetryMode:         f.retry,
-		Type:              driver.Read,
-		Client:            f.session,
-		Clock:             f.clock,
-		CommandMonitor:    f.monitor,
-		Crypt:             f.crypt,
-		Database:          f.database,
-		Deployment:        f.deployment,
-		ReadConcern:       f.readConcern,
-		ReadPreference:    f.readPreference,
-		Selector:          f.selector,
-		Legacy:            driver.LegacyFind,
-		ServerAPI:         f.serverAPI,
+		CommandFn:              f.command,
+		ProcessResponseFn:      f.processResponse,
+		RetryMode:              f.retry,
+		Type:                   driver.Read,
+		Client:                 f.session,
+		Clock:                  f.clock,
+		CommandMonitor:         f.monitor,
+		Crypt:                  f.crypt,
+		Database:               f.database,
+		Deployment:             f.deployment,
+		ReadConcern:            f.readConcern,
+		ReadPreference:         f.readPreference,
+		Selector:               f.selector,
+		Legacy:                 driver.LegacyFind,
+		ServerAPI:              f.serverAPI,
+		MaxTimeMSCeiling:       f.maxTimeMSCeiling,
+		DefaultMaxTime:         f.defaultMaxTime,
+		PrimaryFallback:        f.primaryFallback,
+		LocalThreshold:         f.localThreshold,
+		EscalateReadPreference: f.escalateReadPref,
 	}.Execute(ctx, nil)
 
 }
@@ -535,3 +546,59 @@ func (f *Find) ServerAPI(serverAPI *driver.ServerAPIOptions) *Find {
 	f.serverAPI = serverAPI
 	return f
 }
+
+// MaxTimeMSCeiling specifies the maximum maxTimeMS value to derive from the deadline of the Context passed to Execute.
+// If non-zero and the Context has a deadline, maxTimeMS is set to the time remaining before the deadline, capped at
+// this value, unless the command already specifies maxTimeMS.
+func (f *Find) MaxTimeMSCeiling(ceiling time.Duration) *Find {
+	if f == nil {
+		f = new(Find)
+	}
+
+	f.maxTimeMSCeiling = ceiling
+	return f
+}
+
+// DefaultMaxTime specifies the maxTimeMS value to send if the command does not already specify maxTimeMS. If
+// MaxTimeMSCeiling also derives a maxTimeMS from the Context deadline, the smaller of the two is sent.
+func (f *Find) DefaultMaxTime(maxTime time.Duration) *Find {
+	if f == nil {
+		f = new(Find)
+	}
+
+	f.defaultMaxTime = maxTime
+	return f
+}
+
+// PrimaryFallback specifies whether the operation should be retried against the primary if it fails on a secondary
+// with a retryable read error.
+func (f *Find) PrimaryFallback(primaryFallback bool) *Find {
+	if f == nil {
+		f = new(Find)
+	}
+
+	f.primaryFallback = primaryFallback
+	return f
+}
+
+// LocalThreshold specifies the latency window to use when selecting a server for a primary fallback or escalated read
+// preference retry. If not set, a default of 15 milliseconds is used.
+func (f *Find) LocalThreshold(threshold time.Duration) *Find {
+	if f == nil {
+		f = new(Find)
+	}
+
+	f.localThreshold = &threshold
+	return f
+}
+
+// EscalateReadPreference specifies whether the operation should be retried with a primaryPreferred read preference if
+// it fails on a server other than the primary with a retryable read error.
+func (f *Find) EscalateReadPreference(escalate bool) *Find {
+	if f == nil {
+		f = new(Find)
+	}
+
+	f.escalateReadPref = escalate
+	return f
+}
@@ -10,6 +10,7 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"time"
 
 	"go.mongodb.org/mongo-driver/bson"
 	"go.mongodb.org/mongo-driver/bson/bsontype"
@@ -46,6 +47,8 @@ type FindAndModify struct {
 	crypt                    driver.Crypt
 	hint                     bsoncore.Value
 	serverAPI                *driver.ServerAPIOptions
+	maxTimeMSCeiling         time.Duration
+	defaultMaxTime           time.Duration
 	let                      bsoncore.Document
 
 	result FindAndModifyResult
@@ -127,17 +130,19 @@ func (fam *FindAndModify) Execute(ctx context.Context) error {
 		CommandFn:         fam.command,
 		ProcessResponseFn: fam.processResponse,
 
-		RetryMode:      fam.retry,
-		Type:           driver.Write,
-		Client:         fam.session,
-		Clock:          fam.clock,
-		CommandMonitor: fam.monitor,
-		Database:       fam.database,
-		Deployment:     fam.deployment,
-		Selector:       fam.selector,
-		WriteConcern:   fam.writeConcern,
-		Crypt:          fam.crypt,
-		ServerAPI:      fam.serverAPI,
+		RetryMode:        fam.retry,
+		Type:             driver.Write,
+		Client:           fam.session,
+		Clock:            fam.clock,
+		CommandMonitor:   fam.monitor,
+		Database:         fam.database,
+		Deployment:       fam.deployment,
+		Selector:         fam.selector,
+		WriteConcern:     fam.writeConcern,
+		Crypt:            fam.crypt,
+		ServerAPI:        fam.serverAPI,
+		MaxTimeMSCeiling: fam.maxTimeMSCeiling,
+		DefaultMaxTime:   fam.defaultMaxTime,
 	}.Execute(ctx, nil)
 
 }
@@ -443,6 +448,29 @@ func (fam *FindAndModify) ServerAPI(serverAPI *driver.ServerAPIOptions) *FindAnd
 	return fam
 }
 
+// MaxTimeMSCeiling specifies the maximum maxTimeMS value to derive from the deadline of the Context passed to Execute.
+// If non-zero and the Context has a deadline, maxTimeMS is set to the time remaining before the deadline, capped at
+// this value, unless the command already specifies maxTimeMS.
+func (fam *FindAndModify) MaxTimeMSCeiling(ceiling time.Duration) *FindAndModify {
+	if fam == nil {
+		fam = new(FindAndModify)
+	}
+
+	fam.maxTimeMSCeiling = ceiling
+	return fam
+}
+
+// DefaultMaxTime specifies the maxTimeMS value to send if the command does not already specify maxTimeMS. If
+// MaxTimeMSCeiling also derives a maxTimeMS from the Context deadline, the smaller of the two is sent.
+func (fam *FindAndModify) DefaultMaxTime(maxTime time.Duration) *FindAndModify {
+	if fam == nil {
+		fam = new(FindAndModify)
+	}
+
+	fam.defaultMaxTime = maxTime
+	return fam
+}
+
 // Let specifies the let document to use. This option is only valid for server versions 5.0 and above.
 func (fam *FindAndModify) Let(let bsoncore.Document) *FindAndModify {
 	if fam == nil {
@@ -159,13 +159,7 @@ func (h *Hello) handshakeCommand(dst []byte, desc description.SelectedServer) ([
 
 // command appends all necessary command fields.
 func (h *Hello) command(dst []byte, desc description.SelectedServer) ([]byte, error) {
-	// Use "hello" if topology is LoadBalanced, API version is declared or server
-	// has responded with "helloOk". Otherwise, use legacy hello.
-	if desc.Kind == description.LoadBalanced || h.serverAPI != nil || desc.Server.HelloOK {
-		dst = bsoncore.AppendInt32Element(dst, "hello", 1)
-	} else {
-		dst = bsoncore.AppendInt32Element(dst, internal.LegacyHello, 1)
-	}
+	dst = bsoncore.AppendInt32Element(dst, h.commandName(desc), 1)
 	dst = bsoncore.AppendBooleanElement(dst, "helloOk", true)
 
 	if tv := h.topologyVersion; tv != nil {
@@ -188,6 +182,16 @@ func (h *Hello) command(dst []byte, desc description.SelectedServer) ([]byte, er
 	return dst, nil
 }
 
+// commandName returns the name of the command to send to the server described by desc.
+func (h *Hello) commandName(desc description.SelectedServer) string {
+	// Use "hello" if topology is LoadBalanced, API version is declared or server
+	// has responded with "helloOk". Otherwise, use legacy hello.
+	if desc.Kind == description.LoadBalanced || h.serverAPI != nil || desc.Server.HelloOK {
+		return "hello"
+	}
+	return internal.LegacyHello
+}
+
 // Execute runs this operation.
 func (h *Hello) Execute(ctx context.Context) error {
 	if h.d == nil {
@@ -219,9 +223,13 @@ func (h *Hello) createOperation() driver.Operation {
 // GetHandshakeInformation performs the MongoDB handshake for the provided connection and returns the relevant
 // information about the server. This function implements the driver.Handshaker interface.
 func (h *Hello) GetHandshakeInformation(ctx context.Context, _ address.Address, c driver.Connection) (driver.HandshakeInformation, error) {
+	var helloCommand string
 	err := driver.Operation{
-		Clock:      h.clock,
-		CommandFn:  h.handshakeCommand,
+		Clock: h.clock,
+		CommandFn: func(dst []byte, desc description.SelectedServer) ([]byte, error) {
+			helloCommand = h.commandName(desc)
+			return h.handshakeCommand(dst, desc)
+		},
 		Deployment: driver.SingleConnectionDeployment{c},
 		Database:   "admin",
 		ProcessResponseFn: func(info driver.ResponseInfo) error {
@@ -235,7 +243,8 @@ func (h *Hello) GetHandshakeInformation(ctx context.Context, _ address.Address,
 	}
 
 	info := driver.HandshakeInformation{
-		Description: h.Result(c.Address()),
+		Description:  h.Result(c.Address()),
+		HelloCommand: helloCommand,
 	}
 	if speculativeAuthenticate, ok := h.res.Lookup("speculativeAuthenticate").DocumentOK(); ok {
 		info.SpeculativeAuthenticate = speculativeAuthenticate
@@ -10,7 +10,9 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"time"
 
+	"go.mongodb.org/mongo-driver/bson/primitive"
 	"go.mongodb.org/mongo-driver/event"
 	"go.mongodb.org/mongo-driver/mongo/description"
 	"go.mongodb.org/mongo-driver/mongo/writeconcern"
@@ -36,12 +38,16 @@ type Insert struct {
 	retry                    *driver.RetryMode
 	result                   InsertResult
 	serverAPI                *driver.ServerAPIOptions
+	maxTimeMSCeiling         time.Duration
+	defaultMaxTime           time.Duration
 }
 
 // InsertResult represents an insert result returned by the server.
 type InsertResult struct {
 	// Number of documents successfully inserted.
 	N int32
+	// The operationTime returned by the server for the last batch, if any.
+	OperationTime *primitive.Timestamp
 }
 
 func buildInsertResult(response bsoncore.Document) (InsertResult, error) {
@@ -58,6 +64,12 @@ func buildInsertResult(response bsoncore.Document) (InsertResult, error) {
 			if !ok {
 				return ir, fmt.Errorf("response field 'n' is type int32, but received BSON type %s", element.Value().Type)
 			}
+		case "operationTime":
+			t, i, ok := element.Value().TimestampOK()
+			if !ok {
+				return ir, fmt.Errorf("response field 'operationTime' is type timestamp, but received BSON type %s", element.Value().Type)
+			}
+			ir.OperationTime = &primitive.Timestamp{T: t, I: i}
 		}
 	}
 	return ir, nil
@@ -76,6 +88,9 @@ func (i *Insert) Result() InsertResult { return i.result }
 func (i *Insert) processResponse(info driver.ResponseInfo) error {
 	ir, err := buildInsertResult(info.ServerResponse)
 	i.result.N += ir.N
+	if ir.OperationTime != nil {
+		i.result.OperationTime = ir.OperationTime
+	}
 	return err
 }
 
@@ -105,6 +120,8 @@ func (i *Insert) Execute(ctx context.Context) error {
 		Selector:          i.selector,
 		WriteConcern:      i.writeConcern,
 		ServerAPI:         i.serverAPI,
+		MaxTimeMSCeiling:  i.maxTimeMSCeiling,
+		DefaultMaxTime:    i.defaultMaxTime,
 	}.Execute(ctx, nil)
 
 }
@@ -263,3 +280,26 @@ func (i *Insert) ServerAPI(serverAPI *driver.ServerAPIOptions) *Insert {
 	i.serverAPI = serverAPI
 	return i
 }
+
+// MaxTimeMSCeiling specifies the maximum maxTimeMS value to derive from the deadline of the Context passed to Execute.
+// If non-zero and the Context has a deadline, maxTimeMS is set to the time remaining before the deadline, capped at
+// this value, unless the command already specifies maxTimeMS.
+func (i *Insert) MaxTimeMSCeiling(ceiling time.Duration) *Insert {
+	if i == nil {
+		i = new(Insert)
+	}
+
+	i.maxTimeMSCeiling = ceiling
+	return i
+}
+
+// DefaultMaxTime specifies the maxTimeMS value to send if the command does not already specify maxTimeMS. If
+// MaxTimeMSCeiling also derives a maxTimeMS from the Context deadline, the smaller of the two is sent.
+func (i *Insert) DefaultMaxTime(maxTime time.Duration) *Insert {
+	if i == nil {
+		i = new(Insert)
+	}
+
+	i.defaultMaxTime = maxTime
+	return i
+}
@@ -10,8 +10,10 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"time"
 
 	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
 	"go.mongodb.org/mongo-driver/event"
 	"go.mongodb.org/mongo-driver/mongo/description"
 	"go.mongodb.org/mongo-driver/mongo/writeconcern"
@@ -39,6 +41,8 @@ type Update struct {
 	result                   UpdateResult
 	crypt                    driver.Crypt
 	serverAPI                *driver.ServerAPIOptions
+	maxTimeMSCeiling         time.Duration
+	defaultMaxTime           time.Duration
 	let                      bsoncore.Document
 }
 
@@ -56,6 +60,8 @@ type UpdateResult struct {
 	NModified int32
 	// Information about upserted documents.
 	Upserted []Upsert
+	// The operationTime returned by the server for the last batch, if any.
+	OperationTime *primitive.Timestamp
 }
 
 func buildUpdateResult(response bsoncore.Document) (UpdateResult, error) {
@@ -78,6 +84,12 @@ func buildUpdateResult(response bsoncore.Document) (UpdateResult, error) {
 			if !ok {
 				return ur, fmt.Errorf("response field 'n' is type int32, but received BSON type %s", element.Value().Type)
 			}
+		case "operationTime":
+			t, i, ok := element.Value().TimestampOK()
+			if !ok {
+				return ur, fmt.Errorf("response field 'operationTime' is type timestamp, but received BSON type %s", element.Value().Type)
+			}
+			ur.OperationTime = &primitive.Timestamp{T: t, I: i}
 		case "upserted":
 			arr, ok := element.Value().ArrayOK()
 			if !ok {
@@ -127,6 +139,9 @@ func (u *Update) processResponse(info driver.ResponseInfo) error {
 		}
 	}
 	u.result.Upserted = append(u.result.Upserted, ur.Upserted...)
+	if ur.OperationTime != nil {
+		u.result.OperationTime = ur.OperationTime
+	}
 	return err
 
 }
@@ -157,6 +172,8 @@ func (u *Update) Execute(ctx context.Context) error {
 		WriteConcern:      u.writeConcern,
 		Crypt:             u.crypt,
 		ServerAPI:         u.serverAPI,
+		MaxTimeMSCeiling:  u.maxTimeMSCeiling,
+		DefaultMaxTime:    u.defaultMaxTime,
 	}.Execute(ctx, nil)
 
 }
@@ -362,6 +379,29 @@ func (u *Update) ServerAPI(serverAPI *driver.ServerAPIOptions) *Update {
 	return u
 }
 
+// MaxTimeMSCeiling specifies the maximum maxTimeMS value to derive from the deadline of the Context passed to Execute.
+// If non-zero and the Context has a deadline, maxTimeMS is set to the time remaining before the deadline, capped at
+// this value, unless the command already specifies maxTimeMS.
+func (u *Update) MaxTimeMSCeiling(ceiling time.Duration) *Update {
+	if u == nil {
+		u = new(Update)
+	}
+
+	u.maxTimeMSCeiling = ceiling
+	return u
+}
+
+// DefaultMaxTime specifies the maxTimeMS value to send if the command does not already specify maxTimeMS. If
+// MaxTimeMSCeiling also derives a maxTimeMS from the Context deadline, the smaller of the two is sent.
+func (u *Update) DefaultMaxTime(maxTime time.Duration) *Update {
+	if u == nil {
+		u = new(Update)
+	}
+
+	u.defaultMaxTime = maxTime
+	return u
+}
+
 // Let specifies the let document to use. This option is only valid for server versions 5.0 and above.
 func (u *Update) Let(let bsoncore.Document) *Update {
 	if u == nil {
@@ -10,6 +10,7 @@ import (
 	"github.com/google/go-cmp/cmp"
 	"go.mongodb.org/mongo-driver/bson/bsontype"
 	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/event"
 	"go.mongodb.org/mongo-driver/internal"
 	"go.mongodb.org/mongo-driver/internal/testutil/assert"
 	"go.mongodb.org/mongo-driver/mongo/address"
@@ -215,6 +216,9 @@ func TestOperation(t *testing.T) {
 		majorityRc := bsoncore.AppendDocumentElement(nil, "readConcern", bsoncore.BuildDocument(nil,
 			bsoncore.AppendStringElement(nil, "level", "majority"),
 		))
+		availableRc := bsoncore.AppendDocumentElement(nil, "readConcern", bsoncore.BuildDocument(nil,
+			bsoncore.AppendStringElement(nil, "level", "available"),
+		))
 
 		testCases := []struct {
 			name string
@@ -224,6 +228,7 @@ func TestOperation(t *testing.T) {
 			{"nil", nil, nil},
 			{"empty", readconcern.New(), nil},
 			{"non-empty", readconcern.Majority(), majorityRc},
+			{"available", readconcern.Available(), availableRc},
 		}
 
 		for _, tc := range testCases {
@@ -524,7 +529,7 @@ func TestOperation(t *testing.T) {
 						Kind: tc.server,
 					},
 				}
-				wm, _, err := op.createQueryWireMessage(wm, desc)
+				wm, _, err := op.createQueryWireMessage(context.Background(), wm, desc)
 				noerr(t, err)
 
 				// We know where the $query would be within the OP_QUERY, so we'll just index into there.
@@ -599,6 +604,207 @@ func TestOperation(t *testing.T) {
 		assert.Nil(t, err, "ExecuteExhaust error: %v", err)
 		assert.True(t, conn.CurrentlyStreaming(), "expected CurrentlyStreaming to be true")
 	})
+	t.Run("connection tag in command events", func(t *testing.T) {
+		serverResponseDoc := bsoncore.BuildDocumentFromElements(nil,
+			bsoncore.AppendInt32Element(nil, "ok", 1),
+		)
+		conn := &mockTaggedConnection{
+			mockConnection: &mockConnection{
+				rDesc: description.Server{
+					WireVersion: &description.VersionRange{
+						Max: 6,
+					},
+				},
+				rReadWM: createExhaustServerResponse(serverResponseDoc, false),
+			},
+			tag: "reporting",
+		}
+
+		var started *event.CommandStartedEvent
+		var succeeded *event.CommandSucceededEvent
+		op := Operation{
+			CommandFn: func(dst []byte, desc description.SelectedServer) ([]byte, error) {
+				return bsoncore.AppendInt32Element(dst, "ping", 1), nil
+			},
+			Database:   "admin",
+			Deployment: SingleConnectionDeployment{conn},
+			CommandMonitor: &event.CommandMonitor{
+				Started: func(_ context.Context, evt *event.CommandStartedEvent) {
+					started = evt
+				},
+				Succeeded: func(_ context.Context, evt *event.CommandSucceededEvent) {
+					succeeded = evt
+				},
+			},
+		}
+		err := op.Execute(context.TODO(), nil)
+		assert.Nil(t, err, "Execute error: %v", err)
+
+		assert.NotNil(t, started, "expected CommandStartedEvent, got nil")
+		assert.Equal(t, "reporting", started.ConnectionTag,
+			"expected started event tag %q, got %q", "reporting", started.ConnectionTag)
+		assert.NotNil(t, succeeded, "expected CommandSucceededEvent, got nil")
+		assert.Equal(t, "reporting", succeeded.ConnectionTag,
+			"expected succeeded event tag %q, got %q", "reporting", succeeded.ConnectionTag)
+	})
+	t.Run("API deprecation callback", func(t *testing.T) {
+		deprecationErrResponse := bsoncore.BuildDocumentFromElements(nil,
+			bsoncore.AppendInt32Element(nil, "ok", 0),
+			bsoncore.AppendInt32Element(nil, "code", 363),
+			bsoncore.AppendStringElement(nil, "codeName", "APIDeprecationError"),
+			bsoncore.AppendStringElement(nil, "errmsg", "Provided apiDeprecationErrors:true, but the command count is deprecated in API Version 1"),
+		)
+		otherErrResponse := bsoncore.BuildDocumentFromElements(nil,
+			bsoncore.AppendInt32Element(nil, "ok", 0),
+			bsoncore.AppendInt32Element(nil, "code", 2),
+			bsoncore.AppendStringElement(nil, "errmsg", "bad value"),
+		)
+		okResponse := bsoncore.BuildDocumentFromElements(nil,
+			bsoncore.AppendInt32Element(nil, "ok", 1),
+		)
+
+		testCases := []struct {
+			name     string
+			response bsoncore.Document
+			expected []string
+		}{
+			{"deprecation error", deprecationErrResponse, []string{"count"}},
+			{"other error", otherErrResponse, nil},
+			{"success", okResponse, nil},
+		}
+		for _, tc := range testCases {
+			t.Run(tc.name, func(t *testing.T) {
+				conn := &mockConnection{
+					rDesc: description.Server{
+						WireVersion: &description.VersionRange{
+							Max: 6,
+						},
+					},
+					rReadWM: createExhaustServerResponse(tc.response, false),
+				}
+
+				var deprecated []string
+				serverAPI := NewServerAPIOptions(TestServerAPIVersion).
+					SetDeprecationErrors(true).
+					SetDeprecationCallback(func(command string) {
+						deprecated = append(deprecated, command)
+					})
+				op := Operation{
+					CommandFn: func(dst []byte, desc description.SelectedServer) ([]byte, error) {
+						return bsoncore.AppendStringElement(dst, "count", "coll"), nil
+					},
+					Database:   "testing",
+					Deployment: SingleConnectionDeployment{conn},
+					ServerAPI:  serverAPI,
+				}
+				_ = op.Execute(context.Background(), nil)
+				assert.Equal(t, tc.expected, deprecated, "expected deprecated commands %v, got %v", tc.expected,
+					deprecated)
+			})
+		}
+	})
+	t.Run("maxTimeMS from context deadline", func(t *testing.T) {
+		desc := description.SelectedServer{
+			Server: description.Server{
+				WireVersion: &description.VersionRange{Max: 6},
+			},
+		}
+		findCmd := func(dst []byte, desc description.SelectedServer) ([]byte, error) {
+			return bsoncore.AppendStringElement(dst, "find", "coll"), nil
+		}
+		findWithMaxTimeCmd := func(dst []byte, desc description.SelectedServer) ([]byte, error) {
+			dst = bsoncore.AppendStringElement(dst, "find", "coll")
+			return bsoncore.AppendInt64Element(dst, "maxTimeMS", 50), nil
+		}
+		// getMaxTimeMS creates the wire message for op and returns the maxTimeMS in the command, or -1 if there is
+		// no maxTimeMS.
+		getMaxTimeMS := func(ctx context.Context, t *testing.T, op Operation) int64 {
+			t.Helper()
+
+			wm, _, err := op.createWireMessage(ctx, nil, desc, &mockConnection{})
+			assert.Nil(t, err, "createWireMessage error: %v", err)
+			cmd := getMsgCommand(t, wm)
+			val, err := cmd.LookupErr("maxTimeMS")
+			if err != nil {
+				return -1
+			}
+			return val.Int64()
+		}
+
+		t.Run("clamped to ceiling for long deadlines", func(t *testing.T) {
+			ctx, cancel := context.WithTimeout(context.Background(), 2*time.Hour)
+			defer cancel()
+
+			op := Operation{CommandFn: findCmd, Database: "testing", Type: Read, MaxTimeMSCeiling: time.Second}
+			got := getMaxTimeMS(ctx, t, op)
+			assert.Equal(t, int64(1000), got, "expected maxTimeMS 1000, got %v", got)
+		})
+		t.Run("remaining time for short deadlines", func(t *testing.T) {
+			ctx, cancel := context.WithTimeout(context.Background(), 500*time.Millisecond)
+			defer cancel()
+
+			op := Operation{CommandFn: findCmd, Database: "testing", Type: Write, MaxTimeMSCeiling: time.Minute}
+			got := getMaxTimeMS(ctx, t, op)
+			assert.True(t, got > 0 && got <= 500, "expected maxTimeMS in (0, 500], got %v", got)
+		})
+		t.Run("expired deadline", func(t *testing.T) {
+			ctx, cancel := context.WithDeadline(context.Background(), time.Now().Add(-time.Second))
+			defer cancel()
+
+			op := Operation{CommandFn: findCmd, Database: "testing", Type: Read, MaxTimeMSCeiling: time.Minute}
+			got := getMaxTimeMS(ctx, t, op)
+			assert.Equal(t, int64(1), got, "expected maxTimeMS 1, got %v", got)
+		})
+		t.Run("no deadline", func(t *testing.T) {
+			op := Operation{CommandFn: findCmd, Database: "testing", Type: Read, MaxTimeMSCeiling: time.Second}
+			got := getMaxTimeMS(context.Background(), t, op)
+			assert.Equal(t, int64(-1), got, "expected no maxTimeMS, got %v", got)
+		})
+		t.Run("no ceiling", func(t *testing.T) {
+			ctx, cancel := context.WithTimeout(context.Background(), time.Hour)
+			defer cancel()
+
+			op := Operation{CommandFn: findCmd, Database: "testing", Type: Read}
+			got := getMaxTimeMS(ctx, t, op)
+			assert.Equal(t, int64(-1), got, "expected no maxTimeMS, got %v", got)
+		})
+		t.Run("explicit maxTimeMS", func(t *testing.T) {
+			ctx, cancel := context.WithTimeout(context.Background(), time.Hour)
+			defer cancel()
+
+			op := Operation{CommandFn: findWithMaxTimeCmd, Database: "testing", Type: Read, MaxTimeMSCeiling: time.Second}
+			got := getMaxTimeMS(ctx, t, op)
+			assert.Equal(t, int64(50), got, "expected maxTimeMS 50, got %v", got)
+		})
+		t.Run("default maxTimeMS", func(t *testing.T) {
+			op := Operation{CommandFn: findCmd, Database: "testing", Type: Write, DefaultMaxTime: 30 * time.Second}
+			got := getMaxTimeMS(context.Background(), t, op)
+			assert.Equal(t, int64(30000), got, "expected maxTimeMS 30000, got %v", got)
+		})
+		t.Run("explicit maxTimeMS overrides default", func(t *testing.T) {
+			op := Operation{CommandFn: findWithMaxTimeCmd, Database: "testing", Type: Read, DefaultMaxTime: time.Minute}
+			got := getMaxTimeMS(context.Background(), t, op)
+			assert.Equal(t, int64(50), got, "expected maxTimeMS 50, got %v", got)
+		})
+		t.Run("default maxTimeMS only for reads and writes", func(t *testing.T) {
+			op := Operation{CommandFn: findCmd, Database: "testing", DefaultMaxTime: time.Minute}
+			got := getMaxTimeMS(context.Background(), t, op)
+			assert.Equal(t, int64(-1), got, "expected no maxTimeMS, got %v", got)
+		})
+		t.Run("smaller of default and deadline", func(t *testing.T) {
+			ctx, cancel := context.WithTimeout(context.Background(), time.Hour)
+			defer cancel()
+
+			op := Operation{CommandFn: findCmd, Database: "testing", Type: Read, MaxTimeMSCeiling: time.Minute,
+				DefaultMaxTime: time.Second}
+			got := getMaxTimeMS(ctx, t, op)
+			assert.Equal(t, int64(1000), got, "expected maxTimeMS 1000, got %v", got)
+
+			op.DefaultMaxTime = 2 * time.Minute
+			got = getMaxTimeMS(ctx, t, op)
+			assert.Equal(t, int64(60000), got, "expected maxTimeMS 60000, got %v", got)
+		})
+	})
 }
 
 func createExhaustServerResponse(response bsoncore.Document, moreToCome bool) []byte {
@@ -628,6 +834,28 @@ func assertExhaustAllowedSet(t *testing.T, wm []byte, expected bool) {
 	assert.Equal(t, expected, actual, "expected exhaustAllowed set %v, got %v", expected, actual)
 }
 
+// getMsgCommand returns the command document from the body section of the OP_MSG wire message wm.
+func getMsgCommand(t *testing.T, wm []byte) bsoncore.Document {
+	t.Helper()
+	_, _, _, _, wm, ok := wiremessage.ReadHeader(wm)
+	if !ok {
+		t.Fatal("could not read wm header")
+	}
+	_, wm, ok = wiremessage.ReadMsgFlags(wm)
+	if !ok {
+		t.Fatal("could not read wm flags")
+	}
+	_, wm, ok = wiremessage.ReadMsgSectionType(wm)
+	if !ok {
+		t.Fatal("could not read wm section type")
+	}
+	cmd, _, ok := wiremessage.ReadMsgSectionSingleDocument(wm)
+	if !ok {
+		t.Fatal("could not read wm command document")
+	}
+	return cmd
+}
+
 type mockDeployment struct {
 	params struct {
 		selector description.ServerSelector
@@ -691,6 +919,15 @@ func (m *mockConnection) ReadWireMessage(_ context.Context, dst []byte) ([]byte,
 	return m.rReadWM, m.rReadErr
 }
 
+type mockTaggedConnection struct {
+	*mockConnection
+	tag string
+}
+
+func (m *mockTaggedConnection) Tag() string { return m.tag }
+
+var _ Tagger = (*mockTaggedConnection)(nil)
+
 type retryableError struct {
 	error
 }
@@ -753,4 +990,606 @@ func TestRetry(t *testing.T) {
 			time.Now().After(deadline),
 			"expected operation to complete only after the context deadline is exceeded")
 	})
+	t.Run("reports retry count in command events", func(t *testing.T) {
+		retryableErrResponse := bsoncore.BuildDocumentFromElements(nil,
+			bsoncore.AppendInt32Element(nil, "ok", 0),
+			bsoncore.AppendInt32Element(nil, "code", 91),
+			bsoncore.AppendStringElement(nil, "errmsg", "shutdown in progress"),
+		)
+		okResponse := bsoncore.BuildDocumentFromElements(nil,
+			bsoncore.AppendInt32Element(nil, "ok", 1),
+		)
+		d := new(mockDeployment)
+		d.returns.server = &mockSequenceServer{responses: []bsoncore.Document{retryableErrResponse, okResponse}}
+
+		var started []int
+		var failed []int
+		var succeeded []int
+		monitor := &event.CommandMonitor{
+			Started: func(_ context.Context, evt *event.CommandStartedEvent) {
+				started = append(started, evt.RetryCount)
+			},
+			Failed: func(_ context.Context, evt *event.CommandFailedEvent) {
+				failed = append(failed, evt.RetryCount)
+			},
+			Succeeded: func(_ context.Context, evt *event.CommandSucceededEvent) {
+				succeeded = append(succeeded, evt.RetryCount)
+			},
+		}
+
+		retry := RetryOnce
+		err := Operation{
+			CommandFn: func(dst []byte, desc description.SelectedServer) ([]byte, error) {
+				return bsoncore.AppendStringElement(dst, "find", "coll"), nil
+			},
+			CommandMonitor: monitor,
+			Deployment:     d,
+			Database:       "testing",
+			RetryMode:      &retry,
+			Type:           Read,
+		}.Execute(context.Background(), nil)
+		assert.Nil(t, err, "Execute error: %v", err)
+
+		assert.Equal(t, []int{0, 1}, started, "expected started event retry counts [0 1], got %v", started)
+		assert.Equal(t, []int{0}, failed, "expected failed event retry counts [0], got %v", failed)
+		assert.Equal(t, []int{1}, succeeded, "expected succeeded event retry counts [1], got %v", succeeded)
+	})
+	t.Run("injected error labels", func(t *testing.T) {
+		// Code 2 (BadValue) is not retryable, so the write is only retried if a RetryableWriteError label is added.
+		errResponse := bsoncore.BuildDocumentFromElements(nil,
+			bsoncore.AppendInt32Element(nil, "ok", 0),
+			bsoncore.AppendInt32Element(nil, "code", 2),
+			bsoncore.AppendStringElement(nil, "errmsg", "bad value"),
+		)
+		okResponse := bsoncore.BuildDocumentFromElements(nil,
+			bsoncore.AppendInt32Element(nil, "ok", 1),
+		)
+		desc := description.Server{
+			Kind:                  description.RSPrimary,
+			SessionTimeoutMinutes: 30,
+			WireVersion:           &description.VersionRange{Max: 9},
+		}
+		sess, err := session.NewClientSession(session.NewPool(nil), uuid.UUID{}, session.Explicit)
+		noerr(t, err)
+
+		testCases := []struct {
+			name    string
+			labels  []string
+			calls   int
+			wantErr bool
+		}{
+			{"RetryableWriteError label triggers retry", []string{RetryableWriteError, RetryableWriteError}, 2, false},
+			{"other labels do not trigger retry", []string{"CustomLabel"}, 1, true},
+			{"no labels do not trigger retry", nil, 1, true},
+		}
+		for _, tc := range testCases {
+			t.Run(tc.name, func(t *testing.T) {
+				ms := &mockSequenceServer{responses: []bsoncore.Document{errResponse, okResponse}, desc: &desc}
+				d := new(mockDeployment)
+				d.returns.server = ms
+
+				var cmdNames []string
+				retry := RetryOnce
+				err := Operation{
+					CommandFn: func(dst []byte, desc description.SelectedServer) ([]byte, error) {
+						return bsoncore.AppendStringElement(dst, "insert", "coll"), nil
+					},
+					Deployment: d,
+					Database:   "testing",
+					Client:     sess,
+					Clock:      &session.ClusterClock{},
+					RetryMode:  &retry,
+					Type:       Write,
+					ErrorLabelsFn: func(cmdName string, _ error) []string {
+						cmdNames = append(cmdNames, cmdName)
+						return tc.labels
+					},
+				}.Execute(context.Background(), nil)
+
+				assert.Equal(t, tc.calls, ms.calls, "expected %d attempts, got %d", tc.calls, ms.calls)
+				assert.Equal(t, []string{"insert"}, cmdNames, "expected ErrorLabelsFn to be called for [insert], got %v",
+					cmdNames)
+				if !tc.wantErr {
+					assert.Nil(t, err, "Execute error: %v", err)
+					return
+				}
+				driverErr, ok := err.(Error)
+				assert.True(t, ok, "expected error of type %T, got %T", Error{}, err)
+				for _, label := range tc.labels {
+					assert.True(t, driverErr.HasErrorLabel(label), "expected error to have label %q, got %v", label,
+						driverErr.Labels)
+				}
+				assert.Equal(t, len(tc.labels), len(driverErr.Labels), "expected labels %v, got %v", tc.labels,
+					driverErr.Labels)
+			})
+		}
+	})
+}
+
+// mockSequenceServer is a Server that returns a new connection for each call to Connection. Each connection replies
+// with the next response in responses.
+type mockSequenceServer struct {
+	responses []bsoncore.Document
+	calls     int
+	desc      *description.Server // The description of the returned connections. Defaults to wire version 6.
+}
+
+func (ms *mockSequenceServer) Connection(context.Context) (Connection, error) {
+	response := ms.responses[ms.calls]
+	ms.calls++
+	desc := description.Server{
+		WireVersion: &description.VersionRange{Max: 6},
+	}
+	if ms.desc != nil {
+		desc = *ms.desc
+	}
+	return &mockConnection{
+		rDesc:   desc,
+		rReadWM: createExhaustServerResponse(response, false),
+	}, nil
+}
+
+func (ms *mockSequenceServer) MinRTT() time.Duration { return 0 }
+
+// mockFallbackServer is a Server that always returns the same connection.
+type mockFallbackServer struct {
+	conn *mockConnection
+}
+
+func (ms *mockFallbackServer) Connection(context.Context) (Connection, error) { return ms.conn, nil }
+func (ms *mockFallbackServer) MinRTT() time.Duration                          { return 0 }
+
+// mockFallbackDeployment is a replica set Deployment that runs server selection against a primary and a secondary
+// and records the servers that were selected.
+type mockFallbackDeployment struct {
+	primary   *mockFallbackServer
+	secondary *mockFallbackServer
+	selected  []description.ServerKind
+}
+
+func (md *mockFallbackDeployment) SelectServer(_ context.Context, selector description.ServerSelector) (Server, error) {
+	topo := description.Topology{
+		Kind:    description.ReplicaSetWithPrimary,
+		Servers: []description.Server{md.primary.conn.rDesc, md.secondary.conn.rDesc},
+	}
+	selected, err := selector.SelectServer(topo, topo.Servers)
+	if err != nil {
+		return nil, err
+	}
+	if len(selected) == 0 {
+		return nil, errors.New("no servers selected")
+	}
+
+	md.selected = append(md.selected, selected[0].Kind)
+	if selected[0].Kind == description.RSPrimary {
+		return md.primary, nil
+	}
+	return md.secondary, nil
+}
+
+func (md *mockFallbackDeployment) Kind() description.TopologyKind {
+	return description.ReplicaSetWithPrimary
+}
+
+func TestPrimaryFallback(t *testing.T) {
+	okResponse := bsoncore.BuildDocumentFromElements(nil,
+		bsoncore.AppendInt32Element(nil, "ok", 1),
+	)
+	retryableErrResponse := bsoncore.BuildDocumentFromElements(nil,
+		bsoncore.AppendInt32Element(nil, "ok", 0),
+		bsoncore.AppendInt32Element(nil, "code", 91),
+		bsoncore.AppendStringElement(nil, "errmsg", "shutdown in progress"),
+	)
+	nonRetryableErrResponse := bsoncore.BuildDocumentFromElements(nil,
+		bsoncore.AppendInt32Element(nil, "ok", 0),
+		bsoncore.AppendInt32Element(nil, "code", 2),
+		bsoncore.AppendStringElement(nil, "errmsg", "bad value"),
+	)
+	newDeployment := func(secondaryResponse bsoncore.Document) *mockFallbackDeployment {
+		newServer := func(addr string, kind description.ServerKind, response bsoncore.Document) *mockFallbackServer {
+			return &mockFallbackServer{
+				conn: &mockConnection{
+					rDesc: description.Server{
+						Addr:        address.Address(addr),
+						Kind:        kind,
+						WireVersion: &description.VersionRange{Max: 6},
+					},
+					rReadWM: createExhaustServerResponse(response, false),
+				},
+			}
+		}
+		return &mockFallbackDeployment{
+			primary:   newServer("primary:27017", description.RSPrimary, okResponse),
+			secondary: newServer("secondary:27017", description.RSSecondary, secondaryResponse),
+		}
+	}
+	newOperation := func(d Deployment, fallback bool, opType Type) Operation {
+		return Operation{
+			CommandFn: func(dst []byte, desc description.SelectedServer) ([]byte, error) {
+				return bsoncore.AppendInt32Element(dst, "count", 1), nil
+			},
+			Database:        "testing",
+			Deployment:      d,
+			ReadPreference:  readpref.Secondary(),
+			Type:            opType,
+			PrimaryFallback: fallback,
+		}
+	}
+
+	testCases := []struct {
+		name              string
+		fallback          bool
+		opType            Type
+		secondaryResponse bsoncore.Document
+		expectErr         bool
+		expectedSelected  []description.ServerKind
+	}{
+		{
+			"retryable error falls back to primary",
+			true, Read, retryableErrResponse, false,
+			[]description.ServerKind{description.RSSecondary, description.RSPrimary},
+		},
+		{
+			"fallback disabled",
+			false, Read, retryableErrResponse, true,
+			[]description.ServerKind{description.RSSecondary},
+		},
+		{
+			"non-retryable error does not fall back",
+			true, Read, nonRetryableErrResponse, true,
+			[]description.ServerKind{description.RSSecondary},
+		},
+		{
+			"writes do not fall back",
+			true, Write, retryableErrResponse, true,
+			[]description.ServerKind{description.RSSecondary},
+		},
+		{
+			"success on secondary",
+			true, Read, okResponse, false,
+			[]description.ServerKind{description.RSSecondary},
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			d := newDeployment(tc.secondaryResponse)
+			err := newOperation(d, tc.fallback, tc.opType).Execute(context.Background(), nil)
+			if tc.expectErr {
+				assert.NotNil(t, err, "expected Execute error, got nil")
+			} else {
+				assert.Nil(t, err, "Execute error: %v", err)
+			}
+			assert.Equal(t, tc.expectedSelected, d.selected,
+				"expected selected servers %v, got %v", tc.expectedSelected, d.selected)
+		})
+	}
+}
+
+func TestReadPrefRetrySelector(t *testing.T) {
+	primary := description.Server{
+		Addr:          address.Address("primary:27017"),
+		Kind:          description.RSPrimary,
+		AverageRTT:    10 * time.Millisecond,
+		AverageRTTSet: true,
+	}
+	secondary := description.Server{
+		Addr:          address.Address("secondary:27017"),
+		Kind:          description.RSSecondary,
+		AverageRTT:    40 * time.Millisecond,
+		AverageRTTSet: true,
+	}
+	topo := description.Topology{
+		Kind:    description.ReplicaSetWithPrimary,
+		Servers: []description.Server{primary, secondary},
+	}
+
+	t.Run("keeps other stages of the original selector", func(t *testing.T) {
+		var excludePrimary description.ServerSelectorFunc = func(_ description.Topology, svrs []description.Server) ([]description.Server, error) {
+			var result []description.Server
+			for _, s := range svrs {
+				if s.Addr != primary.Addr {
+					result = append(result, s)
+				}
+			}
+			return result, nil
+		}
+		op := Operation{
+			Selector: description.CompositeSelector([]description.ServerSelector{
+				excludePrimary,
+				description.ReadPrefSelector(readpref.Secondary()),
+				description.LatencySelector(defaultLocalThreshold),
+			}),
+		}
+
+		selected, err := op.readPrefRetrySelector(readpref.Primary()).SelectServer(topo, topo.Servers)
+		assert.Nil(t, err, "SelectServer error: %v", err)
+		assert.Equal(t, 0, len(selected), "expected no servers to be selected, got %v", selected)
+	})
+	t.Run("replaces the read preference", func(t *testing.T) {
+		op := Operation{
+			Selector: description.CompositeSelector([]description.ServerSelector{
+				description.ReadPrefSelector(readpref.Secondary()),
+				description.LatencySelector(defaultLocalThreshold),
+			}),
+		}
+
+		selected, err := op.readPrefRetrySelector(readpref.Primary()).SelectServer(topo, topo.Servers)
+		assert.Nil(t, err, "SelectServer error: %v", err)
+		assert.Equal(t, []description.Server{primary}, selected, "expected primary to be selected, got %v", selected)
+	})
+	t.Run("uses the configured local threshold", func(t *testing.T) {
+		threshold := 50 * time.Millisecond
+		testCases := []struct {
+			name      string
+			threshold *time.Duration
+			expected  []description.Server
+		}{
+			{"default", nil, []description.Server{primary}},
+			{"configured", &threshold, []description.Server{primary, secondary}},
+		}
+		for _, tc := range testCases {
+			t.Run(tc.name, func(t *testing.T) {
+				op := Operation{LocalThreshold: tc.threshold}
+
+				selected, err := op.readPrefRetrySelector(readpref.Nearest()).SelectServer(topo, topo.Servers)
+				assert.Nil(t, err, "SelectServer error: %v", err)
+				assert.Equal(t, tc.expected, selected, "expected selected servers %v, got %v", tc.expected, selected)
+			})
+		}
+	})
+	t.Run("rewraps a wrapping selector", func(t *testing.T) {
+		inner := description.CompositeSelector([]description.ServerSelector{
+			description.ReadPrefSelector(readpref.Secondary()),
+			description.LatencySelector(defaultLocalThreshold),
+		})
+		testCases := []struct {
+			name     string
+			pinned   *address.Address
+			expected []description.Server
+		}{
+			{"not pinned", nil, []description.Server{primary}},
+			{"pinned", &secondary.Addr, []description.Server{secondary}},
+		}
+		for _, tc := range testCases {
+			t.Run(tc.name, func(t *testing.T) {
+				op := Operation{Selector: &testPinnedSelector{pinned: tc.pinned, selector: inner}}
+
+				retry := op.readPrefRetrySelector(readpref.Primary())
+				_, ok := retry.(description.WrappingSelector)
+				assert.True(t, ok, "expected retry selector to be a WrappingSelector, got %T", retry)
+				selected, err := retry.SelectServer(topo, topo.Servers)
+				assert.Nil(t, err, "SelectServer error: %v", err)
+				assert.Equal(t, tc.expected, selected, "expected selected servers %v, got %v", tc.expected, selected)
+			})
+		}
+	})
+}
+
+// testPinnedSelector is a description.WrappingSelector that only selects the server at pinned if it is set and
+// otherwise uses selector.
+type testPinnedSelector struct {
+	pinned   *address.Address
+	selector description.ServerSelector
+}
+
+func (ps *testPinnedSelector) SelectServer(t description.Topology, svrs []description.Server) ([]description.Server, error) {
+	if ps.pinned == nil {
+		return ps.selector.SelectServer(t, svrs)
+	}
+	for _, s := range svrs {
+		if s.Addr == *ps.pinned {
+			return []description.Server{s}, nil
+		}
+	}
+	return nil, nil
+}
+
+func (ps *testPinnedSelector) Unwrap() description.ServerSelector {
+	return ps.selector
+}
+
+func (ps *testPinnedSelector) Wrap(selector description.ServerSelector) description.ServerSelector {
+	return &testPinnedSelector{pinned: ps.pinned, selector: selector}
+}
+
+func TestEscalateReadPreference(t *testing.T) {
+	retryOnce := RetryOnce
+	okResponse := bsoncore.BuildDocumentFromElements(nil,
+		bsoncore.AppendInt32Element(nil, "ok", 1),
+	)
+	retryableErrResponse := bsoncore.BuildDocumentFromElements(nil,
+		bsoncore.AppendInt32Element(nil, "ok", 0),
+		bsoncore.AppendInt32Element(nil, "code", 91),
+		bsoncore.AppendStringElement(nil, "errmsg", "shutdown in progress"),
+	)
+	newServer := func(addr string, kind description.ServerKind, response bsoncore.Document) *mockFallbackServer {
+		return &mockFallbackServer{
+			conn: &mockConnection{
+				rDesc: description.Server{
+					Addr:        address.Address(addr),
+					Kind:        kind,
+					WireVersion: &description.VersionRange{Max: 6},
+				},
+				rReadWM: createExhaustServerResponse(response, false),
+			},
+		}
+	}
+
+	testCases := []struct {
+		name             string
+		escalate         bool
+		retryMode        *RetryMode
+		rp               *readpref.ReadPref
+		expectErr        bool
+		expectedSelected []description.ServerKind
+	}{
+		{
+			"secondary read escalates to primary",
+			true, &retryOnce, readpref.Secondary(), false,
+			[]description.ServerKind{description.RSSecondary, description.RSPrimary},
+		},
+		{
+			"secondaryPreferred read escalates to primary",
+			true, &retryOnce, readpref.SecondaryPreferred(), false,
+			[]description.ServerKind{description.RSSecondary, description.RSPrimary},
+		},
+		{
+			"escalation disabled retries on secondary",
+			false, &retryOnce, readpref.Secondary(), true,
+			[]description.ServerKind{description.RSSecondary, description.RSSecondary},
+		},
+		{
+			"no escalation without retries",
+			true, nil, readpref.Secondary(), true,
+			[]description.ServerKind{description.RSSecondary},
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			d := &mockFallbackDeployment{
+				primary:   newServer("primary:27017", description.RSPrimary, okResponse),
+				secondary: newServer("secondary:27017", description.RSSecondary, retryableErrResponse),
+			}
+			err := Operation{
+				CommandFn: func(dst []byte, desc description.SelectedServer) ([]byte, error) {
+					return bsoncore.AppendInt32Element(dst, "count", 1), nil
+				},
+				Database:               "testing",
+				Deployment:             d,
+				ReadPreference:         tc.rp,
+				Type:                   Read,
+				RetryMode:              tc.retryMode,
+				EscalateReadPreference: tc.escalate,
+			}.Execute(context.Background(), nil)
+			if tc.expectErr {
+				assert.NotNil(t, err, "expected Execute error, got nil")
+			} else {
+				assert.Nil(t, err, "Execute error: %v", err)
+			}
+			assert.Equal(t, tc.expectedSelected, d.selected,
+				"expected selected servers %v, got %v", tc.expectedSelected, d.selected)
+		})
+	}
+	t.Run("escalation keeps other selector stages", func(t *testing.T) {
+		d := &mockFallbackDeployment{
+			primary:   newServer("primary:27017", description.RSPrimary, okResponse),
+			secondary: newServer("secondary:27017", description.RSSecondary, retryableErrResponse),
+		}
+		var excludePrimary description.ServerSelectorFunc = func(_ description.Topology, svrs []description.Server) ([]description.Server, error) {
+			return []description.Server{d.secondary.conn.rDesc}, nil
+		}
+		err := Operation{
+			CommandFn: func(dst []byte, desc description.SelectedServer) ([]byte, error) {
+				return bsoncore.AppendInt32Element(dst, "count", 1), nil
+			},
+			Database:       "testing",
+			Deployment:     d,
+			ReadPreference: readpref.Secondary(),
+			Selector: description.CompositeSelector([]description.ServerSelector{
+				excludePrimary,
+				description.ReadPrefSelector(readpref.Secondary()),
+				description.LatencySelector(defaultLocalThreshold),
+			}),
+			Type:                   Read,
+			RetryMode:              &retryOnce,
+			EscalateReadPreference: true,
+		}.Execute(context.Background(), nil)
+		assert.NotNil(t, err, "expected Execute error, got nil")
+		expected := []description.ServerKind{description.RSSecondary, description.RSSecondary}
+		assert.Equal(t, expected, d.selected, "expected selected servers %v, got %v", expected, d.selected)
+	})
+	t.Run("primary read preference is not weakened", func(t *testing.T) {
+		assert.False(t, escalatableReadPreference(nil), "expected nil read preference not to be escalated")
+		assert.False(t, escalatableReadPreference(readpref.Primary()), "expected primary not to be escalated")
+		assert.True(t, escalatableReadPreference(readpref.Nearest()), "expected nearest to be escalated")
+	})
+}
+
+// mockDelayDeployment is a Deployment that waits for selectDelay before selecting a server.
+type mockDelayDeployment struct {
+	server      Server
+	selectDelay time.Duration
+}
+
+func (m *mockDelayDeployment) SelectServer(context.Context, description.ServerSelector) (Server, error) {
+	time.Sleep(m.selectDelay)
+	return m.server, nil
+}
+
+func (m *mockDelayDeployment) Kind() description.TopologyKind { return description.Single }
+
+// mockDelayServer is a Server that waits for checkoutDelay before returning its connection.
+type mockDelayServer struct {
+	conn          Connection
+	checkoutDelay time.Duration
+}
+
+func (m *mockDelayServer) Connection(context.Context) (Connection, error) {
+	time.Sleep(m.checkoutDelay)
+	return m.conn, nil
+}
+
+func (m *mockDelayServer) MinRTT() time.Duration { return 0 }
+
+// mockDelayConnection is a Connection that waits for readDelay before reading a wire message.
+type mockDelayConnection struct {
+	*mockConnection
+	readDelay time.Duration
+}
+
+func (m *mockDelayConnection) ReadWireMessage(ctx context.Context, dst []byte) ([]byte, error) {
+	time.Sleep(m.readDelay)
+	return m.mockConnection.ReadWireMessage(ctx, dst)
+}
+
+func TestCommandTimings(t *testing.T) {
+	const delay = 20 * time.Millisecond
+	okResponse := bsoncore.BuildDocumentFromElements(nil,
+		bsoncore.AppendInt32Element(nil, "ok", 1),
+	)
+	conn := &mockDelayConnection{
+		mockConnection: &mockConnection{
+			rDesc:   description.Server{WireVersion: &description.VersionRange{Max: 6}},
+			rReadWM: createExhaustServerResponse(okResponse, false),
+		},
+		readDelay: delay,
+	}
+	deployment := &mockDelayDeployment{
+		server:      &mockDelayServer{conn: conn, checkoutDelay: delay},
+		selectDelay: delay,
+	}
+
+	var timings *event.CommandTimings
+	err := Operation{
+		CommandFn: func(dst []byte, desc description.SelectedServer) ([]byte, error) {
+			return bsoncore.AppendInt32Element(dst, "ping", 1), nil
+		},
+		Database:   "admin",
+		Deployment: deployment,
+		CommandMonitor: &event.CommandMonitor{
+			Succeeded: func(_ context.Context, evt *event.CommandSucceededEvent) {
+				timings = &evt.Timings
+			},
+		},
+	}.Execute(context.Background(), nil)
+	assert.Nil(t, err, "Execute error: %v", err)
+	assert.NotNil(t, timings, "expected CommandSucceededEvent, got nil")
+
+	phases := []struct {
+		name     string
+		duration time.Duration
+		min      time.Duration
+	}{
+		{"ServerSelection", timings.ServerSelection, delay},
+		{"ConnectionCheckout", timings.ConnectionCheckout, delay},
+		{"RoundTrip", timings.RoundTrip, delay},
+		{"Decode", timings.Decode, 0},
+	}
+	var sum time.Duration
+	for _, phase := range phases {
+		assert.True(t, phase.duration >= phase.min, "expected %s to be at least %v, got %v", phase.name, phase.min,
+			phase.duration)
+		sum += phase.duration
+	}
+	assert.True(t, timings.Total >= sum, "expected Total %v to be at least the sum of the phases %v", timings.Total, sum)
 }
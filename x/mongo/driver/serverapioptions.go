@@ -13,9 +13,10 @@ const TestServerAPIVersion = "1"
 // ServerAPIOptions represents options used to configure the API version sent to the server
 // when running commands.
 type ServerAPIOptions struct {
-	ServerAPIVersion  string
-	Strict            *bool
-	DeprecationErrors *bool
+	ServerAPIVersion    string
+	Strict              *bool
+	DeprecationErrors   *bool
+	DeprecationCallback func(command string)
 }
 
 // NewServerAPIOptions creates a new ServerAPIOptions configured with the provided serverAPIVersion.
@@ -34,3 +35,10 @@ func (s *ServerAPIOptions) SetDeprecationErrors(deprecationErrors bool) *ServerA
 	s.DeprecationErrors = &deprecationErrors
 	return s
 }
+
+// SetDeprecationCallback specifies a function that is called with the command name when the server reports that a
+// command used a deprecated feature.
+func (s *ServerAPIOptions) SetDeprecationCallback(callback func(command string)) *ServerAPIOptions {
+	s.DeprecationCallback = callback
+	return s
+}
@@ -357,13 +357,17 @@ NextEvent:
 			t.Fatalf("unable to get next event. too few events occurred")
 		}
 
-		event := <-events
+		evt := <-events
+		// PoolWarmedUp is a driver-specific event that the spec tests do not account for.
+		if evt.Type == event.PoolWarmedUp {
+			continue NextEvent
+		}
 		for _, Type := range ignoreEvents {
-			if event.Type == Type {
+			if evt.Type == Type {
 				continue NextEvent
 			}
 		}
-		return event
+		return evt
 	}
 }
 
@@ -18,6 +18,7 @@ import (
 	"sync/atomic"
 	"time"
 
+	"go.mongodb.org/mongo-driver/event"
 	"go.mongodb.org/mongo-driver/internal"
 	"go.mongodb.org/mongo-driver/mongo/address"
 	"go.mongodb.org/mongo-driver/mongo/description"
@@ -192,6 +193,12 @@ func (c *connection) connect(ctx context.Context) (err error) {
 	}
 	c.nc = tempNc
 
+	if tcpConn, ok := tempNc.(*net.TCPConn); ok && c.config.tcpKeepAlive.isSet() {
+		if err = setTCPKeepAlive(tcpConn, c.config.tcpKeepAlive); err != nil {
+			return ConnectionError{Wrapped: err, init: true, message: "failed to configure TCP keepalive"}
+		}
+	}
+
 	if c.config.tlsConfig != nil {
 		tlsConfig := c.config.tlsConfig.Clone()
 
@@ -249,6 +256,15 @@ func (c *connection) connect(ctx context.Context) (err error) {
 		return ConnectionError{Wrapped: err, init: true}
 	}
 
+	if c.config.handshakeComplete != nil {
+		negotiated := event.HandshakeInfo{HelloCommand: handshakeInfo.HelloCommand}
+		if wv := c.desc.WireVersion; wv != nil {
+			negotiated.MinWireVersion = wv.Min
+			negotiated.MaxWireVersion = wv.Max
+		}
+		c.config.handshakeComplete(c.addr, negotiated)
+	}
+
 	if len(c.desc.Compression) > 0 {
 	clientMethodLoop:
 		for _, method := range c.config.compressors {
@@ -556,6 +572,7 @@ type initConnection struct{ *connection }
 
 var _ driver.Connection = initConnection{}
 var _ driver.StreamerConnection = initConnection{}
+var _ driver.PoolGenerationReporter = initConnection{}
 
 func (c initConnection) Description() description.Server {
 	if c.connection == nil {
@@ -567,6 +584,7 @@ func (c initConnection) Close() error             { return nil }
 func (c initConnection) ID() string               { return c.id }
 func (c initConnection) Address() address.Address { return c.addr }
 func (c initConnection) Stale() bool              { return false }
+func (c initConnection) PoolGeneration() uint64   { return c.generation }
 func (c initConnection) LocalAddress() address.Address {
 	if c.connection == nil || c.nc == nil {
 		return address.Address("0.0.0.0")
@@ -602,6 +620,7 @@ type Connection struct {
 var _ driver.Connection = (*Connection)(nil)
 var _ driver.Expirable = (*Connection)(nil)
 var _ driver.PinnedConnection = (*Connection)(nil)
+var _ driver.PoolGenerationReporter = (*Connection)(nil)
 
 // WriteWireMessage handles writing a wire message to the underlying connection.
 func (c *Connection) WriteWireMessage(ctx context.Context, wm []byte) error {
@@ -626,14 +645,15 @@ func (c *Connection) ReadWireMessage(ctx context.Context, dst []byte) ([]byte, e
 
 // CompressWireMessage handles compressing the provided wire message using the underlying
 // connection's compressor. The dst parameter will be overwritten with the new wire message. If
-// there is no compressor set on the underlying connection, then no compression will be performed.
+// there is no compressor set on the underlying connection or the wire message is smaller than the
+// configured minimum compression size, then no compression will be performed.
 func (c *Connection) CompressWireMessage(src, dst []byte) ([]byte, error) {
 	c.mu.RLock()
 	defer c.mu.RUnlock()
 	if c.connection == nil {
 		return dst, ErrConnectionClosed
 	}
-	if c.connection.compressor == wiremessage.CompressorNoOp {
+	if c.connection.compressor == wiremessage.CompressorNoOp || len(src) < c.connection.config.compressionMinSize {
 		return append(dst, src...), nil
 	}
 	_, reqid, respto, origcode, rem, ok := wiremessage.ReadHeader(src)
@@ -716,6 +736,27 @@ func (c *Connection) ID() string {
 	return c.id
 }
 
+// Tag returns the workload tag of this connection. It implements the driver.Tagger interface.
+func (c *Connection) Tag() string {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	if c.connection == nil {
+		return ""
+	}
+	return c.config.tag
+}
+
+// PoolGeneration returns the generation of the pool that this connection was created in. It implements the
+// driver.PoolGenerationReporter interface.
+func (c *Connection) PoolGeneration() uint64 {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	if c.connection == nil {
+		return 0
+	}
+	return c.connection.generation
+}
+
 // Stale returns if the connection is stale.
 func (c *Connection) Stale() bool {
 	c.mu.RLock()
@@ -0,0 +1,140 @@
+// Copyright (C) MongoDB, Inc. 2017-present.
+//
+// Licensed under the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License. You may obtain
+// a copy of the License at http://www.apache.org/licenses/LICENSE-2.0
+
+package topology
+
+import (
+	"sync"
+)
+
+// connectionLimiter is a semaphore shared by the connection pools of all servers in a topology that limits the total
+// number of connections they hold. A pool acquires a slot before it creates a connection and releases it when the
+// connection is removed from the pool. Idle connections keep their slots, so a pool that can't acquire a slot evicts an
+// idle connection from another pool to free one.
+type connectionLimiter struct {
+	max int
+
+	mu    sync.Mutex
+	count int
+	pools map[*pool]struct{} // pools are the pools that share the limiter.
+	// wakeups is incremented each time the limiter wakes up the pools, so a pool that checks for idle connections to
+	// evict without holding its createConnectionsCond lock can tell whether it missed a wakeup.
+	wakeups uint64
+}
+
+func newConnectionLimiter(max int) *connectionLimiter {
+	return &connectionLimiter{
+		max:   max,
+		pools: make(map[*pool]struct{}),
+	}
+}
+
+// register adds a pool to the set that is woken up when a slot is released and that idle connections are evicted from.
+func (l *connectionLimiter) register(p *pool) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	l.pools[p] = struct{}{}
+}
+
+// unregister removes a pool from the set that is woken up when a slot is released and that idle connections are
+// evicted from.
+func (l *connectionLimiter) unregister(p *pool) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	delete(l.pools, p)
+}
+
+// tryAcquire takes a slot and returns true if one is available, or returns false otherwise.
+func (l *connectionLimiter) tryAcquire() bool {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	if l.count >= l.max {
+		return false
+	}
+	l.count++
+	return true
+}
+
+// release returns a slot and wakes up the createConnections goroutines of all registered pools so one of them can take
+// it. Callers must not hold the createConnectionsCond lock of any registered pool.
+func (l *connectionLimiter) release() {
+	l.mu.Lock()
+	l.count--
+	l.mu.Unlock()
+
+	l.wake()
+}
+
+// idleAvailable wakes up the createConnections goroutines of all registered pools if every slot is taken, so a pool
+// waiting for a slot can evict the connection that was just made idle. Callers must not hold the createConnectionsCond
+// lock of any registered pool.
+func (l *connectionLimiter) idleAvailable() {
+	l.mu.Lock()
+	full := l.count >= l.max
+	l.mu.Unlock()
+
+	if full {
+		l.wake()
+	}
+}
+
+// wake wakes up the createConnections goroutines of all registered pools.
+func (l *connectionLimiter) wake() {
+	l.mu.Lock()
+	l.wakeups++
+	pools := make([]*pool, 0, len(l.pools))
+	for p := range l.pools {
+		pools = append(pools, p)
+	}
+	l.mu.Unlock()
+
+	// Lock each pool's createConnectionsCond before broadcasting so a pool that saw the limiter as full can't miss the
+	// wakeup between checking the limiter and waiting on its condition.
+	for _, p := range pools {
+		p.createConnectionsCond.L.Lock()
+		p.createConnectionsCond.Broadcast()
+		p.createConnectionsCond.L.Unlock()
+	}
+}
+
+// wakeupCount returns the number of times the limiter has woken up the pools.
+func (l *connectionLimiter) wakeupCount() uint64 {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	return l.wakeups
+}
+
+// evictIdle closes an idle connection of a registered pool other than requester, which releases its slot. It returns
+// true if a connection was evicted. Callers must not hold the createConnectionsCond lock of any registered pool.
+func (l *connectionLimiter) evictIdle(requester *pool) bool {
+	l.mu.Lock()
+	pools := make([]*pool, 0, len(l.pools))
+	for p := range l.pools {
+		if p != requester {
+			pools = append(pools, p)
+		}
+	}
+	l.mu.Unlock()
+
+	for _, p := range pools {
+		if p.evictIdleConnection() {
+			return true
+		}
+	}
+	return false
+}
+
+// inUse returns the number of slots that are currently taken.
+func (l *connectionLimiter) inUse() int {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	return l.count
+}
@@ -49,6 +49,7 @@ type connectionConfig struct {
 	writeTimeout             time.Duration
 	tlsConfig                *tls.Config
 	compressors              []string
+	compressionMinSize       int
 	zlibLevel                *int
 	zstdLevel                *int
 	ocspCache                ocsp.Cache
@@ -56,6 +57,10 @@ type connectionConfig struct {
 	tlsConnectionSource      tlsConnectionSource
 	loadBalanced             bool
 	getGenerationFn          generationNumberFn
+	tag                      string
+	tcpKeepAlive             TCPKeepAlive
+	socks5Proxy              SOCKS5Proxy
+	handshakeComplete        event.HandshakeCompleteFunc
 }
 
 func newConnectionConfig(opts ...ConnectionOption) *connectionConfig {
@@ -72,6 +77,9 @@ func newConnectionConfig(opts ...ConnectionOption) *connectionConfig {
 	if cfg.dialer == nil {
 		cfg.dialer = &net.Dialer{}
 	}
+	if cfg.socks5Proxy.Address != "" {
+		cfg.dialer = &socks5Dialer{proxy: cfg.socks5Proxy, forward: cfg.dialer}
+	}
 
 	return cfg
 }
@@ -92,6 +100,14 @@ func WithCompressors(fn func([]string) []string) ConnectionOption {
 	}
 }
 
+// WithConnectionTag configures a workload tag (e.g. "reporting" or "oltp") for connections. The tag is included in the
+// pool and command monitoring events for those connections.
+func WithConnectionTag(fn func(string) string) ConnectionOption {
+	return func(c *connectionConfig) {
+		c.tag = fn(c.tag)
+	}
+}
+
 // WithConnectTimeout configures the maximum amount of time a dial will wait for a
 // Connect to complete. The default is 30 seconds.
 func WithConnectTimeout(fn func(time.Duration) time.Duration) ConnectionOption {
@@ -107,6 +123,31 @@ func WithDialer(fn func(Dialer) Dialer) ConnectionOption {
 	}
 }
 
+// TCPKeepAlive configures TCP keepalive probes for a connection. A zero value for any field leaves the corresponding
+// operating system setting unchanged.
+type TCPKeepAlive struct {
+	// Idle is how long a connection must be idle before the first keepalive probe is sent.
+	Idle time.Duration
+	// Interval is the time between keepalive probes.
+	Interval time.Duration
+	// Count is the number of unanswered probes after which the connection is considered dead.
+	Count int
+}
+
+func (ka TCPKeepAlive) isSet() bool {
+	return ka.Idle > 0 || ka.Interval > 0 || ka.Count > 0
+}
+
+// WithTCPKeepAlive configures TCP keepalive for new connections. The settings are applied to the connection returned
+// by the Dialer if it is a *net.TCPConn, and keepalive probes are enabled on the connection if any setting is
+// non-zero. Interval and Count are only applied on Linux. On other platforms, Idle is applied using
+// (*net.TCPConn).SetKeepAlivePeriod.
+func WithTCPKeepAlive(fn func(TCPKeepAlive) TCPKeepAlive) ConnectionOption {
+	return func(c *connectionConfig) {
+		c.tcpKeepAlive = fn(c.tcpKeepAlive)
+	}
+}
+
 // WithHandshaker configures the Handshaker that wll be used to initialize newly
 // dialed connections.
 func WithHandshaker(fn func(Handshaker) Handshaker) ConnectionOption {
@@ -115,6 +156,14 @@ func WithHandshaker(fn func(Handshaker) Handshaker) ConnectionOption {
 	}
 }
 
+// WithHandshakeCompleteCallback configures a function that is called with the negotiated wire versions after the
+// handshake for a newly dialed connection completes successfully. It is also called for monitoring connections.
+func WithHandshakeCompleteCallback(fn func(event.HandshakeCompleteFunc) event.HandshakeCompleteFunc) ConnectionOption {
+	return func(c *connectionConfig) {
+		c.handshakeComplete = fn(c.handshakeComplete)
+	}
+}
+
 // WithIdleTimeout configures the maximum idle time to allow for a connection.
 func WithIdleTimeout(fn func(time.Duration) time.Duration) ConnectionOption {
 	return func(c *connectionConfig) {
@@ -150,6 +199,14 @@ func WithMonitor(fn func(*event.CommandMonitor) *event.CommandMonitor) Connectio
 	}
 }
 
+// WithCompressionMinSize sets the minimum size in bytes of a wire message for it to be compressed. Messages smaller than
+// this size are sent uncompressed even if a compressor was negotiated.
+func WithCompressionMinSize(fn func(int) int) ConnectionOption {
+	return func(c *connectionConfig) {
+		c.compressionMinSize = fn(c.compressionMinSize)
+	}
+}
+
 // WithZlibLevel sets the zLib compression level.
 func WithZlibLevel(fn func(*int) *int) ConnectionOption {
 	return func(c *connectionConfig) {
@@ -17,11 +17,13 @@ import (
 	"time"
 
 	"github.com/google/go-cmp/cmp"
+	"go.mongodb.org/mongo-driver/event"
 	"go.mongodb.org/mongo-driver/internal"
 	"go.mongodb.org/mongo-driver/internal/testutil/assert"
 	"go.mongodb.org/mongo-driver/mongo/address"
 	"go.mongodb.org/mongo-driver/mongo/description"
 	"go.mongodb.org/mongo-driver/x/mongo/driver"
+	"go.mongodb.org/mongo-driver/x/mongo/driver/wiremessage"
 )
 
 type testHandshaker struct {
@@ -95,6 +97,73 @@ func TestConnection(t *testing.T) {
 				connState := atomic.LoadInt64(&conn.state)
 				assert.Equal(t, connDisconnected, connState, "expected connection state %v, got %v", connDisconnected, connState)
 			})
+			t.Run("handshake complete callback", func(t *testing.T) {
+				newTestConnection := func(info driver.HandshakeInformation, finishErr error, cb event.HandshakeCompleteFunc) *connection {
+					return newConnection(address.Address("localhost:27017"),
+						WithHandshaker(func(Handshaker) Handshaker {
+							return &testHandshaker{
+								getHandshakeInformation: func(context.Context, address.Address, driver.Connection) (driver.HandshakeInformation, error) {
+									return info, nil
+								},
+								finishHandshake: func(context.Context, driver.Connection) error {
+									return finishErr
+								},
+							}
+						}),
+						WithDialer(func(Dialer) Dialer {
+							return DialerFunc(func(context.Context, string, string) (net.Conn, error) {
+								return &net.TCPConn{}, nil
+							})
+						}),
+						WithHandshakeCompleteCallback(func(event.HandshakeCompleteFunc) event.HandshakeCompleteFunc {
+							return cb
+						}),
+					)
+				}
+
+				t.Run("receives negotiated versions", func(t *testing.T) {
+					var gotAddr address.Address
+					var got []event.HandshakeInfo
+					info := driver.HandshakeInformation{
+						Description: description.Server{
+							WireVersion: &description.VersionRange{Min: 6, Max: 13},
+						},
+						HelloCommand: "hello",
+					}
+					conn := newTestConnection(info, nil, func(addr address.Address, negotiated event.HandshakeInfo) {
+						gotAddr = addr
+						got = append(got, negotiated)
+					})
+
+					err := conn.connect(context.Background())
+					assert.Nil(t, err, "connect error: %v", err)
+					want := []event.HandshakeInfo{{HelloCommand: "hello", MinWireVersion: 6, MaxWireVersion: 13}}
+					assert.Equal(t, want, got, "expected callback to be called with %v, got %v", want, got)
+					assert.Equal(t, address.Address("localhost:27017"), gotAddr, "expected address %q, got %q",
+						"localhost:27017", gotAddr)
+				})
+				t.Run("no wire version reported", func(t *testing.T) {
+					var got []event.HandshakeInfo
+					info := driver.HandshakeInformation{HelloCommand: internal.LegacyHello}
+					conn := newTestConnection(info, nil, func(_ address.Address, negotiated event.HandshakeInfo) {
+						got = append(got, negotiated)
+					})
+
+					err := conn.connect(context.Background())
+					assert.Nil(t, err, "connect error: %v", err)
+					want := []event.HandshakeInfo{{HelloCommand: internal.LegacyHello}}
+					assert.Equal(t, want, got, "expected callback to be called with %v, got %v", want, got)
+				})
+				t.Run("not called if the handshake fails", func(t *testing.T) {
+					var called bool
+					conn := newTestConnection(driver.HandshakeInformation{}, errors.New("handshake error"),
+						func(address.Address, event.HandshakeInfo) { called = true })
+
+					err := conn.connect(context.Background())
+					assert.NotNil(t, err, "expected connect error, got nil")
+					assert.False(t, called, "expected callback not to be called")
+				})
+			})
 			t.Run("context is not pinned by connect", func(t *testing.T) {
 				// connect creates a cancel-able version of the context passed to it and stores the CancelFunc on the
 				// connection. The CancelFunc must be set to nil once the connection has been established so the driver
@@ -775,6 +844,47 @@ func TestConnection(t *testing.T) {
 			}
 		})
 
+		t.Run("compression min size", func(t *testing.T) {
+			newWireMessage := func(bodyLen int) []byte {
+				wm := wiremessage.AppendHeader(nil, int32(16+bodyLen), 1, 0, wiremessage.OpMsg)
+				return append(wm, make([]byte, bodyLen)...)
+			}
+			conn := &Connection{
+				connection: &connection{
+					compressor: wiremessage.CompressorZLib,
+					zliblevel:  wiremessage.DefaultZlibLevel,
+					config:     newConnectionConfig(WithCompressionMinSize(func(int) int { return 1024 })),
+				},
+			}
+
+			testCases := []struct {
+				name           string
+				bodyLen        int
+				wantCompressed bool
+			}{
+				{"small message is not compressed", 100, false},
+				{"message at the threshold is compressed", 1024 - 16, true},
+				{"large message is compressed", 4096, true},
+			}
+			for _, tc := range testCases {
+				t.Run(tc.name, func(t *testing.T) {
+					src := newWireMessage(tc.bodyLen)
+					got, err := conn.CompressWireMessage(src, nil)
+					assert.Nil(t, err, "CompressWireMessage error: %v", err)
+
+					_, _, _, opcode, _, ok := wiremessage.ReadHeader(got)
+					assert.True(t, ok, "could not read header")
+					if tc.wantCompressed {
+						assert.Equal(t, wiremessage.OpCompressed, opcode, "expected opcode %v, got %v",
+							wiremessage.OpCompressed, opcode)
+						return
+					}
+					assert.Equal(t, wiremessage.OpMsg, opcode, "expected opcode %v, got %v", wiremessage.OpMsg, opcode)
+					assert.Equal(t, src, got, "expected uncompressed message to be unchanged")
+				})
+			}
+		})
+
 		t.Run("pinning", func(t *testing.T) {
 			makeMultipleConnections := func(t *testing.T, numConns int) (*pool, []*Connection, func()) {
 				t.Helper()
@@ -2,7 +2,9 @@ package topology
 
 import (
 	"fmt"
+	"time"
 
+	"go.mongodb.org/mongo-driver/mongo/address"
 	"go.mongodb.org/mongo-driver/mongo/description"
 )
 
@@ -43,16 +45,20 @@ func (e ConnectionError) Unwrap() error {
 
 // ServerSelectionError represents a Server Selection error.
 type ServerSelectionError struct {
-	Desc    description.Topology
-	Wrapped error
+	Desc     description.Topology
+	Wrapped  error
+	Duration time.Duration // The time spent selecting a server before the error occurred.
+	Updates  int           // The number of topology updates received while waiting for a suitable server.
 }
 
 // Error implements the error interface.
 func (e ServerSelectionError) Error() string {
 	if e.Wrapped != nil {
-		return fmt.Sprintf("server selection error: %s, current topology: { %s }", e.Wrapped.Error(), e.Desc.String())
+		return fmt.Sprintf("server selection error: %s, waited %s for %d topology updates, current topology: { %s }",
+			e.Wrapped.Error(), e.Duration, e.Updates, e.Desc.String())
 	}
-	return fmt.Sprintf("server selection error: current topology: { %s }", e.Desc.String())
+	return fmt.Sprintf("server selection error: waited %s for %d topology updates, current topology: { %s }",
+		e.Duration, e.Updates, e.Desc.String())
 }
 
 // Unwrap returns the underlying error.
@@ -60,12 +66,16 @@ func (e ServerSelectionError) Unwrap() error {
 	return e.Wrapped
 }
 
-// WaitQueueTimeoutError represents a timeout when requesting a connection from the pool
+// WaitQueueTimeoutError represents a timeout when requesting a connection from the pool. Wrapped is
+// ErrWaitQueueTimeout if the pool's wait queue timeout elapsed, or the Context error if the Context expired first.
 type WaitQueueTimeoutError struct {
 	Wrapped                      error
+	Address                      address.Address // The address of the server the pool connects to.
+	MaxPoolSize                  uint64          // The pool's configured maxPoolSize, or 0 if it has no limit.
+	Waiters                      int             // The number of checkouts waiting for a connection at the timeout.
+	InUseConnections             int             // The number of connections that were checked out or connecting.
 	PinnedCursorConnections      uint64
 	PinnedTransactionConnections uint64
-	maxPoolSize                  uint64
 	totalConnectionCount         int
 }
 
@@ -77,10 +87,12 @@ func (w WaitQueueTimeoutError) Error() string {
 	}
 
 	return fmt.Sprintf(
-		"%s; maxPoolSize: %d, connections in use by cursors: %d"+
+		"%s; address: %s, maxPoolSize: %d, waiters: %d, connections in use by cursors: %d"+
 			", connections in use by transactions: %d, connections in use by other operations: %d",
 		errorMsg,
-		w.maxPoolSize,
+		w.Address,
+		w.MaxPoolSize,
+		w.Waiters,
 		w.PinnedCursorConnections,
 		w.PinnedTransactionConnections,
 		uint64(w.totalConnectionCount)-w.PinnedCursorConnections-w.PinnedTransactionConnections)
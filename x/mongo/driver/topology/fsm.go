@@ -32,6 +32,12 @@ type fsm struct {
 	maxSetVersion    uint32
 	compatible       atomic.Value
 	compatibilityErr error
+
+	// incompatibleServer is the server that caused compatibilityErr to be set.
+	incompatibleServer description.Server
+
+	// splitBrain holds the conflicting primaries found by the most recent call to apply, if any.
+	splitBrain []description.Server
 }
 
 func newFSM() *fsm {
@@ -47,6 +53,7 @@ func newFSM() *fsm {
 // apply should operation on immutable descriptions so we don't have to lock for the entire time we're applying the
 // server description.
 func (f *fsm) apply(s description.Server) (description.Topology, description.Server) {
+	f.splitBrain = nil
 	newServers := make([]description.Server, len(f.Servers))
 	copy(newServers, f.Servers)
 
@@ -98,6 +105,7 @@ func (f *fsm) apply(s description.Server) (description.Topology, description.Ser
 		if server.WireVersion != nil {
 			if server.WireVersion.Max < SupportedWireVersions.Min {
 				f.compatible.Store(false)
+				f.incompatibleServer = server
 				f.compatibilityErr = fmt.Errorf(
 					"server at %s reports wire version %d, but this version of the Go driver requires "+
 						"at least %d (MongoDB %s)",
@@ -112,6 +120,7 @@ func (f *fsm) apply(s description.Server) (description.Topology, description.Ser
 
 			if server.WireVersion.Min > SupportedWireVersions.Max {
 				f.compatible.Store(false)
+				f.incompatibleServer = server
 				f.compatibilityErr = fmt.Errorf(
 					"server at %s requires wire version %d, but this version of the Go driver only supports up to %d",
 					server.Addr.String(),
@@ -257,6 +266,9 @@ func (f *fsm) updateRSFromPrimary(s description.Server) {
 	}
 
 	if j, ok := f.findPrimary(); ok {
+		if old := f.Servers[j]; old.Addr != s.Addr && !s.ElectionID.IsZero() && old.ElectionID == s.ElectionID {
+			f.splitBrain = []description.Server{old, s}
+		}
 		f.setServer(j, description.Server{
 			Addr:      f.Servers[j].Addr,
 			LastError: fmt.Errorf("was a primary, but a new primary was discovered"),
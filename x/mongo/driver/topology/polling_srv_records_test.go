@@ -435,3 +435,188 @@ func TestPollSRVRecordsServiceName(t *testing.T) {
 		compareHosts(t, actualHosts, expectedHosts)
 	})
 }
+
+func TestRequestSRVPoll(t *testing.T) {
+	var records int32 = 1
+	var lookupFail int32
+	dnsResolver := &dns.Resolver{
+		LookupSRV: func(string, string, string) (string, []*net.SRV, error) {
+			if atomic.LoadInt32(&lookupFail) == 1 {
+				return "", nil, &net.DNSError{Err: "lookup failed"}
+			}
+			srvs := []*net.SRV{{"a.example.com.", 27017, 0, 0}}
+			if atomic.LoadInt32(&records) == 2 {
+				srvs = append(srvs, &net.SRV{"b.example.com.", 27017, 0, 0})
+			}
+			return "", srvs, nil
+		},
+		LookupTXT: func(string) ([]string, error) { return nil, nil },
+	}
+	newTopology := func(t *testing.T, uri string, resolver *dns.Resolver) *Topology {
+		t.Helper()
+
+		topo, err := New(
+			WithURI(func(string) string { return uri }),
+			WithSeedList(func(...string) []string { return []string{"a.example.com:27017"} }),
+			WithServerOptions(func(opts ...ServerOption) []ServerOption {
+				return append(opts, withMonitoringDisabled(func(bool) bool { return true }))
+			}),
+		)
+		assert.Nil(t, err, "New error: %v", err)
+		topo.dnsResolver = resolver
+		topo.rescanSRVInterval = time.Hour
+		err = topo.Connect()
+		assert.Nil(t, err, "Connect error: %v", err)
+		return topo
+	}
+
+	t.Run("polls immediately", func(t *testing.T) {
+		topo := newTopology(t, "mongodb+srv://test.example.com", dnsResolver)
+		defer func() { _ = topo.Disconnect(context.Background()) }()
+		compareHosts(t, topo.Description().Servers, []string{"a.example.com:27017"})
+
+		atomic.StoreInt32(&records, 2)
+		defer atomic.StoreInt32(&records, 1)
+		err := topo.RequestSRVPoll(context.Background())
+		assert.Nil(t, err, "RequestSRVPoll error: %v", err)
+		compareHosts(t, topo.Description().Servers, []string{"a.example.com:27017", "b.example.com:27017"})
+	})
+	t.Run("lookup failure", func(t *testing.T) {
+		topo := newTopology(t, "mongodb+srv://test.example.com", dnsResolver)
+		defer func() { _ = topo.Disconnect(context.Background()) }()
+
+		atomic.StoreInt32(&lookupFail, 1)
+		defer atomic.StoreInt32(&lookupFail, 0)
+		err := topo.RequestSRVPoll(context.Background())
+		assert.NotNil(t, err, "expected RequestSRVPoll error, got nil")
+		compareHosts(t, topo.Description().Servers, []string{"a.example.com:27017"})
+	})
+	t.Run("context done", func(t *testing.T) {
+		// Block the lookup so the poll can't complete before the Context times out.
+		unblock := make(chan struct{})
+		blockingResolver := &dns.Resolver{
+			LookupSRV: func(service, proto, name string) (string, []*net.SRV, error) {
+				<-unblock
+				return dnsResolver.LookupSRV(service, proto, name)
+			},
+			LookupTXT: dnsResolver.LookupTXT,
+		}
+		topo := newTopology(t, "mongodb+srv://test.example.com", blockingResolver)
+		defer func() { _ = topo.Disconnect(context.Background()) }()
+		defer close(unblock)
+
+		ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+		defer cancel()
+		err := topo.RequestSRVPoll(ctx)
+		assert.Equal(t, context.DeadlineExceeded, err, "expected error %v, got %v", context.DeadlineExceeded, err)
+	})
+	t.Run("disconnected during poll", func(t *testing.T) {
+		// Block the lookup so the poll is still in progress when the topology is disconnected.
+		lookupStarted := make(chan struct{}, 1)
+		unblock := make(chan struct{})
+		blockingResolver := &dns.Resolver{
+			LookupSRV: func(service, proto, name string) (string, []*net.SRV, error) {
+				lookupStarted <- struct{}{}
+				<-unblock
+				return dnsResolver.LookupSRV(service, proto, name)
+			},
+			LookupTXT: dnsResolver.LookupTXT,
+		}
+		topo := newTopology(t, "mongodb+srv://test.example.com", blockingResolver)
+
+		errs := make(chan error, 1)
+		go func() {
+			errs <- topo.RequestSRVPoll(context.Background())
+		}()
+		<-lookupStarted
+
+		disconnected := make(chan error, 1)
+		go func() {
+			disconnected <- topo.Disconnect(context.Background())
+		}()
+		select {
+		case err := <-errs:
+			assert.Equal(t, ErrTopologyClosed, err, "expected error %v, got %v", ErrTopologyClosed, err)
+		case <-time.After(time.Second):
+			t.Fatal("timed out waiting for RequestSRVPoll to return after Disconnect")
+		}
+
+		close(unblock)
+		err := <-disconnected
+		assert.Nil(t, err, "Disconnect error: %v", err)
+	})
+	t.Run("reconnect", func(t *testing.T) {
+		topo := newTopology(t, "mongodb+srv://test.example.com", dnsResolver)
+
+		// Request a poll while the topology is disconnected and reconnected so the race detector can check the
+		// accesses to the polling state. The poll can be answered by either connection or fail because the topology
+		// is closed, so its result isn't checked.
+		polled := make(chan error, 1)
+		go func() {
+			polled <- topo.RequestSRVPoll(context.Background())
+		}()
+		err := topo.Disconnect(context.Background())
+		assert.Nil(t, err, "Disconnect error: %v", err)
+		err = topo.Connect()
+		assert.Nil(t, err, "Connect error: %v", err)
+		defer func() { _ = topo.Disconnect(context.Background()) }()
+		<-polled
+		compareHosts(t, topo.Description().Servers, []string{"a.example.com:27017"})
+
+		atomic.StoreInt32(&records, 2)
+		defer atomic.StoreInt32(&records, 1)
+		err = topo.RequestSRVPoll(context.Background())
+		assert.Nil(t, err, "RequestSRVPoll error: %v", err)
+		compareHosts(t, topo.Description().Servers, []string{"a.example.com:27017", "b.example.com:27017"})
+	})
+	t.Run("polling not required", func(t *testing.T) {
+		topo := newTopology(t, "mongodb://a.example.com:27017", dnsResolver)
+		defer func() { _ = topo.Disconnect(context.Background()) }()
+
+		err := topo.RequestSRVPoll(context.Background())
+		assert.Equal(t, ErrSRVPollingNotRequired, err, "expected error %v, got %v", ErrSRVPollingNotRequired, err)
+	})
+	t.Run("topology closed", func(t *testing.T) {
+		topo, err := New()
+		assert.Nil(t, err, "New error: %v", err)
+
+		err = topo.RequestSRVPoll(context.Background())
+		assert.Equal(t, ErrTopologyClosed, err, "expected error %v, got %v", ErrTopologyClosed, err)
+	})
+}
+
+func TestWithDNSResolver(t *testing.T) {
+	var records int32 = 1
+	resolver := &dns.Resolver{
+		LookupSRV: func(string, string, string) (string, []*net.SRV, error) {
+			srvs := []*net.SRV{{"a.example.com.", 27017, 0, 0}}
+			if atomic.LoadInt32(&records) == 2 {
+				srvs = []*net.SRV{{"b.example.com.", 27017, 0, 0}, {"c.example.com.", 27017, 0, 0}}
+			}
+			return "", srvs, nil
+		},
+		LookupTXT: func(string) ([]string, error) { return nil, nil },
+	}
+
+	cs, err := connstring.ParseAndValidateWithResolver("mongodb+srv://test.example.com", resolver)
+	assert.Nil(t, err, "ParseAndValidateWithResolver error: %v", err)
+	topo, err := New(
+		WithConnString(func(connstring.ConnString) connstring.ConnString { return cs }),
+		WithURI(func(string) string { return cs.Original }),
+		WithDNSResolver(func(*dns.Resolver) *dns.Resolver { return resolver }),
+		WithServerOptions(func(opts ...ServerOption) []ServerOption {
+			return append(opts, withMonitoringDisabled(func(bool) bool { return true }))
+		}),
+	)
+	assert.Nil(t, err, "New error: %v", err)
+	topo.rescanSRVInterval = time.Hour
+	err = topo.Connect()
+	assert.Nil(t, err, "Connect error: %v", err)
+	defer func() { _ = topo.Disconnect(context.Background()) }()
+	compareHosts(t, topo.Description().Servers, []string{"a.example.com:27017"})
+
+	atomic.StoreInt32(&records, 2)
+	err = topo.RequestSRVPoll(context.Background())
+	assert.Nil(t, err, "RequestSRVPoll error: %v", err)
+	compareHosts(t, topo.Description().Servers, []string{"b.example.com:27017", "c.example.com:27017"})
+}
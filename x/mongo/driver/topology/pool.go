@@ -33,9 +33,16 @@ var ErrPoolNotPaused = PoolError("only a paused pool can be marked ready")
 // ErrPoolClosed is returned when attempting to check out a connection from a closed pool.
 var ErrPoolClosed = PoolError("attempted to check out a connection from closed connection pool")
 
+// ErrPoolDraining is returned when attempting to check out a connection from a pool that is draining.
+var ErrPoolDraining = PoolError("attempted to check out a connection from draining connection pool")
+
 // ErrConnectionClosed is returned from an attempt to use an already closed connection.
 var ErrConnectionClosed = ConnectionError{ConnectionID: "<closed>", message: "connection is closed"}
 
+// ErrWaitQueueTimeout is wrapped by the WaitQueueTimeoutError returned when a checkout waits longer than the pool's
+// wait queue timeout.
+var ErrWaitQueueTimeout = PoolError("wait queue timeout elapsed")
+
 // ErrWrongPool is return when a connection is returned to a pool it doesn't belong to.
 var ErrWrongPool = PoolError("connection does not belong to this pool")
 
@@ -64,6 +71,29 @@ func (poolClearedError) Retryable() bool { return true }
 // Assert that poolClearedError is a driver.RetryablePoolError.
 var _ driver.RetryablePoolError = poolClearedError{}
 
+// PoolFairness controls which waiting checkout receives a connection when one is returned to a saturated pool.
+type PoolFairness int
+
+// PoolFairness constants.
+const (
+	// PoolFairnessFIFO hands returned connections to waiting checkouts in the order they started waiting. This is the
+	// default.
+	PoolFairnessFIFO PoolFairness = iota
+	// PoolFairnessBestEffort hands a returned connection to the checkout that started waiting most recently. Under
+	// bursty load this favors requests that are least likely to hit their timeout, at the cost of letting the oldest
+	// waiters time out.
+	PoolFairnessBestEffort
+)
+
+// PoolStat is a snapshot of the connections in a server's connection pool.
+type PoolStat struct {
+	Total           int // Total is the number of open connections, including connections being established.
+	InUse           int // InUse is the number of established connections that are checked out.
+	Idle            int // Idle is the number of established connections that are available to be checked out.
+	Pending         int // Pending is the number of connections that are being established.
+	WaitQueueLength int // WaitQueueLength is the number of checkOut requests waiting for a connection.
+}
+
 // poolConfig contains all aspects of the pool that can be configured
 type poolConfig struct {
 	Address          address.Address
@@ -72,8 +102,11 @@ type poolConfig struct {
 	MaxConnecting    uint64
 	MaxIdleTime      time.Duration
 	MaintainInterval time.Duration
+	WaitQueueTimeout time.Duration
+	Fairness         PoolFairness
 	PoolMonitor      *event.PoolMonitor
 	handshakeErrFn   func(error, uint64, *primitive.ObjectID)
+	limiter          *connectionLimiter
 }
 
 type pool struct {
@@ -90,7 +123,11 @@ type pool struct {
 	minSize       uint64
 	maxSize       uint64
 	maxConnecting uint64
+	waitTimeout   time.Duration // waitTimeout is how long checkOut waits for a connection, or 0 for no limit.
+	fairness      PoolFairness
 	monitor       *event.PoolMonitor
+	tag           string             // tag is the workload tag included in pool events, if any.
+	limiter       *connectionLimiter // limiter limits the connections of all pools in the topology, if set.
 
 	// handshakeErrFn is used to handle any errors that happen during connection establishment and
 	// handshaking.
@@ -103,9 +140,15 @@ type pool struct {
 	maintainReady    chan struct{}   // maintainReady is a signal channel that starts the maintain() loop when ready() is called.
 	backgroundDone   *sync.WaitGroup // backgroundDone waits for all background goroutines to return.
 
-	stateMu      sync.RWMutex // stateMu guards state, lastClearErr
+	stateMu      sync.RWMutex // stateMu guards state, lastClearErr, draining
 	state        int          // state is the current state of the connection pool.
 	lastClearErr error        // lastClearErr is the last error that caused the pool to be cleared.
+	draining     bool         // draining is true if the pool rejects new checkOut requests.
+
+	warmupMu       sync.Mutex    // warmupMu guards warmupStart, warmupDuration, warmedUp
+	warmupStart    time.Time     // warmupStart is when the pool was last marked "ready", or zero if not warming up.
+	warmupDuration time.Duration // warmupDuration is how long the last completed warmup took.
+	warmedUp       bool          // warmedUp is true if a warmup has completed since the pool was last marked "ready".
 
 	// createConnectionsCond is the condition variable that controls when the createConnections()
 	// loop runs or waits. Its lock guards cancelBackgroundCtx, conns, and newConnWait. Any changes
@@ -164,8 +207,12 @@ func newPool(config poolConfig, connOpts ...ConnectionOption) *pool {
 		minSize:               config.MinPoolSize,
 		maxSize:               config.MaxPoolSize,
 		maxConnecting:         maxConnecting,
+		waitTimeout:           config.WaitQueueTimeout,
+		fairness:              config.Fairness,
 		monitor:               config.PoolMonitor,
+		tag:                   newConnectionConfig(connOpts...).tag,
 		handshakeErrFn:        config.handshakeErrFn,
+		limiter:               config.limiter,
 		connOpts:              connOpts,
 		generation:            newPoolGenerationMap(),
 		state:                 poolPaused,
@@ -179,6 +226,9 @@ func newPool(config poolConfig, connOpts ...ConnectionOption) *pool {
 	pool.connOpts = append(pool.connOpts, withGenerationNumberFn(func(_ generationNumberFn) generationNumberFn { return pool.getGenerationForNewConnection }))
 
 	pool.generation.connect()
+	if pool.limiter != nil {
+		pool.limiter.register(pool)
+	}
 
 	// Create a Context with cancellation that's used to signal the createConnections() and
 	// maintain() background goroutines to stop. Also create a "backgroundDone" WaitGroup that is
@@ -205,7 +255,8 @@ func newPool(config poolConfig, connOpts ...ConnectionOption) *pool {
 				MaxPoolSize: config.MaxPoolSize,
 				MinPoolSize: config.MinPoolSize,
 			},
-			Address: pool.address.String(),
+			Address:       pool.address.String(),
+			ConnectionTag: pool.tag,
 		})
 	}
 
@@ -235,6 +286,15 @@ func (p *pool) ready() error {
 	p.state = poolReady
 	p.stateMu.Unlock()
 
+	// Start timing how long it takes to establish minPoolSize connections.
+	if p.warmupTarget() > 0 {
+		p.warmupMu.Lock()
+		p.warmupStart = time.Now()
+		p.warmupDuration = 0
+		p.warmedUp = false
+		p.warmupMu.Unlock()
+	}
+
 	// Signal maintain() to wake up immediately when marking the pool "ready".
 	select {
 	case p.maintainReady <- struct{}{}:
@@ -243,8 +303,9 @@ func (p *pool) ready() error {
 
 	if p.monitor != nil {
 		p.monitor.Event(&event.PoolEvent{
-			Type:    event.PoolReady,
-			Address: p.address.String(),
+			Type:          event.PoolReady,
+			Address:       p.address.String(),
+			ConnectionTag: p.tag,
 		})
 	}
 
@@ -276,6 +337,9 @@ func (p *pool) close(ctx context.Context) {
 
 	// Wait for all background goroutines to exit.
 	p.backgroundDone.Wait()
+	if p.limiter != nil {
+		p.limiter.unregister(p)
+	}
 
 	p.generation.disconnect()
 
@@ -344,12 +408,22 @@ func (p *pool) close(ctx context.Context) {
 
 	if p.monitor != nil {
 		p.monitor.Event(&event.PoolEvent{
-			Type:    event.PoolClosedEvent,
-			Address: p.address.String(),
+			Type:          event.PoolClosedEvent,
+			Address:       p.address.String(),
+			ConnectionTag: p.tag,
 		})
 	}
 }
 
+// drain marks the pool as draining. All subsequent checkOut requests will return ErrPoolDraining, but connections that
+// are already checked out can still be used and checked in. A draining pool must still be closed to release its
+// resources.
+func (p *pool) drain() {
+	p.stateMu.Lock()
+	p.draining = true
+	p.stateMu.Unlock()
+}
+
 func (p *pool) pinConnectionToCursor() {
 	atomic.AddUint64(&p.pinnedCursorConnections, 1)
 }
@@ -375,8 +449,9 @@ func (p *pool) unpinConnectionFromTransaction() {
 func (p *pool) checkOut(ctx context.Context) (conn *connection, err error) {
 	if p.monitor != nil {
 		p.monitor.Event(&event.PoolEvent{
-			Type:    event.GetStarted,
-			Address: p.address.String(),
+			Type:          event.GetStarted,
+			Address:       p.address.String(),
+			ConnectionTag: p.tag,
 		})
 	}
 
@@ -391,9 +466,10 @@ func (p *pool) checkOut(ctx context.Context) (conn *connection, err error) {
 		p.stateMu.RUnlock()
 		if p.monitor != nil {
 			p.monitor.Event(&event.PoolEvent{
-				Type:    event.GetFailed,
-				Address: p.address.String(),
-				Reason:  event.ReasonPoolClosed,
+				Type:          event.GetFailed,
+				Address:       p.address.String(),
+				ConnectionTag: p.tag,
+				Reason:        event.ReasonPoolClosed,
 			})
 		}
 		return nil, ErrPoolClosed
@@ -402,13 +478,26 @@ func (p *pool) checkOut(ctx context.Context) (conn *connection, err error) {
 		p.stateMu.RUnlock()
 		if p.monitor != nil {
 			p.monitor.Event(&event.PoolEvent{
-				Type:    event.GetFailed,
-				Address: p.address.String(),
-				Reason:  event.ReasonConnectionErrored,
+				Type:          event.GetFailed,
+				Address:       p.address.String(),
+				ConnectionTag: p.tag,
+				Reason:        event.ReasonConnectionErrored,
 			})
 		}
 		return nil, err
 	}
+	if p.draining {
+		p.stateMu.RUnlock()
+		if p.monitor != nil {
+			p.monitor.Event(&event.PoolEvent{
+				Type:          event.GetFailed,
+				Address:       p.address.String(),
+				ConnectionTag: p.tag,
+				Reason:        event.ReasonPoolDraining,
+			})
+		}
+		return nil, ErrPoolDraining
+	}
 
 	if ctx == nil {
 		ctx = context.Background()
@@ -436,9 +525,10 @@ func (p *pool) checkOut(ctx context.Context) (conn *connection, err error) {
 		if w.err != nil {
 			if p.monitor != nil {
 				p.monitor.Event(&event.PoolEvent{
-					Type:    event.GetFailed,
-					Address: p.address.String(),
-					Reason:  event.ReasonConnectionErrored,
+					Type:          event.GetFailed,
+					Address:       p.address.String(),
+					ConnectionTag: p.tag,
+					Reason:        event.ReasonConnectionErrored,
 				})
 			}
 			return nil, w.err
@@ -446,9 +536,10 @@ func (p *pool) checkOut(ctx context.Context) (conn *connection, err error) {
 
 		if p.monitor != nil {
 			p.monitor.Event(&event.PoolEvent{
-				Type:         event.GetSucceeded,
-				Address:      p.address.String(),
-				ConnectionID: w.conn.poolID,
+				Type:          event.GetSucceeded,
+				Address:       p.address.String(),
+				ConnectionTag: p.tag,
+				ConnectionID:  w.conn.poolID,
 			})
 		}
 		return w.conn, nil
@@ -459,15 +550,23 @@ func (p *pool) checkOut(ctx context.Context) (conn *connection, err error) {
 	p.queueForNewConn(w)
 	p.stateMu.RUnlock()
 
-	// Wait for either the wantConn to be ready or for the Context to time out.
+	var waitTimeout <-chan time.Time
+	if p.waitTimeout > 0 {
+		timer := time.NewTimer(p.waitTimeout)
+		defer timer.Stop()
+		waitTimeout = timer.C
+	}
+
+	// Wait for the wantConn to be ready, for the Context to time out, or for the wait queue timeout.
 	select {
 	case <-w.ready:
 		if w.err != nil {
 			if p.monitor != nil {
 				p.monitor.Event(&event.PoolEvent{
-					Type:    event.GetFailed,
-					Address: p.address.String(),
-					Reason:  event.ReasonConnectionErrored,
+					Type:          event.GetFailed,
+					Address:       p.address.String(),
+					ConnectionTag: p.tag,
+					Reason:        event.ReasonConnectionErrored,
 				})
 			}
 			return nil, w.err
@@ -475,27 +574,46 @@ func (p *pool) checkOut(ctx context.Context) (conn *connection, err error) {
 
 		if p.monitor != nil {
 			p.monitor.Event(&event.PoolEvent{
-				Type:         event.GetSucceeded,
-				Address:      p.address.String(),
-				ConnectionID: w.conn.poolID,
+				Type:          event.GetSucceeded,
+				Address:       p.address.String(),
+				ConnectionTag: p.tag,
+				ConnectionID:  w.conn.poolID,
 			})
 		}
 		return w.conn, nil
 	case <-ctx.Done():
-		if p.monitor != nil {
-			p.monitor.Event(&event.PoolEvent{
-				Type:    event.GetFailed,
-				Address: p.address.String(),
-				Reason:  event.ReasonTimedOut,
-			})
-		}
-		return nil, WaitQueueTimeoutError{
-			Wrapped:                      ctx.Err(),
-			PinnedCursorConnections:      atomic.LoadUint64(&p.pinnedCursorConnections),
-			PinnedTransactionConnections: atomic.LoadUint64(&p.pinnedTransactionConnections),
-			maxPoolSize:                  p.maxSize,
-			totalConnectionCount:         p.totalConnectionCount(),
-		}
+		return nil, p.waitQueueTimeoutError(ctx.Err())
+	case <-waitTimeout:
+		return nil, p.waitQueueTimeoutError(ErrWaitQueueTimeout)
+	}
+}
+
+// waitQueueTimeoutError publishes a "ConnectionCheckOutFailed" event and returns a WaitQueueTimeoutError that wraps
+// err and describes the current state of the pool.
+func (p *pool) waitQueueTimeoutError(err error) error {
+	if p.monitor != nil {
+		p.monitor.Event(&event.PoolEvent{
+			Type:          event.GetFailed,
+			Address:       p.address.String(),
+			ConnectionTag: p.tag,
+			Reason:        event.ReasonTimedOut,
+		})
+	}
+
+	p.idleMu.Lock()
+	waiters := p.idleConnWait.waitingLen()
+	p.idleMu.Unlock()
+	total := p.totalConnectionCount()
+
+	return WaitQueueTimeoutError{
+		Wrapped:                      err,
+		Address:                      p.address,
+		MaxPoolSize:                  p.maxSize,
+		Waiters:                      waiters,
+		InUseConnections:             total - p.availableConnectionCount(),
+		PinnedCursorConnections:      atomic.LoadUint64(&p.pinnedCursorConnections),
+		PinnedTransactionConnections: atomic.LoadUint64(&p.pinnedTransactionConnections),
+		totalConnectionCount:         total,
 	}
 }
 
@@ -546,6 +664,12 @@ func (p *pool) removeConnection(conn *connection, reason string) error {
 	p.createConnectionsCond.Signal()
 	p.createConnectionsCond.L.Unlock()
 
+	// Release the connection's slot in the topology-wide limit after unlocking, because releasing wakes up the
+	// createConnections() goroutines of every pool, including this one.
+	if p.limiter != nil {
+		p.limiter.release()
+	}
+
 	// Only update the generation numbers map if the connection has retrieved its generation number.
 	// Otherwise, we'd decrement the count for the generation even though it had never been
 	// incremented.
@@ -555,10 +679,11 @@ func (p *pool) removeConnection(conn *connection, reason string) error {
 
 	if p.monitor != nil {
 		p.monitor.Event(&event.PoolEvent{
-			Type:         event.ConnectionClosed,
-			Address:      p.address.String(),
-			ConnectionID: conn.poolID,
-			Reason:       reason,
+			Type:          event.ConnectionClosed,
+			Address:       p.address.String(),
+			ConnectionTag: p.tag,
+			ConnectionID:  conn.poolID,
+			Reason:        reason,
 		})
 	}
 
@@ -577,9 +702,10 @@ func (p *pool) checkIn(conn *connection) error {
 
 	if p.monitor != nil {
 		p.monitor.Event(&event.PoolEvent{
-			Type:         event.ConnectionReturned,
-			ConnectionID: conn.poolID,
-			Address:      conn.addr.String(),
+			Type:          event.ConnectionReturned,
+			ConnectionID:  conn.poolID,
+			Address:       conn.addr.String(),
+			ConnectionTag: p.tag,
 		})
 	}
 
@@ -620,11 +746,31 @@ func (p *pool) checkInNoEvent(conn *connection) error {
 		return nil
 	}
 
+	if err := p.pushIdle(conn); err != nil {
+		return err
+	}
+
+	// If the topology-wide limit is reached, another pool may be waiting to evict an idle connection so it can create
+	// one of its own.
+	if p.limiter != nil {
+		p.limiter.idleAvailable()
+	}
+	return nil
+}
+
+// pushIdle delivers conn to a checkOut request waiting in the idleConnWait queue or, if there are none, pushes it onto
+// the idle connections stack.
+func (p *pool) pushIdle(conn *connection) error {
 	p.idleMu.Lock()
 	defer p.idleMu.Unlock()
 
 	for {
-		w := p.idleConnWait.popFront()
+		var w *wantConn
+		if p.fairness == PoolFairnessBestEffort {
+			w = p.idleConnWait.popBack()
+		} else {
+			w = p.idleConnWait.popFront()
+		}
 		if w == nil {
 			break
 		}
@@ -643,6 +789,26 @@ func (p *pool) checkInNoEvent(conn *connection) error {
 	return nil
 }
 
+// evictIdleConnection removes and closes the least recently used idle connection, which releases its slot in the
+// topology-wide limit. It returns false if the pool has no idle connections.
+func (p *pool) evictIdleConnection() bool {
+	p.idleMu.Lock()
+	if len(p.idleConns) == 0 {
+		p.idleMu.Unlock()
+		return false
+	}
+	// The idle connections stack is used last-in-first-out, so the first connection is the least recently used.
+	conn := p.idleConns[0]
+	p.idleConns = p.idleConns[1:]
+	p.idleMu.Unlock()
+
+	_ = p.removeConnection(conn, event.ReasonIdle)
+	go func() {
+		_ = p.closeConnection(conn)
+	}()
+	return true
+}
+
 // clear marks all connections as stale by incrementing the generation number, stops all background
 // goroutines, removes all requests from idleConnWait and newConnWait, and sets the pool state to
 // "paused". If serviceID is nil, clear marks all connections as stale. If serviceID is not nil,
@@ -673,6 +839,11 @@ func (p *pool) clear(err error, serviceID *primitive.ObjectID) {
 		p.lastClearErr = err
 		p.stateMu.Unlock()
 
+		// Abandon any warmup in progress. The next call to ready() starts a new one.
+		p.warmupMu.Lock()
+		p.warmupStart = time.Time{}
+		p.warmupMu.Unlock()
+
 		pcErr := poolClearedError{err: err, address: p.address}
 
 		// Clear the idle connections wait queue.
@@ -702,9 +873,10 @@ func (p *pool) clear(err error, serviceID *primitive.ObjectID) {
 
 	if sendEvent && p.monitor != nil {
 		p.monitor.Event(&event.PoolEvent{
-			Type:      event.PoolCleared,
-			Address:   p.address.String(),
-			ServiceID: serviceID,
+			Type:          event.PoolCleared,
+			Address:       p.address.String(),
+			ConnectionTag: p.tag,
+			ServiceID:     serviceID,
 		})
 	}
 }
@@ -766,6 +938,40 @@ func (p *pool) totalConnectionCount() int {
 	return len(p.conns)
 }
 
+// stats returns a snapshot of the pool's connection counters. The counters are read under the pool's locks in turn
+// rather than all at once, so they may be briefly inconsistent with each other while connections are checked out or in.
+func (p *pool) stats() PoolStat {
+	var stat PoolStat
+
+	p.createConnectionsCond.L.Lock()
+	stat.Total = len(p.conns)
+	for _, conn := range p.conns {
+		select {
+		case <-conn.connectDone:
+		default:
+			stat.Pending++
+		}
+	}
+	p.createConnectionsCond.L.Unlock()
+
+	p.idleMu.Lock()
+	stat.Idle = len(p.idleConns)
+	// Every checkOut request that is waiting for a connection is in the idle connection wait queue, and may also be in
+	// the new connection wait queue, so only count the former.
+	stat.WaitQueueLength = p.idleConnWait.waitingLen()
+	p.idleMu.Unlock()
+
+	if stat.InUse = stat.Total - stat.Pending - stat.Idle; stat.InUse < 0 {
+		stat.InUse = 0
+	}
+	return stat
+}
+
+// inUseConnectionCount returns the number of connections that are checked out or still being established.
+func (p *pool) inUseConnectionCount() int {
+	return p.totalConnectionCount() - p.availableConnectionCount()
+}
+
 func (p *pool) availableConnectionCount() int {
 	p.idleMu.Lock()
 	defer p.idleMu.Unlock()
@@ -773,18 +979,84 @@ func (p *pool) availableConnectionCount() int {
 	return len(p.idleConns)
 }
 
+// warmupTarget returns the number of established connections the pool needs before it is considered warmed up. It is
+// minPoolSize, capped at maxPoolSize if one is set.
+func (p *pool) warmupTarget() int {
+	if p.maxSize != 0 && p.minSize > p.maxSize {
+		return int(p.maxSize)
+	}
+	return int(p.minSize)
+}
+
+// checkWarmedUp records the warmup duration and publishes a "ConnectionPoolWarmedUp" event if a warmup is in progress
+// and the pool now has at least warmupTarget() established connections.
+func (p *pool) checkWarmedUp() {
+	p.warmupMu.Lock()
+	if p.warmupStart.IsZero() {
+		p.warmupMu.Unlock()
+		return
+	}
+
+	p.createConnectionsCond.L.Lock()
+	established := 0
+	for _, conn := range p.conns {
+		if atomic.LoadInt64(&conn.state) == connConnected {
+			established++
+		}
+	}
+	p.createConnectionsCond.L.Unlock()
+
+	if established < p.warmupTarget() {
+		p.warmupMu.Unlock()
+		return
+	}
+	duration := time.Since(p.warmupStart)
+	p.warmupStart = time.Time{}
+	p.warmupDuration = duration
+	p.warmedUp = true
+	p.warmupMu.Unlock()
+
+	if p.monitor != nil {
+		p.monitor.Event(&event.PoolEvent{
+			Type:          event.PoolWarmedUp,
+			Address:       p.address.String(),
+			ConnectionTag: p.tag,
+			DurationNanos: duration.Nanoseconds(),
+		})
+	}
+}
+
+// getWarmupDuration returns how long the pool took to establish minPoolSize connections after it was last marked
+// "ready". It returns false if the pool has no minimum size or has not finished warming up.
+func (p *pool) getWarmupDuration() (time.Duration, bool) {
+	p.warmupMu.Lock()
+	defer p.warmupMu.Unlock()
+	return p.warmupDuration, p.warmedUp
+}
+
 // createConnections creates connections for wantConn requests on the newConnWait queue.
 func (p *pool) createConnections(ctx context.Context, wg *sync.WaitGroup) {
 	defer wg.Done()
 
 	// condition returns true if the createConnections() loop should continue and false if it should
 	// wait. Note that the condition also listens for Context cancellation, which also causes the
-	// loop to continue, allowing for a subsequent check to return from createConnections().
-	condition := func() bool {
+	// loop to continue, allowing for a subsequent check to return from createConnections(). If the
+	// pool has a limiter, the condition only returns true for a new connection if it acquired a
+	// slot from the limiter, which is reported by the acquired return value. If the condition
+	// returns false only because the limiter has no free slots, limited is true.
+	condition := func() (ok bool, acquired bool, limited bool) {
 		checkOutWaiting := p.newConnWait.len() > 0
 		poolHasSpace := p.maxSize == 0 || uint64(len(p.conns)) < p.maxSize
-		cancelled := ctx.Err() != nil
-		return (checkOutWaiting && poolHasSpace) || cancelled
+		if ctx.Err() != nil {
+			return true, false, false
+		}
+		if !checkOutWaiting || !poolHasSpace {
+			return false, false, false
+		}
+		if p.limiter != nil && !p.limiter.tryAcquire() {
+			return false, false, true
+		}
+		return true, p.limiter != nil, false
 	}
 
 	// wait waits for there to be an available wantConn and for the pool to have space for a new
@@ -795,17 +1067,47 @@ func (p *pool) createConnections(ctx context.Context, wg *sync.WaitGroup) {
 		p.createConnectionsCond.L.Lock()
 		defer p.createConnectionsCond.L.Unlock()
 
-		for !condition() {
+		ok, acquired, limited := condition()
+		for !ok {
+			if limited {
+				// Every slot in the topology-wide limit is taken. Evict an idle connection from another pool
+				// to free one. The limiter wakes up every pool when a connection is removed or made idle, so
+				// the eviction must be done without holding this pool's lock. If no connection was evicted
+				// but the limiter woke the pools in the meantime, check again rather than wait for a wakeup
+				// that has already happened.
+				wakeups := p.limiter.wakeupCount()
+				p.createConnectionsCond.L.Unlock()
+				evicted := p.limiter.evictIdle(p)
+				p.createConnectionsCond.L.Lock()
+				if evicted || p.limiter.wakeupCount() != wakeups {
+					ok, acquired, limited = condition()
+					continue
+				}
+			}
 			p.createConnectionsCond.Wait()
+			ok, acquired, limited = condition()
+		}
+
+		// releaseSlot returns the limiter slot if one was acquired but no connection is created. The
+		// limiter wakes up every pool's createConnections() goroutines, so it must be released without
+		// holding this pool's lock.
+		releaseSlot := func() {
+			if acquired {
+				p.createConnectionsCond.L.Unlock()
+				p.limiter.release()
+				p.createConnectionsCond.L.Lock()
+			}
 		}
 
 		if ctx.Err() != nil {
+			releaseSlot()
 			return nil, nil, false
 		}
 
 		p.newConnWait.cleanFront()
 		w := p.newConnWait.popFront()
 		if w == nil {
+			releaseSlot()
 			return nil, nil, false
 		}
 
@@ -825,9 +1127,10 @@ func (p *pool) createConnections(ctx context.Context, wg *sync.WaitGroup) {
 
 		if p.monitor != nil {
 			p.monitor.Event(&event.PoolEvent{
-				Type:         event.ConnectionCreated,
-				Address:      p.address.String(),
-				ConnectionID: conn.poolID,
+				Type:          event.ConnectionCreated,
+				Address:       p.address.String(),
+				ConnectionTag: p.tag,
+				ConnectionID:  conn.poolID,
 			})
 		}
 
@@ -854,11 +1157,13 @@ func (p *pool) createConnections(ctx context.Context, wg *sync.WaitGroup) {
 
 		if p.monitor != nil {
 			p.monitor.Event(&event.PoolEvent{
-				Type:         event.ConnectionReady,
-				Address:      p.address.String(),
-				ConnectionID: conn.poolID,
+				Type:          event.ConnectionReady,
+				Address:       p.address.String(),
+				ConnectionTag: p.tag,
+				ConnectionID:  conn.poolID,
 			})
 		}
+		p.checkWarmedUp()
 
 		if w.tryDeliver(conn, nil) {
 			continue
@@ -1106,6 +1411,39 @@ func (q *wantConnQueue) popFront() *wantConn {
 	return w
 }
 
+// popBack removes and returns the wantConn at the back of the queue.
+func (q *wantConnQueue) popBack() *wantConn {
+	if n := len(q.tail); n > 0 {
+		w := q.tail[n-1]
+		q.tail[n-1] = nil
+		q.tail = q.tail[:n-1]
+		return w
+	}
+	if n := len(q.head); q.headPos < n {
+		w := q.head[n-1]
+		q.head[n-1] = nil
+		q.head = q.head[:n-1]
+		return w
+	}
+	return nil
+}
+
+// waitingLen returns the number of wantConns in the queue that are still waiting.
+func (q *wantConnQueue) waitingLen() int {
+	var n int
+	for _, w := range q.head[q.headPos:] {
+		if w.waiting() {
+			n++
+		}
+	}
+	for _, w := range q.tail {
+		if w.waiting() {
+			n++
+		}
+	}
+	return n
+}
+
 // peekFront returns the wantConn at the front of the queue without removing it.
 func (q *wantConnQueue) peekFront() *wantConn {
 	if q.headPos < len(q.head) {
@@ -9,6 +9,7 @@ import (
 	"time"
 
 	"github.com/stretchr/testify/assert"
+	"go.mongodb.org/mongo-driver/event"
 	"go.mongodb.org/mongo-driver/mongo/address"
 	"go.mongodb.org/mongo-driver/x/mongo/driver/operation"
 )
@@ -618,6 +619,100 @@ func TestPool(t *testing.T) {
 
 			p.close(context.Background())
 		})
+		// Test that if a checkOut() waits longer than the pool's WaitQueueTimeout, it returns a
+		// WaitQueueTimeoutError that wraps ErrWaitQueueTimeout and describes the saturated pool.
+		t.Run("wait queue timeout from pool config", func(t *testing.T) {
+			t.Parallel()
+
+			cleanup := make(chan struct{})
+			defer close(cleanup)
+			addr := bootstrapConnections(t, 1, func(nc net.Conn) {
+				<-cleanup
+				_ = nc.Close()
+			})
+
+			p := newPool(poolConfig{
+				Address:          address.Address(addr.String()),
+				MaxPoolSize:      1,
+				WaitQueueTimeout: 10 * time.Millisecond,
+			})
+			err := p.ready()
+			noerr(t, err)
+
+			_, err = p.checkOut(context.Background())
+			noerr(t, err)
+
+			_, err = p.checkOut(context.Background())
+			wqtErr, ok := err.(WaitQueueTimeoutError)
+			assert.Truef(t, ok, "expected a WaitQueueTimeoutError, got %T", err)
+			assert.Equalf(t, ErrWaitQueueTimeout, wqtErr.Unwrap(), "expected wrapped error to be ErrWaitQueueTimeout")
+			assert.Equalf(t, address.Address(addr.String()), wqtErr.Address, "expected error to have the pool address")
+			assert.Equalf(t, uint64(1), wqtErr.MaxPoolSize, "expected error to have the pool's maxPoolSize")
+			assert.Equalf(t, 1, wqtErr.Waiters, "expected error to report 1 waiter")
+			assert.Equalf(t, 1, wqtErr.InUseConnections, "expected error to report 1 in-use connection")
+
+			p.close(context.Background())
+		})
+		// Test that a connection checked in to a saturated pool is delivered to the oldest waiter
+		// with PoolFairnessFIFO and to the newest waiter with PoolFairnessBestEffort.
+		t.Run("fairness", func(t *testing.T) {
+			t.Parallel()
+
+			testCases := []struct {
+				fairness PoolFairness
+				want     int
+			}{
+				{PoolFairnessFIFO, 0},
+				{PoolFairnessBestEffort, 2},
+			}
+			for _, tc := range testCases {
+				cleanup := make(chan struct{})
+				addr := bootstrapConnections(t, 1, func(nc net.Conn) {
+					<-cleanup
+					_ = nc.Close()
+				})
+
+				p := newPool(poolConfig{
+					Address:     address.Address(addr.String()),
+					MaxPoolSize: 1,
+					Fairness:    tc.fairness,
+				})
+				err := p.ready()
+				noerr(t, err)
+
+				c, err := p.checkOut(context.Background())
+				noerr(t, err)
+
+				// Start 3 checkOut() calls one at a time, waiting for each to enter the wait queue
+				// before starting the next so the queue order is deterministic.
+				ctx, cancel := context.WithCancel(context.Background())
+				got := make(chan int, 3)
+				for i := 0; i < 3; i++ {
+					go func(i int) {
+						if _, err := p.checkOut(ctx); err == nil {
+							got <- i
+						}
+					}(i)
+					assert.Eventuallyf(t,
+						func() bool {
+							p.idleMu.Lock()
+							defer p.idleMu.Unlock()
+							return p.idleConnWait.waitingLen() == i+1
+						},
+						1*time.Second,
+						1*time.Millisecond,
+						"expected %d checkOut() calls to be waiting", i+1)
+				}
+
+				err = p.checkIn(c)
+				noerr(t, err)
+				assert.Equalf(t, tc.want, <-got, "expected connection to be delivered to waiter %d", tc.want)
+
+				cancel()
+				close(cleanup)
+				p.close(context.Background())
+			}
+		})
 		// Test that an indefinitely blocked checkOut() doesn't cause the wait queue to overflow
 		// if there are many other checkOut() calls that time out. This tests a scenario where a
 		// wantConnQueue may grow unbounded while a checkOut() is blocked, even if all subsequent
@@ -910,6 +1005,428 @@ func TestPool(t *testing.T) {
 			assert.Equalf(t, 1, p.totalConnectionCount(), "should have 1 total connection in pool")
 		})
 	})
+	t.Run("drain", func(t *testing.T) {
+		t.Parallel()
+
+		t.Run("rejects new checkOut requests", func(t *testing.T) {
+			t.Parallel()
+
+			cleanup := make(chan struct{})
+			defer close(cleanup)
+			addr := bootstrapConnections(t, 1, func(nc net.Conn) {
+				<-cleanup
+				_ = nc.Close()
+			})
+
+			var mu sync.Mutex
+			var failed []*event.PoolEvent
+			p := newPool(poolConfig{
+				Address: address.Address(addr.String()),
+				PoolMonitor: &event.PoolMonitor{
+					Event: func(evt *event.PoolEvent) {
+						if evt.Type != event.GetFailed {
+							return
+						}
+						mu.Lock()
+						failed = append(failed, evt)
+						mu.Unlock()
+					},
+				},
+			})
+			err := p.ready()
+			noerr(t, err)
+
+			c, err := p.checkOut(context.Background())
+			noerr(t, err)
+			err = p.checkIn(c)
+			noerr(t, err)
+
+			p.drain()
+
+			_, err = p.checkOut(context.Background())
+			assert.Equalf(t, ErrPoolDraining, err, "expected an error from checkOut() from a draining pool")
+			mu.Lock()
+			assert.Lenf(t, failed, 1, "expected one ConnectionCheckOutFailed event")
+			assert.Equalf(t, event.ReasonPoolDraining, failed[0].Reason, "expected the draining reason")
+			mu.Unlock()
+
+			p.close(context.Background())
+		})
+		t.Run("in-use connections can complete", func(t *testing.T) {
+			t.Parallel()
+
+			cleanup := make(chan struct{})
+			defer close(cleanup)
+			addr := bootstrapConnections(t, 2, func(nc net.Conn) {
+				<-cleanup
+				_ = nc.Close()
+			})
+
+			p := newPool(poolConfig{
+				Address: address.Address(addr.String()),
+			})
+			err := p.ready()
+			noerr(t, err)
+
+			conns := make([]*connection, 2)
+			for i := range conns {
+				conns[i], err = p.checkOut(context.Background())
+				noerr(t, err)
+			}
+
+			p.drain()
+
+			// Check in the in-use connections after the pool starts closing. Assert that both
+			// connections are still connected before they are checked in.
+			go func() {
+				for p.getState() == poolReady {
+					time.Sleep(time.Millisecond)
+				}
+				for _, c := range conns {
+					assert.Equalf(t, connConnected, c.state, "expected conn to still be connected")
+
+					err := p.checkIn(c)
+					noerr(t, err)
+				}
+			}()
+
+			// Close the pool with a 1-hour graceful shutdown timeout. Expect that the call to
+			// close() returns when all of the connections are checked in.
+			ctx, cancel := context.WithTimeout(context.Background(), 1*time.Hour)
+			defer cancel()
+			p.close(ctx)
+			assert.Equalf(t, 0, p.totalConnectionCount(), "should have 0 total connections")
+		})
+		t.Run("remains draining after clear and ready", func(t *testing.T) {
+			t.Parallel()
+
+			p := newPool(poolConfig{})
+			err := p.ready()
+			noerr(t, err)
+
+			p.drain()
+			p.clear(errors.New("test error"), nil)
+			err = p.ready()
+			noerr(t, err)
+
+			_, err = p.checkOut(context.Background())
+			assert.Equalf(t, ErrPoolDraining, err, "expected an error from checkOut() from a draining pool")
+
+			p.close(context.Background())
+		})
+	})
+	t.Run("connection tag", func(t *testing.T) {
+		t.Parallel()
+
+		cleanup := make(chan struct{})
+		defer close(cleanup)
+		addr := bootstrapConnections(t, 1, func(nc net.Conn) {
+			<-cleanup
+			_ = nc.Close()
+		})
+
+		var mu sync.Mutex
+		var events []*event.PoolEvent
+		p := newPool(poolConfig{
+			Address: address.Address(addr.String()),
+			PoolMonitor: &event.PoolMonitor{
+				Event: func(evt *event.PoolEvent) {
+					mu.Lock()
+					events = append(events, evt)
+					mu.Unlock()
+				},
+			},
+		}, WithConnectionTag(func(string) string { return "oltp" }))
+		err := p.ready()
+		noerr(t, err)
+
+		c, err := p.checkOut(context.Background())
+		noerr(t, err)
+		tag := (&Connection{connection: c}).Tag()
+		assert.Equalf(t, "oltp", tag, "expected connection tag %q, got %q", "oltp", tag)
+		err = p.checkIn(c)
+		noerr(t, err)
+		p.close(context.Background())
+
+		mu.Lock()
+		defer mu.Unlock()
+		assert.NotEmptyf(t, events, "expected pool events to be published")
+		for _, evt := range events {
+			assert.Equalf(t, "oltp", evt.ConnectionTag, "expected tag %q for %s event, got %q",
+				"oltp", evt.Type, evt.ConnectionTag)
+		}
+	})
+	t.Run("stats", func(t *testing.T) {
+		t.Parallel()
+
+		t.Run("counts in-use, idle, and waiting connections", func(t *testing.T) {
+			t.Parallel()
+
+			cleanup := make(chan struct{})
+			defer close(cleanup)
+			addr := bootstrapConnections(t, 2, func(nc net.Conn) {
+				<-cleanup
+				_ = nc.Close()
+			})
+
+			p := newPool(poolConfig{
+				Address:     address.Address(addr.String()),
+				MaxPoolSize: 2,
+			})
+			err := p.ready()
+			noerr(t, err)
+			defer p.close(context.Background())
+
+			c1, err := p.checkOut(context.Background())
+			noerr(t, err)
+			c2, err := p.checkOut(context.Background())
+			noerr(t, err)
+			err = p.checkIn(c1)
+			noerr(t, err)
+			assert.Equalf(t, PoolStat{Total: 2, InUse: 1, Idle: 1}, p.stats(), "unexpected pool stats")
+
+			c1, err = p.checkOut(context.Background())
+			noerr(t, err)
+			assert.Equalf(t, PoolStat{Total: 2, InUse: 2}, p.stats(), "unexpected pool stats")
+
+			// The pool is at MaxPoolSize, so another checkOut waits in the wait queue until its Context is cancelled.
+			ctx, cancel := context.WithCancel(context.Background())
+			done := make(chan struct{})
+			go func() {
+				defer close(done)
+				_, _ = p.checkOut(ctx)
+			}()
+			assert.Eventuallyf(t, func() bool {
+				return p.stats().WaitQueueLength == 1
+			}, time.Second, 10*time.Millisecond, "expected 1 waiting checkOut")
+
+			cancel()
+			<-done
+			assert.Equalf(t, 0, p.stats().WaitQueueLength, "expected no waiting checkOuts")
+
+			noerr(t, p.checkIn(c1))
+			noerr(t, p.checkIn(c2))
+		})
+		t.Run("counts pending connections", func(t *testing.T) {
+			t.Parallel()
+
+			dialStarted := make(chan struct{})
+			unblockDial := make(chan struct{})
+			p := newPool(poolConfig{}, WithDialer(func(Dialer) Dialer {
+				return DialerFunc(func(context.Context, string, string) (net.Conn, error) {
+					close(dialStarted)
+					<-unblockDial
+					return nil, errors.New("dial error")
+				})
+			}))
+			err := p.ready()
+			noerr(t, err)
+			defer p.close(context.Background())
+
+			done := make(chan struct{})
+			go func() {
+				defer close(done)
+				_, _ = p.checkOut(context.Background())
+			}()
+			<-dialStarted
+			assert.Equalf(t, PoolStat{Total: 1, Pending: 1, WaitQueueLength: 1}, p.stats(), "unexpected pool stats")
+
+			close(unblockDial)
+			<-done
+			assert.Equalf(t, PoolStat{}, p.stats(), "unexpected pool stats")
+		})
+	})
+	t.Run("connection limiter", func(t *testing.T) {
+		t.Parallel()
+
+		cleanup := make(chan struct{})
+		defer close(cleanup)
+		addr := bootstrapConnections(t, 4, func(nc net.Conn) {
+			<-cleanup
+			_ = nc.Close()
+		})
+
+		// Two pools that allow 2 connections each share a limit of 3 connections.
+		limiter := newConnectionLimiter(3)
+		newLimitedPool := func() *pool {
+			p := newPool(poolConfig{
+				Address:     address.Address(addr.String()),
+				MaxPoolSize: 2,
+				limiter:     limiter,
+			})
+			err := p.ready()
+			noerr(t, err)
+			return p
+		}
+		p1 := newLimitedPool()
+		defer p1.close(context.Background())
+		p2 := newLimitedPool()
+		defer p2.close(context.Background())
+
+		c1, err := p1.checkOut(context.Background())
+		noerr(t, err)
+		_, err = p1.checkOut(context.Background())
+		noerr(t, err)
+		_, err = p2.checkOut(context.Background())
+		noerr(t, err)
+		assert.Equalf(t, 3, limiter.inUse(), "expected 3 connections to be counted by the limiter")
+
+		// p2 has space for another connection, but the limit has been reached, so the checkOut waits.
+		ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+		defer cancel()
+		_, err = p2.checkOut(ctx)
+		assert.IsTypef(t, WaitQueueTimeoutError{}, err, "expected a WaitQueueTimeoutError, got %v", err)
+		assert.Equalf(t, 1, p2.totalConnectionCount(), "expected p2 to have 1 connection")
+
+		// Removing a connection from p1 frees a slot for p2.
+		errs := make(chan error, 1)
+		go func() {
+			_, err := p2.checkOut(context.Background())
+			errs <- err
+		}()
+		c1.close()
+		err = p1.checkIn(c1)
+		noerr(t, err)
+		select {
+		case err = <-errs:
+			noerr(t, err)
+		case <-time.After(time.Second):
+			t.Fatal("timed out waiting for checkOut after a connection was removed")
+		}
+		assert.Equalf(t, 2, p2.totalConnectionCount(), "expected p2 to have 2 connections")
+		assert.Equalf(t, 3, limiter.inUse(), "expected 3 connections to be counted by the limiter")
+	})
+	t.Run("connection limiter evicts idle connections", func(t *testing.T) {
+		t.Parallel()
+
+		cleanup := make(chan struct{})
+		defer close(cleanup)
+		addr := bootstrapConnections(t, 3, func(nc net.Conn) {
+			<-cleanup
+			_ = nc.Close()
+		})
+
+		// Two pools that allow 2 connections each share a limit of 2 connections.
+		limiter := newConnectionLimiter(2)
+		newLimitedPool := func() *pool {
+			p := newPool(poolConfig{
+				Address:     address.Address(addr.String()),
+				MaxPoolSize: 2,
+				limiter:     limiter,
+			})
+			err := p.ready()
+			noerr(t, err)
+			return p
+		}
+		p1 := newLimitedPool()
+		defer p1.close(context.Background())
+		p2 := newLimitedPool()
+		defer p2.close(context.Background())
+
+		c1, err := p1.checkOut(context.Background())
+		noerr(t, err)
+		c2, err := p1.checkOut(context.Background())
+		noerr(t, err)
+		noerr(t, p1.checkIn(c1))
+		noerr(t, p1.checkIn(c2))
+		assert.Equalf(t, 2, p1.availableConnectionCount(), "expected p1 to have 2 idle connections")
+
+		// p1 holds every slot in idle connections, so p2 evicts one of them.
+		ctx, cancel := context.WithTimeout(context.Background(), time.Second)
+		defer cancel()
+		_, err = p2.checkOut(ctx)
+		noerr(t, err)
+		assert.Equalf(t, 1, p1.totalConnectionCount(), "expected p1 to have 1 connection")
+		assert.Equalf(t, 1, p2.totalConnectionCount(), "expected p2 to have 1 connection")
+		assert.Equalf(t, 2, limiter.inUse(), "expected 2 connections to be counted by the limiter")
+	})
+	t.Run("connection limiter wakes up on check in", func(t *testing.T) {
+		t.Parallel()
+
+		cleanup := make(chan struct{})
+		defer close(cleanup)
+		addr := bootstrapConnections(t, 2, func(nc net.Conn) {
+			<-cleanup
+			_ = nc.Close()
+		})
+
+		limiter := newConnectionLimiter(1)
+		newLimitedPool := func() *pool {
+			p := newPool(poolConfig{
+				Address: address.Address(addr.String()),
+				limiter: limiter,
+			})
+			err := p.ready()
+			noerr(t, err)
+			return p
+		}
+		p1 := newLimitedPool()
+		defer p1.close(context.Background())
+		p2 := newLimitedPool()
+		defer p2.close(context.Background())
+
+		c1, err := p1.checkOut(context.Background())
+		noerr(t, err)
+
+		// p2 waits for a slot while p1's connection is in use and takes it once the connection is checked in.
+		errs := make(chan error, 1)
+		go func() {
+			_, err := p2.checkOut(context.Background())
+			errs <- err
+		}()
+		assert.Eventuallyf(t,
+			func() bool { return p2.stats().WaitQueueLength == 1 },
+			time.Second,
+			time.Millisecond,
+			"expected p2's checkOut to wait for a slot")
+		noerr(t, p1.checkIn(c1))
+		select {
+		case err = <-errs:
+			noerr(t, err)
+		case <-time.After(time.Second):
+			t.Fatal("timed out waiting for checkOut after a connection was checked in")
+		}
+		assert.Equalf(t, 0, p1.totalConnectionCount(), "expected p1's idle connection to be evicted")
+	})
+	t.Run("connection pool generation", func(t *testing.T) {
+		t.Parallel()
+
+		cleanup := make(chan struct{})
+		defer close(cleanup)
+		addr := bootstrapConnections(t, 2, func(nc net.Conn) {
+			<-cleanup
+			_ = nc.Close()
+		})
+
+		p := newPool(poolConfig{
+			Address: address.Address(addr.String()),
+		})
+		err := p.ready()
+		noerr(t, err)
+		defer p.close(context.Background())
+
+		c, err := p.checkOut(context.Background())
+		noerr(t, err)
+		old := &Connection{connection: c}
+		assert.Equalf(t, uint64(0), old.PoolGeneration(), "expected generation 0, got %d", old.PoolGeneration())
+
+		p.clear(nil, nil)
+		err = p.ready()
+		noerr(t, err)
+		assert.Truef(t, old.Stale(), "expected connection from previous generation to be stale")
+
+		c, err = p.checkOut(context.Background())
+		noerr(t, err)
+		conn := &Connection{connection: c}
+		assert.Equalf(t, uint64(1), conn.PoolGeneration(), "expected generation 1, got %d", conn.PoolGeneration())
+		assert.Equalf(t, uint64(0), old.PoolGeneration(), "expected old connection to keep generation 0, got %d",
+			old.PoolGeneration())
+
+		err = old.Close()
+		noerr(t, err)
+		err = conn.Close()
+		noerr(t, err)
+	})
 	t.Run("maintain", func(t *testing.T) {
 		t.Parallel()
 
@@ -937,6 +1454,59 @@ func TestPool(t *testing.T) {
 
 			p.close(context.Background())
 		})
+		t.Run("records warmup duration after establishing MinPoolSize connections", func(t *testing.T) {
+			t.Parallel()
+
+			cleanup := make(chan struct{})
+			defer close(cleanup)
+			addr := bootstrapConnections(t, 3, func(nc net.Conn) {
+				<-cleanup
+				_ = nc.Close()
+			})
+
+			var mu sync.Mutex
+			var warmups []*event.PoolEvent
+			monitor := &event.PoolMonitor{
+				Event: func(evt *event.PoolEvent) {
+					if evt.Type != event.PoolWarmedUp {
+						return
+					}
+					mu.Lock()
+					warmups = append(warmups, evt)
+					mu.Unlock()
+				},
+			}
+			d := newdialer(&net.Dialer{})
+			p := newPool(poolConfig{
+				Address:     address.Address(addr.String()),
+				MinPoolSize: 3,
+				PoolMonitor: monitor,
+			}, WithDialer(func(Dialer) Dialer { return d }))
+			_, ok := p.getWarmupDuration()
+			assert.False(t, ok, "expected no warmup duration before calling ready")
+			err := p.ready()
+			noerr(t, err)
+
+			assertConnectionsOpened(t, d, 3)
+			assert.Eventually(t,
+				func() bool {
+					_, ok := p.getWarmupDuration()
+					return ok
+				},
+				100*time.Millisecond,
+				1*time.Millisecond,
+				"expected pool to finish warming up")
+			duration, _ := p.getWarmupDuration()
+			assert.Greaterf(t, int64(duration), int64(0), "expected a positive warmup duration")
+
+			mu.Lock()
+			defer mu.Unlock()
+			assert.Lenf(t, warmups, 1, "expected 1 %s event", event.PoolWarmedUp)
+			assert.Equalf(t, duration.Nanoseconds(), warmups[0].DurationNanos,
+				"expected event duration to match recorded duration")
+
+			p.close(context.Background())
+		})
 		t.Run("when MinPoolSize > MaxPoolSize should not exceed MaxPoolSize connections", func(t *testing.T) {
 			t.Parallel()
 
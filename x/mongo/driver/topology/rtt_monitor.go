@@ -30,10 +30,11 @@ type rttConfig struct {
 }
 
 type rttMonitor struct {
-	mu            sync.RWMutex // mu guards samples, offset, minRTT, averageRTT, and averageRTTSet
+	mu            sync.RWMutex // mu guards samples, offset, minRTT, lastRTT, averageRTT, and averageRTTSet
 	samples       []time.Duration
 	offset        int
 	minRTT        time.Duration
+	lastRTT       time.Duration
 	averageRTT    time.Duration
 	averageRTTSet bool
 
@@ -145,6 +146,7 @@ func (r *rttMonitor) reset() {
 	}
 	r.offset = 0
 	r.minRTT = 0
+	r.lastRTT = 0
 	r.averageRTT = 0
 	r.averageRTTSet = false
 }
@@ -160,6 +162,7 @@ func (r *rttMonitor) addSample(rtt time.Duration) {
 	// Set the minRTT as the minimum of all collected samples. Require at least 5 samples before
 	// setting minRTT to prevent noisy samples on startup from artificially increasing minRTT.
 	r.minRTT = min(r.samples, minSamples)
+	r.lastRTT = rtt
 
 	if !r.averageRTTSet {
 		r.averageRTT = rtt
@@ -198,6 +201,14 @@ func (r *rttMonitor) getRTT() time.Duration {
 	return r.averageRTT
 }
 
+// getLastRTT returns the most recently observed round-trip time.
+func (r *rttMonitor) getLastRTT() time.Duration {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	return r.lastRTT
+}
+
 // getMinRTT returns the minimum observed round-trip time over the window period.
 func (r *rttMonitor) getMinRTT() time.Duration {
 	r.mu.RLock()
@@ -104,6 +104,11 @@ func TestRTTMonitor(t *testing.T) {
 			rtt.getMinRTT() > 0,
 			"expected getMinRTT() to return a positive duration, got %v",
 			rtt.getMinRTT())
+		assert.True(
+			t,
+			rtt.getLastRTT() > 0,
+			"expected getLastRTT() to return a positive duration, got %v",
+			rtt.getLastRTT())
 	})
 
 	t.Run("creates the correct size samples slice", func(t *testing.T) {
@@ -192,6 +197,17 @@ func TestRTTMonitor(t *testing.T) {
 			rtt.reset()
 		}
 	})
+	t.Run("reset clears the last RTT", func(t *testing.T) {
+		rtt := newRTTMonitor(&rttConfig{interval: 10 * time.Second})
+		rtt.addSample(5 * time.Millisecond)
+		rtt.addSample(15 * time.Millisecond)
+		assert.Equal(t, 15*time.Millisecond, rtt.getLastRTT(), "expected last RTT %v, got %v",
+			15*time.Millisecond, rtt.getLastRTT())
+
+		rtt.reset()
+		assert.Equal(t, time.Duration(0), rtt.getLastRTT(), "expected last RTT 0 after reset, got %v",
+			rtt.getLastRTT())
+	})
 }
 
 func TestMin(t *testing.T) {
@@ -74,13 +74,29 @@ func (ss *SelectedServer) Description() description.SelectedServer {
 	}
 }
 
+// ErrorAction specifies how a server reacts to an error returned by an operation. See WithErrorHandlerHook.
+type ErrorAction int
+
+// ErrorAction constants.
+const (
+	// ErrorActionNone applies the default SDAM error handling.
+	ErrorActionNone ErrorAction = iota
+	// ErrorActionMarkUnknown marks the server Unknown and requests an immediate check.
+	ErrorActionMarkUnknown
+	// ErrorActionRequestCheck requests an immediate check without changing the server description.
+	ErrorActionRequestCheck
+	// ErrorActionClearPool marks the server Unknown, clears its connection pool, and requests an immediate check.
+	ErrorActionClearPool
+)
+
 // Server is a single server within a topology.
 type Server struct {
-	// state must be accessed using the atomic package and should be at the beginning of
+	// state and heartbeatFailures must be accessed using the atomic package and should be at the beginning of
 	// the struct.
 	// - atomic bug: https://pkg.go.dev/sync/atomic#pkg-note-BUG
 	// - suggested layout: https://go101.org/article/memory-layout.html
-	state int64
+	state             int64
+	heartbeatFailures int64 // the number of consecutive failed checks
 
 	cfg     *serverConfig
 	address address.Address
@@ -118,6 +134,10 @@ type Server struct {
 
 	processErrorLock sync.Mutex
 	rttMonitor       *rttMonitor
+
+	// pauseLock guards resumed, which is non-nil while monitoring is paused and is closed when monitoring is resumed.
+	pauseLock sync.Mutex
+	resumed   chan struct{}
 }
 
 // updateTopologyCallback is a callback used to create a server that should be called when the parent Topology instance
@@ -180,8 +200,11 @@ func NewServer(addr address.Address, topologyID primitive.ObjectID, opts ...Serv
 		MaxConnecting:    cfg.maxConnecting,
 		MaxIdleTime:      cfg.poolMaxIdleTime,
 		MaintainInterval: cfg.poolMaintainInterval,
+		WaitQueueTimeout: cfg.poolWaitQueueTimeout,
+		Fairness:         cfg.poolFairness,
 		PoolMonitor:      cfg.poolMonitor,
 		handshakeErrFn:   s.ProcessHandshakeError,
+		limiter:          cfg.connectionLimiter,
 	}
 
 	connectionOpts := copyConnectionOpts(cfg.connectionOpts)
@@ -255,6 +278,46 @@ func (s *Server) Disconnect(ctx context.Context) error {
 	return nil
 }
 
+// Drain marks the server's connection pool as draining. Subsequent calls to Connection will return ErrPoolDraining, but
+// connections that are already checked out can still be used and returned to the pool.
+func (s *Server) Drain() {
+	s.pool.drain()
+}
+
+// PauseMonitoring stops the heartbeats for this server until ResumeMonitoring is called. Any in-progress check is
+// cancelled. The server keeps its last known description and its connection pool while monitoring is paused.
+func (s *Server) PauseMonitoring() {
+	s.pauseLock.Lock()
+	if s.resumed != nil {
+		s.pauseLock.Unlock()
+		return
+	}
+	s.resumed = make(chan struct{})
+	s.pauseLock.Unlock()
+
+	s.cancelCheck()
+}
+
+// ResumeMonitoring restarts the heartbeats for this server after a call to PauseMonitoring. A check is performed
+// immediately.
+func (s *Server) ResumeMonitoring() {
+	s.pauseLock.Lock()
+	defer s.pauseLock.Unlock()
+
+	if s.resumed != nil {
+		close(s.resumed)
+		s.resumed = nil
+	}
+}
+
+// monitoringResumed returns a channel that is closed when monitoring is resumed, or nil if monitoring is not paused.
+func (s *Server) monitoringResumed() <-chan struct{} {
+	s.pauseLock.Lock()
+	defer s.pauseLock.Unlock()
+
+	return s.resumed
+}
+
 // Connection gets a connection to the server.
 func (s *Server) Connection(ctx context.Context) (driver.Connection, error) {
 	if atomic.LoadInt64(&s.state) != serverConnected {
@@ -388,9 +451,15 @@ func (s *Server) ProcessError(err error, conn driver.Connection) driver.ProcessE
 	if conn.Stale() {
 		return driver.NoChange
 	}
+	desc := conn.Description()
+	if hook := s.cfg.errorHandlerHook; hook != nil {
+		if action := hook(err, desc); action != ErrorActionNone {
+			return s.applyErrorAction(action, err, desc)
+		}
+	}
+
 	// Invalidate server description if not primary or node recovering error occurs.
 	// These errors can be reported as a command error or a write concern error.
-	desc := conn.Description()
 	if cerr, ok := err.(driver.Error); ok && (cerr.NodeIsRecovering() || cerr.NotPrimary()) {
 		// ignore stale error
 		if desc.TopologyVersion.CompareToIncoming(cerr.TopologyVersion) >= 0 {
@@ -451,13 +520,35 @@ func (s *Server) ProcessError(err error, conn driver.Connection) driver.ProcessE
 	return driver.ConnectionPoolCleared
 }
 
+// applyErrorAction applies an ErrorAction returned by the error handler hook. The processErrorLock must be held.
+func (s *Server) applyErrorAction(action ErrorAction, err error, desc description.Server) driver.ProcessErrorResult {
+	switch action {
+	case ErrorActionMarkUnknown:
+		s.updateDescription(description.NewServerFromError(s.address, err, nil))
+		s.RequestImmediateCheck()
+		return driver.ServerMarkedUnknown
+	case ErrorActionRequestCheck:
+		s.RequestImmediateCheck()
+		return driver.NoChange
+	case ErrorActionClearPool:
+		s.updateDescription(description.NewServerFromError(s.address, err, nil))
+		s.pool.clear(err, desc.ServiceID)
+		s.RequestImmediateCheck()
+		return driver.ConnectionPoolCleared
+	}
+	return driver.NoChange
+}
+
 // update handles performing heartbeats and updating any subscribers of the
 // newest description.Server retrieved.
 func (s *Server) update() {
 	defer s.closewg.Done()
-	heartbeatTicker := time.NewTicker(s.cfg.heartbeatInterval)
+	heartbeatInterval := s.heartbeatInterval(s.Description().Kind)
+	heartbeatTicker := time.NewTicker(heartbeatInterval)
 	rateLimiter := time.NewTicker(minHeartbeatInterval)
-	defer heartbeatTicker.Stop()
+	defer func() {
+		heartbeatTicker.Stop()
+	}()
 	defer rateLimiter.Stop()
 	checkNow := s.checkNow
 	done := s.done
@@ -491,10 +582,29 @@ func (s *Server) update() {
 	}
 
 	waitUntilNextCheck := func() {
+		// The heartbeat interval can depend on the server kind, so restart the ticker if the kind changed the interval.
+		if interval := s.heartbeatInterval(s.Description().Kind); interval != heartbeatInterval {
+			heartbeatTicker.Stop()
+			heartbeatTicker = time.NewTicker(interval)
+			heartbeatInterval = interval
+		}
+
+		// With jitter, each heartbeat is scheduled individually rather than by the ticker.
+		heartbeatC := heartbeatTicker.C
+		if backoff, open := s.circuitBreakerBackoff(heartbeatInterval); open {
+			timer := time.NewTimer(backoff)
+			defer timer.Stop()
+			heartbeatC = timer.C
+		} else if s.cfg.heartbeatJitter > 0 {
+			timer := time.NewTimer(s.jitteredHeartbeatInterval(heartbeatInterval))
+			defer timer.Stop()
+			heartbeatC = timer.C
+		}
+
 		// Wait until heartbeatFrequency elapses, an application operation requests an immediate check, or the server
 		// is disconnecting.
 		select {
-		case <-heartbeatTicker.C:
+		case <-heartbeatC:
 		case <-checkNow:
 		case <-done:
 			// Return because the next update iteration will check the done channel again and clean up.
@@ -519,12 +629,21 @@ func (s *Server) update() {
 		default:
 		}
 
+		// If monitoring is paused, wait until it is resumed or the server is disconnecting.
+		if resumed := s.monitoringResumed(); resumed != nil {
+			select {
+			case <-resumed:
+			case <-done:
+			}
+			continue
+		}
+
 		previousDescription := s.Description()
 
 		// Perform the next check.
 		desc, err := s.check()
 		if err == errCheckCancelled {
-			if atomic.LoadInt64(&s.state) != serverConnected {
+			if atomic.LoadInt64(&s.state) != serverConnected || s.monitoringResumed() != nil {
 				continue
 			}
 
@@ -537,6 +656,9 @@ func (s *Server) update() {
 		// Must hold the processErrorLock while updating the server description and clearing the
 		// pool. Not holding the lock leads to possible out-of-order processing of pool.clear() and
 		// pool.ready() calls from concurrent server description updates.
+		if _, open := s.circuitBreakerBackoff(heartbeatInterval); open {
+			desc.CircuitBreakerOpen = true
+		}
 		s.processErrorLock.Lock()
 		s.updateDescription(desc)
 		if err := desc.LastError; err != nil {
@@ -729,13 +851,14 @@ func (s *Server) check() (description.Server, error) {
 			// the wire message will advertise streaming support to the server.
 
 			// Calculation for maxAwaitTimeMS is taken from time.Duration.Milliseconds (added in Go 1.13).
-			maxAwaitTimeMS := int64(s.cfg.heartbeatInterval) / 1e6
+			heartbeatInterval := s.heartbeatInterval(previousDescription.Kind)
+			maxAwaitTimeMS := int64(heartbeatInterval) / 1e6
 			// If connectTimeoutMS=0, the socket timeout should be infinite. Otherwise, it is connectTimeoutMS +
 			// heartbeatFrequencyMS to account for the fact that the query will block for heartbeatFrequencyMS
 			// server-side.
 			socketTimeout := s.cfg.heartbeatTimeout
 			if socketTimeout != 0 {
-				socketTimeout += s.cfg.heartbeatInterval
+				socketTimeout += heartbeatInterval
 			}
 			s.conn.setSocketTimeout(socketTimeout)
 			baseOperation = baseOperation.TopologyVersion(previousDescription.TopologyVersion).
@@ -766,10 +889,12 @@ func (s *Server) check() (description.Server, error) {
 	}
 
 	if descPtr != nil {
-		// The check was successful. Set the average RTT and return.
+		// The check was successful. Set the average and most recent RTTs and return.
 		desc := *descPtr
 		desc = desc.SetAverageRTT(s.rttMonitor.getRTT())
-		desc.HeartbeatInterval = s.cfg.heartbeatInterval
+		desc.LastRTT = s.rttMonitor.getLastRTT()
+		desc.HeartbeatInterval = s.heartbeatInterval(desc.Kind)
+		atomic.StoreInt64(&s.heartbeatFailures, 0)
 		return desc, nil
 	}
 
@@ -783,6 +908,7 @@ func (s *Server) check() (description.Server, error) {
 	// be cleared, but only after the description has already been updated, so that is handled by the caller.
 	topologyVersion := extractTopologyVersion(err)
 	s.rttMonitor.reset()
+	atomic.AddInt64(&s.heartbeatFailures, 1)
 	return description.NewServerFromError(s.address, err, topologyVersion), nil
 }
 
@@ -803,11 +929,72 @@ func extractTopologyVersion(err error) *description.TopologyVersion {
 	return nil
 }
 
+// jitteredHeartbeatInterval returns interval randomized by up to the configured heartbeat jitter fraction of interval in
+// either direction.
+func (s *Server) jitteredHeartbeatInterval(interval time.Duration) time.Duration {
+	spread := int64(float64(interval) * s.cfg.heartbeatJitter)
+	if spread <= 0 {
+		return interval
+	}
+	offset := random.Int63n(2*spread+1) - spread
+	return interval + time.Duration(offset)
+}
+
+// heartbeatInterval returns the heartbeat interval to use for the server when it is of the given kind.
+func (s *Server) heartbeatInterval(kind description.ServerKind) time.Duration {
+	if s.cfg.heartbeatForAddr != nil {
+		if interval := s.cfg.heartbeatForAddr(s.address); interval > 0 {
+			return interval
+		}
+	}
+	if s.cfg.heartbeatForKind != nil {
+		if interval := s.cfg.heartbeatForKind(kind); interval > 0 {
+			return interval
+		}
+	}
+	return s.cfg.heartbeatInterval
+}
+
+// circuitBreakerBackoff returns the delay before the next heartbeat and true if the server's circuit breaker is open
+// because at least the configured threshold of consecutive heartbeats have failed. The delay starts at twice interval
+// and doubles with each further failure, up to the configured maximum backoff. It is never shorter than interval so an
+// open breaker never checks the server more often than a healthy server is checked.
+func (s *Server) circuitBreakerBackoff(interval time.Duration) (time.Duration, bool) {
+	threshold := s.cfg.breakerThreshold
+	failures := s.heartbeatFailureStreak()
+	if threshold <= 0 || failures < threshold {
+		return 0, false
+	}
+
+	backoff := 2 * interval
+	for i := threshold; i < failures && backoff < s.cfg.breakerMaxBackoff; i++ {
+		backoff *= 2
+	}
+	if backoff > s.cfg.breakerMaxBackoff {
+		backoff = s.cfg.breakerMaxBackoff
+	}
+	if backoff < interval {
+		backoff = interval
+	}
+	return backoff, true
+}
+
+// heartbeatFailureStreak returns the number of consecutive heartbeats to the server that have failed.
+func (s *Server) heartbeatFailureStreak() int {
+	return int(atomic.LoadInt64(&s.heartbeatFailures))
+}
+
 // MinRTT returns the minimum round-trip time to the server observed over the last 5 minutes.
 func (s *Server) MinRTT() time.Duration {
 	return s.rttMonitor.getMinRTT()
 }
 
+// PoolWarmupDuration returns how long the server's connection pool took to establish minPoolSize connections after it
+// was last connected or cleared. It returns false if minPoolSize is 0 or the pool has not finished warming up.
+func (s *Server) PoolWarmupDuration() (time.Duration, bool) {
+	return s.pool.getWarmupDuration()
+}
+
 // String implements the Stringer interface.
 func (s *Server) String() string {
 	desc := s.Description()
@@ -7,11 +7,14 @@
 package topology
 
 import (
+	"fmt"
 	"time"
 
 	"go.mongodb.org/mongo-driver/bson"
 	"go.mongodb.org/mongo-driver/bson/bsoncodec"
 	"go.mongodb.org/mongo-driver/event"
+	"go.mongodb.org/mongo-driver/mongo/address"
+	"go.mongodb.org/mongo-driver/mongo/description"
 	"go.mongodb.org/mongo-driver/x/mongo/driver"
 	"go.mongodb.org/mongo-driver/x/mongo/driver/session"
 )
@@ -25,6 +28,12 @@ type serverConfig struct {
 	appname            string
 	heartbeatInterval  time.Duration
 	heartbeatTimeout   time.Duration
+	heartbeatForKind   func(description.ServerKind) time.Duration
+	heartbeatForAddr   func(address.Address) time.Duration
+	heartbeatJitter    float64
+	breakerThreshold   int
+	breakerMaxBackoff  time.Duration
+	errorHandlerHook   func(error, description.Server) ErrorAction
 	serverMonitor      *event.ServerMonitor
 	registry           *bsoncodec.Registry
 	monitoringDisabled bool
@@ -38,6 +47,9 @@ type serverConfig struct {
 	poolMonitor          *event.PoolMonitor
 	poolMaxIdleTime      time.Duration
 	poolMaintainInterval time.Duration
+	poolWaitQueueTimeout time.Duration
+	poolFairness         PoolFairness
+	connectionLimiter    *connectionLimiter
 }
 
 func newServerConfig(opts ...ServerOption) (*serverConfig, error) {
@@ -68,6 +80,15 @@ func withMonitoringDisabled(fn func(bool) bool) ServerOption {
 	}
 }
 
+// withConnectionLimiter configures a limiter that is shared with the connection pools of other servers to limit the
+// total number of connections across all of them.
+func withConnectionLimiter(fn func(*connectionLimiter) *connectionLimiter) ServerOption {
+	return func(cfg *serverConfig) error {
+		cfg.connectionLimiter = fn(cfg.connectionLimiter)
+		return nil
+	}
+}
+
 // WithConnectionOptions configures the server's connections.
 func WithConnectionOptions(fn func(...ConnectionOption) []ConnectionOption) ServerOption {
 	return func(cfg *serverConfig) error {
@@ -100,6 +121,75 @@ func WithHeartbeatInterval(fn func(time.Duration) time.Duration) ServerOption {
 	}
 }
 
+// WithHeartbeatIntervalForKind configures a function that returns the heartbeat interval for a server based on its
+// current kind. The function is consulted by the server's monitor each time it schedules the next heartbeat. If the
+// function returns a non-positive duration, the interval configured by WithHeartbeatInterval is used.
+func WithHeartbeatIntervalForKind(fn func(kind description.ServerKind) time.Duration) ServerOption {
+	return func(cfg *serverConfig) error {
+		cfg.heartbeatForKind = fn
+		return nil
+	}
+}
+
+// WithHeartbeatIntervalForAddress configures a function that returns the heartbeat interval for a server based on its
+// address, e.g. to check servers in the local data center more often than remote ones. The function is consulted by
+// the server's monitor each time it schedules the next heartbeat and takes precedence over
+// WithHeartbeatIntervalForKind. If the function returns a non-positive duration, the interval for the server's kind or
+// the interval configured by WithHeartbeatInterval is used. Immediate checks requested with RequestImmediateCheck are
+// not delayed by the returned interval.
+func WithHeartbeatIntervalForAddress(fn func(addr address.Address) time.Duration) ServerOption {
+	return func(cfg *serverConfig) error {
+		cfg.heartbeatForAddr = fn
+		return nil
+	}
+}
+
+// WithHeartbeatJitter configures the server's monitor to randomize the delay before each heartbeat by up to fraction of
+// the heartbeat interval in either direction, so that the monitors of many clients do not check a server in lockstep.
+// The fraction must be between 0 and 1. The default is 0, which disables jitter. Immediate checks requested with
+// RequestImmediateCheck are not delayed by the jitter.
+func WithHeartbeatJitter(fraction float64) ServerOption {
+	return func(cfg *serverConfig) error {
+		if fraction < 0 || fraction > 1 {
+			return fmt.Errorf("heartbeat jitter must be between 0 and 1, got %v", fraction)
+		}
+		cfg.heartbeatJitter = fraction
+		return nil
+	}
+}
+
+// WithServerCircuitBreaker configures the server's monitor to back off after threshold consecutive heartbeats have
+// failed. Failures are counted without a time window, so the breaker opens after threshold failures in a row however
+// far apart they are. While the breaker is open, the server is Unknown, its description has CircuitBreakerOpen set, and
+// the delay before each heartbeat doubles with every further failure, starting at twice the heartbeat interval, up to
+// maxBackoff. The delay is never shorter than the heartbeat interval, even if maxBackoff is. A successful heartbeat
+// closes the breaker. Immediate checks requested with RequestImmediateCheck are not delayed by the backoff. A threshold
+// of 0, the default, disables the breaker.
+func WithServerCircuitBreaker(threshold int, maxBackoff time.Duration) ServerOption {
+	return func(cfg *serverConfig) error {
+		if threshold < 0 {
+			return fmt.Errorf("circuit breaker threshold must not be negative, got %d", threshold)
+		}
+		if threshold > 0 && maxBackoff <= 0 {
+			return fmt.Errorf("circuit breaker max backoff must be positive, got %v", maxBackoff)
+		}
+		cfg.breakerThreshold = threshold
+		cfg.breakerMaxBackoff = maxBackoff
+		return nil
+	}
+}
+
+// WithErrorHandlerHook configures a function that is called when an operation returns an error, before the server
+// applies the default SDAM error handling. The function is passed the error and the description of the connection the
+// operation ran on. If it returns ErrorActionNone, the default error handling is applied. Otherwise, the returned
+// action replaces it. This allows non-standard errors, such as those returned by a proxy, to mark the server Unknown.
+func WithErrorHandlerHook(fn func(err error, desc description.Server) ErrorAction) ServerOption {
+	return func(cfg *serverConfig) error {
+		cfg.errorHandlerHook = fn
+		return nil
+	}
+}
+
 // WithHeartbeatTimeout configures how long to wait for a heartbeat socket to
 // connection.
 func WithHeartbeatTimeout(fn func(time.Duration) time.Duration) ServerOption {
@@ -148,6 +238,25 @@ func WithConnectionPoolMaxIdleTime(fn func(time.Duration) time.Duration) ServerO
 	}
 }
 
+// WithWaitQueueTimeout configures the maximum time a connection pool checkout waits for a connection when the pool
+// is saturated. If the timeout elapses before the Context expires, the checkout fails with a WaitQueueTimeoutError
+// wrapping ErrWaitQueueTimeout. If waitQueueTimeout is 0, checkouts wait until the Context expires.
+func WithWaitQueueTimeout(fn func(time.Duration) time.Duration) ServerOption {
+	return func(cfg *serverConfig) error {
+		cfg.poolWaitQueueTimeout = fn(cfg.poolWaitQueueTimeout)
+		return nil
+	}
+}
+
+// WithPoolFairness configures which waiting checkout receives a connection when one is returned to a saturated
+// connection pool. The default is PoolFairnessFIFO.
+func WithPoolFairness(fn func(PoolFairness) PoolFairness) ServerOption {
+	return func(cfg *serverConfig) error {
+		cfg.poolFairness = fn(cfg.poolFairness)
+		return nil
+	}
+}
+
 // WithConnectionPoolMaintainInterval configures the interval that the background connection pool
 // maintenance goroutine runs.
 func WithConnectionPoolMaintainInterval(fn func(time.Duration) time.Duration) ServerOption {
@@ -655,6 +655,73 @@ func TestServer(t *testing.T) {
 			})
 		}
 	})
+	t.Run("error handler hook", func(t *testing.T) {
+		proxyError := driver.Error{Code: 9999, Message: "proxy unavailable"}
+		shutdownError := driver.Error{Code: 11600}
+
+		testCases := []struct {
+			name         string
+			err          error
+			action       ErrorAction
+			result       driver.ProcessErrorResult
+			expectedKind description.ServerKind
+			expectedGen  uint64
+		}{
+			{"none preserves default for non state change error", proxyError, ErrorActionNone, driver.NoChange,
+				description.RSPrimary, 0},
+			{"none preserves default for shutdown error", shutdownError, ErrorActionNone,
+				driver.ConnectionPoolCleared, description.Unknown, 1},
+			{"mark unknown", proxyError, ErrorActionMarkUnknown, driver.ServerMarkedUnknown,
+				description.Unknown, 0},
+			{"request check", shutdownError, ErrorActionRequestCheck, driver.NoChange,
+				description.RSPrimary, 0},
+			{"clear pool", proxyError, ErrorActionClearPool, driver.ConnectionPoolCleared,
+				description.Unknown, 1},
+		}
+		for _, tc := range testCases {
+			t.Run(tc.name, func(t *testing.T) {
+				var hookErr error
+				hook := func(err error, desc description.Server) ErrorAction {
+					hookErr = err
+					return tc.action
+				}
+				server, err := NewServer(address.Address("localhost"), primitive.NewObjectID(),
+					WithErrorHandlerHook(hook))
+				assert.Nil(t, err, "NewServer error: %v", err)
+
+				server.state = serverConnected
+				err = server.pool.ready()
+				assert.Nil(t, err, "pool.ready() error: %v", err)
+				server.desc.Store(description.Server{Kind: description.RSPrimary})
+
+				result := server.ProcessError(tc.err, newProcessErrorTestConn(nil))
+				assert.Equal(t, tc.result, result, "expected ProcessError result %v, got %v", tc.result, result)
+				assert.Equal(t, tc.err, hookErr, "expected hook to be called with %v, got %v", tc.err, hookErr)
+
+				desc := server.Description()
+				assert.Equal(t, tc.expectedKind, desc.Kind, "expected server kind %q, got %q", tc.expectedKind,
+					desc.Kind)
+				generation := server.pool.generation.getGeneration(nil)
+				assert.Equal(t, tc.expectedGen, generation, "expected pool generation %d, got %d", tc.expectedGen,
+					generation)
+			})
+		}
+		t.Run("not called for stale connections", func(t *testing.T) {
+			var called bool
+			hook := func(error, description.Server) ErrorAction {
+				called = true
+				return ErrorActionClearPool
+			}
+			server, err := NewServer(address.Address("localhost"), primitive.NewObjectID(),
+				WithErrorHandlerHook(hook))
+			assert.Nil(t, err, "NewServer error: %v", err)
+
+			result := server.ProcessError(proxyError, newStaleProcessErrorTestConn())
+			assert.Equal(t, driver.NoChange, result, "expected ProcessError result %v, got %v", driver.NoChange,
+				result)
+			assert.False(t, called, "expected hook not to be called for a stale connection")
+		})
+	})
 	t.Run("update topology", func(t *testing.T) {
 		var updated atomic.Value // bool
 		updated.Store(false)
@@ -712,6 +779,374 @@ func TestServer(t *testing.T) {
 			t.Fatal("client metadata not expected in heartbeat but found")
 		}
 	})
+	t.Run("heartbeat sets RTTs", func(t *testing.T) {
+		dialer := &channelNetConnDialer{}
+		dialerOpt := WithDialer(func(Dialer) Dialer {
+			return dialer
+		})
+		serverOpts := []ServerOption{
+			WithConnectionOptions(func(connOpts ...ConnectionOption) []ConnectionOption {
+				return append(connOpts, dialerOpt)
+			}),
+			withMonitoringDisabled(func(bool) bool { return true }),
+		}
+
+		s, err := NewServer(address.Address("localhost:27017"), primitive.NewObjectID(), serverOpts...)
+		assert.Nil(t, err, "NewServer error: %v", err)
+
+		// set up heartbeat connection
+		_, err = s.check()
+		assert.Nil(t, err, "check error: %v", err)
+		channelConn := s.conn.nc.(*drivertest.ChannelNetConn)
+		_ = channelConn.GetWrittenMessage()
+
+		s.rttMonitor.addSample(10 * time.Millisecond)
+		s.rttMonitor.addSample(20 * time.Millisecond)
+		err = channelConn.AddResponse(makeHelloReply())
+		assert.Nil(t, err, "AddResponse error: %v", err)
+
+		desc, err := s.check()
+		_ = channelConn.GetWrittenMessage()
+		assert.Nil(t, err, "check error: %v", err)
+		assert.True(t, desc.AverageRTTSet, "expected AverageRTTSet to be true")
+		assert.Equal(t, s.rttMonitor.getRTT(), desc.AverageRTT, "expected average RTT %v, got %v",
+			s.rttMonitor.getRTT(), desc.AverageRTT)
+		assert.Equal(t, 20*time.Millisecond, desc.LastRTT, "expected last RTT %v, got %v",
+			20*time.Millisecond, desc.LastRTT)
+	})
+	t.Run("heartbeat interval for kind", func(t *testing.T) {
+		intervalForKind := func(kind description.ServerKind) time.Duration {
+			switch kind {
+			case description.RSSecondary:
+				return 2 * time.Second
+			case description.RSArbiter:
+				return 30 * time.Second
+			}
+			return 0
+		}
+
+		dialer := &channelNetConnDialer{}
+		dialerOpt := WithDialer(func(Dialer) Dialer {
+			return dialer
+		})
+		serverOpts := []ServerOption{
+			WithConnectionOptions(func(connOpts ...ConnectionOption) []ConnectionOption {
+				return append(connOpts, dialerOpt)
+			}),
+			withMonitoringDisabled(func(bool) bool { return true }),
+			WithHeartbeatInterval(func(time.Duration) time.Duration { return 10 * time.Second }),
+			WithHeartbeatIntervalForKind(intervalForKind),
+		}
+
+		s, err := NewServer(address.Address("localhost:27017"), primitive.NewObjectID(), serverOpts...)
+		assert.Nil(t, err, "NewServer error: %v", err)
+
+		// set up heartbeat connection
+		_, err = s.check()
+		assert.Nil(t, err, "check error: %v", err)
+		channelConn := s.conn.nc.(*drivertest.ChannelNetConn)
+		_ = channelConn.GetWrittenMessage()
+
+		testCases := []struct {
+			name     string
+			reply    bsoncore.Document
+			kind     description.ServerKind
+			interval time.Duration
+		}{
+			{
+				"secondary",
+				bsoncore.NewDocumentBuilder().
+					AppendInt32("ok", 1).
+					AppendString("setName", "rs").
+					AppendBoolean("secondary", true).
+					Build(),
+				description.RSSecondary,
+				2 * time.Second,
+			},
+			{
+				"arbiter",
+				bsoncore.NewDocumentBuilder().
+					AppendInt32("ok", 1).
+					AppendString("setName", "rs").
+					AppendBoolean("arbiterOnly", true).
+					Build(),
+				description.RSArbiter,
+				30 * time.Second,
+			},
+			{
+				"default for other kinds",
+				bsoncore.NewDocumentBuilder().
+					AppendInt32("ok", 1).
+					AppendString("setName", "rs").
+					AppendBoolean("isWritablePrimary", true).
+					Build(),
+				description.RSPrimary,
+				10 * time.Second,
+			},
+		}
+		for _, tc := range testCases {
+			t.Run(tc.name, func(t *testing.T) {
+				err := channelConn.AddResponse(drivertest.MakeReply(tc.reply))
+				assert.Nil(t, err, "AddResponse error: %v", err)
+
+				desc, err := s.check()
+				_ = channelConn.GetWrittenMessage()
+				assert.Nil(t, err, "check error: %v", err)
+				assert.Equal(t, tc.kind, desc.Kind, "expected kind %v, got %v", tc.kind, desc.Kind)
+				assert.Equal(t, tc.interval, desc.HeartbeatInterval,
+					"expected heartbeat interval %v, got %v", tc.interval, desc.HeartbeatInterval)
+
+				got := s.heartbeatInterval(tc.kind)
+				assert.Equal(t, tc.interval, got, "expected monitor interval %v, got %v", tc.interval, got)
+			})
+		}
+	})
+	t.Run("heartbeat interval for address", func(t *testing.T) {
+		local := address.Address("local:27017")
+		remote := address.Address("remote:27017")
+		other := address.Address("other:27017")
+		intervalForAddr := func(addr address.Address) time.Duration {
+			switch addr {
+			case local:
+				return 2 * time.Second
+			case remote:
+				return 20 * time.Second
+			}
+			return 0
+		}
+		intervalForKind := func(kind description.ServerKind) time.Duration {
+			if kind == description.RSArbiter {
+				return 30 * time.Second
+			}
+			return 0
+		}
+
+		testCases := []struct {
+			addr     address.Address
+			kind     description.ServerKind
+			interval time.Duration
+		}{
+			{local, description.RSSecondary, 2 * time.Second},
+			{local, description.RSArbiter, 2 * time.Second},
+			{remote, description.RSPrimary, 20 * time.Second},
+			{other, description.RSArbiter, 30 * time.Second},
+			{other, description.RSSecondary, 10 * time.Second},
+		}
+		for _, tc := range testCases {
+			s, err := NewServer(tc.addr, primitive.NewObjectID(),
+				withMonitoringDisabled(func(bool) bool { return true }),
+				WithHeartbeatInterval(func(time.Duration) time.Duration { return 10 * time.Second }),
+				WithHeartbeatIntervalForKind(intervalForKind),
+				WithHeartbeatIntervalForAddress(intervalForAddr),
+			)
+			assert.Nil(t, err, "NewServer error: %v", err)
+
+			got := s.heartbeatInterval(tc.kind)
+			assert.Equal(t, tc.interval, got, "expected interval %v for %v server %v, got %v", tc.interval, tc.kind,
+				tc.addr, got)
+		}
+	})
+	t.Run("heartbeat jitter", func(t *testing.T) {
+		interval := 10 * time.Second
+
+		t.Run("scheduled times diverge", func(t *testing.T) {
+			const fraction = 0.2
+			minDelay := interval - time.Duration(float64(interval)*fraction)
+			maxDelay := interval + time.Duration(float64(interval)*fraction)
+
+			delays := make(map[time.Duration]struct{})
+			for i := 0; i < 5; i++ {
+				s, err := NewServer(address.Address("localhost:27017"), primitive.NewObjectID(),
+					WithHeartbeatJitter(fraction))
+				assert.Nil(t, err, "NewServer error: %v", err)
+
+				delay := s.jitteredHeartbeatInterval(interval)
+				assert.True(t, delay >= minDelay && delay <= maxDelay, "expected delay between %v and %v, got %v",
+					minDelay, maxDelay, delay)
+				delays[delay] = struct{}{}
+			}
+			assert.True(t, len(delays) > 1, "expected scheduled heartbeat delays to diverge, got %v", delays)
+		})
+		t.Run("no jitter by default", func(t *testing.T) {
+			s, err := NewServer(address.Address("localhost:27017"), primitive.NewObjectID())
+			assert.Nil(t, err, "NewServer error: %v", err)
+
+			delay := s.jitteredHeartbeatInterval(interval)
+			assert.Equal(t, interval, delay, "expected delay %v, got %v", interval, delay)
+		})
+		t.Run("invalid fraction", func(t *testing.T) {
+			for _, fraction := range []float64{-0.1, 1.5} {
+				_, err := NewServer(address.Address("localhost:27017"), primitive.NewObjectID(),
+					WithHeartbeatJitter(fraction))
+				assert.NotNil(t, err, "expected error for jitter fraction %v, got nil", fraction)
+			}
+		})
+		t.Run("immediate checks are not delayed", func(t *testing.T) {
+			s, err := NewServer(address.Address("localhost:27017"), primitive.NewObjectID(),
+				WithConnectionOptions(func(connOpts ...ConnectionOption) []ConnectionOption {
+					return append(connOpts, WithDialer(func(Dialer) Dialer {
+						return DialerFunc(func(context.Context, string, string) (net.Conn, error) {
+							return nil, errors.New("dial error")
+						})
+					}))
+				}),
+				WithHeartbeatInterval(func(time.Duration) time.Duration { return time.Hour }),
+				WithHeartbeatJitter(0.5))
+			assert.Nil(t, err, "NewServer error: %v", err)
+			err = s.Connect(nil)
+			assert.Nil(t, err, "Connect error: %v", err)
+			defer func() { _ = s.Disconnect(context.Background()) }()
+
+			// Wait for the initial check, then request another one well before the jittered interval elapses.
+			assert.Eventually(t, func() bool {
+				return s.heartbeatFailureStreak() >= 1
+			}, 5*time.Second, 10*time.Millisecond)
+			s.RequestImmediateCheck()
+			assert.Eventually(t, func() bool {
+				return s.heartbeatFailureStreak() >= 2
+			}, 5*time.Second, 10*time.Millisecond)
+		})
+	})
+	t.Run("heartbeat circuit breaker", func(t *testing.T) {
+		interval := 10 * time.Second
+
+		t.Run("backoff doubles up to the maximum", func(t *testing.T) {
+			s, err := NewServer(address.Address("localhost:27017"), primitive.NewObjectID(),
+				WithServerCircuitBreaker(3, time.Minute))
+			assert.Nil(t, err, "NewServer error: %v", err)
+
+			testCases := []struct {
+				failures int64
+				backoff  time.Duration
+				open     bool
+			}{
+				{2, 0, false},
+				{3, 20 * time.Second, true},
+				{4, 40 * time.Second, true},
+				{5, time.Minute, true},
+				{10, time.Minute, true},
+			}
+			for _, tc := range testCases {
+				atomic.StoreInt64(&s.heartbeatFailures, tc.failures)
+				backoff, open := s.circuitBreakerBackoff(interval)
+				assert.Equal(t, tc.open, open, "expected open %v after %d failures, got %v", tc.open, tc.failures, open)
+				assert.Equal(t, tc.backoff, backoff, "expected backoff %v after %d failures, got %v", tc.backoff,
+					tc.failures, backoff)
+			}
+		})
+		t.Run("backoff is at least the heartbeat interval", func(t *testing.T) {
+			s, err := NewServer(address.Address("localhost:27017"), primitive.NewObjectID(),
+				WithServerCircuitBreaker(1, time.Second))
+			assert.Nil(t, err, "NewServer error: %v", err)
+
+			for _, failures := range []int64{1, 2, 10} {
+				atomic.StoreInt64(&s.heartbeatFailures, failures)
+				backoff, open := s.circuitBreakerBackoff(interval)
+				assert.True(t, open, "expected breaker to be open after %d failures", failures)
+				assert.Equal(t, interval, backoff, "expected backoff %v after %d failures, got %v", interval, failures,
+					backoff)
+			}
+		})
+		t.Run("disabled by default", func(t *testing.T) {
+			s, err := NewServer(address.Address("localhost:27017"), primitive.NewObjectID())
+			assert.Nil(t, err, "NewServer error: %v", err)
+
+			atomic.StoreInt64(&s.heartbeatFailures, 100)
+			_, open := s.circuitBreakerBackoff(interval)
+			assert.False(t, open, "expected circuit breaker to be disabled")
+		})
+		t.Run("invalid arguments", func(t *testing.T) {
+			_, err := NewServer(address.Address("localhost:27017"), primitive.NewObjectID(),
+				WithServerCircuitBreaker(-1, time.Minute))
+			assert.NotNil(t, err, "expected error for negative threshold, got nil")
+			_, err = NewServer(address.Address("localhost:27017"), primitive.NewObjectID(),
+				WithServerCircuitBreaker(1, 0))
+			assert.NotNil(t, err, "expected error for non-positive max backoff, got nil")
+		})
+		t.Run("opens on failures and resets on success", func(t *testing.T) {
+			var fail int32 = 1
+			dialer := DialerFunc(func(ctx context.Context, network, address string) (net.Conn, error) {
+				if atomic.LoadInt32(&fail) == 1 {
+					return nil, errors.New("dial error")
+				}
+				return (&channelNetConnDialer{}).DialContext(ctx, network, address)
+			})
+			s, err := NewServer(address.Address("localhost:27017"), primitive.NewObjectID(),
+				WithConnectionOptions(func(connOpts ...ConnectionOption) []ConnectionOption {
+					return append(connOpts, WithDialer(func(Dialer) Dialer { return dialer }))
+				}),
+				WithHeartbeatInterval(func(time.Duration) time.Duration { return time.Hour }),
+				WithServerCircuitBreaker(1, 24*time.Hour))
+			assert.Nil(t, err, "NewServer error: %v", err)
+			err = s.Connect(nil)
+			assert.Nil(t, err, "Connect error: %v", err)
+			defer func() { _ = s.Disconnect(context.Background()) }()
+
+			// The initial check fails, which opens the breaker and marks the server Unknown.
+			assert.Eventually(t, func() bool {
+				return s.Description().CircuitBreakerOpen
+			}, 5*time.Second, 10*time.Millisecond)
+			assert.Equal(t, description.ServerKind(description.Unknown), s.Description().Kind,
+				"expected server kind Unknown, got %v", s.Description().Kind)
+
+			// An immediate check is not delayed by the backoff.
+			s.RequestImmediateCheck()
+			assert.Eventually(t, func() bool {
+				return s.heartbeatFailureStreak() >= 2
+			}, 5*time.Second, 10*time.Millisecond)
+
+			// A successful heartbeat closes the breaker.
+			atomic.StoreInt32(&fail, 0)
+			s.RequestImmediateCheck()
+			assert.Eventually(t, func() bool {
+				desc := s.Description()
+				return desc.Kind != description.Unknown && !desc.CircuitBreakerOpen
+			}, 5*time.Second, 10*time.Millisecond)
+			assert.Equal(t, 0, s.heartbeatFailureStreak(), "expected heartbeat failure streak 0, got %d",
+				s.heartbeatFailureStreak())
+		})
+	})
+	t.Run("pause and resume monitoring", func(t *testing.T) {
+		// Every check fails to dial, so each heartbeat results in a call to the update callback.
+		var checks int64
+		updateCallback := func(desc description.Server) description.Server {
+			atomic.AddInt64(&checks, 1)
+			return desc
+		}
+		var dialer DialerFunc = func(context.Context, string, string) (net.Conn, error) {
+			return nil, errors.New("dial error")
+		}
+		serverOpts := []ServerOption{
+			WithConnectionOptions(func(connOpts ...ConnectionOption) []ConnectionOption {
+				return append(connOpts, WithDialer(func(Dialer) Dialer { return dialer }))
+			}),
+			WithHeartbeatInterval(func(time.Duration) time.Duration { return minHeartbeatInterval }),
+		}
+		s, err := ConnectServer(address.Address("localhost:27017"), updateCallback, primitive.NewObjectID(),
+			serverOpts...)
+		assert.Nil(t, err, "ConnectServer error: %v", err)
+		defer func() {
+			_ = s.Disconnect(context.Background())
+		}()
+
+		checkCount := func() int64 { return atomic.LoadInt64(&checks) }
+		assert.Eventually(t, func() bool {
+			return checkCount() >= 1
+		}, 5*time.Second, 10*time.Millisecond, "expected an initial check")
+
+		s.PauseMonitoring()
+		// A check that was already running when monitoring was paused can still finish, but no new checks start.
+		paused := checkCount()
+		assert.Never(t, func() bool {
+			return checkCount() > paused+1
+		}, 4*minHeartbeatInterval, 10*time.Millisecond, "expected no new checks while paused")
+
+		resumed := checkCount()
+		s.ResumeMonitoring()
+		assert.Eventually(t, func() bool {
+			return checkCount() > resumed
+		}, 5*time.Second, 10*time.Millisecond, "expected a check after monitoring was resumed")
+	})
 	t.Run("heartbeat monitoring", func(t *testing.T) {
 		var publishedEvents []interface{}
 
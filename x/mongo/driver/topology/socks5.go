@@ -0,0 +1,217 @@
+// Copyright (C) MongoDB, Inc. 2017-present.
+//
+// Licensed under the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License. You may obtain
+// a copy of the License at http://www.apache.org/licenses/LICENSE-2.0
+
+package topology
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"net"
+	"strconv"
+	"time"
+)
+
+// SOCKS5 protocol constants from RFC 1928 and RFC 1929.
+const (
+	socks5Version            = 0x05
+	socks5AuthNone           = 0x00
+	socks5AuthPassword       = 0x02
+	socks5AuthNoAcceptable   = 0xff
+	socks5PasswordVersion    = 0x01
+	socks5CmdConnect         = 0x01
+	socks5AddrIPv4           = 0x01
+	socks5AddrDomain         = 0x03
+	socks5AddrIPv6           = 0x04
+	socks5ReplySucceeded     = 0x00
+	socks5MaxCredentialBytes = 255
+)
+
+var socks5ReplyErrors = map[byte]string{
+	0x01: "general SOCKS server failure",
+	0x02: "connection not allowed by ruleset",
+	0x03: "network unreachable",
+	0x04: "host unreachable",
+	0x05: "connection refused",
+	0x06: "TTL expired",
+	0x07: "command not supported",
+	0x08: "address type not supported",
+}
+
+// SOCKS5Proxy configures a SOCKS5 proxy that connections are routed through. Username and Password are only sent if
+// Username is non-empty.
+type SOCKS5Proxy struct {
+	// Address is the host and port of the proxy. If it is empty, connections are not proxied.
+	Address  string
+	Username string
+	Password string
+}
+
+// WithSOCKS5Proxy configures a SOCKS5 proxy for new connections. The configured Dialer is used to connect to the proxy,
+// which then connects to the server. Connections to Unix domain sockets are not proxied.
+func WithSOCKS5Proxy(fn func(SOCKS5Proxy) SOCKS5Proxy) ConnectionOption {
+	return func(c *connectionConfig) {
+		c.socks5Proxy = fn(c.socks5Proxy)
+	}
+}
+
+// socks5Dialer is a Dialer that connects to addresses through a SOCKS5 proxy.
+type socks5Dialer struct {
+	proxy   SOCKS5Proxy
+	forward Dialer
+}
+
+var _ Dialer = (*socks5Dialer)(nil)
+
+// DialContext connects to the proxy with the forward Dialer and asks it to connect to address. The deadline of ctx, if
+// any, applies to the proxy handshake.
+func (d *socks5Dialer) DialContext(ctx context.Context, network, address string) (net.Conn, error) {
+	switch network {
+	case "tcp", "tcp4", "tcp6":
+	default:
+		return d.forward.DialContext(ctx, network, address)
+	}
+
+	conn, err := d.forward.DialContext(ctx, network, d.proxy.Address)
+	if err != nil {
+		return nil, fmt.Errorf("unable to connect to SOCKS5 proxy %s: %v", d.proxy.Address, err)
+	}
+
+	if deadline, ok := ctx.Deadline(); ok {
+		_ = conn.SetDeadline(deadline)
+	}
+	if err = d.connect(conn, address); err != nil {
+		_ = conn.Close()
+		if ctx.Err() != nil {
+			err = ctx.Err()
+		}
+		return nil, fmt.Errorf("SOCKS5 proxy %s could not connect to %s: %v", d.proxy.Address, address, err)
+	}
+	_ = conn.SetDeadline(time.Time{})
+
+	return conn, nil
+}
+
+// connect performs the SOCKS5 handshake over conn, authenticating if a username is configured, and sends a CONNECT
+// request for address.
+func (d *socks5Dialer) connect(conn net.Conn, address string) error {
+	host, portStr, err := net.SplitHostPort(address)
+	if err != nil {
+		return err
+	}
+	port, err := strconv.ParseUint(portStr, 10, 16)
+	if err != nil {
+		return fmt.Errorf("invalid port %q", portStr)
+	}
+
+	method := byte(socks5AuthNone)
+	if d.proxy.Username != "" {
+		method = socks5AuthPassword
+	}
+	if _, err = conn.Write([]byte{socks5Version, 1, method}); err != nil {
+		return err
+	}
+	var resp [2]byte
+	if _, err = io.ReadFull(conn, resp[:]); err != nil {
+		return err
+	}
+	if resp[0] != socks5Version {
+		return fmt.Errorf("unexpected SOCKS version %d", resp[0])
+	}
+	switch resp[1] {
+	case method:
+	case socks5AuthNoAcceptable:
+		if method == socks5AuthPassword {
+			return errors.New("proxy does not support username/password authentication")
+		}
+		return errors.New("proxy requires authentication")
+	default:
+		return fmt.Errorf("proxy selected unsupported authentication method %d", resp[1])
+	}
+
+	if method == socks5AuthPassword {
+		if err = d.authenticate(conn); err != nil {
+			return err
+		}
+	}
+
+	req := []byte{socks5Version, socks5CmdConnect, 0}
+	if ip := net.ParseIP(host); ip == nil {
+		if len(host) > 255 {
+			return fmt.Errorf("host name %q is too long", host)
+		}
+		req = append(req, socks5AddrDomain, byte(len(host)))
+		req = append(req, host...)
+	} else if ip4 := ip.To4(); ip4 != nil {
+		req = append(req, socks5AddrIPv4)
+		req = append(req, ip4...)
+	} else {
+		req = append(req, socks5AddrIPv6)
+		req = append(req, ip.To16()...)
+	}
+	req = append(req, byte(port>>8), byte(port))
+	if _, err = conn.Write(req); err != nil {
+		return err
+	}
+
+	var reply [4]byte
+	if _, err = io.ReadFull(conn, reply[:]); err != nil {
+		return err
+	}
+	if reply[0] != socks5Version {
+		return fmt.Errorf("unexpected SOCKS version %d", reply[0])
+	}
+	if reply[1] != socks5ReplySucceeded {
+		if msg, ok := socks5ReplyErrors[reply[1]]; ok {
+			return errors.New(msg)
+		}
+		return fmt.Errorf("unknown SOCKS reply code %d", reply[1])
+	}
+
+	// Discard the bound address and port, which are not needed.
+	var bindLen int
+	switch reply[3] {
+	case socks5AddrIPv4:
+		bindLen = net.IPv4len
+	case socks5AddrIPv6:
+		bindLen = net.IPv6len
+	case socks5AddrDomain:
+		var n [1]byte
+		if _, err = io.ReadFull(conn, n[:]); err != nil {
+			return err
+		}
+		bindLen = int(n[0])
+	default:
+		return fmt.Errorf("unexpected SOCKS address type %d", reply[3])
+	}
+	_, err = io.ReadFull(conn, make([]byte, bindLen+2))
+	return err
+}
+
+// authenticate performs username/password authentication as described in RFC 1929.
+func (d *socks5Dialer) authenticate(conn net.Conn) error {
+	if len(d.proxy.Username) > socks5MaxCredentialBytes || len(d.proxy.Password) > socks5MaxCredentialBytes {
+		return fmt.Errorf("proxy username and password must be at most %d bytes", socks5MaxCredentialBytes)
+	}
+
+	req := []byte{socks5PasswordVersion, byte(len(d.proxy.Username))}
+	req = append(req, d.proxy.Username...)
+	req = append(req, byte(len(d.proxy.Password)))
+	req = append(req, d.proxy.Password...)
+	if _, err := conn.Write(req); err != nil {
+		return err
+	}
+
+	var resp [2]byte
+	if _, err := io.ReadFull(conn, resp[:]); err != nil {
+		return err
+	}
+	if resp[1] != 0 {
+		return errors.New("proxy authentication failed: invalid username or password")
+	}
+	return nil
+}
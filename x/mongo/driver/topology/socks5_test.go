@@ -0,0 +1,278 @@
+// Copyright (C) MongoDB, Inc. 2017-present.
+//
+// Licensed under the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License. You may obtain
+// a copy of the License at http://www.apache.org/licenses/LICENSE-2.0
+
+package topology
+
+import (
+	"context"
+	"io"
+	"net"
+	"strconv"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"go.mongodb.org/mongo-driver/internal/testutil/assert"
+)
+
+// testSOCKS5Server is a minimal SOCKS5 server that supports the CONNECT command and, if username is set,
+// username/password authentication. It records the addresses it was asked to connect to.
+type testSOCKS5Server struct {
+	listener net.Listener
+	username string
+	password string
+
+	mu      sync.Mutex
+	targets []string
+}
+
+func newTestSOCKS5Server(t *testing.T, username, password string) *testSOCKS5Server {
+	t.Helper()
+
+	l, err := net.Listen("tcp", "localhost:0")
+	assert.Nil(t, err, "Listen error: %v", err)
+	s := &testSOCKS5Server{listener: l, username: username, password: password}
+	go func() {
+		for {
+			c, err := l.Accept()
+			if err != nil {
+				return
+			}
+			go s.serve(c)
+		}
+	}()
+	return s
+}
+
+func (s *testSOCKS5Server) close() {
+	_ = s.listener.Close()
+}
+
+func (s *testSOCKS5Server) addr() string {
+	return s.listener.Addr().String()
+}
+
+func (s *testSOCKS5Server) connectedTo() []string {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return append([]string(nil), s.targets...)
+}
+
+func (s *testSOCKS5Server) serve(c net.Conn) {
+	defer c.Close()
+
+	var hdr [2]byte
+	if _, err := io.ReadFull(c, hdr[:]); err != nil {
+		return
+	}
+	methods := make([]byte, hdr[1])
+	if _, err := io.ReadFull(c, methods); err != nil {
+		return
+	}
+	want := byte(socks5AuthNone)
+	if s.username != "" {
+		want = socks5AuthPassword
+	}
+	if !strings.Contains(string(methods), string([]byte{want})) {
+		_, _ = c.Write([]byte{socks5Version, socks5AuthNoAcceptable})
+		return
+	}
+	_, _ = c.Write([]byte{socks5Version, want})
+
+	if want == socks5AuthPassword {
+		readString := func() string {
+			var n [1]byte
+			if _, err := io.ReadFull(c, n[:]); err != nil {
+				return ""
+			}
+			b := make([]byte, n[0])
+			_, _ = io.ReadFull(c, b)
+			return string(b)
+		}
+		var ver [1]byte
+		if _, err := io.ReadFull(c, ver[:]); err != nil {
+			return
+		}
+		user, pass := readString(), readString()
+		if user != s.username || pass != s.password {
+			_, _ = c.Write([]byte{socks5PasswordVersion, 1})
+			return
+		}
+		_, _ = c.Write([]byte{socks5PasswordVersion, 0})
+	}
+
+	var req [4]byte
+	if _, err := io.ReadFull(c, req[:]); err != nil {
+		return
+	}
+	var host string
+	switch req[3] {
+	case socks5AddrIPv4, socks5AddrIPv6:
+		ip := make([]byte, net.IPv4len)
+		if req[3] == socks5AddrIPv6 {
+			ip = make([]byte, net.IPv6len)
+		}
+		if _, err := io.ReadFull(c, ip); err != nil {
+			return
+		}
+		host = net.IP(ip).String()
+	case socks5AddrDomain:
+		var n [1]byte
+		if _, err := io.ReadFull(c, n[:]); err != nil {
+			return
+		}
+		b := make([]byte, n[0])
+		if _, err := io.ReadFull(c, b); err != nil {
+			return
+		}
+		host = string(b)
+	}
+	var port [2]byte
+	if _, err := io.ReadFull(c, port[:]); err != nil {
+		return
+	}
+	target := net.JoinHostPort(host, strconv.Itoa(int(port[0])<<8|int(port[1])))
+
+	s.mu.Lock()
+	s.targets = append(s.targets, target)
+	s.mu.Unlock()
+
+	upstream, err := net.Dial("tcp", target)
+	if err != nil {
+		_, _ = c.Write([]byte{socks5Version, 0x05, 0, socks5AddrIPv4, 0, 0, 0, 0, 0, 0})
+		return
+	}
+	defer upstream.Close()
+	_, _ = c.Write([]byte{socks5Version, socks5ReplySucceeded, 0, socks5AddrIPv4, 127, 0, 0, 1, 0, 0})
+
+	go func() { _, _ = io.Copy(upstream, c) }()
+	_, _ = io.Copy(c, upstream)
+}
+
+// newEchoServer starts a server that echoes everything it reads and returns its listener.
+func newEchoServer(t *testing.T) net.Listener {
+	t.Helper()
+
+	l, err := net.Listen("tcp", "localhost:0")
+	assert.Nil(t, err, "Listen error: %v", err)
+	go func() {
+		for {
+			c, err := l.Accept()
+			if err != nil {
+				return
+			}
+			go func() {
+				defer c.Close()
+				_, _ = io.Copy(c, c)
+			}()
+		}
+	}()
+	return l
+}
+
+func TestSOCKS5Dialer(t *testing.T) {
+	echo := newEchoServer(t)
+	defer echo.Close()
+	echoAddr := echo.Addr().String()
+
+	dial := func(proxy SOCKS5Proxy, address string) (net.Conn, error) {
+		ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+		defer cancel()
+		d := newConnectionConfig(WithSOCKS5Proxy(func(SOCKS5Proxy) SOCKS5Proxy { return proxy })).dialer
+		return d.DialContext(ctx, "tcp", address)
+	}
+	assertEcho := func(t *testing.T, conn net.Conn) {
+		t.Helper()
+
+		_, err := conn.Write([]byte("ping"))
+		assert.Nil(t, err, "Write error: %v", err)
+		got := make([]byte, 4)
+		_, err = io.ReadFull(conn, got)
+		assert.Nil(t, err, "Read error: %v", err)
+		assert.Equal(t, "ping", string(got), "expected echo %q, got %q", "ping", got)
+	}
+
+	t.Run("routes connections through the proxy", func(t *testing.T) {
+		server := newTestSOCKS5Server(t, "", "")
+		defer server.close()
+
+		conn, err := dial(SOCKS5Proxy{Address: server.addr()}, echoAddr)
+		assert.Nil(t, err, "DialContext error: %v", err)
+		defer conn.Close()
+
+		assertEcho(t, conn)
+		assert.Equal(t, []string{echoAddr}, server.connectedTo(), "expected proxy to connect to %v, got %v",
+			[]string{echoAddr}, server.connectedTo())
+	})
+	t.Run("host names are resolved by the proxy", func(t *testing.T) {
+		server := newTestSOCKS5Server(t, "", "")
+		defer server.close()
+		_, port, _ := net.SplitHostPort(echoAddr)
+		target := net.JoinHostPort("localhost", port)
+
+		conn, err := dial(SOCKS5Proxy{Address: server.addr()}, target)
+		assert.Nil(t, err, "DialContext error: %v", err)
+		defer conn.Close()
+
+		assertEcho(t, conn)
+		assert.Equal(t, []string{target}, server.connectedTo(), "expected proxy to connect to %v, got %v",
+			[]string{target}, server.connectedTo())
+	})
+	t.Run("authenticates with username and password", func(t *testing.T) {
+		server := newTestSOCKS5Server(t, "user", "pencil")
+		defer server.close()
+
+		conn, err := dial(SOCKS5Proxy{Address: server.addr(), Username: "user", Password: "pencil"}, echoAddr)
+		assert.Nil(t, err, "DialContext error: %v", err)
+		defer conn.Close()
+
+		assertEcho(t, conn)
+	})
+	t.Run("errors", func(t *testing.T) {
+		authServer := newTestSOCKS5Server(t, "user", "pencil")
+		defer authServer.close()
+		noAuthServer := newTestSOCKS5Server(t, "", "")
+		defer noAuthServer.close()
+
+		// Reserve an address that nothing is listening on.
+		l, err := net.Listen("tcp", "localhost:0")
+		assert.Nil(t, err, "Listen error: %v", err)
+		closedAddr := l.Addr().String()
+		_ = l.Close()
+
+		testCases := []struct {
+			name    string
+			proxy   SOCKS5Proxy
+			address string
+			errMsg  string
+		}{
+			{"proxy unreachable", SOCKS5Proxy{Address: closedAddr}, echoAddr, "unable to connect to SOCKS5 proxy"},
+			{
+				"wrong password",
+				SOCKS5Proxy{Address: authServer.addr(), Username: "user", Password: "wrong"},
+				echoAddr,
+				"invalid username or password",
+			},
+			{"missing credentials", SOCKS5Proxy{Address: authServer.addr()}, echoAddr, "proxy requires authentication"},
+			{
+				"unexpected credentials",
+				SOCKS5Proxy{Address: noAuthServer.addr(), Username: "user", Password: "pencil"},
+				echoAddr,
+				"does not support username/password authentication",
+			},
+			{"target unreachable", SOCKS5Proxy{Address: noAuthServer.addr()}, closedAddr, "connection refused"},
+		}
+		for _, tc := range testCases {
+			t.Run(tc.name, func(t *testing.T) {
+				_, err := dial(tc.proxy, tc.address)
+				assert.NotNil(t, err, "expected DialContext error, got nil")
+				assert.True(t, strings.Contains(err.Error(), tc.errMsg), "expected error to contain %q, got %v",
+					tc.errMsg, err)
+			})
+		}
+	})
+}
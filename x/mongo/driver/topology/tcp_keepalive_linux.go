@@ -0,0 +1,56 @@
+// Copyright (C) MongoDB, Inc. 2017-present.
+//
+// Licensed under the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License. You may obtain
+// a copy of the License at http://www.apache.org/licenses/LICENSE-2.0
+
+//go:build linux
+// +build linux
+
+package topology
+
+import (
+	"net"
+	"syscall"
+	"time"
+)
+
+// setTCPKeepAlive enables keepalive on conn and sets the TCP_KEEPIDLE, TCP_KEEPINTVL, and TCP_KEEPCNT socket options
+// for the non-zero fields of ka.
+func setTCPKeepAlive(conn *net.TCPConn, ka TCPKeepAlive) error {
+	if err := conn.SetKeepAlive(true); err != nil {
+		return err
+	}
+	raw, err := conn.SyscallConn()
+	if err != nil {
+		return err
+	}
+
+	var sockErr error
+	setOpt := func(fd int, opt, value int) {
+		if sockErr == nil && value > 0 {
+			sockErr = syscall.SetsockoptInt(fd, syscall.IPPROTO_TCP, opt, value)
+		}
+	}
+	err = raw.Control(func(fd uintptr) {
+		setOpt(int(fd), syscall.TCP_KEEPIDLE, keepAliveSeconds(ka.Idle))
+		setOpt(int(fd), syscall.TCP_KEEPINTVL, keepAliveSeconds(ka.Interval))
+		setOpt(int(fd), syscall.TCP_KEEPCNT, ka.Count)
+	})
+	if err != nil {
+		return err
+	}
+	return sockErr
+}
+
+// keepAliveSeconds converts d to the whole number of seconds used by the keepalive socket options, rounding up
+// positive durations shorter than a second to 1.
+func keepAliveSeconds(d time.Duration) int {
+	if d <= 0 {
+		return 0
+	}
+	if secs := int(d / time.Second); secs > 0 {
+		return secs
+	}
+	return 1
+}
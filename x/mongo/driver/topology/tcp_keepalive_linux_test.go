@@ -0,0 +1,99 @@
+// Copyright (C) MongoDB, Inc. 2017-present.
+//
+// Licensed under the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License. You may obtain
+// a copy of the License at http://www.apache.org/licenses/LICENSE-2.0
+
+//go:build linux
+// +build linux
+
+package topology
+
+import (
+	"context"
+	"net"
+	"syscall"
+	"testing"
+	"time"
+
+	"go.mongodb.org/mongo-driver/internal/testutil/assert"
+	"go.mongodb.org/mongo-driver/mongo/address"
+)
+
+func TestTCPKeepAlive(t *testing.T) {
+	// getSockopts returns the SO_KEEPALIVE, TCP_KEEPIDLE, TCP_KEEPINTVL, and TCP_KEEPCNT socket options of conn.
+	getSockopts := func(t *testing.T, conn *net.TCPConn) [4]int {
+		t.Helper()
+
+		raw, err := conn.SyscallConn()
+		assert.Nil(t, err, "SyscallConn error: %v", err)
+
+		var opts [4]int
+		var sockErr error
+		get := func(fd, level, opt int) int {
+			val, err := syscall.GetsockoptInt(fd, level, opt)
+			if err != nil {
+				sockErr = err
+			}
+			return val
+		}
+		err = raw.Control(func(fd uintptr) {
+			opts[0] = get(int(fd), syscall.SOL_SOCKET, syscall.SO_KEEPALIVE)
+			opts[1] = get(int(fd), syscall.IPPROTO_TCP, syscall.TCP_KEEPIDLE)
+			opts[2] = get(int(fd), syscall.IPPROTO_TCP, syscall.TCP_KEEPINTVL)
+			opts[3] = get(int(fd), syscall.IPPROTO_TCP, syscall.TCP_KEEPCNT)
+		})
+		assert.Nil(t, err, "Control error: %v", err)
+		assert.Nil(t, sockErr, "GetsockoptInt error: %v", sockErr)
+		return opts
+	}
+	connect := func(t *testing.T, opts ...ConnectionOption) *net.TCPConn {
+		t.Helper()
+
+		addr := bootstrapConnections(t, 1, func(nc net.Conn) {
+			_, _ = nc.Read(make([]byte, 1))
+			_ = nc.Close()
+		})
+		// Use a dialer that doesn't enable keepalive so the configured settings are the only source of them.
+		opts = append(opts, WithDialer(func(Dialer) Dialer { return &net.Dialer{KeepAlive: -1} }))
+		conn := newConnection(address.Address(addr.String()), opts...)
+		err := conn.connect(context.Background())
+		assert.Nil(t, err, "connect error: %v", err)
+
+		tcpConn, ok := conn.nc.(*net.TCPConn)
+		assert.True(t, ok, "expected connection of type *net.TCPConn, got %T", conn.nc)
+		return tcpConn
+	}
+
+	t.Run("settings are applied", func(t *testing.T) {
+		keepAlive := TCPKeepAlive{Idle: 45 * time.Second, Interval: 7 * time.Second, Count: 4}
+		nc := connect(t, WithTCPKeepAlive(func(TCPKeepAlive) TCPKeepAlive { return keepAlive }))
+		defer nc.Close()
+
+		got := getSockopts(t, nc)
+		expected := [4]int{1, 45, 7, 4}
+		assert.Equal(t, expected, got, "expected socket options %v, got %v", expected, got)
+	})
+	t.Run("zero settings are unchanged", func(t *testing.T) {
+		nc := connect(t)
+		defer nc.Close()
+		defaults := getSockopts(t, nc)
+
+		keepAlive := TCPKeepAlive{Count: 2}
+		nc = connect(t, WithTCPKeepAlive(func(TCPKeepAlive) TCPKeepAlive { return keepAlive }))
+		defer nc.Close()
+
+		got := getSockopts(t, nc)
+		expected := [4]int{1, defaults[1], defaults[2], 2}
+		assert.Equal(t, expected, got, "expected socket options %v, got %v", expected, got)
+	})
+	t.Run("sub-second durations are rounded up", func(t *testing.T) {
+		keepAlive := TCPKeepAlive{Idle: 100 * time.Millisecond, Interval: 1500 * time.Millisecond}
+		nc := connect(t, WithTCPKeepAlive(func(TCPKeepAlive) TCPKeepAlive { return keepAlive }))
+		defer nc.Close()
+
+		got := getSockopts(t, nc)
+		assert.Equal(t, 1, got[1], "expected TCP_KEEPIDLE 1, got %v", got[1])
+		assert.Equal(t, 1, got[2], "expected TCP_KEEPINTVL 1, got %v", got[2])
+	})
+}
@@ -0,0 +1,24 @@
+// Copyright (C) MongoDB, Inc. 2017-present.
+//
+// Licensed under the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License. You may obtain
+// a copy of the License at http://www.apache.org/licenses/LICENSE-2.0
+
+//go:build !linux
+// +build !linux
+
+package topology
+
+import "net"
+
+// setTCPKeepAlive enables keepalive on conn and sets the keepalive period to ka.Idle if it is non-zero. The standard
+// library does not expose the probe interval and count on all platforms, so ka.Interval and ka.Count are ignored.
+func setTCPKeepAlive(conn *net.TCPConn, ka TCPKeepAlive) error {
+	if err := conn.SetKeepAlive(true); err != nil {
+		return err
+	}
+	if ka.Idle > 0 {
+		return conn.SetKeepAlivePeriod(ka.Idle)
+	}
+	return nil
+}
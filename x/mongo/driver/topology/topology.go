@@ -14,6 +14,7 @@ import (
 	"context"
 	"errors"
 	"math/rand"
+	"sort"
 	"strings"
 	"sync"
 	"sync/atomic"
@@ -36,16 +37,38 @@ const (
 	topologyDisconnecting
 	topologyConnected
 	topologyConnecting
+	topologyDraining
 )
 
+// drainCheckInterval is how often DisconnectDrain checks whether all checked out connections have been returned.
+const drainCheckInterval = 10 * time.Millisecond
+
+// primaryBoostWindow is how long a server that has just become the replica set primary after a failover is preferred
+// by server selections that had to wait for the topology to change.
+const primaryBoostWindow = 10 * time.Second
+
+// promotedPrimary records the server that most recently transitioned to RSPrimary and when it did so.
+type promotedPrimary struct {
+	addr address.Address
+	at   time.Time
+}
+
 // ErrSubscribeAfterClosed is returned when a user attempts to subscribe to a
 // closed Server or Topology.
 var ErrSubscribeAfterClosed = errors.New("cannot subscribe after closeConnection")
 
+// ErrSRVPollingNotRequired is returned by RequestSRVPoll if the topology does not poll SRV records, either because it
+// was not created from a mongodb+srv URI or because it was discovered to be a replica set or standalone.
+var ErrSRVPollingNotRequired = errors.New("SRV polling is not required for this topology")
+
 // ErrTopologyClosed is returned when a user attempts to call a method on a
 // closed Topology.
 var ErrTopologyClosed = errors.New("topology is closed")
 
+// ErrTopologyNotConnected is returned when a user attempts to select a server
+// from a Topology that has not been connected.
+var ErrTopologyNotConnected = errors.New("topology is not connected")
+
 // ErrTopologyConnected is returned whena  user attempts to Connect to an
 // already connected Topology.
 var ErrTopologyConnected = errors.New("topology is connected or connecting")
@@ -66,9 +89,47 @@ const (
 	SingleMode
 )
 
+// CompatibilityMode determines how server selection behaves when the topology description reports a wire version
+// compatibility error.
+type CompatibilityMode uint8
+
+// These constants are the available compatibility modes.
+const (
+	// CompatibilityStrict causes server selection to fail for all operations while the topology has a compatibility
+	// error. This is the default.
+	CompatibilityStrict CompatibilityMode = iota
+
+	// CompatibilityWarnReadOnly allows server selection for read operations to proceed while the topology has a
+	// compatibility error. Server selection for write operations, including selectors that contain a
+	// description.MayWriteSelector such as the ones used for commands run through Database.RunCommand, still fails. The
+	// error remains available through the CompatibilityErr field of the topology description and a
+	// TopologyCompatibilityWarningEvent is published each time the error is set or changes.
+	CompatibilityWarnReadOnly
+)
+
+// MongosLoadScorer reports the current load of a mongos, where lower scores indicate less load. If a MongosLoadScorer
+// is configured and more than one mongos is suitable for an operation, server selection picks two of the suitable
+// mongoses at random and selects the one with the lower score. A scorer can be backed by any load metric, such as the
+// connection counts reported by serverStatus. LoadScore is called during server selection, so it must be fast and safe
+// for concurrent use.
+type MongosLoadScorer interface {
+	LoadScore(server description.Server) float64
+}
+
+// MongosLoadScorerFunc is an adapter to allow the use of ordinary functions as MongosLoadScorers.
+type MongosLoadScorerFunc func(server description.Server) float64
+
+// LoadScore implements the MongosLoadScorer interface.
+func (f MongosLoadScorerFunc) LoadScore(server description.Server) float64 {
+	return f(server)
+}
+
 // Topology represents a MongoDB deployment.
 type Topology struct {
 	state int64
+	// connectedOnce is set to 1 the first time Connect succeeds. It is used to distinguish a topology that has never
+	// been connected from one that has been disconnected.
+	connectedOnce int32
 
 	cfg *config
 
@@ -78,11 +139,19 @@ type Topology struct {
 
 	done chan struct{}
 
-	pollingRequired   bool
+	pollingRequired bool
+	// pollingLock guards pollingDone, which is recreated each time the topology is connected and closed by Disconnect.
+	pollingLock       sync.Mutex
 	pollingDone       chan struct{}
 	pollingwg         sync.WaitGroup
 	rescanSRVInterval time.Duration
 	pollHeartbeatTime atomic.Value // holds a bool
+	pollRequests      chan chan error
+
+	promoted atomic.Value // holds a promotedPrimary
+
+	// random is used to pick among suitable servers. It is the package-global generator unless replaced by a test.
+	random *randutil.LockedRand
 
 	updateCallback updateTopologyCallback
 	fsm            *fsm
@@ -91,6 +160,7 @@ type Topology struct {
 	// require a redesign so we can share a minimum of data between the
 	// subscribers and the topology.
 	subscribers         map[uint64]chan description.Topology
+	filteredSubscribers map[uint64]*filteredSubscriber
 	currentSubscriberID uint64
 	subscriptionsClosed bool
 	subLock             sync.Mutex
@@ -99,9 +169,15 @@ type Topology struct {
 	// too difficult to maintain and it's rather easy to accidentally access
 	// the servers without acquiring the lock or checking if the servers are
 	// closed. This lock should also be an RWMutex.
-	serversLock   sync.Mutex
-	serversClosed bool
-	servers       map[address.Address]*Server
+	serversLock     sync.Mutex
+	serversClosed   bool
+	serversDraining bool
+	serversPaused   bool
+	servers         map[address.Address]*Server
+	lastUpdated     map[address.Address]time.Time // lastUpdated is guarded by serversLock.
+	// primarySeen is set once a primary has been discovered, so that the initial discovery of a primary isn't
+	// treated as a failover. It is guarded by serversLock.
+	primarySeen bool
 
 	id primitive.ObjectID
 }
@@ -109,15 +185,121 @@ type Topology struct {
 var _ driver.Deployment = &Topology{}
 var _ driver.Subscriber = &Topology{}
 
+// ServerSelectionEvent describes the outcome of a call to Topology.SelectServer.
+type ServerSelectionEvent struct {
+	// Duration is the total time spent in SelectServer.
+	Duration time.Duration
+	// FastPath is true if the server was selected from the topology description that was current when SelectServer
+	// was called, without waiting for a topology update.
+	FastPath bool
+	// Candidates is the number of known servers passed to the selector in the last selection attempt.
+	Candidates int
+	// Selected is the address of the selected server. It is empty if selection failed.
+	Selected address.Address
+	// Err is the error returned by SelectServer, if any.
+	Err error
+}
+
+// SelectionTrace records the servers considered at each stage of a call to Topology.SelectServerWithTrace. If the
+// topology description changes while waiting for a suitable server, the trace describes the last selection attempt.
+type SelectionTrace struct {
+	// Candidates are the known servers passed to the selector, after excluded servers and servers of kind Unknown
+	// were removed.
+	Candidates []description.Server
+	// AfterReadPref are the servers that remained after the stages of the selector that precede its first
+	// LatencySelector, such as a ReadPrefSelector, were applied.
+	AfterReadPref []description.Server
+	// AfterLatency are the servers that remained after all stages of the selector, including the latency window, were
+	// applied. These are the servers the final choice is made from.
+	AfterLatency []description.Server
+	// Selected is the address of the selected server. It is empty if selection failed.
+	Selected address.Address
+}
+
 type serverSelectionState struct {
 	selector    description.ServerSelector
 	timeoutChan <-chan time.Time
+
+	// candidates, if non-nil, is set to the number of servers passed to the selector by each selection attempt.
+	candidates *int
+
+	// trace, if non-nil, is populated with the servers remaining after each stage of the selector.
+	trace *SelectionTrace
+
+	// start is the time selection started and updates counts the topology updates received while waiting for a
+	// suitable server. They are reported in ServerSelectionErrors. snapshotReceived is set once the description that
+	// Subscribe pre-populates the subscription with has been received, which is not counted as an update.
+	start            time.Time
+	updates          *int
+	snapshotReceived *bool
+
+	// excluded holds the addresses of servers that are removed from the candidates before the selector runs.
+	excluded map[address.Address]struct{}
 }
 
 func newServerSelectionState(selector description.ServerSelector, timeoutChan <-chan time.Time) serverSelectionState {
-	return serverSelectionState{
-		selector:    selector,
-		timeoutChan: timeoutChan,
+	state := serverSelectionState{
+		selector:         selector,
+		timeoutChan:      timeoutChan,
+		start:            time.Now(),
+		updates:          new(int),
+		snapshotReceived: new(bool),
+	}
+	if es, ok := selector.(*excludingSelector); ok {
+		state.selector = es.selector
+		state.excluded = es.excluded
+	}
+	return state
+}
+
+// excludingSelector wraps a ServerSelector to exclude servers by address. See WithExcludedAddresses.
+type excludingSelector struct {
+	selector description.ServerSelector
+	excluded map[address.Address]struct{}
+}
+
+// WithExcludedAddresses returns a ServerSelector that never selects the servers with the given addresses, e.g. to
+// avoid a server that just returned a "not primary" error when selecting a server to retry an operation on. When used
+// with a Topology, the excluded servers are removed from the candidates before ss runs, both when selecting from the
+// current topology description and while waiting for topology updates. If every suitable server is excluded,
+// selection waits for another server to become suitable until it times out.
+func WithExcludedAddresses(ss description.ServerSelector, addrs ...address.Address) description.ServerSelector {
+	excluded := make(map[address.Address]struct{}, len(addrs))
+	for _, addr := range addrs {
+		excluded[addr.Canonicalize()] = struct{}{}
+	}
+	return &excludingSelector{selector: ss, excluded: excluded}
+}
+
+// SelectServer implements the description.ServerSelector interface. It removes the excluded servers from candidates
+// and applies the wrapped selector to the remaining servers.
+func (es *excludingSelector) SelectServer(t description.Topology, candidates []description.Server) ([]description.Server, error) {
+	return es.selector.SelectServer(t, excludeServers(candidates, es.excluded))
+}
+
+// excludeServers returns the servers in candidates whose addresses are not in excluded.
+func excludeServers(candidates []description.Server, excluded map[address.Address]struct{}) []description.Server {
+	if len(excluded) == 0 {
+		return candidates
+	}
+
+	var remaining []description.Server
+	for _, s := range candidates {
+		if _, ok := excluded[s.Addr.Canonicalize()]; !ok {
+			remaining = append(remaining, s)
+		}
+	}
+	return remaining
+}
+
+// selectionError returns a ServerSelectionError for the given error and topology description that reports how long
+// selection has taken and how many topology updates were received.
+func (s serverSelectionState) selectionError(err error, desc description.Topology) ServerSelectionError {
+	return ServerSelectionError{
+		Desc:     desc,
+		Wrapped:  err,
+		Duration: time.Since(s.start),
+		Updates:  *s.updates,
 	}
 }
 
@@ -129,33 +311,64 @@ func New(opts ...Option) (*Topology, error) {
 	}
 
 	t := &Topology{
-		cfg:               cfg,
-		done:              make(chan struct{}),
-		pollingDone:       make(chan struct{}),
-		rescanSRVInterval: 60 * time.Second,
-		fsm:               newFSM(),
-		subscribers:       make(map[uint64]chan description.Topology),
-		servers:           make(map[address.Address]*Server),
-		dnsResolver:       dns.DefaultResolver,
-		id:                primitive.NewObjectID(),
+		cfg:                 cfg,
+		done:                make(chan struct{}),
+		pollingDone:         make(chan struct{}),
+		pollRequests:        make(chan chan error),
+		rescanSRVInterval:   60 * time.Second,
+		fsm:                 newFSM(),
+		subscribers:         make(map[uint64]chan description.Topology),
+		filteredSubscribers: make(map[uint64]*filteredSubscriber),
+		servers:             make(map[address.Address]*Server),
+		lastUpdated:         make(map[address.Address]time.Time),
+		dnsResolver:         dns.DefaultResolver,
+		random:              random,
+		id:                  primitive.NewObjectID(),
 	}
 	t.desc.Store(description.Topology{})
 	t.updateCallback = func(desc description.Server) description.Server {
 		return t.apply(context.TODO(), desc)
 	}
 
+	if t.cfg.dnsResolver != nil {
+		t.dnsResolver = t.cfg.dnsResolver
+	}
+
 	if t.cfg.uri != "" {
 		t.pollingRequired = strings.HasPrefix(t.cfg.uri, "mongodb+srv://") && !t.cfg.loadBalanced
 	}
 
+	if t.cfg.globalMaxConnections > 0 {
+		limiter := newConnectionLimiter(t.cfg.globalMaxConnections)
+		t.cfg.serverOpts = append(t.cfg.serverOpts, withConnectionLimiter(func(*connectionLimiter) *connectionLimiter {
+			return limiter
+		}))
+	}
+
 	t.publishTopologyOpeningEvent()
 
 	return t, nil
 }
 
 // Connect initializes a Topology and starts the monitoring process. This function
-// must be called to properly monitor the topology.
+// must be called to properly monitor the topology. It is equivalent to calling
+// ConnectContext with context.Background().
 func (t *Topology) Connect() error {
+	return t.ConnectContext(context.Background())
+}
+
+// ConnectContext is like Connect, but returns the ctx error if ctx is done before monitoring has been started for every
+// seed server. In that case, the servers that were already started are disconnected and the topology is left
+// disconnected. Starting a server monitor does not wait for the server to respond, and SRV records are resolved when
+// the connection string is parsed, so ctx does not bound how long it takes to discover the servers. Use the server
+// selection timeout to bound how long operations wait for a suitable server.
+func (t *Topology) ConnectContext(ctx context.Context) error {
+	if ctx == nil {
+		ctx = context.Background()
+	}
+	if err := ctx.Err(); err != nil {
+		return err
+	}
 	if !atomic.CompareAndSwapInt64(&t.state, topologyDisconnected, topologyConnecting) {
 		return ErrTopologyConnected
 	}
@@ -164,6 +377,13 @@ func (t *Topology) Connect() error {
 	var err error
 	t.serversLock.Lock()
 
+	// Start from an empty topology in case the topology was disconnected and is being reconnected. The servers from
+	// the previous connection were disconnected by Disconnect, so they are replaced. The rest of the FSM state, such as
+	// the highest election ID seen, is kept.
+	t.serversClosed = false
+	t.servers = make(map[address.Address]*Server)
+	t.fsm.Topology = description.Topology{}
+
 	// A replica set name sets the initial topology type to ReplicaSetNoPrimary unless a direct connection is also
 	// specified, in which case the initial type is Single.
 	if t.cfg.replicaSetName != "" {
@@ -195,6 +415,7 @@ func (t *Topology) Connect() error {
 		addr := address.Address(t.cfg.seedList[0]).Canonicalize()
 		if err := t.addServer(addr); err != nil {
 			t.serversLock.Unlock()
+			t.abortConnect()
 			return err
 		}
 
@@ -221,10 +442,16 @@ func (t *Topology) Connect() error {
 		t.desc.Store(newDesc)
 		t.publishTopologyDescriptionChangedEvent(description.Topology{}, t.fsm.Topology)
 		for _, a := range t.cfg.seedList {
+			if err = ctx.Err(); err != nil {
+				t.serversLock.Unlock()
+				t.abortConnect()
+				return err
+			}
 			addr := address.Address(a).Canonicalize()
 			err = t.addServer(addr)
 			if err != nil {
 				t.serversLock.Unlock()
+				t.abortConnect()
 				return err
 			}
 		}
@@ -232,16 +459,41 @@ func (t *Topology) Connect() error {
 
 	t.serversLock.Unlock()
 	if t.pollingRequired {
-		go t.pollSRVRecords()
+		// pollingDone is closed by Disconnect, so it must be recreated in case the topology is being reconnected.
+		t.pollingLock.Lock()
+		done := make(chan struct{})
+		t.pollingDone = done
+		t.pollingLock.Unlock()
+
 		t.pollingwg.Add(1)
+		go t.pollSRVRecords(done)
 	}
 
 	t.subscriptionsClosed = false // explicitly set in case topology was disconnected and then reconnected
 
+	atomic.StoreInt32(&t.connectedOnce, 1)
 	atomic.StoreInt64(&t.state, topologyConnected)
 	return nil
 }
 
+// abortConnect undoes a partial Connect by disconnecting the servers that were started and resetting the topology to
+// its disconnected state. Like Disconnect, it keeps the FSM state. The caller must not hold serversLock.
+func (t *Topology) abortConnect() {
+	t.serversLock.Lock()
+	servers := t.servers
+	t.servers = make(map[address.Address]*Server)
+	// Ignore updates from servers that are still shutting down.
+	t.serversClosed = true
+	t.serversLock.Unlock()
+
+	for _, server := range servers {
+		_ = server.Disconnect(context.Background())
+	}
+
+	t.desc.Store(description.Topology{})
+	atomic.StoreInt64(&t.state, topologyDisconnected)
+}
+
 // Disconnect closes the topology. It stops the monitoring thread and
 // closes all open subscriptions.
 func (t *Topology) Disconnect(ctx context.Context) error {
@@ -249,6 +501,57 @@ func (t *Topology) Disconnect(ctx context.Context) error {
 		return ErrTopologyClosed
 	}
 
+	t.disconnect(ctx)
+	return nil
+}
+
+// DisconnectDrain closes the topology after waiting for operations that are already in progress to finish. Server
+// selection fails with ErrTopologyClosed as soon as DisconnectDrain is called and the connection pools of all servers
+// are drained, so no new connections can be checked out. DisconnectDrain then waits until all checked out connections
+// have been returned or ctx is done, after which it closes the topology like Disconnect, closing any connections that
+// are still in use. If ctx has no deadline and is never canceled, DisconnectDrain waits for all connections to be
+// returned.
+func (t *Topology) DisconnectDrain(ctx context.Context) error {
+	if !atomic.CompareAndSwapInt64(&t.state, topologyConnected, topologyDraining) {
+		return ErrTopologyClosed
+	}
+	if ctx == nil {
+		ctx = context.Background()
+	}
+
+	t.Drain()
+
+	ticker := time.NewTicker(drainCheckInterval)
+	defer ticker.Stop()
+wait:
+	for t.inUseConnections() > 0 {
+		select {
+		case <-ticker.C:
+		case <-ctx.Done():
+			break wait
+		}
+	}
+
+	atomic.StoreInt64(&t.state, topologyDisconnecting)
+	t.disconnect(ctx)
+	return nil
+}
+
+// inUseConnections returns the number of connections that are checked out from the pools of all servers in the
+// topology.
+func (t *Topology) inUseConnections() int {
+	t.serversLock.Lock()
+	defer t.serversLock.Unlock()
+
+	var inUse int
+	for _, server := range t.servers {
+		inUse += server.pool.inUseConnectionCount()
+	}
+	return inUse
+}
+
+// disconnect implements Disconnect. The topology must be in the disconnecting state.
+func (t *Topology) disconnect(ctx context.Context) {
 	servers := make(map[address.Address]*Server)
 	t.serversLock.Lock()
 	t.serversClosed = true
@@ -267,11 +570,15 @@ func (t *Topology) Disconnect(ctx context.Context) error {
 		close(ch)
 		delete(t.subscribers, id)
 	}
+	for id, fs := range t.filteredSubscribers {
+		close(fs.ch)
+		delete(t.filteredSubscribers, id)
+	}
 	t.subscriptionsClosed = true
 	t.subLock.Unlock()
 
 	if t.pollingRequired {
-		t.pollingDone <- struct{}{}
+		close(t.pollingDoneChan())
 		t.pollingwg.Wait()
 	}
 
@@ -279,7 +586,44 @@ func (t *Topology) Disconnect(ctx context.Context) error {
 
 	atomic.StoreInt64(&t.state, topologyDisconnected)
 	t.publishTopologyClosedEvent()
-	return nil
+}
+
+// Drain marks the connection pools of all servers in the topology as draining, including servers that are discovered
+// later. Operations that need a new connection will fail with ErrPoolDraining, but operations that already hold a
+// connection can complete. Drain is intended to be followed by Disconnect with a Context deadline, which waits for
+// in-use connections to be returned before closing them.
+func (t *Topology) Drain() {
+	t.serversLock.Lock()
+	defer t.serversLock.Unlock()
+
+	t.serversDraining = true
+	for _, server := range t.servers {
+		server.Drain()
+	}
+}
+
+// PauseMonitoring stops the heartbeats for all servers in the topology, including servers that are discovered later,
+// until ResumeMonitoring is called. Connection pools are not closed and the last known server descriptions are kept
+// while monitoring is paused.
+func (t *Topology) PauseMonitoring() {
+	t.serversLock.Lock()
+	defer t.serversLock.Unlock()
+
+	t.serversPaused = true
+	for _, server := range t.servers {
+		server.PauseMonitoring()
+	}
+}
+
+// ResumeMonitoring restarts the heartbeats for all servers in the topology after a call to PauseMonitoring.
+func (t *Topology) ResumeMonitoring() {
+	t.serversLock.Lock()
+	defer t.serversLock.Unlock()
+
+	t.serversPaused = false
+	for _, server := range t.servers {
+		server.ResumeMonitoring()
+	}
 }
 
 // Description returns a description of the topology.
@@ -291,6 +635,58 @@ func (t *Topology) Description() description.Topology {
 	return td
 }
 
+// ReplicationLag returns the estimated replication lag of each secondary in the topology, keyed by address. The lag is
+// computed from the lastWriteDate reported in each server's hello reply. If there is a primary, the lag is estimated
+// relative to the primary, adjusting for the time at which each server was last checked. Otherwise, the lag is
+// relative to the secondary with the most recent write. Secondaries that have not reported a lastWriteDate are
+// omitted. Negative estimates are reported as 0.
+func (t *Topology) ReplicationLag() map[address.Address]time.Duration {
+	return replicationLag(t.Description())
+}
+
+func replicationLag(desc description.Topology) map[address.Address]time.Duration {
+	var primary *description.Server
+	var secondaries []description.Server
+	for i, server := range desc.Servers {
+		switch server.Kind {
+		case description.RSPrimary:
+			primary = &desc.Servers[i]
+		case description.RSSecondary:
+			if !server.LastWriteTime.IsZero() {
+				secondaries = append(secondaries, server)
+			}
+		}
+	}
+
+	lags := make(map[address.Address]time.Duration, len(secondaries))
+	if primary != nil && !primary.LastWriteTime.IsZero() {
+		primaryLag := primary.LastUpdateTime.Sub(primary.LastWriteTime)
+		for _, secondary := range secondaries {
+			lag := secondary.LastUpdateTime.Sub(secondary.LastWriteTime) - primaryLag
+			lags[secondary.Addr] = nonNegative(lag)
+		}
+		return lags
+	}
+
+	var latest time.Time
+	for _, secondary := range secondaries {
+		if secondary.LastWriteTime.After(latest) {
+			latest = secondary.LastWriteTime
+		}
+	}
+	for _, secondary := range secondaries {
+		lags[secondary.Addr] = latest.Sub(secondary.LastWriteTime)
+	}
+	return lags
+}
+
+func nonNegative(d time.Duration) time.Duration {
+	if d < 0 {
+		return 0
+	}
+	return d
+}
+
 // Kind returns the topology kind of this Topology.
 func (t *Topology) Kind() description.TopologyKind { return t.Description().Kind }
 
@@ -324,6 +720,91 @@ func (t *Topology) Subscribe() (*driver.Subscription, error) {
 	}, nil
 }
 
+// filteredSubscriber is a subscriber created by SubscribeFiltered.
+type filteredSubscriber struct {
+	ch        chan description.Topology
+	predicate func(description.Server) bool
+	last      map[address.Address]description.ServerKind // The servers in the last description sent on ch.
+}
+
+// send sends td to the subscriber with only the servers that match its predicate, if those servers differ from the
+// ones in the last description it sent. The caller must hold subLock.
+func (fs *filteredSubscriber) send(td description.Topology) {
+	servers := make([]description.Server, 0, len(td.Servers))
+	matched := make(map[address.Address]description.ServerKind, len(td.Servers))
+	for _, s := range td.Servers {
+		if fs.predicate(s) {
+			servers = append(servers, s)
+			matched[s.Addr] = s.Kind
+		}
+	}
+
+	if fs.last != nil && len(matched) == len(fs.last) {
+		changed := false
+		for addr, kind := range matched {
+			if lastKind, ok := fs.last[addr]; !ok || lastKind != kind {
+				changed = true
+				break
+			}
+		}
+		if !changed {
+			return
+		}
+	}
+	fs.last = matched
+
+	td.Servers = servers
+	// We drain the description if there's one in the channel
+	select {
+	case <-fs.ch:
+	default:
+	}
+	fs.ch <- td
+}
+
+// SubscribeFiltered returns a Subscription on which updated description.Topologys are sent with only the servers for
+// which predicate returns true. A description is only sent when the set of matching servers or their kinds change, so
+// subscribers are not woken by heartbeats that don't affect them. If no servers match, a description with no servers
+// is sent. Like Subscribe, the channel has a buffer size of one and is pre-populated with the current
+// description.Topology, filtered by predicate.
+//
+// To receive updates only when the set of data-bearing servers changes, use description.Server.DataBearing as the
+// predicate. predicate is called with the topology's subscription lock held and must not call methods on the
+// Topology. The Subscription must be closed with Unsubscribe.
+func (t *Topology) SubscribeFiltered(predicate func(description.Server) bool) (*driver.Subscription, error) {
+	if atomic.LoadInt64(&t.state) != topologyConnected {
+		return nil, errors.New("cannot subscribe to Topology that is not connected")
+	}
+	if predicate == nil {
+		return nil, errors.New("predicate must not be nil")
+	}
+
+	t.subLock.Lock()
+	defer t.subLock.Unlock()
+	if t.subscriptionsClosed {
+		return nil, ErrSubscribeAfterClosed
+	}
+
+	fs := &filteredSubscriber{
+		ch:        make(chan description.Topology, 1),
+		predicate: predicate,
+	}
+	td, ok := t.desc.Load().(description.Topology)
+	if !ok {
+		td = description.Topology{}
+	}
+	fs.send(td)
+
+	id := t.currentSubscriberID
+	t.filteredSubscribers[id] = fs
+	t.currentSubscriberID++
+
+	return &driver.Subscription{
+		Updates: fs.ch,
+		ID:      id,
+	}, nil
+}
+
 // Unsubscribe unsubscribes the given subscription from the topology and closes the subscription channel.
 // Unsubscribe implements the driver.Subscriber interface.
 func (t *Topology) Unsubscribe(sub *driver.Subscription) error {
@@ -334,6 +815,12 @@ func (t *Topology) Unsubscribe(sub *driver.Subscription) error {
 		return nil
 	}
 
+	if fs, ok := t.filteredSubscribers[sub.ID]; ok {
+		close(fs.ch)
+		delete(t.filteredSubscribers, sub.ID)
+		return nil
+	}
+
 	ch, ok := t.subscribers[sub.ID]
 	if !ok {
 		return nil
@@ -344,6 +831,66 @@ func (t *Topology) Unsubscribe(sub *driver.Subscription) error {
 	return nil
 }
 
+// SubscriptionCount returns the number of subscriptions created by Subscribe and SubscribeFiltered that have not been
+// unsubscribed. Subscriptions are removed when the topology is disconnected. It can be used by tests and health checks
+// to detect subscriptions that are never unsubscribed.
+func (t *Topology) SubscriptionCount() int {
+	t.subLock.Lock()
+	defer t.subLock.Unlock()
+
+	return len(t.subscribers) + len(t.filteredSubscribers)
+}
+
+// SubscriptionIDs returns the IDs of the subscriptions counted by SubscriptionCount in ascending order. Because IDs are
+// assigned in increasing order, the lowest IDs belong to the longest-lived subscriptions.
+func (t *Topology) SubscriptionIDs() []uint64 {
+	t.subLock.Lock()
+	defer t.subLock.Unlock()
+
+	ids := make([]uint64, 0, len(t.subscribers)+len(t.filteredSubscribers))
+	for id := range t.subscribers {
+		ids = append(ids, id)
+	}
+	for id := range t.filteredSubscribers {
+		ids = append(ids, id)
+	}
+	sort.Slice(ids, func(i, j int) bool { return ids[i] < ids[j] })
+	return ids
+}
+
+// WaitForKind blocks until the topology is of the given kind, e.g. until a replica set has a primary. It returns nil
+// immediately if the topology is already of the given kind. Otherwise, it subscribes to the topology and waits for a
+// matching description. The ctx error is returned if ctx is done first, and ErrTopologyClosed is returned if the
+// topology is disconnected while waiting.
+func (t *Topology) WaitForKind(ctx context.Context, kind description.TopologyKind) error {
+	if ctx == nil {
+		ctx = context.Background()
+	}
+	if t.Description().Kind == kind {
+		return nil
+	}
+
+	sub, err := t.Subscribe()
+	if err != nil {
+		return err
+	}
+	defer func() { _ = t.Unsubscribe(sub) }()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case desc, ok := <-sub.Updates:
+			if !ok {
+				return ErrTopologyClosed
+			}
+			if desc.Kind == kind {
+				return nil
+			}
+		}
+	}
+}
+
 // RequestImmediateCheck will send heartbeats to all the servers in the
 // topology right away, instead of waiting for the heartbeat timeout.
 func (t *Topology) RequestImmediateCheck() {
@@ -359,8 +906,105 @@ func (t *Topology) RequestImmediateCheck() {
 
 // SelectServer selects a server with given a selector. SelectServer complies with the
 // server selection spec, and will time out after severSelectionTimeout or when the
-// parent context is done.
+// parent context is done. ErrTopologyNotConnected is returned immediately if Connect
+// has not completed and ErrTopologyClosed is returned if the topology has been disconnected.
 func (t *Topology) SelectServer(ctx context.Context, ss description.ServerSelector) (driver.Server, error) {
+	observer := t.cfg.selectionObserver
+	if t.notConnected() {
+		// The observer is called for every SelectServer call, including ones that fail before selection starts.
+		if observer != nil {
+			observer(ServerSelectionEvent{Err: ErrTopologyNotConnected})
+		}
+		return nil, ErrTopologyNotConnected
+	}
+
+	if observer == nil {
+		return t.selectServer(ctx, ss, nil, nil)
+	}
+
+	start := time.Now()
+	var evt ServerSelectionEvent
+	srv, err := t.selectServer(ctx, ss, &evt, nil)
+	evt.Duration = time.Since(start)
+	evt.Err = err
+	observer(evt)
+	return srv, err
+}
+
+// notConnected returns true if the topology has never been connected or is being connected.
+func (t *Topology) notConnected() bool {
+	switch atomic.LoadInt64(&t.state) {
+	case topologyConnecting:
+		return true
+	case topologyDisconnected:
+		return atomic.LoadInt32(&t.connectedOnce) == 0
+	}
+	return false
+}
+
+// SelectServerMultiple selects up to max servers with the given selector, which allows an operation to be sent to
+// several servers at once, as for hedged reads. The servers are ordered by average round trip time, lowest first, and
+// each can be used to check out connections independently. If fewer than max servers are suitable, all of them are
+// returned. If no servers are suitable, SelectServerMultiple waits for a suitable server like SelectServer and times
+// out after serverSelectionTimeout or when ctx is done.
+func (t *Topology) SelectServerMultiple(ctx context.Context, ss description.ServerSelector,
+	max int) ([]*SelectedServer, error) {
+
+	if max < 1 {
+		return nil, fmt.Errorf("max must be at least 1, got %d", max)
+	}
+	return t.selectServers(ctx, ss, nil, nil, max, func(suitable []description.Server, _ bool) []description.Server {
+		return sortByRTT(suitable)
+	})
+}
+
+// SelectServerWithTrace selects a server with the given selector like SelectServer and also returns a trace of the
+// servers that remained after each stage of the selection, which can be used to diagnose why a server was or wasn't
+// selected. The trace is returned even if selection fails. Selections made with SelectServerWithTrace are not reported
+// to the observer configured with WithServerSelectionObserver.
+func (t *Topology) SelectServerWithTrace(ctx context.Context, ss description.ServerSelector) (*SelectedServer,
+	SelectionTrace, error) {
+
+	var trace SelectionTrace
+	if t.notConnected() {
+		// Unlike SelectServer, this is not reported to the observer.
+		return nil, trace, ErrTopologyNotConnected
+	}
+
+	srv, err := t.selectServer(ctx, ss, nil, &trace)
+	if err != nil {
+		return nil, trace, err
+	}
+	return srv.(*SelectedServer), trace, nil
+}
+
+// selectServer implements SelectServer. If evt is non-nil, it is populated with the details of the selection other
+// than its duration and error. If trace is non-nil, it is populated with the servers remaining after each stage of
+// the selection.
+func (t *Topology) selectServer(ctx context.Context, ss description.ServerSelector,
+	evt *ServerSelectionEvent, trace *SelectionTrace) (driver.Server, error) {
+
+	selected, err := t.selectServers(ctx, ss, evt, trace, 1, func(suitable []description.Server,
+		retry bool) []description.Server {
+
+		return []description.Server{t.pickServer(suitable, retry)}
+	})
+	if err != nil {
+		return nil, err
+	}
+	return selected[0], nil
+}
+
+// selectServers selects at least one and at most max servers with the given selector. Each time the selector returns
+// suitable servers, order is called to arrange them by preference and the first max of them that are still part of the
+// topology are returned. If none of them are, selection is retried. The retry argument to order is true if the
+// current description didn't contain a suitable server and selection had to wait for the topology to change. If evt is non-nil, it is populated with the details
+// of the selection other than its duration and error. If trace is non-nil, it is populated as for
+// SelectServerWithTrace.
+func (t *Topology) selectServers(ctx context.Context, ss description.ServerSelector, evt *ServerSelectionEvent,
+	trace *SelectionTrace, max int, order func([]description.Server, bool) []description.Server) ([]*SelectedServer,
+	error) {
+
 	if atomic.LoadInt64(&t.state) != topologyConnected {
 		return nil, ErrTopologyClosed
 	}
@@ -375,6 +1019,10 @@ func (t *Topology) SelectServer(ctx context.Context, ss description.ServerSelect
 	var doneOnce bool
 	var sub *driver.Subscription
 	selectionState := newServerSelectionState(ss, ssTimeoutCh)
+	if evt != nil {
+		selectionState.candidates = &evt.Candidates
+	}
+	selectionState.trace = trace
 	for {
 		var suitable []description.Server
 		var selectErr error
@@ -407,19 +1055,153 @@ func (t *Topology) SelectServer(ctx context.Context, ss description.ServerSelect
 			continue
 		}
 
-		selected := suitable[random.Intn(len(suitable))]
-		selectedS, err := t.FindServer(selected)
-		switch {
-		case err != nil:
-			return nil, err
-		case selectedS != nil:
-			return selectedS, nil
-		default:
+		var selected []*SelectedServer
+		for _, desc := range order(suitable, sub != nil) {
+			selectedS, err := t.FindServer(desc)
+			if err != nil {
+				return nil, err
+			}
 			// We don't have an actual server for the provided description.
 			// This could happen for a number of reasons, including that the
 			// server has since stopped being a part of this topology, or that
 			// the server selector returned no suitable servers.
+			if selectedS == nil {
+				continue
+			}
+			selected = append(selected, selectedS)
+			if len(selected) == max {
+				break
+			}
+		}
+		if len(selected) > 0 {
+			if evt != nil {
+				evt.FastPath = sub == nil
+				evt.Selected = selected[0].Server.address
+			}
+			if trace != nil {
+				trace.Selected = selected[0].Server.address
+			}
+			return selected, nil
+		}
+	}
+}
+
+// sortByRTT returns a copy of the given servers sorted by average round trip time, lowest first. Servers without an
+// average round trip time are sorted last.
+func sortByRTT(servers []description.Server) []description.Server {
+	sorted := make([]description.Server, len(servers))
+	copy(sorted, servers)
+	sort.SliceStable(sorted, func(i, j int) bool {
+		if sorted[i].AverageRTTSet != sorted[j].AverageRTTSet {
+			return sorted[i].AverageRTTSet
 		}
+		return sorted[i].AverageRTT < sorted[j].AverageRTT
+	})
+	return sorted
+}
+
+// pickServer chooses one of the suitable servers at random. If retry is true, meaning the selection had to wait for the
+// topology to change as operations do during a failover, and a server that became the replica set primary within the
+// last primaryBoostWindow is among the suitable servers, it is chosen instead so operations resume on the new primary
+// quickly. The boost never chooses a server the selector didn't return. Otherwise, if a MongosLoadScorer is
+// configured and the servers are mongoses, two servers are chosen at random and the one with the lower load score is
+// returned.
+func (t *Topology) pickServer(suitable []description.Server, retry bool) description.Server {
+	if addr, ok := t.boostedPrimary(); ok && retry && len(suitable) > 1 {
+		for _, s := range suitable {
+			if s.Addr == addr {
+				return s
+			}
+		}
+	}
+
+	first := t.random.Intn(len(suitable))
+	scorer := t.cfg.mongosLoadScorer
+	if scorer == nil || len(suitable) < 2 || suitable[first].Kind != description.Mongos {
+		return suitable[first]
+	}
+
+	// Choose a second server that is different from the first.
+	second := t.random.Intn(len(suitable) - 1)
+	if second >= first {
+		second++
+	}
+	if scorer.LoadScore(suitable[second]) < scorer.LoadScore(suitable[first]) {
+		return suitable[second]
+	}
+	return suitable[first]
+}
+
+// ServerLastUpdated returns the time at which the description of each server in the topology was last refreshed by a
+// successful heartbeat. Servers that have not completed a successful heartbeat since they were added to the topology
+// are omitted. The returned map is a copy and may be modified by the caller.
+func (t *Topology) ServerLastUpdated() map[address.Address]time.Time {
+	t.serversLock.Lock()
+	defer t.serversLock.Unlock()
+
+	updated := make(map[address.Address]time.Time, len(t.lastUpdated))
+	for addr, at := range t.lastUpdated {
+		updated[addr] = at
+	}
+	return updated
+}
+
+// HeartbeatFailureStreaks returns the number of consecutive heartbeats that have failed for each server in the
+// topology. The count for a server is reset to 0 when a heartbeat succeeds. The returned map is a copy and may be
+// modified by the caller.
+func (t *Topology) HeartbeatFailureStreaks() map[address.Address]int {
+	t.serversLock.Lock()
+	defer t.serversLock.Unlock()
+
+	streaks := make(map[address.Address]int, len(t.servers))
+	for addr, s := range t.servers {
+		streaks[addr] = s.heartbeatFailureStreak()
+	}
+	return streaks
+}
+
+// PoolStats returns a snapshot of the connection pool of each server in the topology. The snapshot does not block
+// connections from being checked out or in while it is taken. The returned map is a copy and may be modified by the
+// caller.
+func (t *Topology) PoolStats() map[address.Address]PoolStat {
+	t.serversLock.Lock()
+	defer t.serversLock.Unlock()
+
+	stats := make(map[address.Address]PoolStat, len(t.servers))
+	for addr, s := range t.servers {
+		stats[addr] = s.pool.stats()
+	}
+	return stats
+}
+
+// boostedPrimary returns the address of the server that became the replica set primary after a failover within the
+// last primaryBoostWindow and is still the primary, if any.
+func (t *Topology) boostedPrimary() (address.Address, bool) {
+	p, ok := t.promoted.Load().(promotedPrimary)
+	if !ok || p.addr == "" || time.Since(p.at) >= primaryBoostWindow {
+		return "", false
+	}
+	return p.addr, true
+}
+
+// updatePromotedPrimary records the server described by oldDesc as promoted if it became the primary in current after
+// a failover, and clears the promoted server if it is no longer the primary. The caller must hold serversLock.
+func (t *Topology) updatePromotedPrimary(oldDesc description.Server, current description.Topology) {
+	promoted, _ := t.promoted.Load().(promotedPrimary)
+	for _, s := range current.Servers {
+		if s.Kind != description.RSPrimary {
+			if s.Addr == promoted.addr {
+				t.promoted.Store(promotedPrimary{})
+			}
+			continue
+		}
+
+		// Only boost the server if the FSM accepted it as primary (i.e. it wasn't stale) and it isn't the first
+		// primary to be discovered.
+		if s.Addr == oldDesc.Addr && oldDesc.Kind != description.RSPrimary && t.primarySeen {
+			t.promoted.Store(promotedPrimary{addr: s.Addr, at: time.Now()})
+		}
+		t.primarySeen = true
 	}
 }
 
@@ -453,10 +1235,14 @@ func (t *Topology) selectServerFromSubscription(ctx context.Context, subscriptio
 	for {
 		select {
 		case <-ctx.Done():
-			return nil, ServerSelectionError{Wrapped: ctx.Err(), Desc: current}
+			return nil, selectionState.selectionError(ctx.Err(), current)
 		case <-selectionState.timeoutChan:
-			return nil, ServerSelectionError{Wrapped: ErrServerSelectionTimeout, Desc: current}
+			return nil, selectionState.selectionError(ErrServerSelectionTimeout, current)
 		case current = <-subscriptionCh:
+			if *selectionState.snapshotReceived {
+				*selectionState.updates++
+			}
+			*selectionState.snapshotReceived = true
 		}
 
 		suitable, err := t.selectServerFromDescription(current, selectionState)
@@ -479,31 +1265,114 @@ func (t *Topology) selectServerFromDescription(desc description.Topology,
 	// selecting a server from a description is not a blocking operation.
 
 	if desc.CompatibilityErr != nil {
-		return nil, desc.CompatibilityErr
+		if t.cfg.compatibilityMode != CompatibilityWarnReadOnly || description.IsWriteSelector(selectionState.selector) {
+			return nil, desc.CompatibilityErr
+		}
 	}
 
 	// If the topology kind is LoadBalanced, the LB is the only server and it is always considered selectable. The
 	// selectors exported by the driver should already return the LB as a candidate, so this but this check ensures that
 	// the LB is always selectable even if a user of the low-level driver provides a custom selector.
 	if desc.Kind == description.LoadBalanced {
+		if selectionState.candidates != nil {
+			*selectionState.candidates = len(desc.Servers)
+		}
+		if trace := selectionState.trace; trace != nil {
+			trace.Candidates, trace.AfterReadPref, trace.AfterLatency = desc.Servers, desc.Servers, desc.Servers
+		}
 		return desc.Servers, nil
 	}
 
 	var allowed []description.Server
-	for _, s := range desc.Servers {
+	for _, s := range excludeServers(desc.Servers, selectionState.excluded) {
 		if s.Kind != description.Unknown {
 			allowed = append(allowed, s)
 		}
 	}
+	if selectionState.candidates != nil {
+		*selectionState.candidates = len(allowed)
+	}
 
-	suitable, err := selectionState.selector.SelectServer(desc, allowed)
+	var suitable []description.Server
+	var err error
+	if selectionState.trace != nil {
+		suitable, err = traceSelection(selectionState.selector, desc, allowed, selectionState.trace)
+	} else {
+		suitable, err = selectionState.selector.SelectServer(desc, allowed)
+	}
 	if err != nil {
-		return nil, ServerSelectionError{Wrapped: err, Desc: desc}
+		return nil, selectionState.selectionError(err, desc)
 	}
 	return suitable, nil
 }
 
-func (t *Topology) pollSRVRecords() {
+// traceSelection applies the stages of the given selector to the candidates one at a time, recording the servers that
+// remain after the read preference and latency stages in trace. It returns the same servers as the selector would.
+func traceSelection(selector description.ServerSelector, desc description.Topology, candidates []description.Server,
+	trace *SelectionTrace) ([]description.Server, error) {
+
+	*trace = SelectionTrace{Candidates: candidates, AfterReadPref: candidates}
+	seenLatency := false
+	for _, stage := range description.SelectorStages(selector) {
+		var err error
+		candidates, err = stage.SelectServer(desc, candidates)
+		if err != nil {
+			return nil, err
+		}
+		if description.IsLatencySelector(stage) {
+			seenLatency = true
+		}
+		if !seenLatency {
+			trace.AfterReadPref = candidates
+		}
+	}
+	trace.AfterLatency = candidates
+	return candidates, nil
+}
+
+// RequestSRVPoll causes the SRV records for the topology to be polled immediately instead of at the next polling
+// interval, and blocks until the poll has completed and the topology has been updated with the results, or until ctx
+// is done. It returns an error if the DNS lookup failed or returned no valid hosts. If the topology does not poll SRV
+// records, ErrSRVPollingNotRequired is returned. If the topology is disconnected before the poll completes,
+// ErrTopologyClosed is returned.
+func (t *Topology) RequestSRVPoll(ctx context.Context) error {
+	if atomic.LoadInt64(&t.state) != topologyConnected {
+		return ErrTopologyClosed
+	}
+	if !t.pollingRequired {
+		return ErrSRVPollingNotRequired
+	}
+
+	// The result channel is buffered so the polling goroutine never blocks on a caller that has given up.
+	done := t.pollingDoneChan()
+	result := make(chan error, 1)
+	select {
+	case t.pollRequests <- result:
+	case <-done:
+		return ErrTopologyClosed
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+	select {
+	case err := <-result:
+		return err
+	case <-done:
+		return ErrTopologyClosed
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
+
+// pollingDoneChan returns the channel that is closed when SRV polling for the current connection must stop.
+func (t *Topology) pollingDoneChan() chan struct{} {
+	t.pollingLock.Lock()
+	defer t.pollingLock.Unlock()
+
+	return t.pollingDone
+}
+
+// pollSRVRecords polls the SRV records for the topology until done is closed.
+func (t *Topology) pollSRVRecords(done <-chan struct{}) {
 	defer t.pollingwg.Done()
 
 	serverConfig, _ := newServerConfig(t.cfg.serverOpts...)
@@ -516,7 +1385,7 @@ func (t *Topology) pollSRVRecords() {
 	defer func() {
 		//  ¯\_(ツ)_/¯
 		if r := recover(); r != nil && !doneOnce {
-			<-t.pollingDone
+			<-done
 		}
 	}()
 
@@ -528,14 +1397,20 @@ func (t *Topology) pollSRVRecords() {
 	}
 
 	for {
+		// result is non-nil if the poll was requested by RequestSRVPoll.
+		var result chan error
 		select {
 		case <-pollTicker.C:
-		case <-t.pollingDone:
+		case result = <-t.pollRequests:
+		case <-done:
 			doneOnce = true
 			return
 		}
 		topoKind := t.Description().Kind
 		if !(topoKind == description.Unknown || topoKind == description.Sharded) {
+			if result != nil {
+				result <- ErrSRVPollingNotRequired
+			}
 			break
 		}
 
@@ -547,6 +1422,12 @@ func (t *Topology) pollSRVRecords() {
 				pollTicker = time.NewTicker(heartbeatInterval)
 				t.pollHeartbeatTime.Store(true)
 			}
+			if result != nil {
+				if err == nil {
+					err = errors.New("SRV lookup returned no valid hosts")
+				}
+				result <- err
+			}
 			continue
 		}
 		if t.pollHeartbeatTime.Load().(bool) {
@@ -556,12 +1437,24 @@ func (t *Topology) pollSRVRecords() {
 		}
 
 		cont := t.processSRVResults(parsedHosts)
+		if result != nil {
+			result <- nil
+		}
 		if !cont {
 			break
 		}
 	}
-	<-t.pollingDone
-	doneOnce = true
+
+	// Polling has stopped, so answer any further requests until the topology is disconnected.
+	for {
+		select {
+		case result := <-t.pollRequests:
+			result <- ErrSRVPollingNotRequired
+		case <-done:
+			doneOnce = true
+			return
+		}
+	}
 }
 
 func (t *Topology) processSRVResults(parsedHosts []string) bool {
@@ -590,6 +1483,7 @@ func (t *Topology) processSRVResults(parsedHosts []string) bool {
 			_ = s.Disconnect(cancelCtx)
 		}()
 		delete(t.servers, addr)
+		delete(t.lastUpdated, addr)
 		t.fsm.removeServerByAddr(addr)
 		t.publishServerClosedEvent(s.address)
 	}
@@ -633,6 +1527,9 @@ func (t *Topology) processSRVResults(parsedHosts []string) bool {
 		}
 		ch <- newDesc
 	}
+	for _, fs := range t.filteredSubscribers {
+		fs.send(newDesc)
+	}
 	t.subLock.Unlock()
 
 	return true
@@ -656,12 +1553,34 @@ func (t *Topology) apply(ctx context.Context, desc description.Server) descripti
 		return oldDesc
 	}
 
+	prevCompatibilityErr := t.fsm.compatibilityErr
+	prevIncompatibleServer := t.fsm.incompatibleServer
+
 	var current description.Topology
 	current, desc = t.fsm.apply(desc)
+	if desc.LastError == nil {
+		t.lastUpdated[desc.Addr] = time.Now()
+	}
 
 	if !oldDesc.Equal(desc) {
 		t.publishServerDescriptionChangedEvent(oldDesc, desc)
 	}
+	t.updatePromotedPrimary(oldDesc, current)
+	if t.fsm.splitBrain != nil {
+		t.publishSplitBrainDetectedEvent(current.SetName, t.fsm.splitBrain)
+	}
+	if (prevCompatibilityErr == nil) != (t.fsm.compatibilityErr == nil) {
+		if t.fsm.compatibilityErr != nil {
+			t.publishTopologyCompatibilityChangedEvent(t.fsm.compatibilityErr, t.fsm.incompatibleServer)
+		} else {
+			t.publishTopologyCompatibilityChangedEvent(nil, prevIncompatibleServer)
+		}
+	}
+	// The FSM builds a new error on every update, so compare messages to only warn when the error itself changes.
+	if t.cfg.compatibilityMode == CompatibilityWarnReadOnly && t.fsm.compatibilityErr != nil &&
+		(prevCompatibilityErr == nil || prevCompatibilityErr.Error() != t.fsm.compatibilityErr.Error()) {
+		t.publishTopologyCompatibilityWarningEvent(t.fsm.compatibilityErr)
+	}
 
 	diff := diffTopology(prev, current)
 
@@ -673,6 +1592,7 @@ func (t *Topology) apply(ctx context.Context, desc description.Server) descripti
 				_ = s.Disconnect(cancelCtx)
 			}()
 			delete(t.servers, removed.Addr)
+			delete(t.lastUpdated, removed.Addr)
 			t.publishServerClosedEvent(s.address)
 		}
 	}
@@ -695,6 +1615,9 @@ func (t *Topology) apply(ctx context.Context, desc description.Server) descripti
 		}
 		ch <- current
 	}
+	for _, fs := range t.filteredSubscribers {
+		fs.send(current)
+	}
 	t.subLock.Unlock()
 
 	return desc
@@ -705,10 +1628,20 @@ func (t *Topology) addServer(addr address.Address) error {
 		return nil
 	}
 
-	svr, err := ConnectServer(addr, t.updateCallback, t.id, t.cfg.serverOpts...)
+	svr, err := NewServer(addr, t.id, t.cfg.serverOpts...)
 	if err != nil {
 		return err
 	}
+	if t.serversPaused {
+		// Pause before connecting so the new server does not run its initial check.
+		svr.PauseMonitoring()
+	}
+	if err = svr.Connect(t.updateCallback); err != nil {
+		return err
+	}
+	if t.serversDraining {
+		svr.Drain()
+	}
 
 	t.servers[addr] = svr
 
@@ -767,6 +1700,49 @@ func (t *Topology) publishTopologyDescriptionChangedEvent(prev description.Topol
 	}
 }
 
+// publishes a SplitBrainDetectedEvent to indicate two servers claimed to be primary for the same election
+func (t *Topology) publishSplitBrainDetectedEvent(setName string, primaries []description.Server) {
+	splitBrainDetected := &event.SplitBrainDetectedEvent{
+		TopologyID: t.id,
+		SetName:    setName,
+		ElectionID: primaries[len(primaries)-1].ElectionID,
+		Primaries:  primaries,
+	}
+
+	if t.cfg.serverMonitor != nil && t.cfg.serverMonitor.SplitBrainDetected != nil {
+		t.cfg.serverMonitor.SplitBrainDetected(splitBrainDetected)
+	}
+}
+
+// publishes a TopologyCompatibilityChangedEvent to indicate that the topology became incompatible because of server
+// if err is non-nil, or that it became compatible again if err is nil
+func (t *Topology) publishTopologyCompatibilityChangedEvent(err error, server description.Server) {
+	compatibilityChanged := &event.TopologyCompatibilityChangedEvent{
+		TopologyID:            t.id,
+		Compatible:            err == nil,
+		Error:                 err,
+		Address:               server.Addr,
+		WireVersion:           server.WireVersion,
+		SupportedWireVersions: SupportedWireVersions,
+	}
+
+	if t.cfg.serverMonitor != nil && t.cfg.serverMonitor.TopologyCompatibilityChanged != nil {
+		t.cfg.serverMonitor.TopologyCompatibilityChanged(compatibilityChanged)
+	}
+}
+
+// publishes a TopologyCompatibilityWarningEvent to indicate that server selection for reads will proceed despite err
+func (t *Topology) publishTopologyCompatibilityWarningEvent(err error) {
+	compatibilityWarning := &event.TopologyCompatibilityWarningEvent{
+		TopologyID: t.id,
+		Error:      err,
+	}
+
+	if t.cfg.serverMonitor != nil && t.cfg.serverMonitor.TopologyCompatibilityWarning != nil {
+		t.cfg.serverMonitor.TopologyCompatibilityWarning(compatibilityWarning)
+	}
+}
+
 // publishes a TopologyOpeningEvent to indicate the topology is being initialized
 func (t *Topology) publishTopologyOpeningEvent() {
 	topologyOpening := &event.TopologyOpeningEvent{
@@ -60,4 +60,14 @@ func TestTopologyErrors(t *testing.T) {
 				context.DeadlineExceeded, serverSelectionErr)
 		})
 	})
+	t.Run("server selection error reports elapsed time", func(t *testing.T) {
+		err := ServerSelectionError{
+			Wrapped:  ErrServerSelectionTimeout,
+			Duration: 30 * time.Second,
+			Updates:  4,
+		}
+		want := "server selection error: server selection timeout, waited 30s for 4 topology updates, " +
+			"current topology: { Type: Unknown, Servers: [] }"
+		assert.Equal(t, want, err.Error(), "expected error message %q, got %q", want, err.Error())
+	})
 }
@@ -21,6 +21,7 @@ import (
 	"go.mongodb.org/mongo-driver/x/mongo/driver"
 	"go.mongodb.org/mongo-driver/x/mongo/driver/auth"
 	"go.mongodb.org/mongo-driver/x/mongo/driver/connstring"
+	"go.mongodb.org/mongo-driver/x/mongo/driver/dns"
 	"go.mongodb.org/mongo-driver/x/mongo/driver/operation"
 )
 
@@ -33,12 +34,18 @@ type config struct {
 	seedList               []string
 	serverOpts             []ServerOption
 	cs                     connstring.ConnString // This must not be used for any logic in topology.Topology.
+	connStringOpts         *connStringServerOptions
 	uri                    string
 	serverSelectionTimeout time.Duration
 	serverMonitor          *event.ServerMonitor
 	srvMaxHosts            int
 	srvServiceName         string
 	loadBalanced           bool
+	compatibilityMode      CompatibilityMode
+	mongosLoadScorer       MongosLoadScorer
+	selectionObserver      func(ServerSelectionEvent)
+	globalMaxConnections   int
+	dnsResolver            *dns.Resolver
 }
 
 func newConfig(opts ...Option) (*config, error) {
@@ -57,7 +64,10 @@ func newConfig(opts ...Option) (*config, error) {
 	return cfg, nil
 }
 
-// WithConnString configures the topology using the connection string.
+// WithConnString configures the topology using the connection string. fn is passed the connection string built by
+// any previous WithConnString options, so multiple WithConnString options are applied in order and each can change
+// only the fields it needs. The server options derived from the resulting connection string replace those derived by
+// previous WithConnString options and are applied at the position of the last WithConnString option.
 func WithConnString(fn func(connstring.ConnString) connstring.ConnString) Option {
 	return func(c *config) error {
 		cs := fn(c.cs)
@@ -67,10 +77,11 @@ func WithConnString(fn func(connstring.ConnString) connstring.ConnString) Option
 			c.serverSelectionTimeout = cs.ServerSelectionTimeout
 		}
 
+		var serverOpts []ServerOption
 		var connOpts []ConnectionOption
 
 		if cs.AppName != "" {
-			c.serverOpts = append(c.serverOpts, WithServerAppName(func(string) string { return cs.AppName }))
+			serverOpts = append(serverOpts, WithServerAppName(func(string) string { return cs.AppName }))
 		}
 
 		if cs.Connect == connstring.SingleConnect || (cs.DirectConnectionSet && cs.DirectConnection) {
@@ -80,7 +91,7 @@ func WithConnString(fn func(connstring.ConnString) connstring.ConnString) Option
 		c.seedList = cs.Hosts
 
 		if cs.ConnectTimeout > 0 {
-			c.serverOpts = append(c.serverOpts, WithHeartbeatTimeout(func(time.Duration) time.Duration { return cs.ConnectTimeout }))
+			serverOpts = append(serverOpts, WithHeartbeatTimeout(func(time.Duration) time.Duration { return cs.ConnectTimeout }))
 			connOpts = append(connOpts, WithConnectTimeout(func(time.Duration) time.Duration { return cs.ConnectTimeout }))
 		}
 
@@ -93,7 +104,7 @@ func WithConnString(fn func(connstring.ConnString) connstring.ConnString) Option
 		}
 
 		if cs.HeartbeatInterval > 0 {
-			c.serverOpts = append(c.serverOpts, WithHeartbeatInterval(func(time.Duration) time.Duration { return cs.HeartbeatInterval }))
+			serverOpts = append(serverOpts, WithHeartbeatInterval(func(time.Duration) time.Duration { return cs.HeartbeatInterval }))
 		}
 
 		if cs.MaxConnIdleTime > 0 {
@@ -101,11 +112,11 @@ func WithConnString(fn func(connstring.ConnString) connstring.ConnString) Option
 		}
 
 		if cs.MaxPoolSizeSet {
-			c.serverOpts = append(c.serverOpts, WithMaxConnections(func(uint64) uint64 { return cs.MaxPoolSize }))
+			serverOpts = append(serverOpts, WithMaxConnections(func(uint64) uint64 { return cs.MaxPoolSize }))
 		}
 
 		if cs.MinPoolSizeSet {
-			c.serverOpts = append(c.serverOpts, WithMinConnections(func(u uint64) uint64 { return cs.MinPoolSize }))
+			serverOpts = append(serverOpts, WithMinConnections(func(u uint64) uint64 { return cs.MinPoolSize }))
 		}
 
 		if cs.ReplicaSet != "" {
@@ -226,7 +237,7 @@ func WithConnString(fn func(connstring.ConnString) connstring.ConnString) Option
 				}
 			}
 
-			c.serverOpts = append(c.serverOpts, WithCompressionOptions(func(opts ...string) []string {
+			serverOpts = append(serverOpts, WithCompressionOptions(func(opts ...string) []string {
 				return append(opts, cs.Compressors...)
 			}))
 		}
@@ -234,7 +245,7 @@ func WithConnString(fn func(connstring.ConnString) connstring.ConnString) Option
 		// LoadBalanced
 		if cs.LoadBalancedSet {
 			c.loadBalanced = cs.LoadBalanced
-			c.serverOpts = append(c.serverOpts, WithServerLoadBalanced(func(bool) bool {
+			serverOpts = append(serverOpts, WithServerLoadBalanced(func(bool) bool {
 				return cs.LoadBalanced
 			}))
 			connOpts = append(connOpts, WithConnectionLoadBalanced(func(bool) bool {
@@ -243,15 +254,36 @@ func WithConnString(fn func(connstring.ConnString) connstring.ConnString) Option
 		}
 
 		if len(connOpts) > 0 {
-			c.serverOpts = append(c.serverOpts, WithConnectionOptions(func(opts ...ConnectionOption) []ConnectionOption {
+			serverOpts = append(serverOpts, WithConnectionOptions(func(opts ...ConnectionOption) []ConnectionOption {
 				return append(opts, connOpts...)
 			}))
 		}
 
+		if c.connStringOpts != nil {
+			c.connStringOpts.opts = nil
+		}
+		c.connStringOpts = &connStringServerOptions{opts: serverOpts}
+		c.serverOpts = append(c.serverOpts, c.connStringOpts.apply)
+
 		return nil
 	}
 }
 
+// connStringServerOptions holds the server options derived from a connection string. They are applied through a
+// single ServerOption so that a later WithConnString option can discard them.
+type connStringServerOptions struct {
+	opts []ServerOption
+}
+
+func (o *connStringServerOptions) apply(cfg *serverConfig) error {
+	for _, opt := range o.opts {
+		if err := opt(cfg); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
 // WithMode configures the topology's monitor mode.
 func WithMode(fn func(MonitorMode) MonitorMode) Option {
 	return func(cfg *config) error {
@@ -287,6 +319,9 @@ func WithServerOptions(fn func(...ServerOption) []ServerOption) Option {
 
 // WithServerSelectionTimeout configures a topology's server selection timeout.
 // A server selection timeout of 0 means there is no timeout for server selection.
+// The timeout only bounds server selection and is independent of socket and operation
+// timeouts. Options are applied in order, so this overrides the value set by an earlier
+// WithConnString option.
 func WithServerSelectionTimeout(fn func(time.Duration) time.Duration) Option {
 	return func(cfg *config) error {
 		cfg.serverSelectionTimeout = fn(cfg.serverSelectionTimeout)
@@ -318,6 +353,34 @@ func WithLoadBalanced(fn func(bool) bool) Option {
 	}
 }
 
+// WithCompatibilityMode specifies how server selection behaves when the topology has a wire version compatibility
+// error. The default is CompatibilityStrict.
+func WithCompatibilityMode(fn func(CompatibilityMode) CompatibilityMode) Option {
+	return func(cfg *config) error {
+		cfg.compatibilityMode = fn(cfg.compatibilityMode)
+		return nil
+	}
+}
+
+// WithMongosLoadScorer specifies a MongosLoadScorer that is used to prefer less loaded mongoses during server selection
+// for sharded topologies. By default, a suitable mongos is selected at random.
+func WithMongosLoadScorer(fn func(MongosLoadScorer) MongosLoadScorer) Option {
+	return func(cfg *config) error {
+		cfg.mongosLoadScorer = fn(cfg.mongosLoadScorer)
+		return nil
+	}
+}
+
+// WithServerSelectionObserver specifies a callback that is called once for every call to Topology.SelectServer,
+// including calls that fail, with a ServerSelectionEvent describing how selection went. The callback is called after
+// selection has finished and before SelectServer returns, so it should return quickly.
+func WithServerSelectionObserver(fn func(func(ServerSelectionEvent)) func(ServerSelectionEvent)) Option {
+	return func(cfg *config) error {
+		cfg.selectionObserver = fn(cfg.selectionObserver)
+		return nil
+	}
+}
+
 // WithSRVMaxHosts specifies the SRV host limit that was used to create the topology.
 func WithSRVMaxHosts(fn func(int) int) Option {
 	return func(cfg *config) error {
@@ -326,6 +389,22 @@ func WithSRVMaxHosts(fn func(int) int) Option {
 	}
 }
 
+// WithGlobalMaxConnections limits the total number of connections held by the connection pools of all servers in the
+// topology to n, in addition to the per-server limit set by WithMaxConnections. When the limit is reached, checking out
+// a connection that requires a new connection to be created closes the least recently used idle connection to another
+// server to make room. If no other server has an idle connection, the checkOut waits until a connection to any server
+// is closed or made idle, in the same way as when a single pool is full. A value of zero means there is no
+// topology-wide limit, which is the default.
+func WithGlobalMaxConnections(n int) Option {
+	return func(cfg *config) error {
+		if n < 0 {
+			return fmt.Errorf("global max connections must be non-negative, got %d", n)
+		}
+		cfg.globalMaxConnections = n
+		return nil
+	}
+}
+
 // WithSRVServiceName specifies the SRV service name that was used to create the topology.
 func WithSRVServiceName(fn func(string) string) Option {
 	return func(cfg *config) error {
@@ -334,6 +413,18 @@ func WithSRVServiceName(fn func(string) string) Option {
 	}
 }
 
+// WithDNSResolver configures the resolver used to look up the SRV records of a mongodb+srv URI when polling for changes
+// to its hosts. fn is passed nil if no resolver was configured by a previous WithDNSResolver option, and the system
+// resolver is used if fn returns nil. The initial hosts are resolved when the connection string is parsed, so a
+// connection string passed to WithConnString should be parsed with connstring.ParseAndValidateWithResolver using the
+// same resolver.
+func WithDNSResolver(fn func(*dns.Resolver) *dns.Resolver) Option {
+	return func(cfg *config) error {
+		cfg.dnsResolver = fn(cfg.dnsResolver)
+		return nil
+	}
+}
+
 // addCACertFromFile adds a root CA certificate to the configuration given a path
 // to the containing file.
 func addCACertFromFile(cfg *tls.Config, file string) error {
@@ -7,11 +7,13 @@
 package topology
 
 import (
+	"context"
 	"fmt"
 	"testing"
 	"time"
 
 	"github.com/stretchr/testify/assert"
+	"go.mongodb.org/mongo-driver/mongo/description"
 	"go.mongodb.org/mongo-driver/x/mongo/driver/connstring"
 )
 
@@ -39,6 +41,38 @@ func TestOptionsSetting(t *testing.T) {
 	assert.Equal(t, name, serverConf.appname, "expected appname to be: %v, got: %v", name, serverConf.appname)
 }
 
+func TestWithConnStringComposes(t *testing.T) {
+	cs, err := connstring.ParseAndValidate("mongodb://localhost/?compressors=zlib&heartbeatFrequencyMS=60000")
+	assert.Nil(t, err, "connstring.ParseAndValidate error: %v", err)
+
+	topo, err := New(
+		WithConnString(func(connstring.ConnString) connstring.ConnString { return cs }),
+		WithConnString(func(cs connstring.ConnString) connstring.ConnString {
+			cs.MinPoolSize = 5
+			cs.MinPoolSizeSet = true
+			return cs
+		}),
+		WithConnString(func(cs connstring.ConnString) connstring.ConnString {
+			cs.HeartbeatInterval = 20 * time.Second
+			return cs
+		}),
+	)
+	assert.Nil(t, err, "topology.New error: %v", err)
+
+	serverConf, err := newServerConfig(topo.cfg.serverOpts...)
+	assert.Nil(t, err, "newServerConfig error: %v", err)
+	assert.Equal(t, uint64(5), serverConf.minConns, "expected minConns 5, got %v", serverConf.minConns)
+	assert.Equal(t, 20*time.Second, serverConf.heartbeatInterval, "expected heartbeat interval 20s, got %v",
+		serverConf.heartbeatInterval)
+
+	// Settings that append to a list are only applied once.
+	assert.Equal(t, []string{"zlib"}, serverConf.compressionOpts, "expected compression options [zlib], got %v",
+		serverConf.compressionOpts)
+	connConf := newConnectionConfig(serverConf.connectionOpts...)
+	assert.Equal(t, []string{"zlib"}, connConf.compressors, "expected compressors [zlib], got %v",
+		connConf.compressors)
+}
+
 func TestDirectConnectionFromConnString(t *testing.T) {
 	singleConnect := connstring.ConnString{
 		Connect:    connstring.SingleConnect,
@@ -107,3 +141,38 @@ func TestLoadBalancedFromConnString(t *testing.T) {
 		})
 	}
 }
+
+func TestServerSelectionTimeoutOverridesConnString(t *testing.T) {
+	// The connection string sets a long server selection timeout and socket timeout, as would be used for slow
+	// queries. WithServerSelectionTimeout shortens only the server selection timeout.
+	cs, err := connstring.ParseAndValidate(
+		"mongodb://localhost:27017/?serverSelectionTimeoutMS=30000&socketTimeoutMS=60000")
+	assert.Nil(t, err, "connstring.ParseAndValidate error: %v", err)
+
+	const ssTimeout = 100 * time.Millisecond
+	topo, err := New(
+		WithConnString(func(connstring.ConnString) connstring.ConnString { return cs }),
+		WithServerSelectionTimeout(func(time.Duration) time.Duration { return ssTimeout }),
+		WithServerOptions(func(opts ...ServerOption) []ServerOption {
+			return append(opts, withMonitoringDisabled(func(bool) bool { return true }))
+		}),
+	)
+	assert.Nil(t, err, "topology.New error: %v", err)
+	assert.Equal(t, ssTimeout, topo.cfg.serverSelectionTimeout, "expected server selection timeout %v, got %v",
+		ssTimeout, topo.cfg.serverSelectionTimeout)
+
+	assert.Nil(t, topo.Connect(), "Connect error")
+	defer func() { _ = topo.Disconnect(context.Background()) }()
+
+	// Monitoring is disabled, so the server stays Unknown and selection can only end when the timeout fires.
+	start := time.Now()
+	_, err = topo.SelectServer(context.Background(), description.WriteSelector())
+	elapsed := time.Since(start)
+
+	sse, ok := err.(ServerSelectionError)
+	assert.True(t, ok, "expected error type %T, got %T", ServerSelectionError{}, err)
+	assert.Equal(t, ErrServerSelectionTimeout, sse.Wrapped, "expected wrapped error %v, got %v",
+		ErrServerSelectionTimeout, sse.Wrapped)
+	assert.True(t, elapsed >= ssTimeout, "expected selection to take at least %v, took %v", ssTimeout, elapsed)
+	assert.True(t, elapsed < 5*time.Second, "expected selection to fail after about %v, took %v", ssTimeout, elapsed)
+}
@@ -10,14 +10,20 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"math/rand"
+	"net"
 	"sync/atomic"
 	"testing"
 	"time"
 
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/event"
 	"go.mongodb.org/mongo-driver/internal"
+	"go.mongodb.org/mongo-driver/internal/randutil"
 	"go.mongodb.org/mongo-driver/internal/testutil/assert"
 	"go.mongodb.org/mongo-driver/mongo/address"
 	"go.mongodb.org/mongo-driver/mongo/description"
+	"go.mongodb.org/mongo-driver/mongo/readpref"
 	"go.mongodb.org/mongo-driver/x/mongo/driver"
 	"go.mongodb.org/mongo-driver/x/mongo/driver/connstring"
 )
@@ -134,6 +140,119 @@ func TestServerSelection(t *testing.T) {
 		_, err = topo.SelectServer(context.Background(), selectFirst)
 		assert.Equal(t, err, want, "expected %v, got %v", want, err)
 	})
+	t.Run("Compatibility Error Warn Read Only", func(t *testing.T) {
+		servers := []description.Server{
+			{Addr: address.Address("one:27017"), Kind: description.Standalone, WireVersion: &description.VersionRange{Max: 11, Min: 11}},
+			{Addr: address.Address("two:27017"), Kind: description.Standalone, WireVersion: &description.VersionRange{Max: 1, Min: 1}},
+			{Addr: address.Address("three:27017"), Kind: description.Standalone, WireVersion: &description.VersionRange{Max: 9, Min: 2}},
+		}
+		testCases := []struct {
+			name string
+			want error
+		}{
+			{
+				"min version too high",
+				fmt.Errorf(
+					"server at %s requires wire version %d, but this version of the Go driver only supports up to %d",
+					servers[0].Addr.String(),
+					servers[0].WireVersion.Min,
+					SupportedWireVersions.Max,
+				),
+			},
+			{
+				"max version too low",
+				fmt.Errorf(
+					"server at %s reports wire version %d, but this version of the Go driver requires "+
+						"at least %d (MongoDB %s)",
+					servers[1].Addr.String(),
+					servers[1].WireVersion.Max,
+					SupportedWireVersions.Min,
+					MinSupportedMongoDBVersion,
+				),
+			},
+		}
+		for _, tc := range testCases {
+			t.Run(tc.name, func(t *testing.T) {
+				topo, err := New(
+					WithCompatibilityMode(func(CompatibilityMode) CompatibilityMode {
+						return CompatibilityWarnReadOnly
+					}),
+				)
+				noerr(t, err)
+				desc := description.Topology{
+					Kind:             description.Single,
+					Servers:          servers,
+					CompatibilityErr: tc.want,
+				}
+
+				srvs, err := topo.selectServerFromDescription(desc, newServerSelectionState(selectFirst, nil))
+				assert.Nil(t, err, "expected no error for read selection, got %v", err)
+				assert.Equal(t, 1, len(srvs), "expected 1 server, got %d", len(srvs))
+
+				writeSelectors := []description.ServerSelector{
+					description.CompositeSelector([]description.ServerSelector{description.WriteSelector(), selectFirst}),
+					description.CompositeSelector([]description.ServerSelector{selectFirst, description.MayWriteSelector()}),
+				}
+				for _, ss := range writeSelectors {
+					_, err = topo.selectServerFromDescription(desc, newServerSelectionState(ss, nil))
+					assert.Equal(t, tc.want, err, "expected %v, got %v", tc.want, err)
+				}
+			})
+		}
+	})
+	t.Run("Compatibility Warning", func(t *testing.T) {
+		addr := address.Address("one:27017")
+		newTopology := func(t *testing.T, mode CompatibilityMode, warnings *[]*event.TopologyCompatibilityWarningEvent) *Topology {
+			t.Helper()
+
+			monitor := &event.ServerMonitor{
+				TopologyCompatibilityWarning: func(evt *event.TopologyCompatibilityWarningEvent) {
+					*warnings = append(*warnings, evt)
+				},
+			}
+			topo, err := New(
+				WithCompatibilityMode(func(CompatibilityMode) CompatibilityMode { return mode }),
+				WithTopologyServerMonitor(func(*event.ServerMonitor) *event.ServerMonitor { return monitor }),
+			)
+			noerr(t, err)
+			topo.fsm.Kind = description.Single
+			topo.fsm.Servers = []description.Server{{Addr: addr}}
+			return topo
+		}
+		server := func(wireVersion description.VersionRange) description.Server {
+			return description.Server{Addr: addr, Kind: description.Standalone, WireVersion: &wireVersion}
+		}
+		tooLow := server(description.VersionRange{Min: 1, Max: 1})
+		tooHigh := server(description.VersionRange{Min: 100, Max: 100})
+		compatible := server(SupportedWireVersions)
+
+		t.Run("published once per error", func(t *testing.T) {
+			var warnings []*event.TopologyCompatibilityWarningEvent
+			topo := newTopology(t, CompatibilityWarnReadOnly, &warnings)
+
+			topo.apply(context.Background(), tooLow)
+			topo.apply(context.Background(), tooLow)
+			assert.Equal(t, 1, len(warnings), "expected 1 warning while the error is unchanged, got %d", len(warnings))
+			assert.Equal(t, topo.fsm.compatibilityErr, warnings[0].Error,
+				"expected warning error %v, got %v", topo.fsm.compatibilityErr, warnings[0].Error)
+
+			topo.apply(context.Background(), tooHigh)
+			assert.Equal(t, 2, len(warnings), "expected a warning when the error changes, got %d", len(warnings))
+
+			topo.apply(context.Background(), compatible)
+			assert.Equal(t, 2, len(warnings), "expected no warning when compatible, got %d", len(warnings))
+
+			topo.apply(context.Background(), tooHigh)
+			assert.Equal(t, 3, len(warnings), "expected a warning when incompatible again, got %d", len(warnings))
+		})
+		t.Run("not published in strict mode", func(t *testing.T) {
+			var warnings []*event.TopologyCompatibilityWarningEvent
+			topo := newTopology(t, CompatibilityStrict, &warnings)
+
+			topo.apply(context.Background(), tooLow)
+			assert.Equal(t, 0, len(warnings), "expected no warnings, got %d", len(warnings))
+		})
+	})
 	t.Run("Updated", func(t *testing.T) {
 		topo, err := New()
 		noerr(t, err)
@@ -210,8 +329,41 @@ func TestServerSelection(t *testing.T) {
 			t.Errorf("Timed out while trying to retrieve selected servers")
 		}
 
-		want := ServerSelectionError{Wrapped: context.Canceled, Desc: desc}
-		assert.Equal(t, err, want, "Incorrect error received. got %v; want %v", err, want)
+		sse, ok := err.(ServerSelectionError)
+		assert.True(t, ok, "expected error type %T, got %T", ServerSelectionError{}, err)
+		assert.Equal(t, context.Canceled, sse.Wrapped, "expected wrapped error %v, got %v", context.Canceled, sse.Wrapped)
+		assert.Equal(t, desc, sse.Desc, "expected topology description %v, got %v", desc, sse.Desc)
+		assert.Equal(t, 0, sse.Updates, "expected 0 topology updates, got %v", sse.Updates)
+		assert.True(t, sse.Duration >= 100*time.Millisecond, "expected duration of at least 100ms, got %v", sse.Duration)
+	})
+	t.Run("Updates", func(t *testing.T) {
+		desc := description.Topology{
+			Servers: []description.Server{
+				{Addr: address.Address("one"), Kind: description.Standalone},
+			},
+		}
+		topo, err := New()
+		noerr(t, err)
+		subCh := make(chan description.Topology)
+		resp := make(chan error)
+		ctx, cancel := context.WithCancel(context.Background())
+		go func() {
+			state := newServerSelectionState(selectNone, nil)
+			_, err := topo.selectServerFromSubscription(ctx, subCh, state)
+			resp <- err
+		}()
+
+		// The first description is the one Subscribe pre-populates the subscription with, so only the next two are
+		// counted as updates.
+		for i := 0; i < 3; i++ {
+			subCh <- desc
+		}
+		cancel()
+		err = <-resp
+
+		sse, ok := err.(ServerSelectionError)
+		assert.True(t, ok, "expected error type %T, got %T", ServerSelectionError{}, err)
+		assert.Equal(t, 2, sse.Updates, "expected 2 topology updates, got %v", sse.Updates)
 	})
 	t.Run("Timeout", func(t *testing.T) {
 		desc := description.Topology{
@@ -248,6 +400,11 @@ func TestServerSelection(t *testing.T) {
 		if err == nil {
 			t.Fatalf("did not receive error from server selection")
 		}
+		sse, ok := err.(ServerSelectionError)
+		assert.True(t, ok, "expected error type %T, got %T", ServerSelectionError{}, err)
+		assert.Equal(t, ErrServerSelectionTimeout, sse.Wrapped, "expected wrapped error %v, got %v",
+			ErrServerSelectionTimeout, sse.Wrapped)
+		assert.True(t, sse.Duration > 0, "expected a positive duration, got %v", sse.Duration)
 	})
 	t.Run("Error", func(t *testing.T) {
 		desc := description.Topology{
@@ -409,6 +566,619 @@ func TestServerSelection(t *testing.T) {
 		_, err = topo.SelectServer(context.Background(), description.WriteSelector())
 		assert.Equal(t, ErrSubscribeAfterClosed, err, "expected error %v, got %v", ErrSubscribeAfterClosed, err)
 	})
+	t.Run("mongos load scorer", func(t *testing.T) {
+		loads := map[address.Address]float64{
+			"low:27017":    1,
+			"medium:27017": 5,
+			"high:27017":   10,
+		}
+		scorer := MongosLoadScorerFunc(func(server description.Server) float64 {
+			return loads[server.Addr]
+		})
+		newTopology := func(t *testing.T, addrs ...address.Address) *Topology {
+			t.Helper()
+
+			topo, err := New(WithMongosLoadScorer(func(MongosLoadScorer) MongosLoadScorer { return scorer }))
+			noerr(t, err)
+			atomic.StoreInt64(&topo.state, topologyConnected)
+			// Use a fixed seed so the servers chosen at random, and therefore the selection counts, are deterministic.
+			topo.random = randutil.NewLockedRand(rand.NewSource(1))
+
+			desc := description.Topology{Kind: description.Sharded}
+			for _, addr := range addrs {
+				desc.Servers = append(desc.Servers, description.Server{Addr: addr, Kind: description.Mongos})
+			}
+			topo.desc.Store(desc)
+			for _, srv := range desc.Servers {
+				s, err := ConnectServer(srv.Addr, topo.updateCallback, topo.id,
+					withMonitoringDisabled(func(bool) bool { return true }))
+				noerr(t, err)
+				topo.servers[srv.Addr] = s
+			}
+			return topo
+		}
+		selectMany := func(t *testing.T, topo *Topology) map[address.Address]int {
+			t.Helper()
+
+			counts := make(map[address.Address]int)
+			for i := 0; i < 100; i++ {
+				selected, err := topo.SelectServer(context.Background(), description.WriteSelector())
+				noerr(t, err)
+				counts[selected.(*SelectedServer).address]++
+			}
+			return counts
+		}
+
+		t.Run("lower load is preferred", func(t *testing.T) {
+			topo := newTopology(t, "low:27017", "high:27017")
+			counts := selectMany(t, topo)
+			assert.Equal(t, 100, counts["low:27017"], "expected the lower-loaded mongos to always be selected, got %v",
+				counts)
+		})
+		t.Run("highest load is never selected", func(t *testing.T) {
+			topo := newTopology(t, "low:27017", "medium:27017", "high:27017")
+			counts := selectMany(t, topo)
+			assert.Equal(t, 0, counts["high:27017"], "expected the highest-loaded mongos not to be selected, got %v",
+				counts)
+			assert.True(t, counts["low:27017"] > counts["medium:27017"],
+				"expected the lowest-loaded mongos to be selected most often, got %v", counts)
+		})
+		t.Run("not used for non-mongos servers", func(t *testing.T) {
+			var calls int32
+			topo := newTopology(t)
+			topo.cfg.mongosLoadScorer = MongosLoadScorerFunc(func(description.Server) float64 {
+				atomic.AddInt32(&calls, 1)
+				return 0
+			})
+			got := topo.pickServer([]description.Server{
+				{Addr: "one:27017", Kind: description.RSSecondary},
+				{Addr: "two:27017", Kind: description.RSSecondary},
+			}, false)
+			assert.NotEqual(t, address.Address(""), got.Addr, "expected a server to be selected")
+			assert.Equal(t, int32(0), atomic.LoadInt32(&calls), "expected scorer not to be called, got %v calls", calls)
+		})
+	})
+	t.Run("not connected", func(t *testing.T) {
+		topo, err := New()
+		noerr(t, err)
+
+		ctx, cancel := context.WithTimeout(context.Background(), time.Second)
+		defer cancel()
+		_, err = topo.SelectServer(ctx, description.WriteSelector())
+		assert.Equal(t, ErrTopologyNotConnected, err, "expected error %v, got %v", ErrTopologyNotConnected, err)
+
+		noerr(t, topo.Connect())
+		noerr(t, topo.Disconnect(context.Background()))
+		_, err = topo.SelectServer(ctx, description.WriteSelector())
+		assert.Equal(t, ErrTopologyClosed, err, "expected error %v, got %v", ErrTopologyClosed, err)
+	})
+}
+
+func TestPrimaryBoost(t *testing.T) {
+	one := address.Address("one").Canonicalize()
+	two := address.Address("two").Canonicalize()
+	three := address.Address("three").Canonicalize()
+	members := []address.Address{one, two, three}
+
+	newTopology := func(t *testing.T) *Topology {
+		t.Helper()
+
+		topo, err := New()
+		noerr(t, err)
+		atomic.StoreInt64(&topo.state, topologyConnected)
+		topo.random = randutil.NewLockedRand(rand.NewSource(1))
+		topo.fsm.Kind = description.ReplicaSetNoPrimary
+		topo.fsm.SetName = "rs"
+		for _, addr := range members {
+			s, err := ConnectServer(addr, topo.updateCallback, topo.id,
+				withMonitoringDisabled(func(bool) bool { return true }))
+			noerr(t, err)
+			topo.servers[addr] = s
+			topo.fsm.Servers = append(topo.fsm.Servers, description.Server{Addr: addr})
+		}
+		topo.desc.Store(topo.fsm.Topology)
+		return topo
+	}
+	primary := func(addr address.Address) description.Server {
+		return description.Server{
+			Addr:          addr,
+			CanonicalAddr: addr,
+			Kind:          description.RSPrimary,
+			SetName:       "rs",
+			Members:       members,
+		}
+	}
+	secondary := func(addr address.Address) description.Server {
+		return description.Server{
+			Addr:          addr,
+			CanonicalAddr: addr,
+			Kind:          description.RSSecondary,
+			SetName:       "rs",
+			Members:       members,
+		}
+	}
+	// discover applies the initial discovery of the replica set with "one" as the primary.
+	discover := func(topo *Topology) {
+		topo.apply(context.Background(), primary(one))
+		topo.apply(context.Background(), secondary(two))
+		topo.apply(context.Background(), secondary(three))
+	}
+	// failover makes the primary "one" step down and elects "two".
+	failover := func(topo *Topology) {
+		topo.apply(context.Background(), description.Server{Addr: one, Kind: description.Unknown})
+		topo.apply(context.Background(), primary(two))
+		topo.apply(context.Background(), secondary(one))
+	}
+	suitable := func(addrs ...address.Address) []description.Server {
+		servers := make([]description.Server, 0, len(addrs))
+		for _, addr := range addrs {
+			servers = append(servers, description.Server{Addr: addr})
+		}
+		return servers
+	}
+
+	t.Run("initial discovery is not boosted", func(t *testing.T) {
+		topo := newTopology(t)
+		discover(topo)
+
+		_, ok := topo.boostedPrimary()
+		assert.False(t, ok, "expected the first primary discovered not to be boosted")
+	})
+	t.Run("new primary is boosted after failover", func(t *testing.T) {
+		topo := newTopology(t)
+		discover(topo)
+		failover(topo)
+
+		addr, ok := topo.boostedPrimary()
+		assert.True(t, ok, "expected a boosted primary after failover")
+		assert.Equal(t, two, addr, "expected boosted primary %v, got %v", two, addr)
+	})
+	t.Run("boost applies to selection retries", func(t *testing.T) {
+		topo := newTopology(t)
+		discover(topo)
+		failover(topo)
+
+		for i := 0; i < 50; i++ {
+			got := topo.pickServer(suitable(one, two, three), true)
+			assert.Equal(t, two, got.Addr, "expected retried selection to pick %v, got %v", two, got.Addr)
+		}
+	})
+	t.Run("boost does not apply to first selection attempts", func(t *testing.T) {
+		topo := newTopology(t)
+		discover(topo)
+		failover(topo)
+
+		counts := make(map[address.Address]int)
+		for i := 0; i < 50; i++ {
+			counts[topo.pickServer(suitable(one, two, three), false).Addr]++
+		}
+		assert.True(t, counts[one] > 0 && counts[three] > 0,
+			"expected selections to be spread across suitable servers, got %v", counts)
+
+		for i := 0; i < 50; i++ {
+			selected, err := topo.SelectServer(context.Background(), description.ReadPrefSelector(readpref.Nearest()))
+			noerr(t, err)
+			counts[selected.(*SelectedServer).address]++
+		}
+		assert.True(t, counts[one] > 0 && counts[three] > 0,
+			"expected nearest reads to be spread across suitable servers, got %v", counts)
+	})
+	t.Run("boost never chooses an unsuitable server", func(t *testing.T) {
+		topo := newTopology(t)
+		discover(topo)
+		failover(topo)
+
+		for i := 0; i < 50; i++ {
+			got := topo.pickServer(suitable(one, three), true)
+			assert.NotEqual(t, two, got.Addr, "expected the boosted primary not to be chosen when unsuitable")
+		}
+	})
+	t.Run("boost is cleared when the primary steps down", func(t *testing.T) {
+		topo := newTopology(t)
+		discover(topo)
+		failover(topo)
+		topo.apply(context.Background(), secondary(two))
+
+		_, ok := topo.boostedPrimary()
+		assert.False(t, ok, "expected no boosted primary after it stepped down")
+	})
+	t.Run("boost expires", func(t *testing.T) {
+		topo := newTopology(t)
+		discover(topo)
+		failover(topo)
+		topo.promoted.Store(promotedPrimary{addr: two, at: time.Now().Add(-primaryBoostWindow)})
+
+		_, ok := topo.boostedPrimary()
+		assert.False(t, ok, "expected no boosted primary after the boost window")
+	})
+	t.Run("stale primary is not boosted", func(t *testing.T) {
+		topo := newTopology(t)
+		electionID := primitive.NewObjectID()
+		topo.apply(context.Background(), description.Server{
+			Addr:       one,
+			Kind:       description.RSPrimary,
+			SetName:    "rs",
+			SetVersion: 1,
+			ElectionID: electionID,
+			Members:    members,
+		})
+		topo.apply(context.Background(), description.Server{
+			Addr:       two,
+			Kind:       description.RSPrimary,
+			SetName:    "rs",
+			SetVersion: 1,
+			ElectionID: primitive.ObjectID{11: 1}, // older than electionID
+			Members:    members,
+		})
+		_, ok := topo.boostedPrimary()
+		assert.False(t, ok, "expected a stale primary not to be boosted")
+	})
+}
+
+func TestServerSelectionObserver(t *testing.T) {
+	primary := address.Address("primary:27017")
+	secondary := address.Address("secondary:27017")
+
+	newTopology := func(t *testing.T, events *[]ServerSelectionEvent, opts ...Option) *Topology {
+		t.Helper()
+
+		opts = append(opts, WithServerSelectionObserver(func(func(ServerSelectionEvent)) func(ServerSelectionEvent) {
+			return func(evt ServerSelectionEvent) {
+				*events = append(*events, evt)
+			}
+		}))
+		topo, err := New(opts...)
+		noerr(t, err)
+		atomic.StoreInt64(&topo.state, topologyConnected)
+
+		desc := description.Topology{
+			Kind: description.ReplicaSetWithPrimary,
+			Servers: []description.Server{
+				{Addr: primary, Kind: description.RSPrimary},
+				{Addr: secondary, Kind: description.RSSecondary},
+				{Addr: "unknown:27017", Kind: description.Unknown},
+			},
+		}
+		topo.desc.Store(desc)
+		for _, srv := range desc.Servers {
+			s, err := ConnectServer(srv.Addr, topo.updateCallback, topo.id,
+				withMonitoringDisabled(func(bool) bool { return true }))
+			noerr(t, err)
+			topo.servers[srv.Addr] = s
+		}
+		return topo
+	}
+
+	t.Run("success", func(t *testing.T) {
+		var events []ServerSelectionEvent
+		topo := newTopology(t, &events)
+
+		selected, err := topo.SelectServer(context.Background(), description.WriteSelector())
+		noerr(t, err)
+		assert.Equal(t, primary, selected.(*SelectedServer).address, "expected %v to be selected, got %v", primary,
+			selected.(*SelectedServer).address)
+
+		assert.Equal(t, 1, len(events), "expected 1 event, got %v", len(events))
+		evt := events[0]
+		assert.True(t, evt.FastPath, "expected fast path to be used")
+		assert.Equal(t, 2, evt.Candidates, "expected 2 candidates, got %v", evt.Candidates)
+		assert.Equal(t, primary, evt.Selected, "expected selected address %v, got %v", primary, evt.Selected)
+		assert.Nil(t, evt.Err, "expected no error, got %v", evt.Err)
+		assert.True(t, evt.Duration >= 0, "expected a non-negative duration, got %v", evt.Duration)
+	})
+	t.Run("timeout", func(t *testing.T) {
+		var events []ServerSelectionEvent
+		topo := newTopology(t, &events,
+			WithServerSelectionTimeout(func(time.Duration) time.Duration { return 50 * time.Millisecond }))
+		var selectNone description.ServerSelectorFunc = func(description.Topology, []description.Server) ([]description.Server, error) {
+			return nil, nil
+		}
+
+		_, err := topo.SelectServer(context.Background(), selectNone)
+		assert.NotNil(t, err, "expected SelectServer error, got nil")
+
+		assert.Equal(t, 1, len(events), "expected 1 event, got %v", len(events))
+		evt := events[0]
+		assert.Equal(t, err, evt.Err, "expected error %v, got %v", err, evt.Err)
+		assert.False(t, evt.FastPath, "expected fast path not to be used")
+		assert.Equal(t, address.Address(""), evt.Selected, "expected no selected address, got %v", evt.Selected)
+		assert.True(t, evt.Duration >= 50*time.Millisecond, "expected duration of at least 50ms, got %v", evt.Duration)
+	})
+	t.Run("selector error", func(t *testing.T) {
+		var events []ServerSelectionEvent
+		topo := newTopology(t, &events)
+		selectorErr := errors.New("selector error")
+		var selectErr description.ServerSelectorFunc = func(description.Topology, []description.Server) ([]description.Server, error) {
+			return nil, selectorErr
+		}
+
+		_, err := topo.SelectServer(context.Background(), selectErr)
+		sse, ok := err.(ServerSelectionError)
+		assert.True(t, ok, "expected error type %T, got %T", ServerSelectionError{}, err)
+		assert.Equal(t, selectorErr, sse.Wrapped, "expected wrapped error %v, got %v", selectorErr, sse.Wrapped)
+
+		assert.Equal(t, 1, len(events), "expected 1 event, got %v", len(events))
+		assert.Equal(t, err, events[0].Err, "expected error %v, got %v", err, events[0].Err)
+		assert.Equal(t, 2, events[0].Candidates, "expected 2 candidates, got %v", events[0].Candidates)
+	})
+	t.Run("closed topology", func(t *testing.T) {
+		var events []ServerSelectionEvent
+		topo := newTopology(t, &events)
+		atomic.StoreInt32(&topo.connectedOnce, 1)
+		atomic.StoreInt64(&topo.state, topologyDisconnected)
+
+		_, err := topo.SelectServer(context.Background(), description.WriteSelector())
+		assert.Equal(t, ErrTopologyClosed, err, "expected error %v, got %v", ErrTopologyClosed, err)
+		assert.Equal(t, 1, len(events), "expected 1 event, got %v", len(events))
+		assert.Equal(t, ErrTopologyClosed, events[0].Err, "expected error %v, got %v", ErrTopologyClosed,
+			events[0].Err)
+	})
+	t.Run("not connected", func(t *testing.T) {
+		var events []ServerSelectionEvent
+		topo, err := New(WithServerSelectionObserver(func(func(ServerSelectionEvent)) func(ServerSelectionEvent) {
+			return func(evt ServerSelectionEvent) {
+				events = append(events, evt)
+			}
+		}))
+		noerr(t, err)
+
+		_, err = topo.SelectServer(context.Background(), description.WriteSelector())
+		assert.Equal(t, ErrTopologyNotConnected, err, "expected error %v, got %v", ErrTopologyNotConnected, err)
+		assert.Equal(t, 1, len(events), "expected 1 event, got %v", len(events))
+		assert.Equal(t, ErrTopologyNotConnected, events[0].Err, "expected error %v, got %v", ErrTopologyNotConnected,
+			events[0].Err)
+		assert.Equal(t, time.Duration(0), events[0].Duration, "expected zero duration, got %v", events[0].Duration)
+	})
+}
+
+func TestSelectServerMultiple(t *testing.T) {
+	var selectSecondaries description.ServerSelectorFunc = func(_ description.Topology, candidates []description.Server) ([]description.Server, error) {
+		var secondaries []description.Server
+		for _, s := range candidates {
+			if s.Kind == description.RSSecondary {
+				secondaries = append(secondaries, s)
+			}
+		}
+		return secondaries, nil
+	}
+	secondary := func(addr address.Address, rtt time.Duration) description.Server {
+		return description.Server{Addr: addr, Kind: description.RSSecondary, AverageRTT: rtt, AverageRTTSet: true}
+	}
+
+	newTopology := func(t *testing.T, servers ...description.Server) *Topology {
+		t.Helper()
+
+		topo, err := New(WithServerSelectionTimeout(func(time.Duration) time.Duration { return 50 * time.Millisecond }))
+		noerr(t, err)
+		atomic.StoreInt64(&topo.state, topologyConnected)
+
+		desc := description.Topology{Kind: description.ReplicaSetWithPrimary, Servers: servers}
+		topo.desc.Store(desc)
+		for _, srv := range desc.Servers {
+			s, err := ConnectServer(srv.Addr, topo.updateCallback, topo.id,
+				withMonitoringDisabled(func(bool) bool { return true }))
+			noerr(t, err)
+			topo.servers[srv.Addr] = s
+		}
+		return topo
+	}
+	addrs := func(selected []*SelectedServer) []address.Address {
+		var got []address.Address
+		for _, s := range selected {
+			got = append(got, s.address)
+		}
+		return got
+	}
+
+	t.Run("returns up to max servers ordered by round trip time", func(t *testing.T) {
+		topo := newTopology(t,
+			description.Server{Addr: "primary:27017", Kind: description.RSPrimary},
+			secondary("slow:27017", 30*time.Millisecond),
+			secondary("fast:27017", 10*time.Millisecond),
+			secondary("medium:27017", 20*time.Millisecond),
+		)
+
+		selected, err := topo.SelectServerMultiple(context.Background(), selectSecondaries, 2)
+		noerr(t, err)
+		want := []address.Address{"fast:27017", "medium:27017"}
+		assert.Equal(t, want, addrs(selected), "expected servers %v, got %v", want, addrs(selected))
+		assert.True(t, selected[0].Server != selected[1].Server, "expected distinct servers to be returned")
+		assert.Equal(t, description.ReplicaSetWithPrimary, selected[0].Kind, "expected topology kind %v, got %v",
+			description.ReplicaSetWithPrimary, selected[0].Kind)
+	})
+	t.Run("returns all suitable servers if fewer than max", func(t *testing.T) {
+		topo := newTopology(t,
+			description.Server{Addr: "primary:27017", Kind: description.RSPrimary},
+			secondary("slow:27017", 30*time.Millisecond),
+			secondary("fast:27017", 10*time.Millisecond),
+		)
+
+		selected, err := topo.SelectServerMultiple(context.Background(), selectSecondaries, 5)
+		noerr(t, err)
+		want := []address.Address{"fast:27017", "slow:27017"}
+		assert.Equal(t, want, addrs(selected), "expected servers %v, got %v", want, addrs(selected))
+	})
+	t.Run("waits for a suitable server", func(t *testing.T) {
+		topo := newTopology(t, description.Server{Addr: "primary:27017", Kind: description.RSPrimary})
+
+		_, err := topo.SelectServerMultiple(context.Background(), selectSecondaries, 2)
+		sse, ok := err.(ServerSelectionError)
+		assert.True(t, ok, "expected error type %T, got %T", ServerSelectionError{}, err)
+		assert.Equal(t, ErrServerSelectionTimeout, sse.Wrapped, "expected wrapped error %v, got %v",
+			ErrServerSelectionTimeout, sse.Wrapped)
+	})
+	t.Run("invalid max", func(t *testing.T) {
+		topo := newTopology(t, secondary("fast:27017", 10*time.Millisecond))
+
+		_, err := topo.SelectServerMultiple(context.Background(), selectSecondaries, 0)
+		assert.NotNil(t, err, "expected error for max 0, got nil")
+	})
+}
+
+func TestSelectServerWithTrace(t *testing.T) {
+	primary := description.Server{Addr: "primary:27017", Kind: description.RSPrimary}
+	unknown := description.Server{Addr: "unknown:27017", Kind: description.Unknown}
+	secondary := func(addr address.Address, rtt time.Duration) description.Server {
+		return description.Server{Addr: addr, Kind: description.RSSecondary, AverageRTT: rtt, AverageRTTSet: true}
+	}
+	near := secondary("near:27017", 5*time.Millisecond)
+	nearer := secondary("nearer:27017", 2*time.Millisecond)
+	far := secondary("far:27017", 50*time.Millisecond)
+
+	newTopology := func(t *testing.T, servers ...description.Server) *Topology {
+		t.Helper()
+
+		topo, err := New(WithServerSelectionTimeout(func(time.Duration) time.Duration { return 50 * time.Millisecond }))
+		noerr(t, err)
+		atomic.StoreInt64(&topo.state, topologyConnected)
+
+		desc := description.Topology{Kind: description.ReplicaSetWithPrimary, Servers: servers}
+		topo.desc.Store(desc)
+		for _, srv := range desc.Servers {
+			s, err := ConnectServer(srv.Addr, topo.updateCallback, topo.id,
+				withMonitoringDisabled(func(bool) bool { return true }))
+			noerr(t, err)
+			topo.servers[srv.Addr] = s
+		}
+		return topo
+	}
+	selector := func(rp *readpref.ReadPref) description.ServerSelector {
+		return description.CompositeSelector([]description.ServerSelector{
+			description.ReadPrefSelector(rp),
+			description.LatencySelector(15 * time.Millisecond),
+		})
+	}
+
+	t.Run("records the candidates after each stage", func(t *testing.T) {
+		topo := newTopology(t, primary, unknown, near, nearer, far)
+
+		selected, trace, err := topo.SelectServerWithTrace(context.Background(), selector(readpref.Secondary()))
+		noerr(t, err)
+
+		want := []description.Server{primary, near, nearer, far}
+		assert.Equal(t, want, trace.Candidates, "expected candidates %v, got %v", want, trace.Candidates)
+		want = []description.Server{near, nearer, far}
+		assert.Equal(t, want, trace.AfterReadPref, "expected servers after read preference %v, got %v", want,
+			trace.AfterReadPref)
+		want = []description.Server{near, nearer}
+		assert.Equal(t, want, trace.AfterLatency, "expected servers after latency window %v, got %v", want,
+			trace.AfterLatency)
+		assert.Equal(t, selected.address, trace.Selected, "expected selected address %v, got %v", selected.address,
+			trace.Selected)
+		assert.True(t, trace.Selected == near.Addr || trace.Selected == nearer.Addr,
+			"expected a server in the latency window to be selected, got %v", trace.Selected)
+	})
+	t.Run("returns the trace if selection fails", func(t *testing.T) {
+		topo := newTopology(t, primary, far)
+
+		_, trace, err := topo.SelectServerWithTrace(context.Background(), selector(readpref.Secondary(
+			readpref.WithTags("dc", "east"))))
+		assert.NotNil(t, err, "expected error, got nil")
+
+		want := []description.Server{primary, far}
+		assert.Equal(t, want, trace.Candidates, "expected candidates %v, got %v", want, trace.Candidates)
+		assert.Equal(t, 0, len(trace.AfterReadPref), "expected no servers after read preference, got %v",
+			trace.AfterReadPref)
+		assert.Equal(t, 0, len(trace.AfterLatency), "expected no servers after latency window, got %v",
+			trace.AfterLatency)
+		assert.Equal(t, address.Address(""), trace.Selected, "expected no server to be selected, got %v",
+			trace.Selected)
+	})
+	t.Run("returns ErrTopologyNotConnected before Connect", func(t *testing.T) {
+		topo, err := New()
+		noerr(t, err)
+
+		_, _, err = topo.SelectServerWithTrace(context.Background(), selector(readpref.Primary()))
+		assert.Equal(t, ErrTopologyNotConnected, err, "expected error %v, got %v", ErrTopologyNotConnected, err)
+	})
+}
+
+func TestSelectServerExcludedAddresses(t *testing.T) {
+	failed := address.Address("failed:27017")
+	other := address.Address("other:27017")
+	var selectStandalones description.ServerSelectorFunc = func(_ description.Topology, candidates []description.Server) ([]description.Server, error) {
+		var selected []description.Server
+		for _, s := range candidates {
+			if s.Kind == description.Standalone {
+				selected = append(selected, s)
+			}
+		}
+		return selected, nil
+	}
+
+	t.Run("retried selection never returns excluded server", func(t *testing.T) {
+		topo, err := New()
+		noerr(t, err)
+		atomic.StoreInt64(&topo.state, topologyConnected)
+		desc := description.Topology{
+			Kind: description.Sharded,
+			Servers: []description.Server{
+				{Addr: failed, Kind: description.Standalone},
+				{Addr: other, Kind: description.Standalone},
+			},
+		}
+		topo.desc.Store(desc)
+		for _, srv := range desc.Servers {
+			s, err := ConnectServer(srv.Addr, topo.updateCallback, topo.id,
+				withMonitoringDisabled(func(bool) bool { return true }))
+			noerr(t, err)
+			topo.servers[srv.Addr] = s
+		}
+
+		selector := WithExcludedAddresses(selectStandalones, failed)
+		for i := 0; i < 50; i++ {
+			srv, err := topo.SelectServer(context.Background(), selector)
+			noerr(t, err)
+			addr := srv.(*SelectedServer).address
+			assert.Equal(t, other, addr, "expected server %v to be selected, got %v", other, addr)
+		}
+	})
+	t.Run("subscription updates", func(t *testing.T) {
+		topo, err := New()
+		noerr(t, err)
+		subCh := make(chan description.Topology, 1)
+		resp := make(chan []description.Server, 1)
+		go func() {
+			state := newServerSelectionState(WithExcludedAddresses(selectStandalones, failed), nil)
+			srvs, err := topo.selectServerFromSubscription(context.Background(), subCh, state)
+			noerr(t, err)
+			resp <- srvs
+		}()
+
+		// The first update only contains the excluded server, so selection must keep waiting.
+		subCh <- description.Topology{Servers: []description.Server{{Addr: failed, Kind: description.Standalone}}}
+		subCh <- description.Topology{Servers: []description.Server{
+			{Addr: failed, Kind: description.Standalone},
+			{Addr: other, Kind: description.Standalone},
+		}}
+
+		select {
+		case srvs := <-resp:
+			assert.Equal(t, 1, len(srvs), "expected 1 server, got %d", len(srvs))
+			assert.Equal(t, other, srvs[0].Addr, "expected server %v, got %v", other, srvs[0].Addr)
+		case <-time.After(time.Second):
+			t.Fatal("timed out waiting for server selection")
+		}
+	})
+	t.Run("all suitable servers excluded", func(t *testing.T) {
+		topo, err := New()
+		noerr(t, err)
+		desc := description.Topology{Servers: []description.Server{{Addr: failed, Kind: description.Standalone}}}
+		state := newServerSelectionState(WithExcludedAddresses(selectStandalones, failed), nil)
+		srvs, err := topo.selectServerFromDescription(desc, state)
+		noerr(t, err)
+		assert.Equal(t, 0, len(srvs), "expected no servers, got %v", srvs)
+	})
+	t.Run("used as a plain selector", func(t *testing.T) {
+		servers := []description.Server{
+			{Addr: failed, Kind: description.Standalone},
+			{Addr: other, Kind: description.Standalone},
+		}
+		srvs, err := WithExcludedAddresses(selectStandalones, failed).SelectServer(description.Topology{}, servers)
+		noerr(t, err)
+		assert.Equal(t, 1, len(srvs), "expected 1 server, got %d", len(srvs))
+		assert.Equal(t, other, srvs[0].Addr, "expected server %v, got %v", other, srvs[0].Addr)
+	})
 }
 
 func TestSessionTimeout(t *testing.T) {
@@ -565,6 +1335,612 @@ func TestSessionTimeout(t *testing.T) {
 	})
 }
 
+func TestSubscribeFiltered(t *testing.T) {
+	primary := address.Address("primary").Canonicalize()
+	secondary := address.Address("secondary").Canonicalize()
+	arbiter := address.Address("arbiter").Canonicalize()
+	members := []address.Address{primary, secondary}
+
+	newTopology := func(t *testing.T) *Topology {
+		t.Helper()
+
+		topo, err := New()
+		noerr(t, err)
+		atomic.StoreInt64(&topo.state, topologyConnected)
+		topo.fsm.Kind = description.ReplicaSetWithPrimary
+		topo.fsm.SetName = "rs"
+		topo.fsm.Servers = []description.Server{
+			{Addr: primary, Kind: description.RSPrimary, SetName: "rs", Members: members},
+			{Addr: secondary, Kind: description.RSSecondary, SetName: "rs", Members: members},
+			{Addr: arbiter, Kind: description.RSArbiter, SetName: "rs"},
+		}
+		topo.desc.Store(topo.fsm.Topology)
+		return topo
+	}
+	addrs := func(td description.Topology) []address.Address {
+		var addrs []address.Address
+		for _, s := range td.Servers {
+			addrs = append(addrs, s.Addr)
+		}
+		return addrs
+	}
+
+	t.Run("only emits when matching servers change", func(t *testing.T) {
+		topo := newTopology(t)
+		sub, err := topo.SubscribeFiltered(description.Server.DataBearing)
+		noerr(t, err)
+
+		td := <-sub.Updates
+		want := []address.Address{primary, secondary}
+		assert.Equal(t, want, addrs(td), "expected servers %v, got %v", want, addrs(td))
+
+		// A heartbeat that doesn't change the kind of any server should not be sent.
+		topo.apply(context.Background(), description.Server{
+			Addr:          secondary,
+			CanonicalAddr: secondary,
+			Kind:          description.RSSecondary,
+			SetName:       "rs",
+			Members:       members,
+			AverageRTT:    5 * time.Millisecond,
+			AverageRTTSet: true,
+		})
+		select {
+		case td = <-sub.Updates:
+			t.Fatalf("expected no update, got %v", td)
+		default:
+		}
+
+		topo.apply(context.Background(), description.Server{Addr: secondary, Kind: description.Unknown})
+		td = <-sub.Updates
+		want = []address.Address{primary}
+		assert.Equal(t, want, addrs(td), "expected servers %v, got %v", want, addrs(td))
+		assert.Equal(t, description.ReplicaSetWithPrimary, td.Kind, "expected topology kind %v, got %v",
+			description.ReplicaSetWithPrimary, td.Kind)
+
+		err = topo.Unsubscribe(sub)
+		noerr(t, err)
+		_, ok := <-sub.Updates
+		assert.False(t, ok, "expected subscription channel to be closed")
+	})
+	t.Run("emits empty topology when no servers match", func(t *testing.T) {
+		topo := newTopology(t)
+		sub, err := topo.SubscribeFiltered(func(s description.Server) bool {
+			return s.Kind == description.Mongos
+		})
+		noerr(t, err)
+		defer func() { _ = topo.Unsubscribe(sub) }()
+
+		td := <-sub.Updates
+		assert.Equal(t, 0, len(td.Servers), "expected no servers, got %v", td.Servers)
+		assert.Equal(t, description.ReplicaSetWithPrimary, td.Kind, "expected topology kind %v, got %v",
+			description.ReplicaSetWithPrimary, td.Kind)
+	})
+	t.Run("nil predicate", func(t *testing.T) {
+		topo := newTopology(t)
+		_, err := topo.SubscribeFiltered(nil)
+		assert.NotNil(t, err, "expected SubscribeFiltered error, got nil")
+	})
+}
+
+func TestWaitForKind(t *testing.T) {
+	primary := address.Address("primary").Canonicalize()
+	secondary := address.Address("secondary").Canonicalize()
+	members := []address.Address{primary, secondary}
+
+	newTopology := func(t *testing.T) *Topology {
+		t.Helper()
+
+		topo, err := New()
+		noerr(t, err)
+		atomic.StoreInt64(&topo.state, topologyConnected)
+		topo.fsm.Kind = description.ReplicaSetNoPrimary
+		topo.fsm.SetName = "rs"
+		topo.fsm.Servers = []description.Server{
+			{Addr: primary, Kind: description.Unknown},
+			{Addr: secondary, Kind: description.RSSecondary, SetName: "rs", Members: members},
+		}
+		topo.desc.Store(topo.fsm.Topology)
+		return topo
+	}
+
+	t.Run("returns immediately if the kind matches", func(t *testing.T) {
+		topo := newTopology(t)
+		err := topo.WaitForKind(context.Background(), description.ReplicaSetNoPrimary)
+		assert.Nil(t, err, "WaitForKind error: %v", err)
+	})
+	t.Run("waits for a matching description", func(t *testing.T) {
+		topo := newTopology(t)
+		done := make(chan error, 1)
+		go func() {
+			done <- topo.WaitForKind(context.Background(), description.ReplicaSetWithPrimary)
+		}()
+
+		select {
+		case err := <-done:
+			t.Fatalf("WaitForKind returned before the topology had a primary: %v", err)
+		case <-time.After(50 * time.Millisecond):
+		}
+
+		topo.apply(context.Background(), description.Server{
+			Addr:          primary,
+			CanonicalAddr: primary,
+			Kind:          description.RSPrimary,
+			SetName:       "rs",
+			Members:       members,
+		})
+		select {
+		case err := <-done:
+			assert.Nil(t, err, "WaitForKind error: %v", err)
+		case <-time.After(time.Second):
+			t.Fatal("timed out waiting for WaitForKind to return")
+		}
+	})
+	t.Run("returns ctx error", func(t *testing.T) {
+		topo := newTopology(t)
+		ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+		defer cancel()
+
+		err := topo.WaitForKind(ctx, description.Sharded)
+		assert.Equal(t, context.DeadlineExceeded, err, "expected error %v, got %v", context.DeadlineExceeded, err)
+	})
+	t.Run("not connected", func(t *testing.T) {
+		topo, err := New()
+		noerr(t, err)
+
+		err = topo.WaitForKind(context.Background(), description.ReplicaSetWithPrimary)
+		assert.NotNil(t, err, "expected WaitForKind error, got nil")
+	})
+}
+
+func TestSubscriptionCount(t *testing.T) {
+	newTopology := func(t *testing.T) *Topology {
+		t.Helper()
+
+		topo, err := New(WithServerSelectionTimeout(func(time.Duration) time.Duration { return 10 * time.Millisecond }))
+		noerr(t, err)
+		atomic.StoreInt64(&topo.state, topologyConnected)
+		return topo
+	}
+	assertSubscriptions := func(t *testing.T, topo *Topology, want []uint64) {
+		t.Helper()
+
+		count := topo.SubscriptionCount()
+		assert.Equal(t, len(want), count, "expected %d subscriptions, got %d", len(want), count)
+		ids := topo.SubscriptionIDs()
+		assert.Equal(t, want, ids, "expected subscription IDs %v, got %v", want, ids)
+	}
+
+	t.Run("tracks subscribe and unsubscribe", func(t *testing.T) {
+		topo := newTopology(t)
+		assertSubscriptions(t, topo, []uint64{})
+
+		first, err := topo.Subscribe()
+		noerr(t, err)
+		filtered, err := topo.SubscribeFiltered(func(description.Server) bool { return true })
+		noerr(t, err)
+		last, err := topo.Subscribe()
+		noerr(t, err)
+		assertSubscriptions(t, topo, []uint64{first.ID, filtered.ID, last.ID})
+
+		noerr(t, topo.Unsubscribe(filtered))
+		assertSubscriptions(t, topo, []uint64{first.ID, last.ID})
+
+		// Unsubscribing twice doesn't change the count.
+		noerr(t, topo.Unsubscribe(filtered))
+		noerr(t, topo.Unsubscribe(first))
+		noerr(t, topo.Unsubscribe(last))
+		assertSubscriptions(t, topo, []uint64{})
+	})
+	t.Run("returns to baseline after server selection", func(t *testing.T) {
+		topo := newTopology(t)
+
+		// No server is selectable, so SelectServer subscribes to wait for updates before timing out.
+		_, err := topo.SelectServer(context.Background(), description.WriteSelector())
+		assert.NotNil(t, err, "expected SelectServer error, got nil")
+		assertSubscriptions(t, topo, []uint64{})
+	})
+	t.Run("cleared on disconnect", func(t *testing.T) {
+		topo := newTopology(t)
+		_, err := topo.Subscribe()
+		noerr(t, err)
+		assertSubscriptions(t, topo, []uint64{0})
+
+		noerr(t, topo.Disconnect(context.Background()))
+		assertSubscriptions(t, topo, []uint64{})
+	})
+}
+
+func TestServerLastUpdated(t *testing.T) {
+	primary := address.Address("primary").Canonicalize()
+	secondary := address.Address("secondary").Canonicalize()
+	members := []address.Address{primary, secondary}
+
+	topo, err := New()
+	noerr(t, err)
+	topo.fsm.Kind = description.ReplicaSetWithPrimary
+	topo.fsm.SetName = "rs"
+	topo.fsm.Servers = []description.Server{
+		{Addr: primary, Kind: description.RSPrimary, SetName: "rs", Members: members},
+		{Addr: secondary, Kind: description.RSSecondary, SetName: "rs", Members: members},
+	}
+	heartbeat := func(addr address.Address, kind description.ServerKind) description.Server {
+		return description.Server{Addr: addr, CanonicalAddr: addr, Kind: kind, SetName: "rs", Members: members}
+	}
+
+	updated := topo.ServerLastUpdated()
+	assert.Equal(t, 0, len(updated), "expected no timestamps before any heartbeats, got %v", updated)
+
+	topo.apply(context.Background(), heartbeat(secondary, description.RSSecondary))
+	updated = topo.ServerLastUpdated()
+	first, ok := updated[secondary]
+	assert.True(t, ok, "expected a timestamp for %v, got %v", secondary, updated)
+	_, ok = updated[primary]
+	assert.False(t, ok, "expected no timestamp for %v, got %v", primary, updated)
+
+	time.Sleep(5 * time.Millisecond)
+	topo.apply(context.Background(), heartbeat(secondary, description.RSSecondary))
+	second := topo.ServerLastUpdated()[secondary]
+	assert.True(t, second.After(first), "expected timestamp to advance from %v, got %v", first, second)
+
+	// A failed heartbeat does not refresh the timestamp.
+	time.Sleep(5 * time.Millisecond)
+	failed := description.NewServerFromError(secondary, errors.New("heartbeat error"), nil)
+	topo.apply(context.Background(), failed)
+	got := topo.ServerLastUpdated()[secondary]
+	assert.Equal(t, second, got, "expected timestamp %v after failed heartbeat, got %v", second, got)
+
+	// The returned map is a copy.
+	updated[primary] = time.Now()
+	_, ok = topo.ServerLastUpdated()[primary]
+	assert.False(t, ok, "expected modifying the returned map to not affect the topology")
+}
+
+func TestHeartbeatFailureStreaks(t *testing.T) {
+	addr := address.Address("localhost:27017")
+
+	var fail int32
+	dialer := DialerFunc(func(ctx context.Context, network, address string) (net.Conn, error) {
+		if atomic.LoadInt32(&fail) == 1 {
+			return nil, errors.New("dial error")
+		}
+		return (&channelNetConnDialer{}).DialContext(ctx, network, address)
+	})
+
+	topo, err := New()
+	noerr(t, err)
+	s, err := NewServer(addr, topo.id,
+		WithConnectionOptions(func(opts ...ConnectionOption) []ConnectionOption {
+			return append(opts, WithDialer(func(Dialer) Dialer { return dialer }))
+		}),
+		withMonitoringDisabled(func(bool) bool { return true }),
+	)
+	noerr(t, err)
+	topo.servers[addr] = s
+
+	assertStreak := func(t *testing.T, want int) {
+		t.Helper()
+
+		got := topo.HeartbeatFailureStreaks()[addr]
+		assert.Equal(t, want, got, "expected heartbeat failure streak %d, got %d", want, got)
+	}
+	check := func(t *testing.T) {
+		t.Helper()
+
+		_, err := s.check()
+		assert.Nil(t, err, "check error: %v", err)
+	}
+
+	assertStreak(t, 0)
+
+	atomic.StoreInt32(&fail, 1)
+	check(t)
+	assertStreak(t, 1)
+	check(t)
+	assertStreak(t, 2)
+
+	// A successful heartbeat resets the streak.
+	atomic.StoreInt32(&fail, 0)
+	check(t)
+	assertStreak(t, 0)
+
+	_ = s.conn.close()
+	atomic.StoreInt32(&fail, 1)
+	check(t)
+	assertStreak(t, 1)
+}
+
+func TestTopologyCompatibilityChanged(t *testing.T) {
+	addr := address.Address("foo").Canonicalize()
+
+	var events []*event.TopologyCompatibilityChangedEvent
+	monitor := &event.ServerMonitor{
+		TopologyCompatibilityChanged: func(evt *event.TopologyCompatibilityChangedEvent) {
+			events = append(events, evt)
+		},
+	}
+	topo, err := New(WithTopologyServerMonitor(func(*event.ServerMonitor) *event.ServerMonitor { return monitor }))
+	noerr(t, err)
+	atomic.StoreInt64(&topo.state, topologyConnected)
+	topo.fsm.Kind = description.Single
+	topo.servers[addr] = nil
+	topo.fsm.Servers = []description.Server{{Addr: addr}}
+
+	heartbeat := func(wireVersion *description.VersionRange) description.Server {
+		return description.Server{Addr: addr, Kind: description.Standalone, WireVersion: wireVersion}
+	}
+	supported := description.NewVersionRange(6, 13)
+	tooOld := description.NewVersionRange(0, 1)
+
+	topo.apply(context.Background(), heartbeat(&supported))
+	assert.Equal(t, 0, len(events), "expected no events for a compatible server, got %v", len(events))
+
+	// The event is published once when the server becomes incompatible, not for every heartbeat.
+	topo.apply(context.Background(), heartbeat(&tooOld))
+	topo.apply(context.Background(), heartbeat(&tooOld))
+	assert.Equal(t, 1, len(events), "expected 1 event, got %v", len(events))
+	evt := events[0]
+	assert.Equal(t, topo.id, evt.TopologyID, "expected topology ID %v, got %v", topo.id, evt.TopologyID)
+	assert.False(t, evt.Compatible, "expected topology to be incompatible")
+	assert.NotNil(t, evt.Error, "expected a compatibility error")
+	assert.Equal(t, addr, evt.Address, "expected address %v, got %v", addr, evt.Address)
+	assert.Equal(t, &tooOld, evt.WireVersion, "expected wire version %v, got %v", tooOld, evt.WireVersion)
+	assert.Equal(t, SupportedWireVersions, evt.SupportedWireVersions, "expected supported wire versions %v, got %v",
+		SupportedWireVersions, evt.SupportedWireVersions)
+
+	_, err = topo.SelectServer(context.Background(), description.WriteSelector())
+	assert.Equal(t, evt.Error, err, "expected server selection error %v, got %v", evt.Error, err)
+
+	topo.apply(context.Background(), heartbeat(&supported))
+	topo.apply(context.Background(), heartbeat(&supported))
+	assert.Equal(t, 2, len(events), "expected 2 events, got %v", len(events))
+	evt = events[1]
+	assert.True(t, evt.Compatible, "expected topology to be compatible")
+	assert.Nil(t, evt.Error, "expected no compatibility error, got %v", evt.Error)
+	assert.Equal(t, addr, evt.Address, "expected address %v, got %v", addr, evt.Address)
+}
+
+func TestSplitBrainDetected(t *testing.T) {
+	foo := address.Address("foo").Canonicalize()
+	bar := address.Address("bar").Canonicalize()
+	members := []address.Address{foo, bar}
+	electionID := primitive.NewObjectID()
+
+	testCases := []struct {
+		name           string
+		firstElection  primitive.ObjectID
+		secondElection primitive.ObjectID
+		expected       bool
+	}{
+		{"same election id", electionID, electionID, true},
+		{"newer election id", electionID, primitive.NewObjectID(), false},
+		{"no election ids", primitive.NilObjectID, primitive.NilObjectID, false},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			var events []*event.SplitBrainDetectedEvent
+			monitor := &event.ServerMonitor{
+				SplitBrainDetected: func(evt *event.SplitBrainDetectedEvent) {
+					events = append(events, evt)
+				},
+			}
+			topo, err := New(WithTopologyServerMonitor(func(*event.ServerMonitor) *event.ServerMonitor { return monitor }))
+			noerr(t, err)
+			topo.fsm.Kind = description.ReplicaSetNoPrimary
+			topo.servers[foo] = nil
+			topo.servers[bar] = nil
+			topo.fsm.Servers = []description.Server{{Addr: foo}, {Addr: bar}}
+
+			first := description.Server{
+				Addr:       foo,
+				Kind:       description.RSPrimary,
+				SetName:    "rs",
+				SetVersion: 1,
+				ElectionID: tc.firstElection,
+				Members:    members,
+			}
+			second := first
+			second.Addr = bar
+			second.ElectionID = tc.secondElection
+
+			topo.apply(context.Background(), first)
+			assert.Equal(t, 0, len(events), "expected no events after first primary, got %v", len(events))
+			topo.apply(context.Background(), second)
+
+			if !tc.expected {
+				assert.Equal(t, 0, len(events), "expected no events, got %v", len(events))
+				return
+			}
+			assert.Equal(t, 1, len(events), "expected 1 event, got %v", len(events))
+			evt := events[0]
+			assert.Equal(t, topo.id, evt.TopologyID, "expected topology ID %v, got %v", topo.id, evt.TopologyID)
+			assert.Equal(t, "rs", evt.SetName, "expected set name 'rs', got %q", evt.SetName)
+			assert.Equal(t, electionID, evt.ElectionID, "expected election ID %v, got %v", electionID, evt.ElectionID)
+			assert.Equal(t, 2, len(evt.Primaries), "expected 2 primaries, got %v", len(evt.Primaries))
+			assert.Equal(t, foo, evt.Primaries[0].Addr, "expected first primary %v, got %v", foo, evt.Primaries[0].Addr)
+			assert.Equal(t, bar, evt.Primaries[1].Addr, "expected second primary %v, got %v", bar, evt.Primaries[1].Addr)
+
+			desc := topo.Description()
+			assert.Equal(t, description.ReplicaSetWithPrimary, desc.Kind, "expected kind %v, got %v",
+				description.ReplicaSetWithPrimary, desc.Kind)
+		})
+	}
+}
+
+func TestConnectContext(t *testing.T) {
+	seeds := []string{"one:27017", "two:27017", "three:27017"}
+	newTopology := func(t *testing.T) *Topology {
+		t.Helper()
+
+		topo, err := New(
+			WithSeedList(func(...string) []string { return seeds }),
+			WithServerOptions(func(opts ...ServerOption) []ServerOption {
+				return append(opts, withMonitoringDisabled(func(bool) bool { return true }))
+			}),
+		)
+		noerr(t, err)
+		return topo
+	}
+
+	t.Run("connects", func(t *testing.T) {
+		topo := newTopology(t)
+		err := topo.ConnectContext(context.Background())
+		assert.Nil(t, err, "ConnectContext error: %v", err)
+		defer func() { _ = topo.Disconnect(context.Background()) }()
+
+		assert.Equal(t, int64(topologyConnected), atomic.LoadInt64(&topo.state), "expected topology to be connected")
+		assert.Equal(t, len(seeds), len(topo.servers), "expected %d servers, got %d", len(seeds), len(topo.servers))
+	})
+	t.Run("returns ctx error", func(t *testing.T) {
+		topo := newTopology(t)
+		ctx, cancel := context.WithCancel(context.Background())
+		cancel()
+
+		err := topo.ConnectContext(ctx)
+		assert.Equal(t, context.Canceled, err, "expected error %v, got %v", context.Canceled, err)
+		assert.Equal(t, int64(topologyDisconnected), atomic.LoadInt64(&topo.state),
+			"expected topology to be disconnected")
+		assert.Equal(t, 0, len(topo.servers), "expected no servers, got %d", len(topo.servers))
+
+		// The topology can still be connected after a failed attempt.
+		err = topo.Connect()
+		assert.Nil(t, err, "Connect error: %v", err)
+		defer func() { _ = topo.Disconnect(context.Background()) }()
+		assert.Equal(t, len(seeds), len(topo.servers), "expected %d servers, got %d", len(seeds), len(topo.servers))
+		assert.Equal(t, len(seeds), len(topo.Description().Servers), "expected %d servers in description, got %d",
+			len(seeds), len(topo.Description().Servers))
+	})
+	t.Run("aborts partial connect", func(t *testing.T) {
+		topo := newTopology(t)
+		ctx, cancel := context.WithCancel(context.Background())
+		defer cancel()
+		// Cancel ctx once the first server has been started.
+		poolMonitor := &event.PoolMonitor{
+			Event: func(evt *event.PoolEvent) {
+				if evt.Type == event.PoolCreated {
+					cancel()
+				}
+			},
+		}
+		topo.cfg.serverOpts = append(topo.cfg.serverOpts,
+			WithConnectionPoolMonitor(func(*event.PoolMonitor) *event.PoolMonitor { return poolMonitor }))
+
+		err := topo.ConnectContext(ctx)
+		assert.Equal(t, context.Canceled, err, "expected error %v, got %v", context.Canceled, err)
+		assert.Equal(t, int64(topologyDisconnected), atomic.LoadInt64(&topo.state),
+			"expected topology to be disconnected")
+		assert.Equal(t, 0, len(topo.servers), "expected no servers, got %d", len(topo.servers))
+		assert.Equal(t, 0, len(topo.Description().Servers), "expected no servers in description, got %d",
+			len(topo.Description().Servers))
+	})
+}
+
+func TestTopologyPoolStats(t *testing.T) {
+	topo, err := New()
+	noerr(t, err)
+	atomic.StoreInt64(&topo.state, topologyConnected)
+
+	addrs := []address.Address{"one:27017", "two:27017"}
+	for _, addr := range addrs {
+		server, err := ConnectServer(addr, topo.updateCallback, topo.id, withMonitoringDisabled(func(bool) bool {
+			return true
+		}))
+		noerr(t, err)
+		topo.servers[addr] = server
+	}
+
+	stats := topo.PoolStats()
+	assert.Equal(t, len(addrs), len(stats), "expected stats for %d servers, got %d", len(addrs), len(stats))
+	for _, addr := range addrs {
+		stat, ok := stats[addr]
+		assert.True(t, ok, "expected stats for server %v", addr)
+		assert.Equal(t, PoolStat{}, stat, "expected empty stats for server %v, got %+v", addr, stat)
+	}
+}
+
+func TestGlobalMaxConnections(t *testing.T) {
+	t.Run("servers share a limiter", func(t *testing.T) {
+		topo, err := New(
+			WithGlobalMaxConnections(3),
+			WithSeedList(func(...string) []string { return []string{"one:27017", "two:27017"} }),
+			WithServerOptions(func(opts ...ServerOption) []ServerOption {
+				return append(opts, withMonitoringDisabled(func(bool) bool { return true }))
+			}),
+		)
+		noerr(t, err)
+		noerr(t, topo.Connect())
+		defer func() { _ = topo.Disconnect(context.Background()) }()
+
+		var limiter *connectionLimiter
+		for addr, server := range topo.servers {
+			assert.NotNil(t, server.pool.limiter, "expected server %v to have a connection limiter", addr)
+			if limiter == nil {
+				limiter = server.pool.limiter
+			}
+			assert.True(t, limiter == server.pool.limiter, "expected servers to share a connection limiter")
+		}
+		assert.Equal(t, 3, limiter.max, "expected limiter max 3, got %v", limiter.max)
+	})
+	t.Run("no limit by default", func(t *testing.T) {
+		topo, err := New(WithServerOptions(func(opts ...ServerOption) []ServerOption {
+			return append(opts, withMonitoringDisabled(func(bool) bool { return true }))
+		}))
+		noerr(t, err)
+		noerr(t, topo.Connect())
+		defer func() { _ = topo.Disconnect(context.Background()) }()
+
+		for addr, server := range topo.servers {
+			assert.Nil(t, server.pool.limiter, "expected server %v not to have a connection limiter", addr)
+		}
+	})
+	t.Run("negative limit", func(t *testing.T) {
+		_, err := New(WithGlobalMaxConnections(-1))
+		assert.NotNil(t, err, "expected New error, got nil")
+	})
+}
+
+func TestLoadBalancedTopology(t *testing.T) {
+	cs, err := connstring.ParseAndValidate("mongodb://lb.example.com:27017/?loadBalanced=true")
+	noerr(t, err)
+
+	var heartbeats int32
+	serverMonitor := &event.ServerMonitor{
+		ServerHeartbeatStarted: func(*event.ServerHeartbeatStartedEvent) {
+			atomic.AddInt32(&heartbeats, 1)
+		},
+	}
+	topo, err := New(
+		WithConnString(func(connstring.ConnString) connstring.ConnString { return cs }),
+		WithServerOptions(func(opts ...ServerOption) []ServerOption {
+			return append(opts, WithServerMonitor(func(*event.ServerMonitor) *event.ServerMonitor { return serverMonitor }))
+		}),
+	)
+	noerr(t, err)
+	noerr(t, topo.Connect())
+	defer func() { _ = topo.Disconnect(context.Background()) }()
+
+	lbAddr := address.Address("lb.example.com:27017")
+	desc := topo.Description()
+	assert.Equal(t, description.LoadBalanced, desc.Kind, "expected kind %v, got %v", description.LoadBalanced, desc.Kind)
+	assert.Equal(t, 1, len(desc.Servers), "expected 1 server, got %d", len(desc.Servers))
+	assert.Equal(t, lbAddr, desc.Servers[0].Addr, "expected server %v, got %v", lbAddr, desc.Servers[0].Addr)
+	assert.Equal(t, description.LoadBalancer, desc.Servers[0].Kind, "expected server kind %v, got %v",
+		description.LoadBalancer, desc.Servers[0].Kind)
+
+	// The load balancer is selected for any read preference without waiting for a heartbeat.
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second)
+	defer cancel()
+	srvr, err := topo.SelectServer(ctx, description.ReadPrefSelector(readpref.Secondary()))
+	noerr(t, err)
+	selected := srvr.(*SelectedServer).address
+	assert.Equal(t, lbAddr, selected, "expected selected server %v, got %v", lbAddr, selected)
+
+	// No heartbeats are sent, even if a check is requested.
+	topo.RequestImmediateCheck()
+	time.Sleep(50 * time.Millisecond)
+	assert.Equal(t, int32(0), atomic.LoadInt32(&heartbeats), "expected no heartbeats, got %v",
+		atomic.LoadInt32(&heartbeats))
+}
+
 func TestMinPoolSize(t *testing.T) {
 	connStr := connstring.ConnString{
 		Hosts:          []string{"localhost:27017"},
@@ -581,6 +1957,240 @@ func TestMinPoolSize(t *testing.T) {
 	}
 }
 
+func TestTopologyDrain(t *testing.T) {
+	topo, err := New()
+	noerr(t, err)
+	atomic.StoreInt64(&topo.state, topologyConnected)
+
+	existing, err := ConnectServer(address.Address("one:27017"), topo.updateCallback, topo.id)
+	noerr(t, err)
+	topo.servers[existing.address] = existing
+
+	topo.Drain()
+
+	topo.serversLock.Lock()
+	err = topo.addServer(address.Address("two:27017"))
+	topo.serversLock.Unlock()
+	noerr(t, err)
+
+	for addr, s := range topo.servers {
+		_, err := s.Connection(context.Background())
+		assert.Equal(t, ErrPoolDraining, err, "expected error %v from server %v, got %v", ErrPoolDraining, addr, err)
+	}
+}
+
+func TestTopologyDisconnectDrain(t *testing.T) {
+	newTopology := func(t *testing.T) (*Topology, *Server) {
+		t.Helper()
+
+		topo, err := New()
+		noerr(t, err)
+		atomic.StoreInt64(&topo.state, topologyConnected)
+		topo.desc.Store(description.Topology{
+			Kind:    description.Single,
+			Servers: []description.Server{{Addr: "one:27017", Kind: description.Standalone}},
+		})
+
+		server, err := ConnectServer(address.Address("one:27017"), topo.updateCallback, topo.id,
+			withMonitoringDisabled(func(bool) bool { return true }),
+			WithConnectionOptions(func(opts ...ConnectionOption) []ConnectionOption {
+				return append(opts,
+					WithDialer(func(Dialer) Dialer {
+						return DialerFunc(func(context.Context, string, string) (net.Conn, error) {
+							return &net.TCPConn{}, nil
+						})
+					}),
+					WithHandshaker(func(Handshaker) Handshaker {
+						return &testHandshaker{}
+					}),
+				)
+			}),
+		)
+		noerr(t, err)
+		topo.servers[server.address] = server
+		return topo, server
+	}
+	// disconnectDrain calls DisconnectDrain with the given timeout in the background and waits for the server's pool to
+	// start draining.
+	disconnectDrain := func(t *testing.T, topo *Topology, server *Server, timeout time.Duration) <-chan error {
+		t.Helper()
+
+		done := make(chan error, 1)
+		go func() {
+			ctx, cancel := context.WithTimeout(context.Background(), timeout)
+			defer cancel()
+			done <- topo.DisconnectDrain(ctx)
+		}()
+		assert.Soon(t, func() {
+			for {
+				server.pool.stateMu.RLock()
+				draining := server.pool.draining
+				server.pool.stateMu.RUnlock()
+				if draining {
+					return
+				}
+				time.Sleep(time.Millisecond)
+			}
+		}, time.Second)
+		return done
+	}
+
+	t.Run("waits for checked out connections", func(t *testing.T) {
+		topo, server := newTopology(t)
+		conn, err := server.Connection(context.Background())
+		noerr(t, err)
+
+		done := disconnectDrain(t, topo, server, 10*time.Second)
+
+		_, err = topo.SelectServer(context.Background(), description.WriteSelector())
+		assert.Equal(t, ErrTopologyClosed, err, "expected error %v, got %v", ErrTopologyClosed, err)
+		_, err = server.Connection(context.Background())
+		assert.Equal(t, ErrPoolDraining, err, "expected error %v, got %v", ErrPoolDraining, err)
+
+		select {
+		case err := <-done:
+			t.Fatalf("DisconnectDrain returned before the connection was checked in: %v", err)
+		case <-time.After(50 * time.Millisecond):
+		}
+		assert.True(t, conn.(*Connection).Alive(), "expected checked out connection to remain usable while draining")
+		state := atomic.LoadInt64(&conn.(*Connection).connection.state)
+		assert.Equal(t, connConnected, state, "expected connection state %v, got %v", connConnected, state)
+
+		noerr(t, conn.Close())
+		select {
+		case err := <-done:
+			noerr(t, err)
+		case <-time.After(time.Second):
+			t.Fatal("timed out waiting for DisconnectDrain to return")
+		}
+		state = atomic.LoadInt64(&topo.state)
+		assert.Equal(t, topologyDisconnected, state, "expected topology state %v, got %v", topologyDisconnected,
+			state)
+	})
+	t.Run("closes connections still in use when ctx is done", func(t *testing.T) {
+		topo, server := newTopology(t)
+		conn, err := server.Connection(context.Background())
+		noerr(t, err)
+
+		done := disconnectDrain(t, topo, server, 50*time.Millisecond)
+
+		select {
+		case err := <-done:
+			noerr(t, err)
+		case <-time.After(time.Second):
+			t.Fatal("timed out waiting for DisconnectDrain to return")
+		}
+		state := atomic.LoadInt64(&conn.(*Connection).connection.state)
+		assert.Equal(t, connDisconnected, state, "expected connection state %v, got %v", connDisconnected, state)
+	})
+	t.Run("topology not connected", func(t *testing.T) {
+		topo, err := New()
+		noerr(t, err)
+
+		err = topo.DisconnectDrain(context.Background())
+		assert.Equal(t, ErrTopologyClosed, err, "expected error %v, got %v", ErrTopologyClosed, err)
+	})
+}
+
+func TestTopologyPauseMonitoring(t *testing.T) {
+	topo, err := New()
+	noerr(t, err)
+	atomic.StoreInt64(&topo.state, topologyConnected)
+
+	existing, err := ConnectServer(address.Address("one:27017"), topo.updateCallback, topo.id,
+		withMonitoringDisabled(func(bool) bool { return true }))
+	noerr(t, err)
+	topo.servers[existing.address] = existing
+
+	topo.PauseMonitoring()
+
+	topo.serversLock.Lock()
+	err = topo.addServer(address.Address("two:27017"))
+	topo.serversLock.Unlock()
+	noerr(t, err)
+
+	for addr, s := range topo.servers {
+		assert.NotNil(t, s.monitoringResumed(), "expected monitoring for server %v to be paused", addr)
+	}
+
+	topo.ResumeMonitoring()
+	for addr, s := range topo.servers {
+		assert.Nil(t, s.monitoringResumed(), "expected monitoring for server %v to be resumed", addr)
+	}
+	noerr(t, topo.Disconnect(context.Background()))
+}
+
+func TestTopologyReplicationLag(t *testing.T) {
+	base := time.Date(2017, 2, 11, 14, 0, 0, 0, time.UTC)
+	primary := description.Server{
+		Addr:           address.Address("primary:27017"),
+		Kind:           description.RSPrimary,
+		LastWriteTime:  base,
+		LastUpdateTime: base.Add(2 * time.Second),
+	}
+	upToDate := description.Server{
+		Addr:           address.Address("uptodate:27017"),
+		Kind:           description.RSSecondary,
+		LastWriteTime:  base,
+		LastUpdateTime: base.Add(2 * time.Second),
+	}
+	lagging := description.Server{
+		Addr:           address.Address("lagging:27017"),
+		Kind:           description.RSSecondary,
+		LastWriteTime:  base.Add(-30 * time.Second),
+		LastUpdateTime: base.Add(time.Second),
+	}
+	noWrites := description.Server{
+		Addr: address.Address("nowrites:27017"),
+		Kind: description.RSSecondary,
+	}
+	arbiter := description.Server{
+		Addr: address.Address("arbiter:27017"),
+		Kind: description.RSArbiter,
+	}
+
+	testCases := []struct {
+		name     string
+		servers  []description.Server
+		expected map[address.Address]time.Duration
+	}{
+		{
+			"relative to primary",
+			[]description.Server{primary, upToDate, lagging, noWrites, arbiter},
+			map[address.Address]time.Duration{
+				upToDate.Addr: 0,
+				lagging.Addr:  29 * time.Second,
+			},
+		},
+		{
+			"relative to most recent secondary without primary",
+			[]description.Server{upToDate, lagging, noWrites},
+			map[address.Address]time.Duration{
+				upToDate.Addr: 0,
+				lagging.Addr:  30 * time.Second,
+			},
+		},
+		{
+			"no secondaries",
+			[]description.Server{primary, arbiter},
+			map[address.Address]time.Duration{},
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			topo, err := New()
+			noerr(t, err)
+			topo.desc.Store(description.Topology{
+				Kind:    description.ReplicaSetWithPrimary,
+				Servers: tc.servers,
+			})
+
+			lags := topo.ReplicationLag()
+			assert.Equal(t, tc.expected, lags, "expected lags %v, got %v", tc.expected, lags)
+		})
+	}
+}
+
 func TestTopology_String_Race(t *testing.T) {
 	ch := make(chan bool)
 	topo := &Topology{